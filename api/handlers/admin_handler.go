@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/http/bind"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// AdminHandler lets support staff manage any user account. Routes are
+// guarded by RequirePermission; operations that users can perform on
+// themselves reuse the same services.
+type AdminHandler struct {
+	adminService         services.AdminService
+	userService          services.UserService
+	passwordResetService services.PasswordResetService
+}
+
+func NewAdminHandler(adminService services.AdminService, userService services.UserService, passwordResetService services.PasswordResetService) *AdminHandler {
+	return &AdminHandler{
+		adminService:         adminService,
+		userService:          userService,
+		passwordResetService: passwordResetService,
+	}
+}
+
+// ListUsers lists and searches user accounts
+// @Summary List users
+// @Description List users newest first. q searches email and name; verified and deletion_scheduled filter by status. Requires the users:read permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search email or name"
+// @Param verified query bool false "Only users whose email is (or is not) verified"
+// @Param deletion_scheduled query bool false "Only users whose deletion is (or is not) scheduled"
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 20, max 100)"
+// @Success 200 {object} responses.AdminUserListResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *echo.Context) error {
+	var req requests.ListUsersRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	search := services.UserSearch{
+		Query:             strings.TrimSpace(req.Q),
+		Verified:          req.Verified,
+		DeletionScheduled: req.DeletionScheduled,
+	}
+	users, total, err := h.adminService.ListUsers(c.Request().Context(), search, req.Limit(), req.Offset())
+	if err != nil {
+		return eris.Wrap(err, "failed to list users")
+	}
+
+	items := make([]responses.AdminUserResponse, 0, len(users))
+	for i := range users {
+		items = append(items, adminUserResponse(&users[i]))
+	}
+
+	return c.JSON(http.StatusOK, responses.AdminUserListResponse{
+		Users: items,
+		Pagination: responses.PaginationResponse{
+			Page:    req.PageOrDefault(),
+			PerPage: req.PerPageOrDefault(),
+			Total:   total,
+		},
+	})
+}
+
+// GetUser returns a single user account
+// @Summary Get user
+// @Description Get a user with their number of active sessions. Requires the users:read permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} responses.AdminUserDetailResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *echo.Context) error {
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.adminService.GetUser(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to get user")
+	}
+
+	return c.JSON(http.StatusOK, responses.AdminUserDetailResponse{
+		AdminUserResponse: adminUserResponse(user.User),
+		ActiveSessions:    user.ActiveSessions,
+	})
+}
+
+// VerifyEmail marks a user's email as verified
+// @Summary Force-verify email
+// @Description Mark a user's email as verified without a verification link. Requires the users:write permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /admin/users/{id}/email-verification [put]
+func (h *AdminHandler) VerifyEmail(c *echo.Context) error {
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.adminService.VerifyEmail(c.Request().Context(), userID); err != nil {
+		return eris.Wrap(err, "failed to verify email")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SendPasswordReset emails a user a password reset link
+// @Summary Send password reset
+// @Description Email the user a password reset link, as if they had requested one. Requires the users:write permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 202
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /admin/users/{id}/password-resets [post]
+func (h *AdminHandler) SendPasswordReset(c *echo.Context) error {
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	user, err := h.userService.GetByID(ctx, userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to get user")
+	}
+
+	if err := h.passwordResetService.Create(ctx, user.Email); err != nil {
+		return eris.Wrap(err, "failed to create password reset")
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// RevokeSessions signs a user out everywhere
+// @Summary Revoke user sessions
+// @Description Revoke all of the user's sessions, refresh tokens and API keys. Requires the users:write permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /admin/users/{id}/sessions [delete]
+func (h *AdminHandler) RevokeSessions(c *echo.Context) error {
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.adminService.RevokeSessions(c.Request().Context(), userID); err != nil {
+		return eris.Wrap(err, "failed to revoke sessions")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ScheduleDeletion schedules a user's account for deletion
+// @Summary Schedule user deletion
+// @Description Schedule the account for deletion after the configured delay and revoke its sessions and API keys, as DELETE /users/me does. Requires the users:write permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /admin/users/{id}/deletion [put]
+func (h *AdminHandler) ScheduleDeletion(c *echo.Context) error {
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.userService.ScheduleDeletion(c.Request().Context(), userID); err != nil {
+		return eris.Wrap(err, "failed to schedule deletion")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CancelDeletion cancels a user's scheduled deletion
+// @Summary Cancel user deletion
+// @Description Cancel the account's scheduled deletion. Requires the users:write permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /admin/users/{id}/deletion [delete]
+func (h *AdminHandler) CancelDeletion(c *echo.Context) error {
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.userService.CancelDeletion(c.Request().Context(), userID); err != nil {
+		return eris.Wrap(err, "failed to cancel deletion")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func adminUserIDParam(c *echo.Context) (uuid.UUID, error) {
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userID, err := uuid.Parse(param)
+	if err != nil {
+		return uuid.Nil, apperrors.ErrInvalidUserID
+	}
+	return userID, nil
+}
+
+func adminUserResponse(user *sqlcgen.User) responses.AdminUserResponse {
+	return responses.AdminUserResponse{
+		ID:                  user.ID,
+		Name:                user.Name,
+		Email:               user.Email,
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+	}
+}
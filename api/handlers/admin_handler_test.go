@@ -0,0 +1,263 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockAdminService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "binds search filters and pagination",
+			query: "q=+alice+&verified=false&page=2&per_page=10",
+			setupMock: func(adminSvc *mocks.MockAdminService) {
+				adminSvc.EXPECT().ListUsers(mock.Anything, mock.MatchedBy(func(s services.UserSearch) bool {
+					return s.Query == "alice" && s.Verified != nil && !*s.Verified && s.DeletionScheduled == nil
+				}), int32(10), int32(10)).Return([]sqlcgen.User{{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"}}, int64(11), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error for invalid filter",
+			query:          "per_page=500",
+			setupMock:      func(adminSvc *mocks.MockAdminService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockAdminSvc := mocks.NewMockAdminService(t)
+			tt.setupMock(mockAdminSvc)
+
+			handler := handlers.NewAdminHandler(mockAdminSvc, mocks.NewMockUserService(t), mocks.NewMockPasswordResetService(t))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/users?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.ListUsers(c)
+
+			if tt.expectedError != "" {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.AdminUserListResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.Users, 1)
+				assert.Equal(t, "alice@example.com", resp.Users[0].Email)
+				assert.Equal(t, int64(11), resp.Pagination.Total)
+				assert.Equal(t, 2, resp.Pagination.Page)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetUser(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		setupMock      func(*mocks.MockAdminService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "returns user with session count",
+			param: userID.String(),
+			setupMock: func(adminSvc *mocks.MockAdminService) {
+				adminSvc.EXPECT().GetUser(mock.Anything, userID).Return(&services.AdminUser{
+					User:           &sqlcgen.User{ID: userID, Email: "alice@example.com"},
+					ActiveSessions: 2,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error for invalid id",
+			param:          "not-a-uuid",
+			setupMock:      func(adminSvc *mocks.MockAdminService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_USER_ID",
+		},
+		{
+			name:  "returns error when user not found",
+			param: userID.String(),
+			setupMock: func(adminSvc *mocks.MockAdminService) {
+				adminSvc.EXPECT().GetUser(mock.Anything, userID).Return(nil, apperrors.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "USER_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockAdminSvc := mocks.NewMockAdminService(t)
+			tt.setupMock(mockAdminSvc)
+
+			handler := handlers.NewAdminHandler(mockAdminSvc, mocks.NewMockUserService(t), mocks.NewMockPasswordResetService(t))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/users/"+tt.param, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.param}})
+
+			err := handler.GetUser(c)
+
+			if tt.expectedError != "" {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.AdminUserDetailResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, userID, resp.ID)
+				assert.Equal(t, int64(2), resp.ActiveSessions)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_Actions(t *testing.T) {
+	userID := uuid.New()
+	user := &sqlcgen.User{ID: userID, Email: "alice@example.com"}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		action         func(*handlers.AdminHandler, *echo.Context) error
+		setupMock      func(*mocks.MockAdminService, *mocks.MockUserService, *mocks.MockPasswordResetService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:   "verifies email",
+			method: http.MethodPut,
+			path:   "/email-verification",
+			action: (*handlers.AdminHandler).VerifyEmail,
+			setupMock: func(adminSvc *mocks.MockAdminService, userSvc *mocks.MockUserService, passwordResetSvc *mocks.MockPasswordResetService) {
+				adminSvc.EXPECT().VerifyEmail(mock.Anything, userID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "sends password reset to user's email",
+			method: http.MethodPost,
+			path:   "/password-resets",
+			action: (*handlers.AdminHandler).SendPasswordReset,
+			setupMock: func(adminSvc *mocks.MockAdminService, userSvc *mocks.MockUserService, passwordResetSvc *mocks.MockPasswordResetService) {
+				userSvc.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+				passwordResetSvc.EXPECT().Create(mock.Anything, "alice@example.com").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:   "revokes all sessions and api keys",
+			method: http.MethodDelete,
+			path:   "/sessions",
+			action: (*handlers.AdminHandler).RevokeSessions,
+			setupMock: func(adminSvc *mocks.MockAdminService, userSvc *mocks.MockUserService, passwordResetSvc *mocks.MockPasswordResetService) {
+				adminSvc.EXPECT().RevokeSessions(mock.Anything, userID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "revoke sessions returns error when user not found",
+			method: http.MethodDelete,
+			path:   "/sessions",
+			action: (*handlers.AdminHandler).RevokeSessions,
+			setupMock: func(adminSvc *mocks.MockAdminService, userSvc *mocks.MockUserService, passwordResetSvc *mocks.MockPasswordResetService) {
+				adminSvc.EXPECT().RevokeSessions(mock.Anything, userID).Return(apperrors.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "USER_NOT_FOUND",
+		},
+		{
+			name:   "schedules deletion",
+			method: http.MethodPut,
+			path:   "/deletion",
+			action: (*handlers.AdminHandler).ScheduleDeletion,
+			setupMock: func(adminSvc *mocks.MockAdminService, userSvc *mocks.MockUserService, passwordResetSvc *mocks.MockPasswordResetService) {
+				userSvc.EXPECT().ScheduleDeletion(mock.Anything, userID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "cancel deletion returns error when not scheduled",
+			method: http.MethodDelete,
+			path:   "/deletion",
+			action: (*handlers.AdminHandler).CancelDeletion,
+			setupMock: func(adminSvc *mocks.MockAdminService, userSvc *mocks.MockUserService, passwordResetSvc *mocks.MockPasswordResetService) {
+				userSvc.EXPECT().CancelDeletion(mock.Anything, userID).Return(apperrors.ErrDeletionNotScheduled)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "DELETION_NOT_SCHEDULED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockAdminSvc := mocks.NewMockAdminService(t)
+			mockUserSvc := mocks.NewMockUserService(t)
+			mockPasswordResetSvc := mocks.NewMockPasswordResetService(t)
+			tt.setupMock(mockAdminSvc, mockUserSvc, mockPasswordResetSvc)
+
+			handler := handlers.NewAdminHandler(mockAdminSvc, mockUserSvc, mockPasswordResetSvc)
+
+			req := httptest.NewRequest(tt.method, "/admin/users/"+userID.String()+tt.path, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: userID.String()}})
+
+			err := tt.action(handler, c)
+
+			if tt.expectedError != "" {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// APIKeyHandler handles personal API key management.
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create issues a new API key for the current user
+// @Summary Create API key
+// @Description Create a personal API key for machine clients. The key is returned only in this response; send it as a bearer token. Omit expires_at for a key that lasts until revoked.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.CreateAPIKeyRequest true "Create API key request"
+// @Success 201 {object} responses.CreateAPIKeyResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Router /users/me/api-keys [post]
+func (h *APIKeyHandler) Create(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.CreateAPIKeyRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.apiKeyService.Create(c.Request().Context(), userID, strings.TrimSpace(req.Name), req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return eris.Wrap(err, "failed to create api key")
+	}
+
+	return c.JSON(http.StatusCreated, responses.CreateAPIKeyResponse{
+		APIKeyResponse: apiKeyResponse(created.APIKey),
+		Key:            created.Key,
+	})
+}
+
+// List returns the current user's API keys
+// @Summary List API keys
+// @Description List the current user's API keys that have not been revoked, including expired ones. Keys themselves are never returned, only their prefix.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} responses.APIKeyListResponse
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Router /users/me/api-keys [get]
+func (h *APIKeyHandler) List(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	keys, err := h.apiKeyService.ListForUser(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to list api keys")
+	}
+
+	apiKeys := make([]responses.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		apiKeys = append(apiKeys, apiKeyResponse(&keys[i]))
+	}
+
+	return c.JSON(http.StatusOK, responses.APIKeyListResponse{
+		APIKeys: apiKeys,
+	})
+}
+
+// Update renames one of the current user's API keys
+// @Summary Rename API key
+// @Description Rename an API key with a JSON merge patch (RFC 7396). Scopes and expiry cannot be changed.
+// @Tags api-keys
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Param request body requests.UpdateAPIKeyRequest true "API key merge patch"
+// @Success 200 {object} responses.APIKeyResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 415 {object} errors.AppError
+// @Router /users/me/api-keys/{id} [patch]
+func (h *APIKeyHandler) Update(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	keyID, err := apiKeyIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	var req requests.UpdateAPIKeyRequest
+	if err := bind.MergePatch(c, &req); err != nil {
+		return err
+	}
+
+	apiKey, err := h.apiKeyService.Rename(c.Request().Context(), userID, keyID, strings.TrimSpace(*req.Name))
+	if err != nil {
+		return eris.Wrap(err, "failed to rename api key")
+	}
+
+	return c.JSON(http.StatusOK, apiKeyResponse(apiKey))
+}
+
+// Delete revokes one of the current user's API keys
+// @Summary Revoke API key
+// @Description Revoke one of the current user's API keys by ID. Requests using it are rejected immediately.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /users/me/api-keys/{id} [delete]
+func (h *APIKeyHandler) Delete(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	keyID, err := apiKeyIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.apiKeyService.Revoke(c.Request().Context(), userID, keyID); err != nil {
+		return eris.Wrap(err, "failed to revoke api key")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func apiKeyIDParam(c *echo.Context) (uuid.UUID, error) {
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	keyID, err := uuid.Parse(param)
+	if err != nil {
+		return uuid.Nil, apperrors.ErrInvalidAPIKeyID
+	}
+	return keyID, nil
+}
+
+func apiKeyResponse(apiKey *sqlcgen.ApiKey) responses.APIKeyResponse {
+	return responses.APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.KeyPrefix,
+		Scopes:     apiKey.Scopes,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
@@ -0,0 +1,280 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyHandler_Create(t *testing.T) {
+	userID := uuid.New()
+	keyID := uuid.New()
+	expiresAt := time.Now().UTC().Add(30 * 24 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockAPIKeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "creates key and returns it once",
+			requestBody: `{"name":" CI ","scopes":["profile:read"],"expires_at":"` + expiresAt.Format(time.RFC3339) + `"}`,
+			setupMock: func(apiKeySvc *mocks.MockAPIKeyService) {
+				apiKeySvc.EXPECT().Create(mock.Anything, userID, "CI", []string{"profile:read"}, mock.MatchedBy(func(at *time.Time) bool {
+					return at != nil && at.Equal(expiresAt)
+				})).Return(&services.CreatedAPIKey{
+					APIKey: &sqlcgen.ApiKey{ID: keyID, Name: "CI", KeyPrefix: "ak_12345678", Scopes: []string{"profile:read"}, ExpiresAt: &expiresAt},
+					Key:    "ak_1234567890",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "returns error for missing scopes",
+			requestBody:    `{"name":"CI","scopes":[]}`,
+			setupMock:      func(apiKeySvc *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "returns error for expiry in the past",
+			requestBody:    `{"name":"CI","scopes":["profile:read"],"expires_at":"2000-01-01T00:00:00Z"}`,
+			setupMock:      func(apiKeySvc *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error for unknown scope",
+			requestBody: `{"name":"CI","scopes":["admin"]}`,
+			setupMock: func(apiKeySvc *mocks.MockAPIKeyService) {
+				apiKeySvc.EXPECT().Create(mock.Anything, userID, "CI", []string{"admin"}, (*time.Time)(nil)).
+					Return(nil, apperrors.ErrInvalidAPIKeyScope.WithDetail("scope", "admin"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_API_KEY_SCOPE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockAPIKeySvc := mocks.NewMockAPIKeyService(t)
+			tt.setupMock(mockAPIKeySvc)
+
+			handler := handlers.NewAPIKeyHandler(mockAPIKeySvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/api-keys", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			reqctx.SetUserID(c, userID)
+
+			err := handler.Create(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.CreateAPIKeyResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, keyID, resp.ID)
+				assert.Equal(t, "ak_1234567890", resp.Key)
+				assert.Equal(t, "ak_12345678", resp.Prefix)
+				assert.Equal(t, []string{"profile:read"}, resp.Scopes)
+			}
+		})
+	}
+}
+
+func TestAPIKeyHandler_List(t *testing.T) {
+	userID := uuid.New()
+
+	e := setupEcho()
+	mockAPIKeySvc := mocks.NewMockAPIKeyService(t)
+	mockAPIKeySvc.EXPECT().ListForUser(mock.Anything, userID).Return([]sqlcgen.ApiKey{
+		{ID: uuid.New(), Name: "CI", KeyPrefix: "ak_12345678", TokenHash: "secret-hash", Scopes: []string{"profile:read"}},
+	}, nil)
+
+	handler := handlers.NewAPIKeyHandler(mockAPIKeySvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/api-keys", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	reqctx.SetUserID(c, userID)
+
+	require.NoError(t, handler.List(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret-hash")
+
+	var resp responses.APIKeyListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.APIKeys, 1)
+	assert.Equal(t, "CI", resp.APIKeys[0].Name)
+}
+
+func TestAPIKeyHandler_Update(t *testing.T) {
+	userID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		requestBody    string
+		setupMock      func(*mocks.MockAPIKeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "renames key",
+			param:       keyID.String(),
+			requestBody: `{"name":"Deploy"}`,
+			setupMock: func(apiKeySvc *mocks.MockAPIKeyService) {
+				apiKeySvc.EXPECT().Rename(mock.Anything, userID, keyID, "Deploy").
+					Return(&sqlcgen.ApiKey{ID: keyID, Name: "Deploy"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error for invalid id",
+			param:          "not-a-uuid",
+			requestBody:    `{"name":"Deploy"}`,
+			setupMock:      func(apiKeySvc *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_API_KEY_ID",
+		},
+		{
+			name:           "returns error for null name",
+			param:          keyID.String(),
+			requestBody:    `{"name":null}`,
+			setupMock:      func(apiKeySvc *mocks.MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error when key not found",
+			param:       keyID.String(),
+			requestBody: `{"name":"Deploy"}`,
+			setupMock: func(apiKeySvc *mocks.MockAPIKeyService) {
+				apiKeySvc.EXPECT().Rename(mock.Anything, userID, keyID, "Deploy").Return(nil, apperrors.ErrAPIKeyNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "API_KEY_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockAPIKeySvc := mocks.NewMockAPIKeyService(t)
+			tt.setupMock(mockAPIKeySvc)
+
+			handler := handlers.NewAPIKeyHandler(mockAPIKeySvc)
+
+			req := httptest.NewRequest(http.MethodPatch, "/users/me/api-keys/"+tt.param, strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.param}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler.Update(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAPIKeyHandler_Delete(t *testing.T) {
+	userID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		setupMock      func(*mocks.MockAPIKeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "revokes key",
+			param: keyID.String(),
+			setupMock: func(apiKeySvc *mocks.MockAPIKeyService) {
+				apiKeySvc.EXPECT().Revoke(mock.Anything, userID, keyID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "returns error when key not found",
+			param: keyID.String(),
+			setupMock: func(apiKeySvc *mocks.MockAPIKeyService) {
+				apiKeySvc.EXPECT().Revoke(mock.Anything, userID, keyID).Return(apperrors.ErrAPIKeyNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "API_KEY_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockAPIKeySvc := mocks.NewMockAPIKeyService(t)
+			tt.setupMock(mockAPIKeySvc)
+
+			handler := handlers.NewAPIKeyHandler(mockAPIKeySvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/me/api-keys/"+tt.param, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.param}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler.Delete(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
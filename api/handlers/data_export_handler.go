@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-reasonable-api/api/requests"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// DataExportHandler handles exports of a user's data.
+type DataExportHandler struct {
+	dataExportService services.DataExportService
+}
+
+func NewDataExportHandler(dataExportService services.DataExportService) *DataExportHandler {
+	return &DataExportHandler{
+		dataExportService: dataExportService,
+	}
+}
+
+// Create requests an export of the current user's data
+// @Summary Export my data
+// @Description Queue an export of everything stored about the current user. Exports are asynchronous rather than served from a GET: collecting every table can outlast a request, and each request stores an archive and counts against the export rate limit. The archive is built by the worker and a signed download link for GET /data-exports/{id} is emailed to the user.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.CreateDataExportRequest false "Data export request"
+// @Success 202
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 429 {object} errors.AppError
+// @Router /users/me/exports [post]
+func (h *DataExportHandler) Create(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.CreateDataExportRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = services.DataExportFormatZIP
+	}
+
+	if err := h.dataExportService.Request(c.Request().Context(), userID, format); err != nil {
+		return eris.Wrap(err, "failed to request data export")
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// Download serves a built data export from a signed link
+// @Summary Download data export
+// @Description Download a data export using the signed link from the export email sent after POST /users/me/exports. The link needs no session, so it opens from the email, and expires with the archive.
+// @Tags users
+// @Produce application/zip,json
+// @Param id path string true "Data export ID"
+// @Param expires query int true "Link expiry as a Unix timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 400 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /data-exports/{id} [get]
+func (h *DataExportHandler) Download(c *echo.Context) error {
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return err
+	}
+	exportID, err := uuid.Parse(param)
+	if err != nil {
+		return apperrors.ErrInvalidDataExportID
+	}
+
+	var req requests.DownloadDataExportRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	export, err := h.dataExportService.Download(c.Request().Context(), exportID, time.Unix(req.Expires, 0), req.Signature)
+	if err != nil {
+		return eris.Wrap(err, "failed to download data export")
+	}
+
+	contentType := echo.MIMEApplicationJSON
+	if export.Format == services.DataExportFormatZIP {
+		contentType = "application/zip"
+	}
+	filename := fmt.Sprintf("data-export-%s.%s", export.CreatedAt.Format("2006-01-02"), export.Format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.Blob(http.StatusOK, contentType, export.Archive)
+}
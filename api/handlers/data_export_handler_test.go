@@ -0,0 +1,196 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/api/handlers"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportHandler_Create(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(c *echo.Context)
+		setupMock      func(*mocks.MockDataExportService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:         "requests a zip export by default",
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(svc *mocks.MockDataExportService) {
+				svc.EXPECT().Request(mock.Anything, userID, services.DataExportFormatZIP).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:         "requests a json export",
+			requestBody:  `{"format":"json"}`,
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(svc *mocks.MockDataExportService) {
+				svc.EXPECT().Request(mock.Anything, userID, services.DataExportFormatJSON).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "returns validation error for unknown format",
+			requestBody:    `{"format":"csv"}`,
+			setupContext:   func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock:      func(svc *mocks.MockDataExportService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "returns error when user not in context",
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(svc *mocks.MockDataExportService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+		{
+			name:         "returns error when an export was requested recently",
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(svc *mocks.MockDataExportService) {
+				svc.EXPECT().Request(mock.Anything, userID, services.DataExportFormatZIP).Return(apperrors.ErrDataExportTooSoon)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedError:  "DATA_EXPORT_TOO_SOON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSvc := mocks.NewMockDataExportService(t)
+			tt.setupMock(mockSvc)
+
+			handler := handlers.NewDataExportHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/exports", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Create(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDataExportHandler_Download(t *testing.T) {
+	exportID := uuid.New()
+	expires := time.Now().Add(time.Hour).Unix()
+	expiresQuery := "?expires=" + strconv.FormatInt(expires, 10)
+	createdAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		setupMock      func(*mocks.MockDataExportService)
+		expectedStatus int
+		expectedError  string
+		expectedType   string
+		expectedFile   string
+	}{
+		{
+			name:  "serves the archive as an attachment",
+			id:    exportID.String(),
+			query: expiresQuery + "&signature=sig",
+			setupMock: func(svc *mocks.MockDataExportService) {
+				svc.EXPECT().Download(mock.Anything, exportID, time.Unix(expires, 0), "sig").
+					Return(&sqlcgen.DataExport{ID: exportID, Format: services.DataExportFormatZIP, Archive: []byte("PK"), CreatedAt: createdAt}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/zip",
+			expectedFile:   `attachment; filename="data-export-2026-10-16.zip"`,
+		},
+		{
+			name:           "returns error for invalid id",
+			id:             "not-a-uuid",
+			query:          expiresQuery + "&signature=sig",
+			setupMock:      func(svc *mocks.MockDataExportService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_DATA_EXPORT_ID",
+		},
+		{
+			name:           "returns validation error without a signature",
+			id:             exportID.String(),
+			query:          expiresQuery,
+			setupMock:      func(svc *mocks.MockDataExportService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:  "returns error for an invalid link",
+			id:    exportID.String(),
+			query: expiresQuery + "&signature=forged",
+			setupMock: func(svc *mocks.MockDataExportService) {
+				svc.EXPECT().Download(mock.Anything, exportID, time.Unix(expires, 0), "forged").Return(nil, apperrors.ErrInvalidDataExportLink)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_DATA_EXPORT_LINK",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSvc := mocks.NewMockDataExportService(t)
+			tt.setupMock(mockSvc)
+
+			handler := handlers.NewDataExportHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/data-exports/"+tt.id+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.id}})
+
+			err := handler.Download(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				assert.Equal(t, tt.expectedType, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, tt.expectedFile, rec.Header().Get(echo.HeaderContentDisposition))
+				assert.Equal(t, "PK", rec.Body.String())
+			}
+		})
+	}
+}
@@ -16,6 +16,12 @@
 //
 // Routes requiring authentication use AuthMiddleware, which populates
 // reqctx with user ID and token. Handlers access these via reqctx.GetUserID.
+// AuthMiddleware also accepts personal API keys, so every authenticated
+// route adds RequireScope for the scope it needs or RequireSession to turn
+// API keys away. Routes limited to some users add RequirePermission or
+// RequireRole, which answer 403 when the user's roles fall short.
+// Impersonation sessions carry the administrator's ID (reqctx.GetImpersonatorID);
+// routes that touch credentials or sessions add RejectImpersonation.
 //
 // # Documentation
 //
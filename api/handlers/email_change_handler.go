@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-reasonable-api/api/requests"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+type EmailChangeHandler struct {
+	emailChangeService services.EmailChangeService
+}
+
+func NewEmailChangeHandler(emailChangeService services.EmailChangeService) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		emailChangeService: emailChangeService,
+	}
+}
+
+// Create requests an email address change
+// @Summary Request email change
+// @Description Send a confirmation link to the new address and a revert link to the current one. Requires the current password. In enumeration-safe mode a taken address also answers 202 but sends nothing.
+// @Tags email-changes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.CreateEmailChangeRequest true "Create email change request"
+// @Success 202
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/email-changes [post]
+func (h *EmailChangeHandler) Create(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.CreateEmailChangeRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.emailChangeService.Request(c.Request().Context(), userID, req.NewEmail, req.Password); err != nil {
+		return eris.Wrap(err, "failed to request email change")
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// Update confirms an email change using the token sent to the new address
+// @Summary Confirm email change
+// @Description Apply a pending email change using the token from the confirmation email
+// @Tags email-changes
+// @Accept json
+// @Produce json
+// @Param token path string true "Email change confirmation token"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /email-changes/{token} [put]
+func (h *EmailChangeHandler) Update(c *echo.Context) error {
+	token, err := bind.RequiredParam(c, "token")
+	if err != nil {
+		return err
+	}
+
+	if err := h.emailChangeService.Confirm(c.Request().Context(), token); err != nil {
+		return eris.Wrap(err, "failed to confirm email change")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Delete reverts an email change using the token sent to the old address
+// @Summary Revert email change
+// @Description Cancel a pending email change, or restore the previous address and sign out every session if it was already confirmed
+// @Tags email-changes
+// @Accept json
+// @Produce json
+// @Param token path string true "Email change revert token"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /email-changes/{token} [delete]
+func (h *EmailChangeHandler) Delete(c *echo.Context) error {
+	token, err := bind.RequiredParam(c, "token")
+	if err != nil {
+		return err
+	}
+
+	if err := h.emailChangeService.Revert(c.Request().Context(), token); err != nil {
+		return eris.Wrap(err, "failed to revert email change")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,218 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-reasonable-api/api/handlers"
+	apperrors "go-reasonable-api/app/errors"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailChangeHandler_Create(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(c *echo.Context)
+		setupMock      func(*mocks.MockEmailChangeService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:         "requests email change successfully",
+			requestBody:  `{"new_email":"new@example.com","password":"password123"}`,
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(svc *mocks.MockEmailChangeService) {
+				svc.EXPECT().Request(mock.Anything, userID, "new@example.com", "password123").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "returns error when user not in context",
+			requestBody:    `{"new_email":"new@example.com","password":"password123"}`,
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(svc *mocks.MockEmailChangeService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+		{
+			name:           "returns validation error for invalid email",
+			requestBody:    `{"new_email":"not-an-email","password":"password123"}`,
+			setupContext:   func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock:      func(svc *mocks.MockEmailChangeService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:         "returns error when email is taken",
+			requestBody:  `{"new_email":"taken@example.com","password":"password123"}`,
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(svc *mocks.MockEmailChangeService) {
+				svc.EXPECT().Request(mock.Anything, userID, "taken@example.com", "password123").
+					Return(apperrors.ErrEmailAlreadyExists)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "EMAIL_ALREADY_EXISTS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSvc := mocks.NewMockEmailChangeService(t)
+			tt.setupMock(mockSvc)
+
+			handler := handlers.NewEmailChangeHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/email-changes", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Create(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestEmailChangeHandler_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		setupMock      func(*mocks.MockEmailChangeService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "confirms email change successfully",
+			token: "valid-token",
+			setupMock: func(svc *mocks.MockEmailChangeService) {
+				svc.EXPECT().Confirm(mock.Anything, "valid-token").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns error for missing token",
+			token:          "",
+			setupMock:      func(svc *mocks.MockEmailChangeService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "MISSING_TOKEN",
+		},
+		{
+			name:  "returns error for invalid token",
+			token: "invalid-token",
+			setupMock: func(svc *mocks.MockEmailChangeService) {
+				svc.EXPECT().Confirm(mock.Anything, "invalid-token").Return(apperrors.ErrInvalidEmailChangeToken)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_EMAIL_CHANGE_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSvc := mocks.NewMockEmailChangeService(t)
+			tt.setupMock(mockSvc)
+
+			handler := handlers.NewEmailChangeHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/email-changes/"+tt.token, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "token", Value: tt.token}})
+
+			err := handler.Update(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestEmailChangeHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		setupMock      func(*mocks.MockEmailChangeService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "reverts email change successfully",
+			token: "revert-token",
+			setupMock: func(svc *mocks.MockEmailChangeService) {
+				svc.EXPECT().Revert(mock.Anything, "revert-token").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "returns error when old email was claimed",
+			token: "revert-token",
+			setupMock: func(svc *mocks.MockEmailChangeService) {
+				svc.EXPECT().Revert(mock.Anything, "revert-token").Return(apperrors.ErrEmailAlreadyExists)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "EMAIL_ALREADY_EXISTS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSvc := mocks.NewMockEmailChangeService(t)
+			tt.setupMock(mockSvc)
+
+			handler := handlers.NewEmailChangeHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/email-changes/"+tt.token, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "token", Value: tt.token}})
+
+			err := handler.Delete(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
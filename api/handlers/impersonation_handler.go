@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// ImpersonationHandler starts and ends sessions in which an administrator
+// acts as another user. Both are recorded in the audit log.
+type ImpersonationHandler struct {
+	impersonationService services.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService services.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+// Start begins impersonating a user
+// @Summary Impersonate user
+// @Description Issue a short-lived session token that acts as the user. The session has no refresh token and cannot change credentials, delete the account or manage sessions. Requires the users:impersonate permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 201 {object} responses.ImpersonationResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /admin/users/{id}/impersonation [post]
+func (h *ImpersonationHandler) Start(c *echo.Context) error {
+	impersonatorID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	userID, err := adminUserIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := h.impersonationService.Start(c.Request().Context(), impersonatorID, userID, clientInfo(c))
+	if err != nil {
+		return eris.Wrap(err, "failed to start impersonation")
+	}
+
+	return c.JSON(http.StatusCreated, responses.ImpersonationResponse{
+		Token:     tokens.AccessToken,
+		ExpiresAt: tokens.AccessTokenExpiresAt,
+	})
+}
+
+// End stops the current impersonation session
+// @Summary End impersonation
+// @Description Revoke the impersonation session used to authenticate the request.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Router /impersonation [delete]
+func (h *ImpersonationHandler) End(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	sessionID, ok := reqctx.GetSessionID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	impersonatorID, ok := reqctx.GetImpersonatorID(c)
+	if !ok {
+		return apperrors.ErrNotImpersonating
+	}
+
+	if err := h.impersonationService.End(c.Request().Context(), sessionID, impersonatorID, userID, clientInfo(c)); err != nil {
+		return eris.Wrap(err, "failed to end impersonation")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonationHandler_Start(t *testing.T) {
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("returns impersonation token", func(t *testing.T) {
+		e := setupEcho()
+		mockService := mocks.NewMockImpersonationService(t)
+		handler := handlers.NewImpersonationHandler(mockService)
+
+		expiresAt := time.Now().Add(15 * time.Minute)
+		mockService.EXPECT().Start(mock.Anything, adminID, userID, mock.AnythingOfType("services.ClientInfo")).
+			Return(&services.SessionTokens{AccessToken: "impersonation-token", AccessTokenExpiresAt: expiresAt}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/impersonation", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPathValues(echo.PathValues{{Name: "id", Value: userID.String()}})
+		reqctx.SetUserID(c, adminID)
+
+		require.NoError(t, handler.Start(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var resp responses.ImpersonationResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "impersonation-token", resp.Token)
+	})
+
+	t.Run("returns error for invalid user id", func(t *testing.T) {
+		e := setupEcho()
+		handler := handlers.NewImpersonationHandler(mocks.NewMockImpersonationService(t))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/nope/impersonation", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPathValues(echo.PathValues{{Name: "id", Value: "nope"}})
+		reqctx.SetUserID(c, adminID)
+
+		err := handler.Start(c)
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "INVALID_USER_ID", appErr.Code)
+	})
+
+	t.Run("returns error when user cannot be impersonated", func(t *testing.T) {
+		e := setupEcho()
+		mockService := mocks.NewMockImpersonationService(t)
+		handler := handlers.NewImpersonationHandler(mockService)
+
+		mockService.EXPECT().Start(mock.Anything, adminID, userID, mock.Anything).Return(nil, apperrors.ErrImpersonationNotAllowed)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/impersonation", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPathValues(echo.PathValues{{Name: "id", Value: userID.String()}})
+		reqctx.SetUserID(c, adminID)
+
+		err := handler.Start(c)
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+}
+
+func TestImpersonationHandler_End(t *testing.T) {
+	adminID := uuid.New()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	t.Run("ends impersonation session", func(t *testing.T) {
+		e := setupEcho()
+		mockService := mocks.NewMockImpersonationService(t)
+		handler := handlers.NewImpersonationHandler(mockService)
+
+		mockService.EXPECT().End(mock.Anything, sessionID, adminID, userID, mock.Anything).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/impersonation", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		reqctx.SetUserID(c, userID)
+		reqctx.SetSessionID(c, sessionID)
+		reqctx.SetImpersonatorID(c, adminID)
+
+		require.NoError(t, handler.End(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("returns error for regular session", func(t *testing.T) {
+		e := setupEcho()
+		handler := handlers.NewImpersonationHandler(mocks.NewMockImpersonationService(t))
+
+		req := httptest.NewRequest(http.MethodDelete, "/impersonation", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		reqctx.SetUserID(c, userID)
+		reqctx.SetSessionID(c, sessionID)
+
+		err := handler.End(c)
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "NOT_IMPERSONATING", appErr.Code)
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/logger"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+type MagicLinkHandler struct {
+	config           *config.Config
+	magicLinkService services.MagicLinkService
+}
+
+func NewMagicLinkHandler(cfg *config.Config, magicLinkService services.MagicLinkService) *MagicLinkHandler {
+	return &MagicLinkHandler{
+		config:           cfg,
+		magicLinkService: magicLinkService,
+	}
+}
+
+// Create sends a magic login link email
+// @Summary Request magic link
+// @Description Send a one-time login link to the user's email
+// @Tags magic-links
+// @Accept json
+// @Produce json
+// @Param request body requests.CreateMagicLinkRequest true "Create magic link request"
+// @Success 202
+// @Failure 400 {object} errors.AppError
+// @Router /magic-links [post]
+func (h *MagicLinkHandler) Create(c *echo.Context) error {
+	var req requests.CreateMagicLinkRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// Log error but don't return it to prevent user enumeration
+	if err := h.magicLinkService.Create(c.Request().Context(), req.Email); err != nil {
+		logger.Ctx(c.Request().Context()).Warn().
+			Err(err).
+			Str("email", req.Email).
+			Msg("failed to create magic link")
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// Update redeems a magic link for a session
+// @Summary Login with magic link
+// @Description Exchange the token from a magic link email for a session. When two-factor authentication is enabled, responds with 202 and a challenge token to be completed via POST /sessions/two-factor.
+// @Tags magic-links
+// @Produce json
+// @Param token path string true "Magic link token"
+// @Success 201 {object} responses.SessionResponse
+// @Success 202 {object} responses.TwoFactorChallengeResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /magic-links/{token} [put]
+func (h *MagicLinkHandler) Update(c *echo.Context) error {
+	token, err := bind.RequiredParam(c, "token")
+	if err != nil {
+		return err
+	}
+
+	result, err := h.magicLinkService.Execute(c.Request().Context(), token, clientInfo(c))
+	if err != nil {
+		return eris.Wrap(err, "failed to login with magic link")
+	}
+
+	return loginResponse(c, h.config, result)
+}
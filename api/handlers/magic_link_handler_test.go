@@ -0,0 +1,173 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/api/handlers"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicLinkHandler_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockMagicLinkService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "sends magic link email successfully",
+			requestBody: `{"email":"test@example.com"}`,
+			setupMock: func(magicLinkSvc *mocks.MockMagicLinkService) {
+				magicLinkSvc.EXPECT().Create(mock.Anything, "test@example.com").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "returns accepted even if the service fails",
+			requestBody: `{"email":"test@example.com"}`,
+			setupMock: func(magicLinkSvc *mocks.MockMagicLinkService) {
+				magicLinkSvc.EXPECT().Create(mock.Anything, "test@example.com").Return(assert.AnError)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "returns error for invalid email",
+			requestBody:    `{"email":"notanemail"}`,
+			setupMock:      func(magicLinkSvc *mocks.MockMagicLinkService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockMagicLinkSvc := mocks.NewMockMagicLinkService(t)
+			tt.setupMock(mockMagicLinkSvc)
+
+			handler := handlers.NewMagicLinkHandler(&config.Config{}, mockMagicLinkSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/magic-links", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.Create(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestMagicLinkHandler_Update(t *testing.T) {
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com"}
+
+	tests := []struct {
+		name           string
+		token          string
+		setupMock      func(*mocks.MockMagicLinkService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "creates a session",
+			token: "valid-token",
+			setupMock: func(magicLinkSvc *mocks.MockMagicLinkService) {
+				magicLinkSvc.EXPECT().Execute(mock.Anything, "valid-token", mock.Anything).Return(&services.LoginResult{
+					User:   user,
+					Tokens: &services.SessionTokens{AccessToken: "access-token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:  "returns a challenge when two-factor is enabled",
+			token: "valid-token",
+			setupMock: func(magicLinkSvc *mocks.MockMagicLinkService) {
+				magicLinkSvc.EXPECT().Execute(mock.Anything, "valid-token", mock.Anything).Return(&services.LoginResult{
+					User:      user,
+					Challenge: &services.TwoFactorChallenge{Token: "challenge-token", ExpiresAt: time.Now().Add(5 * time.Minute)},
+				}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "returns error for missing token",
+			token:          "",
+			setupMock:      func(magicLinkSvc *mocks.MockMagicLinkService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "MISSING_TOKEN",
+		},
+		{
+			name:  "returns error for invalid link",
+			token: "invalid-token",
+			setupMock: func(magicLinkSvc *mocks.MockMagicLinkService) {
+				magicLinkSvc.EXPECT().Execute(mock.Anything, "invalid-token", mock.Anything).Return(nil, apperrors.ErrInvalidMagicLink)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_MAGIC_LINK",
+		},
+		{
+			name:  "returns error for expired link",
+			token: "expired-token",
+			setupMock: func(magicLinkSvc *mocks.MockMagicLinkService) {
+				magicLinkSvc.EXPECT().Execute(mock.Anything, "expired-token", mock.Anything).Return(nil, apperrors.ErrTokenExpired)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "TOKEN_EXPIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockMagicLinkSvc := mocks.NewMockMagicLinkService(t)
+			tt.setupMock(mockMagicLinkSvc)
+
+			handler := handlers.NewMagicLinkHandler(&config.Config{}, mockMagicLinkSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/magic-links/"+tt.token, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "token", Value: tt.token}})
+
+			err := handler.Update(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// OIDCHandler handles login with external OpenID Connect providers and the
+// identities linked to accounts.
+type OIDCHandler struct {
+	config      *config.Config
+	oidcService services.OIDCService
+}
+
+func NewOIDCHandler(cfg *config.Config, oidcService services.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		config:      cfg,
+		oidcService: oidcService,
+	}
+}
+
+// Authorize starts an OIDC login
+// @Summary Start OIDC login
+// @Description Return the provider's authorization URL to redirect the user to. The state it carries is single-use and expires after oidc.state_ttl.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} responses.OIDCAuthorizationResponse
+// @Failure 404 {object} errors.AppError
+// @Router /sessions/oidc/{provider}/authorization [post]
+func (h *OIDCHandler) Authorize(c *echo.Context) error {
+	provider, err := bind.RequiredParam(c, "provider")
+	if err != nil {
+		return err
+	}
+
+	authURL, err := h.oidcService.BeginLogin(c.Request().Context(), provider)
+	if err != nil {
+		return eris.Wrap(err, "failed to begin oidc login")
+	}
+
+	return c.JSON(http.StatusOK, responses.OIDCAuthorizationResponse{
+		AuthorizationURL: authURL,
+	})
+}
+
+// Login finishes an OIDC login
+// @Summary Login with OIDC provider
+// @Description Redeem the code and state the provider redirected back with. Links the identity to the account with the same verified email, or creates an account, on first login. When two-factor authentication is enabled, responds with 202 and a challenge token to be completed via POST /sessions/two-factor.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param request body requests.OIDCLoginRequest true "OIDC login request"
+// @Success 201 {object} responses.SessionResponse
+// @Success 202 {object} responses.TwoFactorChallengeResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /sessions/oidc/{provider} [post]
+func (h *OIDCHandler) Login(c *echo.Context) error {
+	provider, err := bind.RequiredParam(c, "provider")
+	if err != nil {
+		return err
+	}
+
+	var req requests.OIDCLoginRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.oidcService.FinishLogin(c.Request().Context(), provider, req.Code, req.State, clientInfo(c))
+	if err != nil {
+		return eris.Wrap(err, "failed to finish oidc login")
+	}
+
+	return loginResponse(c, h.config, result)
+}
+
+// ListIdentities lists the current user's linked identities
+// @Summary List linked identities
+// @Description List the external identities linked to the current user
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} responses.IdentityListResponse
+// @Failure 401 {object} errors.AppError
+// @Router /users/me/identities [get]
+func (h *OIDCHandler) ListIdentities(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	identities, err := h.oidcService.ListIdentities(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to list identities")
+	}
+
+	items := make([]responses.IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		items = append(items, responses.IdentityResponse{
+			ID:          identity.ID,
+			Provider:    identity.Provider,
+			Email:       identity.Email,
+			CreatedAt:   identity.CreatedAt,
+			LastLoginAt: identity.LastLoginAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, responses.IdentityListResponse{
+		Identities: items,
+	})
+}
+
+// UnlinkIdentity removes one of the current user's linked identities
+// @Summary Unlink identity
+// @Description Remove a linked identity by ID. The last identity of an account without a password cannot be removed.
+// @Tags identities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Identity ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/identities/{id} [delete]
+func (h *OIDCHandler) UnlinkIdentity(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	identityID, err := uuid.Parse(param)
+	if err != nil {
+		return apperrors.ErrInvalidIdentityID
+	}
+
+	if err := h.oidcService.Unlink(c.Request().Context(), userID, identityID); err != nil {
+		return eris.Wrap(err, "failed to unlink identity")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
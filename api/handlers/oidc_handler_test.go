@@ -0,0 +1,299 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCHandler_Authorize(t *testing.T) {
+	tests := []struct {
+		name           string
+		provider       string
+		setupMock      func(*mocks.MockOIDCService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:     "returns the authorization url",
+			provider: "google",
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().BeginLogin(mock.Anything, "google").Return("https://accounts.example.com/authorize?state=abc", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "returns error for an unknown provider",
+			provider: "unknown",
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().BeginLogin(mock.Anything, "unknown").Return("", apperrors.ErrOIDCProviderNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "OIDC_PROVIDER_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOIDCSvc := mocks.NewMockOIDCService(t)
+			tt.setupMock(mockOIDCSvc)
+
+			handler := handlers.NewOIDCHandler(&config.Config{}, mockOIDCSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/sessions/oidc/"+tt.provider+"/authorization", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "provider", Value: tt.provider}})
+
+			err := handler.Authorize(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.OIDCAuthorizationResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "https://accounts.example.com/authorize?state=abc", resp.AuthorizationURL)
+			}
+		})
+	}
+}
+
+func TestOIDCHandler_Login(t *testing.T) {
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com"}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockOIDCService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "creates a session",
+			body: `{"code":"code","state":"state"}`,
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().FinishLogin(mock.Anything, "google", "code", "state", mock.Anything).Return(&services.LoginResult{
+					User:   user,
+					Tokens: &services.SessionTokens{AccessToken: "access-token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "returns a challenge when two-factor is enabled",
+			body: `{"code":"code","state":"state"}`,
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().FinishLogin(mock.Anything, "google", "code", "state", mock.Anything).Return(&services.LoginResult{
+					User:      user,
+					Challenge: &services.TwoFactorChallenge{Token: "challenge-token", ExpiresAt: time.Now().Add(5 * time.Minute)},
+				}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "returns error when state is missing",
+			body:           `{"code":"code"}`,
+			setupMock:      func(oidcSvc *mocks.MockOIDCService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name: "returns error for an invalid login",
+			body: `{"code":"code","state":"state"}`,
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().FinishLogin(mock.Anything, "google", "code", "state", mock.Anything).Return(nil, apperrors.ErrInvalidOIDCLogin)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_OIDC_LOGIN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOIDCSvc := mocks.NewMockOIDCService(t)
+			tt.setupMock(mockOIDCSvc)
+
+			handler := handlers.NewOIDCHandler(&config.Config{}, mockOIDCSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/sessions/oidc/google", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "provider", Value: "google"}})
+
+			err := handler.Login(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestOIDCHandler_ListIdentities(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockOIDCService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "lists identities",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().ListIdentities(mock.Anything, userID).Return([]sqlcgen.UserIdentity{
+					{ID: uuid.New(), UserID: userID, Provider: "google", Email: "jane@example.com"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error when user not in context",
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(oidcSvc *mocks.MockOIDCService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOIDCSvc := mocks.NewMockOIDCService(t)
+			tt.setupMock(mockOIDCSvc)
+
+			handler := handlers.NewOIDCHandler(&config.Config{}, mockOIDCSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/me/identities", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.ListIdentities(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.IdentityListResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.Identities, 1)
+				assert.Equal(t, "google", resp.Identities[0].Provider)
+				assert.Equal(t, "jane@example.com", resp.Identities[0].Email)
+			}
+		})
+	}
+}
+
+func TestOIDCHandler_UnlinkIdentity(t *testing.T) {
+	userID := uuid.New()
+	identityID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		setupMock      func(*mocks.MockOIDCService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "unlinks identity",
+			param: identityID.String(),
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().Unlink(mock.Anything, userID, identityID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns error for invalid id",
+			param:          "not-a-uuid",
+			setupMock:      func(oidcSvc *mocks.MockOIDCService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_IDENTITY_ID",
+		},
+		{
+			name:  "returns error for the last sign-in method",
+			param: identityID.String(),
+			setupMock: func(oidcSvc *mocks.MockOIDCService) {
+				oidcSvc.EXPECT().Unlink(mock.Anything, userID, identityID).Return(apperrors.ErrLastSignInMethod)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "LAST_SIGN_IN_METHOD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOIDCSvc := mocks.NewMockOIDCService(t)
+			tt.setupMock(mockOIDCSvc)
+
+			handler := handlers.NewOIDCHandler(&config.Config{}, mockOIDCSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/me/identities/"+tt.param, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.param}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler.UnlinkIdentity(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,567 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// OrganizationHandler handles organizations, their members and invitations.
+// Routes under /organizations/:org_id run behind RequireOrgRole, which
+// resolves the organization and the caller's role into the request context.
+type OrganizationHandler struct {
+	organizationService services.OrganizationService
+}
+
+func NewOrganizationHandler(organizationService services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+	}
+}
+
+// Create creates an organization owned by the current user
+// @Summary Create organization
+// @Description Create an organization. The current user becomes its owner, unless their account is scheduled for deletion.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.CreateOrganizationRequest true "Create organization request"
+// @Success 201 {object} responses.OrganizationResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organizations [post]
+func (h *OrganizationHandler) Create(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.CreateOrganizationRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	organization, err := h.organizationService.Create(c.Request().Context(), userID, strings.TrimSpace(req.Name))
+	if err != nil {
+		return eris.Wrap(err, "failed to create organization")
+	}
+
+	return c.JSON(http.StatusCreated, organizationResponse(organization, services.OrgRoleOwner))
+}
+
+// List returns the organizations the current user belongs to
+// @Summary List organizations
+// @Description List the organizations the current user is a member of, with their role in each
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} responses.OrganizationListResponse
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Router /organizations [get]
+func (h *OrganizationHandler) List(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	rows, err := h.organizationService.ListForUser(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to list organizations")
+	}
+
+	organizations := make([]responses.OrganizationResponse, 0, len(rows))
+	for _, row := range rows {
+		organizations = append(organizations, responses.OrganizationResponse{
+			ID:        row.ID,
+			Name:      row.Name,
+			Role:      row.Role,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, responses.OrganizationListResponse{
+		Organizations: organizations,
+	})
+}
+
+// Get returns an organization the current user belongs to
+// @Summary Get organization
+// @Description Get an organization and the current user's role in it. Organizations the user does not belong to are reported as not found.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Success 200 {object} responses.OrganizationResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /organizations/{org_id} [get]
+func (h *OrganizationHandler) Get(c *echo.Context) error {
+	orgID, role, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	organization, err := h.organizationService.Get(c.Request().Context(), orgID)
+	if err != nil {
+		return eris.Wrap(err, "failed to get organization")
+	}
+
+	return c.JSON(http.StatusOK, organizationResponse(organization, role))
+}
+
+// Update renames an organization
+// @Summary Rename organization
+// @Description Rename an organization with a JSON merge patch (RFC 7396). Requires the admin role.
+// @Tags organizations
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Param request body requests.UpdateOrganizationRequest true "Organization merge patch"
+// @Success 200 {object} responses.OrganizationResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 415 {object} errors.AppError
+// @Router /organizations/{org_id} [patch]
+func (h *OrganizationHandler) Update(c *echo.Context) error {
+	orgID, role, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req requests.UpdateOrganizationRequest
+	if err := bind.MergePatch(c, &req); err != nil {
+		return err
+	}
+
+	organization, err := h.organizationService.Rename(c.Request().Context(), orgID, strings.TrimSpace(*req.Name))
+	if err != nil {
+		return eris.Wrap(err, "failed to rename organization")
+	}
+
+	return c.JSON(http.StatusOK, organizationResponse(organization, role))
+}
+
+// Delete deletes an organization
+// @Summary Delete organization
+// @Description Delete an organization along with its memberships and invitations. Requires the owner role.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /organizations/{org_id} [delete]
+func (h *OrganizationHandler) Delete(c *echo.Context) error {
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.organizationService.Delete(c.Request().Context(), orgID); err != nil {
+		return eris.Wrap(err, "failed to delete organization")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListMembers returns an organization's members
+// @Summary List organization members
+// @Description List the members of an organization with their roles
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Success 200 {object} responses.OrganizationMemberListResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /organizations/{org_id}/members [get]
+func (h *OrganizationHandler) ListMembers(c *echo.Context) error {
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.organizationService.ListMembers(c.Request().Context(), orgID)
+	if err != nil {
+		return eris.Wrap(err, "failed to list organization members")
+	}
+
+	members := make([]responses.OrganizationMemberResponse, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, responses.OrganizationMemberResponse{
+			UserID:   row.UserID,
+			Name:     row.Name,
+			Email:    row.Email,
+			Role:     row.Role,
+			JoinedAt: row.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, responses.OrganizationMemberListResponse{
+		Members: members,
+	})
+}
+
+// UpdateMember changes a member's role
+// @Summary Change member role
+// @Description Change a member's role to admin or member with a JSON merge patch (RFC 7396). Requires the admin role. The owner's role can only change by transferring ownership.
+// @Tags organizations
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Param request body requests.UpdateOrganizationMemberRequest true "Membership merge patch"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 415 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organizations/{org_id}/members/{user_id} [patch]
+func (h *OrganizationHandler) UpdateMember(c *echo.Context) error {
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	memberID, err := memberIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	var req requests.UpdateOrganizationMemberRequest
+	if err := bind.MergePatch(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.organizationService.UpdateMemberRole(c.Request().Context(), orgID, memberID, *req.Role); err != nil {
+		return eris.Wrap(err, "failed to update organization member")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteMember removes a member from an organization
+// @Summary Remove member
+// @Description Remove a member from an organization. Members can remove themselves to leave; removing anyone else requires the admin role. The owner cannot be removed.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organizations/{org_id}/members/{user_id} [delete]
+func (h *OrganizationHandler) DeleteMember(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	memberID, err := memberIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.organizationService.RemoveMember(c.Request().Context(), orgID, userID, memberID); err != nil {
+		return eris.Wrap(err, "failed to remove organization member")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// TransferOwnership hands an organization to another member
+// @Summary Transfer ownership
+// @Description Make another member the owner of an organization. Requires the owner role; the previous owner becomes an admin.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Param request body requests.TransferOrganizationRequest true "Transfer ownership request"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organizations/{org_id}/owner [put]
+func (h *OrganizationHandler) TransferOwnership(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req requests.TransferOrganizationRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.organizationService.TransferOwnership(c.Request().Context(), orgID, userID, req.UserID); err != nil {
+		return eris.Wrap(err, "failed to transfer organization ownership")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateInvitation invites someone to an organization by email
+// @Summary Invite member
+// @Description Email an invitation to join an organization as an admin or member. Requires the admin role. Replaces any pending invitation for the same address.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Param request body requests.InviteOrganizationMemberRequest true "Invite member request"
+// @Success 201 {object} responses.OrganizationInvitationResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organizations/{org_id}/invitations [post]
+func (h *OrganizationHandler) CreateInvitation(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req requests.InviteOrganizationMemberRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	invitation, err := h.organizationService.Invite(c.Request().Context(), orgID, userID, req.Email, req.Role)
+	if err != nil {
+		return eris.Wrap(err, "failed to invite organization member")
+	}
+
+	return c.JSON(http.StatusCreated, invitationResponse(invitation))
+}
+
+// ListInvitations returns an organization's pending invitations
+// @Summary List invitations
+// @Description List invitations that have not been answered and have not expired. Requires the admin role.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Success 200 {object} responses.OrganizationInvitationListResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /organizations/{org_id}/invitations [get]
+func (h *OrganizationHandler) ListInvitations(c *echo.Context) error {
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.organizationService.ListInvitations(c.Request().Context(), orgID)
+	if err != nil {
+		return eris.Wrap(err, "failed to list invitations")
+	}
+
+	invitations := make([]responses.OrganizationInvitationResponse, 0, len(rows))
+	for i := range rows {
+		invitations = append(invitations, invitationResponse(&rows[i]))
+	}
+
+	return c.JSON(http.StatusOK, responses.OrganizationInvitationListResponse{
+		Invitations: invitations,
+	})
+}
+
+// DeleteInvitation revokes a pending invitation
+// @Summary Revoke invitation
+// @Description Revoke an invitation that has not been answered. Requires the admin role.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param org_id path string true "Organization ID"
+// @Param id path string true "Invitation ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /organizations/{org_id}/invitations/{id} [delete]
+func (h *OrganizationHandler) DeleteInvitation(c *echo.Context) error {
+	orgID, _, err := organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	invitationID, err := uuid.Parse(param)
+	if err != nil {
+		return apperrors.ErrInvalidInvitationID
+	}
+
+	if err := h.organizationService.RevokeInvitation(c.Request().Context(), orgID, invitationID); err != nil {
+		return eris.Wrap(err, "failed to revoke invitation")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AcceptInvitation joins an organization using an invitation token
+// @Summary Accept invitation
+// @Description Join an organization using the token from an invitation email. The invitation must have been sent to the current user's email address, and admin invitations can't be accepted while the account is scheduled for deletion.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token path string true "Invitation token"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organization-invitations/{token} [put]
+func (h *OrganizationHandler) AcceptInvitation(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	token, err := bind.RequiredParam(c, "token")
+	if err != nil {
+		return err
+	}
+
+	if err := h.organizationService.AcceptInvitation(c.Request().Context(), userID, token); err != nil {
+		return eris.Wrap(err, "failed to accept invitation")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeclineInvitation declines an invitation using its token
+// @Summary Decline invitation
+// @Description Decline an invitation using the token from the invitation email. No authentication is required.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param token path string true "Invitation token"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /organization-invitations/{token} [delete]
+func (h *OrganizationHandler) DeclineInvitation(c *echo.Context) error {
+	token, err := bind.RequiredParam(c, "token")
+	if err != nil {
+		return err
+	}
+
+	if err := h.organizationService.DeclineInvitation(c.Request().Context(), token); err != nil {
+		return eris.Wrap(err, "failed to decline invitation")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// organizationFromContext returns the organization and role RequireOrgRole
+// resolved for the request.
+func organizationFromContext(c *echo.Context) (uuid.UUID, string, error) {
+	orgID, ok := reqctx.GetOrganizationID(c)
+	if !ok {
+		return uuid.Nil, "", apperrors.ErrOrganizationNotFound
+	}
+	role, _ := reqctx.GetOrganizationRole(c)
+	return orgID, role, nil
+}
+
+func memberIDParam(c *echo.Context) (uuid.UUID, error) {
+	param, err := bind.RequiredParam(c, "user_id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	memberID, err := uuid.Parse(param)
+	if err != nil {
+		return uuid.Nil, apperrors.ErrInvalidUserID
+	}
+	return memberID, nil
+}
+
+func organizationResponse(organization *sqlcgen.Organization, role string) responses.OrganizationResponse {
+	return responses.OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		Role:      role,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
+
+func invitationResponse(invitation *sqlcgen.OrganizationInvitation) responses.OrganizationInvitationResponse {
+	return responses.OrganizationInvitationResponse{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
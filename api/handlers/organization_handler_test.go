@@ -0,0 +1,382 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationHandler_Create(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockOrganizationService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "creates organization owned by the user",
+			requestBody: `{"name":" Acme "}`,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().Create(mock.Anything, userID, "Acme").
+					Return(&sqlcgen.Organization{ID: orgID, Name: "Acme"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "returns error for missing name",
+			requestBody:    `{}`,
+			setupMock:      func(orgSvc *mocks.MockOrganizationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOrgSvc := mocks.NewMockOrganizationService(t)
+			tt.setupMock(mockOrgSvc)
+
+			handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/organizations", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			reqctx.SetUserID(c, userID)
+
+			err := handler.Create(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.OrganizationResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, orgID, resp.ID)
+				assert.Equal(t, services.OrgRoleOwner, resp.Role)
+			}
+		})
+	}
+}
+
+func TestOrganizationHandler_Get(t *testing.T) {
+	orgID := uuid.New()
+
+	e := setupEcho()
+	mockOrgSvc := mocks.NewMockOrganizationService(t)
+	mockOrgSvc.EXPECT().Get(mock.Anything, orgID).Return(&sqlcgen.Organization{ID: orgID, Name: "Acme"}, nil)
+
+	handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/organizations/"+orgID.String(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	reqctx.SetOrganization(c, orgID, services.OrgRoleMember)
+
+	require.NoError(t, handler.Get(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp responses.OrganizationResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "Acme", resp.Name)
+	assert.Equal(t, services.OrgRoleMember, resp.Role)
+}
+
+func TestOrganizationHandler_UpdateMember(t *testing.T) {
+	orgID := uuid.New()
+	memberID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		requestBody    string
+		setupMock      func(*mocks.MockOrganizationService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "changes the role",
+			param:       memberID.String(),
+			requestBody: `{"role":"admin"}`,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().UpdateMemberRole(mock.Anything, orgID, memberID, services.OrgRoleAdmin).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns error for invalid user id",
+			param:          "not-a-uuid",
+			requestBody:    `{"role":"admin"}`,
+			setupMock:      func(orgSvc *mocks.MockOrganizationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_USER_ID",
+		},
+		{
+			name:           "returns error for owner role",
+			param:          memberID.String(),
+			requestBody:    `{"role":"owner"}`,
+			setupMock:      func(orgSvc *mocks.MockOrganizationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error when changing the owner",
+			param:       memberID.String(),
+			requestBody: `{"role":"member"}`,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().UpdateMemberRole(mock.Anything, orgID, memberID, services.OrgRoleMember).Return(apperrors.ErrOrganizationOwner)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "ORGANIZATION_OWNER",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOrgSvc := mocks.NewMockOrganizationService(t)
+			tt.setupMock(mockOrgSvc)
+
+			handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+			req := httptest.NewRequest(http.MethodPatch, "/organizations/"+orgID.String()+"/members/"+tt.param, strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "org_id", Value: orgID.String()}, {Name: "user_id", Value: tt.param}})
+			reqctx.SetOrganization(c, orgID, services.OrgRoleAdmin)
+
+			err := handler.UpdateMember(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestOrganizationHandler_DeleteMember(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	e := setupEcho()
+	mockOrgSvc := mocks.NewMockOrganizationService(t)
+	mockOrgSvc.EXPECT().RemoveMember(mock.Anything, orgID, userID, userID).Return(nil)
+
+	handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/organizations/"+orgID.String()+"/members/"+userID.String(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPathValues(echo.PathValues{{Name: "org_id", Value: orgID.String()}, {Name: "user_id", Value: userID.String()}})
+	reqctx.SetUserID(c, userID)
+	reqctx.SetOrganization(c, orgID, services.OrgRoleMember)
+
+	require.NoError(t, handler.DeleteMember(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestOrganizationHandler_TransferOwnership(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+	newOwnerID := uuid.New()
+
+	e := setupEcho()
+	mockOrgSvc := mocks.NewMockOrganizationService(t)
+	mockOrgSvc.EXPECT().TransferOwnership(mock.Anything, orgID, userID, newOwnerID).Return(nil)
+
+	handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+	req := httptest.NewRequest(http.MethodPut, "/organizations/"+orgID.String()+"/owner", strings.NewReader(`{"user_id":"`+newOwnerID.String()+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	reqctx.SetUserID(c, userID)
+	reqctx.SetOrganization(c, orgID, services.OrgRoleOwner)
+
+	require.NoError(t, handler.TransferOwnership(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestOrganizationHandler_CreateInvitation(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+	invitationID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockOrganizationService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "invites by email",
+			requestBody: `{"email":"new@example.com","role":"member"}`,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().Invite(mock.Anything, orgID, userID, "new@example.com", services.OrgRoleMember).
+					Return(&sqlcgen.OrganizationInvitation{ID: invitationID, Email: "new@example.com", Role: services.OrgRoleMember, TokenHash: "secret-hash"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "returns error for invalid email",
+			requestBody:    `{"email":"not-an-email","role":"member"}`,
+			setupMock:      func(orgSvc *mocks.MockOrganizationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error when already a member",
+			requestBody: `{"email":"member@example.com","role":"admin"}`,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().Invite(mock.Anything, orgID, userID, "member@example.com", services.OrgRoleAdmin).
+					Return(nil, apperrors.ErrAlreadyOrganizationMember)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "ALREADY_ORGANIZATION_MEMBER",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOrgSvc := mocks.NewMockOrganizationService(t)
+			tt.setupMock(mockOrgSvc)
+
+			handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/organizations/"+orgID.String()+"/invitations", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			reqctx.SetUserID(c, userID)
+			reqctx.SetOrganization(c, orgID, services.OrgRoleAdmin)
+
+			err := handler.CreateInvitation(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				assert.NotContains(t, rec.Body.String(), "secret-hash")
+
+				var resp responses.OrganizationInvitationResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, invitationID, resp.ID)
+			}
+		})
+	}
+}
+
+func TestOrganizationHandler_AcceptInvitation(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.MockOrganizationService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "joins the organization",
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().AcceptInvitation(mock.Anything, userID, "invite-token").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "returns error for another user's invitation",
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().AcceptInvitation(mock.Anything, userID, "invite-token").Return(apperrors.ErrInvitationEmailMismatch)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "INVITATION_EMAIL_MISMATCH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockOrgSvc := mocks.NewMockOrganizationService(t)
+			tt.setupMock(mockOrgSvc)
+
+			handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/organization-invitations/invite-token", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "token", Value: "invite-token"}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler.AcceptInvitation(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestOrganizationHandler_DeclineInvitation(t *testing.T) {
+	e := setupEcho()
+	mockOrgSvc := mocks.NewMockOrganizationService(t)
+	mockOrgSvc.EXPECT().DeclineInvitation(mock.Anything, "invite-token").Return(nil)
+
+	handler := handlers.NewOrganizationHandler(mockOrgSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/organization-invitations/invite-token", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPathValues(echo.PathValues{{Name: "token", Value: "invite-token"}})
+
+	require.NoError(t, handler.DeclineInvitation(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
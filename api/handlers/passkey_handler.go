@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// PasskeyHandler handles passkey registration and passwordless login.
+type PasskeyHandler struct {
+	config         *config.Config
+	passkeyService services.PasskeyService
+}
+
+func NewPasskeyHandler(cfg *config.Config, passkeyService services.PasskeyService) *PasskeyHandler {
+	return &PasskeyHandler{
+		config:         cfg,
+		passkeyService: passkeyService,
+	}
+}
+
+// RegistrationOptions starts passkey registration
+// @Summary Start passkey registration
+// @Description Issue options for navigator.credentials.create. The challenge is single-use and expires after webauthn.challenge_ttl.
+// @Tags passkeys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} responses.PasskeyRegistrationOptionsResponse
+// @Failure 401 {object} errors.AppError
+// @Router /users/me/passkeys/options [post]
+func (h *PasskeyHandler) RegistrationOptions(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	options, err := h.passkeyService.BeginRegistration(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to begin passkey registration")
+	}
+
+	params := make([]responses.PasskeyCredentialParameter, 0, len(options.Algorithms))
+	for _, alg := range options.Algorithms {
+		params = append(params, responses.PasskeyCredentialParameter{Type: "public-key", Alg: alg})
+	}
+
+	exclude := make([]responses.PasskeyCredentialDescriptor, 0, len(options.ExcludeCredentials))
+	for _, credential := range options.ExcludeCredentials {
+		exclude = append(exclude, responses.PasskeyCredentialDescriptor{
+			Type:       "public-key",
+			ID:         base64.RawURLEncoding.EncodeToString(credential.ID),
+			Transports: credential.Transports,
+		})
+	}
+
+	return c.JSON(http.StatusOK, responses.PasskeyRegistrationOptionsResponse{
+		Challenge: options.Challenge,
+		RP: responses.PasskeyRelyingParty{
+			ID:   options.RPID,
+			Name: options.RPName,
+		},
+		User: responses.PasskeyUser{
+			ID:          base64.RawURLEncoding.EncodeToString(options.User.ID[:]),
+			Name:        options.User.Email,
+			DisplayName: options.User.Name,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            options.Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: responses.PasskeyAuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		},
+		Attestation: "none",
+	})
+}
+
+// Register finishes passkey registration
+// @Summary Register passkey
+// @Description Verify the authenticator's response to the registration options and store the passkey
+// @Tags passkeys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.RegisterPasskeyRequest true "Register passkey request"
+// @Success 201 {object} responses.PasskeyResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/passkeys [post]
+func (h *PasskeyHandler) Register(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.RegisterPasskeyRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	credential, err := h.passkeyService.FinishRegistration(c.Request().Context(), userID, services.PasskeyRegistration{
+		Name:       strings.TrimSpace(req.Name),
+		Credential: req.Credential,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to finish passkey registration")
+	}
+
+	return c.JSON(http.StatusCreated, passkeyResponse(credential))
+}
+
+// List returns the current user's passkeys
+// @Summary List passkeys
+// @Description List the passkeys registered by the current user
+// @Tags passkeys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} responses.PasskeyListResponse
+// @Failure 401 {object} errors.AppError
+// @Router /users/me/passkeys [get]
+func (h *PasskeyHandler) List(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	credentials, err := h.passkeyService.ListForUser(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to list passkeys")
+	}
+
+	passkeys := make([]responses.PasskeyResponse, 0, len(credentials))
+	for i := range credentials {
+		passkeys = append(passkeys, passkeyResponse(&credentials[i]))
+	}
+
+	return c.JSON(http.StatusOK, responses.PasskeyListResponse{
+		Passkeys: passkeys,
+	})
+}
+
+// Delete removes one of the current user's passkeys
+// @Summary Delete passkey
+// @Description Remove one of the current user's passkeys by ID
+// @Tags passkeys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Passkey ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /users/me/passkeys/{id} [delete]
+func (h *PasskeyHandler) Delete(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	passkeyID, err := uuid.Parse(param)
+	if err != nil {
+		return apperrors.ErrInvalidPasskeyID
+	}
+
+	if err := h.passkeyService.Delete(c.Request().Context(), userID, passkeyID); err != nil {
+		return eris.Wrap(err, "failed to delete passkey")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// LoginOptions starts a passkey login
+// @Summary Start passkey login
+// @Description Issue options for navigator.credentials.get. No email is needed: the passkey identifies the account.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Success 200 {object} responses.PasskeyLoginOptionsResponse
+// @Router /sessions/passkey/options [post]
+func (h *PasskeyHandler) LoginOptions(c *echo.Context) error {
+	options, err := h.passkeyService.BeginLogin(c.Request().Context())
+	if err != nil {
+		return eris.Wrap(err, "failed to begin passkey login")
+	}
+
+	return c.JSON(http.StatusOK, responses.PasskeyLoginOptionsResponse{
+		Challenge:        options.Challenge,
+		RPID:             options.RPID,
+		Timeout:          options.Timeout.Milliseconds(),
+		UserVerification: "required",
+	})
+}
+
+// Login finishes a passkey login
+// @Summary Login with passkey
+// @Description Verify the authenticator's response to the login options and create a session
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param request body requests.PasskeyLoginRequest true "Passkey login request"
+// @Success 201 {object} responses.SessionResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Router /sessions/passkey [post]
+func (h *PasskeyHandler) Login(c *echo.Context) error {
+	var req requests.PasskeyLoginRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	user, tokens, err := h.passkeyService.FinishLogin(c.Request().Context(), req.Credential, clientInfo(c))
+	if err != nil {
+		return eris.Wrap(err, "failed to finish passkey login")
+	}
+
+	return sessionCreated(c, h.config, user, tokens)
+}
+
+func passkeyResponse(credential *sqlcgen.WebauthnCredential) responses.PasskeyResponse {
+	return responses.PasskeyResponse{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		Transports: credential.Transports,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
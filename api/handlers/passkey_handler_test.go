@@ -0,0 +1,437 @@
+package handlers_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+	"go-reasonable-api/support/webauthn/webauthntest"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func b64url(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func newHandlerTestAuthenticator(t *testing.T) *webauthntest.Authenticator {
+	authenticator, err := webauthntest.NewAuthenticator("localhost", "http://localhost:3000")
+	require.NoError(t, err)
+	return authenticator
+}
+
+func TestPasskeyHandler_RegistrationOptions(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockPasskeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "returns creation options",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().BeginRegistration(mock.Anything, userID).Return(&services.PasskeyRegistrationOptions{
+					Challenge:          "challenge",
+					RPID:               "localhost",
+					RPName:             "Test App",
+					User:               &sqlcgen.User{ID: userID, Name: "Test User", Email: "test@example.com"},
+					Algorithms:         []int64{-7, -257},
+					ExcludeCredentials: []services.PasskeyDescriptor{{ID: []byte{1, 2, 3}, Transports: []string{"internal"}}},
+					Timeout:            5 * time.Minute,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error when user not in context",
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(passkeySvc *mocks.MockPasskeyService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockPasskeySvc := mocks.NewMockPasskeyService(t)
+			tt.setupMock(mockPasskeySvc)
+
+			handler := handlers.NewPasskeyHandler(&config.Config{}, mockPasskeySvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/passkeys/options", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.RegistrationOptions(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.PasskeyRegistrationOptionsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "challenge", resp.Challenge)
+				assert.Equal(t, "localhost", resp.RP.ID)
+				assert.Equal(t, b64url(userID[:]), resp.User.ID)
+				assert.Equal(t, "test@example.com", resp.User.Name)
+				assert.Len(t, resp.PubKeyCredParams, 2)
+				assert.Equal(t, int64(300000), resp.Timeout)
+				require.Len(t, resp.ExcludeCredentials, 1)
+				assert.Equal(t, "AQID", resp.ExcludeCredentials[0].ID)
+				assert.Equal(t, "required", resp.AuthenticatorSelection.UserVerification)
+				assert.Equal(t, "none", resp.Attestation)
+			}
+		})
+	}
+}
+
+func TestPasskeyHandler_Register(t *testing.T) {
+	userID := uuid.New()
+	authenticator := newHandlerTestAuthenticator(t)
+	credential, err := authenticator.Create("challenge")
+	require.NoError(t, err)
+
+	validBody := func(name string) string {
+		body, err := json.Marshal(map[string]any{
+			"name":       name,
+			"credential": json.RawMessage(credential),
+		})
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockPasskeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "registers passkey",
+			requestBody: validBody(" Laptop "),
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().FinishRegistration(mock.Anything, userID, services.PasskeyRegistration{
+					Name:       "Laptop",
+					Credential: credential,
+				}).Return(&sqlcgen.WebauthnCredential{ID: uuid.New(), Name: "Laptop", Transports: []string{"internal"}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "returns error for missing credential",
+			requestBody: `{"name":"Laptop"}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock:      func(passkeySvc *mocks.MockPasskeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error for malformed credential",
+			requestBody: `{"credential":{"id":"abc","type":"public-key"}}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().FinishRegistration(mock.Anything, userID, mock.Anything).Return(nil, apperrors.ErrInvalidPasskeyResponse)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_PASSKEY_RESPONSE",
+		},
+		{
+			name:        "returns error when verification fails",
+			requestBody: validBody("Laptop"),
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().FinishRegistration(mock.Anything, userID, mock.Anything).Return(nil, apperrors.ErrPasskeyRegistrationFailed)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "PASSKEY_REGISTRATION_FAILED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockPasskeySvc := mocks.NewMockPasskeyService(t)
+			tt.setupMock(mockPasskeySvc)
+
+			handler := handlers.NewPasskeyHandler(&config.Config{}, mockPasskeySvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/passkeys", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Register(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.PasskeyResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "Laptop", resp.Name)
+			}
+		})
+	}
+}
+
+func TestPasskeyHandler_List(t *testing.T) {
+	userID := uuid.New()
+
+	e := setupEcho()
+	mockPasskeySvc := mocks.NewMockPasskeyService(t)
+	mockPasskeySvc.EXPECT().ListForUser(mock.Anything, userID).Return([]sqlcgen.WebauthnCredential{
+		{ID: uuid.New(), Name: "Laptop", Transports: []string{"internal"}},
+		{ID: uuid.New(), Name: "Phone", Transports: []string{"hybrid"}},
+	}, nil)
+
+	handler := handlers.NewPasskeyHandler(&config.Config{}, mockPasskeySvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/passkeys", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	reqctx.SetUserID(c, userID)
+
+	require.NoError(t, handler.List(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp responses.PasskeyListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Passkeys, 2)
+	assert.Equal(t, "Laptop", resp.Passkeys[0].Name)
+}
+
+func TestPasskeyHandler_Delete(t *testing.T) {
+	userID := uuid.New()
+	passkeyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		setupMock      func(*mocks.MockPasskeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "deletes passkey",
+			param: passkeyID.String(),
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().Delete(mock.Anything, userID, passkeyID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns error for invalid id",
+			param:          "not-a-uuid",
+			setupMock:      func(passkeySvc *mocks.MockPasskeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_PASSKEY_ID",
+		},
+		{
+			name:  "returns error when passkey not found",
+			param: passkeyID.String(),
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().Delete(mock.Anything, userID, passkeyID).Return(apperrors.ErrPasskeyNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "PASSKEY_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockPasskeySvc := mocks.NewMockPasskeyService(t)
+			tt.setupMock(mockPasskeySvc)
+
+			handler := handlers.NewPasskeyHandler(&config.Config{}, mockPasskeySvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/me/passkeys/"+tt.param, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.param}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler.Delete(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestPasskeyHandler_LoginOptions(t *testing.T) {
+	e := setupEcho()
+	mockPasskeySvc := mocks.NewMockPasskeyService(t)
+	mockPasskeySvc.EXPECT().BeginLogin(mock.Anything).Return(&services.PasskeyLoginOptions{
+		Challenge: "challenge",
+		RPID:      "localhost",
+		Timeout:   time.Minute,
+	}, nil)
+
+	handler := handlers.NewPasskeyHandler(&config.Config{}, mockPasskeySvc)
+
+	req := httptest.NewRequest(http.MethodPost, "/sessions/passkey/options", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, handler.LoginOptions(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp responses.PasskeyLoginOptionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "challenge", resp.Challenge)
+	assert.Equal(t, "localhost", resp.RPID)
+	assert.Equal(t, int64(60000), resp.Timeout)
+	assert.Equal(t, "required", resp.UserVerification)
+}
+
+func TestPasskeyHandler_Login(t *testing.T) {
+	userID := uuid.New()
+	authenticator := newHandlerTestAuthenticator(t)
+	credential, err := authenticator.Get("challenge", userID[:])
+	require.NoError(t, err)
+
+	validBody := func() string {
+		body, err := json.Marshal(map[string]any{
+			"credential": json.RawMessage(credential),
+		})
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockPasskeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "creates session",
+			requestBody: validBody(),
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().FinishLogin(mock.Anything, credential, mock.AnythingOfType("services.ClientInfo")).Return(
+					&sqlcgen.User{ID: userID, Email: "test@example.com"},
+					&services.SessionTokens{AccessToken: "token", AccessTokenExpiresAt: time.Now().Add(time.Hour)},
+					nil,
+				)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "returns error for missing credential",
+			requestBody:    `{}`,
+			setupMock:      func(passkeySvc *mocks.MockPasskeyService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error for malformed credential",
+			requestBody: `{"credential":{"id":"a*b","type":"public-key"}}`,
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().FinishLogin(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, apperrors.ErrInvalidPasskeyResponse)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_PASSKEY_RESPONSE",
+		},
+		{
+			name:        "returns error for invalid passkey",
+			requestBody: validBody(),
+			setupMock: func(passkeySvc *mocks.MockPasskeyService) {
+				passkeySvc.EXPECT().FinishLogin(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, apperrors.ErrInvalidPasskey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_PASSKEY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockPasskeySvc := mocks.NewMockPasskeyService(t)
+			tt.setupMock(mockPasskeySvc)
+
+			handler := handlers.NewPasskeyHandler(&config.Config{}, mockPasskeySvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/sessions/passkey", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.Login(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.SessionResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "token", resp.Token)
+				assert.Equal(t, userID, resp.User.ID)
+			}
+		})
+	}
+}
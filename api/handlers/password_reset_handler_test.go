@@ -148,10 +148,13 @@ func TestPasswordResetHandler_Update(t *testing.T) {
 			expectedError:  "VALIDATION_ERROR",
 		},
 		{
-			name:           "returns error for short password",
-			token:          "valid-token",
-			requestBody:    `{"new_password":"short"}`,
-			setupMock:      func(pwResetSvc *mocks.MockPasswordResetService) {},
+			name:        "leaves password length to the password policy",
+			token:       "valid-token",
+			requestBody: `{"new_password":"short"}`,
+			setupMock: func(pwResetSvc *mocks.MockPasswordResetService) {
+				pwResetSvc.EXPECT().Execute(mock.Anything, "valid-token", "short").
+					Return(errors.NewWithDetails("VALIDATION_ERROR", "validation failed", http.StatusBadRequest, map[string]any{"password": []string{"must be at least 8 characters"}}))
+			},
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "VALIDATION_ERROR",
 		},
@@ -7,34 +7,42 @@ import (
 	"go-reasonable-api/api/responses"
 	apperrors "go-reasonable-api/app/errors"
 	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/http/bind"
 	"go-reasonable-api/support/http/reqctx"
+	"go-reasonable-api/support/http/sessioncookie"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v5"
 	"github.com/rotisserie/eris"
 )
 
 // SessionHandler handles authentication (login/logout).
 type SessionHandler struct {
+	config         *config.Config
 	sessionService services.SessionService
 }
 
-func NewSessionHandler(sessionService services.SessionService) *SessionHandler {
+func NewSessionHandler(cfg *config.Config, sessionService services.SessionService) *SessionHandler {
 	return &SessionHandler{
+		config:         cfg,
 		sessionService: sessionService,
 	}
 }
 
 // Create authenticates a user and returns a token
 // @Summary Create session (login)
-// @Description Authenticate user with email and password
+// @Description Authenticate user with email and password. When two-factor authentication is enabled, responds with 202 and a challenge token to be completed via POST /sessions/two-factor. With cookie sessions enabled and the X-Session-Cookie header set to true, the tokens are set in the session and refresh cookies along with a CSRF cookie instead of being returned.
 // @Tags sessions
 // @Accept json
 // @Produce json
 // @Param request body requests.CreateSessionRequest true "Create session request"
 // @Success 201 {object} responses.SessionResponse
+// @Success 202 {object} responses.TwoFactorChallengeResponse
 // @Failure 400 {object} errors.AppError
 // @Failure 401 {object} errors.AppError
+// @Failure 429 {object} errors.AppError
 // @Router /sessions [post]
 func (h *SessionHandler) Create(c *echo.Context) error {
 	var req requests.CreateSessionRequest
@@ -42,25 +50,102 @@ func (h *SessionHandler) Create(c *echo.Context) error {
 		return err
 	}
 
-	user, token, err := h.sessionService.Create(c.Request().Context(), req.Email, req.Password)
+	result, err := h.sessionService.Create(c.Request().Context(), req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		return eris.Wrap(err, "failed to create session")
 	}
 
-	return c.JSON(http.StatusCreated, responses.SessionResponse{
-		User: responses.UserResponse{
-			ID:            user.ID,
-			Name:          user.Name,
-			Email:         user.Email,
-			EmailVerified: user.EmailVerifiedAt != nil,
-		},
-		Token: token,
-	})
+	return loginResponse(c, h.config, result)
+}
+
+// CompleteTwoFactor finishes a login that requires two-factor authentication
+// @Summary Complete two-factor login
+// @Description Exchange a login challenge token and a TOTP or recovery code for a session
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param request body requests.CompleteTwoFactorRequest true "Complete two-factor request"
+// @Success 201 {object} responses.SessionResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /sessions/two-factor [post]
+func (h *SessionHandler) CompleteTwoFactor(c *echo.Context) error {
+	var req requests.CompleteTwoFactorRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	user, tokens, err := h.sessionService.CompleteTwoFactor(c.Request().Context(), req.ChallengeToken, req.Code, clientInfo(c))
+	if err != nil {
+		return eris.Wrap(err, "failed to complete two factor login")
+	}
+
+	return sessionCreated(c, h.config, user, tokens)
+}
+
+// Refresh exchanges a refresh token for a new token pair
+// @Summary Refresh session
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing one revokes the whole session. With cookie sessions enabled and the X-Session-Cookie header set to true, the refresh token is read from the refresh cookie instead of the body, the request must carry the CSRF header, and the new tokens are set in cookies instead of being returned.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param request body requests.RefreshSessionRequest false "Refresh session request"
+// @Success 200 {object} responses.RefreshSessionResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 403 {object} errors.AppError
+// @Router /sessions/refresh [post]
+func (h *SessionHandler) Refresh(c *echo.Context) error {
+	refreshToken, err := h.refreshToken(c)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := h.sessionService.Refresh(c.Request().Context(), refreshToken, clientInfo(c))
+	if err != nil {
+		return eris.Wrap(err, "failed to refresh session")
+	}
+
+	resp := responses.RefreshSessionResponse{
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+	if sessioncookie.Requested(c, h.config.SessionCookie) {
+		setSessionCookies(c, h.config, tokens)
+	} else {
+		resp.Token = tokens.AccessToken
+		resp.RefreshToken = tokens.RefreshToken
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// refreshToken returns the refresh token from the refresh cookie when the
+// client asked for cookie sessions, or from the request body otherwise. The
+// cookie is sent by the browser on its own, so it needs the CSRF check.
+func (h *SessionHandler) refreshToken(c *echo.Context) (string, error) {
+	if sessioncookie.Requested(c, h.config.SessionCookie) {
+		if !sessioncookie.ValidCSRF(c, h.config.SessionCookie) {
+			return "", apperrors.ErrInvalidCSRFToken
+		}
+		token, ok := sessioncookie.RefreshToken(c, h.config.SessionCookie)
+		if !ok {
+			return "", apperrors.ErrInvalidRefreshToken
+		}
+		return token, nil
+	}
+
+	var req requests.RefreshSessionRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return "", err
+	}
+	return req.RefreshToken, nil
 }
 
 // DeleteCurrent invalidates the current user's token
 // @Summary Delete current session (logout)
-// @Description Invalidate the current user's authentication token
+// @Description Invalidate the current user's authentication token and clear the session cookies
 // @Tags sessions
 // @Accept json
 // @Produce json
@@ -78,5 +163,180 @@ func (h *SessionHandler) DeleteCurrent(c *echo.Context) error {
 		return eris.Wrap(err, "failed to delete session")
 	}
 
+	sessioncookie.Clear(c, h.config.SessionCookie)
 	return c.NoContent(http.StatusNoContent)
 }
+
+// List returns the current user's active sessions
+// @Summary List sessions
+// @Description List the current user's active sessions, marking the one used for this request
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Items per page (default 20, max 100)"
+// @Success 200 {object} responses.SessionListResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Router /sessions [get]
+func (h *SessionHandler) List(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+	currentID, _ := reqctx.GetSessionID(c)
+
+	var req requests.ListSessionsRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	tokens, total, err := h.sessionService.ListForUser(c.Request().Context(), userID, req.Limit(), req.Offset())
+	if err != nil {
+		return eris.Wrap(err, "failed to list sessions")
+	}
+
+	sessions := make([]responses.SessionInfoResponse, 0, len(tokens))
+	for _, t := range tokens {
+		sessions = append(sessions, responses.SessionInfoResponse{
+			ID:         t.ID,
+			UserAgent:  t.UserAgent,
+			IPAddress:  t.IpAddress,
+			Current:    t.ID == currentID,
+			CreatedAt:  t.CreatedAt,
+			LastUsedAt: t.LastUsedAt,
+			ExpiresAt:  t.ExpiresAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, responses.SessionListResponse{
+		Sessions: sessions,
+		Pagination: responses.PaginationResponse{
+			Page:    req.PageOrDefault(),
+			PerPage: req.PerPageOrDefault(),
+			Total:   total,
+		},
+	})
+}
+
+// Delete revokes one of the current user's sessions
+// @Summary Delete session
+// @Description Revoke one of the current user's sessions by ID
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 404 {object} errors.AppError
+// @Router /sessions/{id} [delete]
+func (h *SessionHandler) Delete(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	param, err := bind.RequiredParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := uuid.Parse(param)
+	if err != nil {
+		return apperrors.ErrInvalidSessionID
+	}
+
+	if err := h.sessionService.Revoke(c.Request().Context(), userID, sessionID); err != nil {
+		return eris.Wrap(err, "failed to revoke session")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteOthers revokes every session except the current one
+// @Summary Delete other sessions
+// @Description Revoke all of the current user's sessions except the one used for this request
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} errors.AppError
+// @Router /sessions/others [delete]
+func (h *SessionHandler) DeleteOthers(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	sessionID, ok := reqctx.GetSessionID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	if err := h.sessionService.RevokeOthers(c.Request().Context(), userID, sessionID); err != nil {
+		return eris.Wrap(err, "failed to revoke other sessions")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// sessionResponse builds the response returned whenever a session is created.
+func sessionResponse(user *sqlcgen.User, tokens *services.SessionTokens) responses.SessionResponse {
+	return responses.SessionResponse{
+		User: responses.UserResponse{
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerifiedAt != nil,
+		},
+		Token:                 tokens.AccessToken,
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+// sessionCreated writes a new session. When the client asked for cookie
+// sessions its tokens are set in HttpOnly cookies and left out of the body,
+// so scripts never see them.
+func sessionCreated(c *echo.Context, cfg *config.Config, user *sqlcgen.User, tokens *services.SessionTokens) error {
+	resp := sessionResponse(user, tokens)
+	if sessioncookie.Requested(c, cfg.SessionCookie) {
+		setSessionCookies(c, cfg, tokens)
+		resp.Token = ""
+		resp.RefreshToken = ""
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// setSessionCookies sets the session, refresh and CSRF cookies for tokens.
+func setSessionCookies(c *echo.Context, cfg *config.Config, tokens *services.SessionTokens) {
+	sessioncookie.Set(c, cfg.SessionCookie, tokens.AccessToken, tokens.AccessTokenExpiresAt)
+	sessioncookie.SetRefresh(c, cfg.SessionCookie, tokens.RefreshToken, tokens.RefreshTokenExpiresAt)
+}
+
+// loginResponse writes a login result: the session, or the two-factor
+// challenge the client must complete first.
+func loginResponse(c *echo.Context, cfg *config.Config, result *services.LoginResult) error {
+	if result.Challenge != nil {
+		return c.JSON(http.StatusAccepted, responses.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    result.Challenge.Token,
+			ExpiresAt:         result.Challenge.ExpiresAt,
+		})
+	}
+
+	return sessionCreated(c, cfg, result.User, result.Tokens)
+}
+
+// clientInfo extracts the client details recorded on new sessions.
+func clientInfo(c *echo.Context) services.ClientInfo {
+	return services.ClientInfo{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"go-reasonable-api/api/handlers"
 	"go-reasonable-api/api/responses"
 	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
 	mocks "go-reasonable-api/app/mocks/services"
 	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/errors"
 	zhttp "go-reasonable-api/support/http"
 	"go-reasonable-api/support/http/reqctx"
@@ -44,15 +47,30 @@ func TestSessionHandler_Create(t *testing.T) {
 			name:        "creates session successfully",
 			requestBody: `{"email":"test@example.com","password":"password123"}`,
 			setupMock: func(sessionSvc *mocks.MockSessionService) {
-				sessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "password123").
-					Return(&sqlcgen.User{
-						ID:    userID,
-						Name:  "Test User",
-						Email: "test@example.com",
-					}, "token123", nil)
+				sessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "password123", mock.Anything).
+					Return(&services.LoginResult{
+						User: &sqlcgen.User{
+							ID:    userID,
+							Name:  "Test User",
+							Email: "test@example.com",
+						},
+						Tokens: &services.SessionTokens{AccessToken: "token123"},
+					}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 		},
+		{
+			name:        "returns challenge when two factor is required",
+			requestBody: `{"email":"test@example.com","password":"password123"}`,
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "password123", mock.Anything).
+					Return(&services.LoginResult{
+						User:      &sqlcgen.User{ID: userID},
+						Challenge: &services.TwoFactorChallenge{Token: "challenge123", ExpiresAt: time.Now().Add(5 * time.Minute)},
+					}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
 		{
 			name:           "returns error for invalid JSON",
 			requestBody:    `{invalid}`,
@@ -85,8 +103,8 @@ func TestSessionHandler_Create(t *testing.T) {
 			name:        "returns error for invalid credentials",
 			requestBody: `{"email":"test@example.com","password":"wrongpassword"}`,
 			setupMock: func(sessionSvc *mocks.MockSessionService) {
-				sessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "wrongpassword").
-					Return(nil, "", apperrors.ErrInvalidCredentials)
+				sessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "wrongpassword", mock.Anything).
+					Return(nil, apperrors.ErrInvalidCredentials)
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedError:  "INVALID_CREDENTIALS",
@@ -99,7 +117,7 @@ func TestSessionHandler_Create(t *testing.T) {
 			mockSessionSvc := mocks.NewMockSessionService(t)
 			tt.setupMock(mockSessionSvc)
 
-			handler := handlers.NewSessionHandler(mockSessionSvc)
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
 
 			req := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(tt.requestBody))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -121,6 +139,96 @@ func TestSessionHandler_Create(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, tt.expectedStatus, rec.Code)
 
+				if tt.expectedStatus == http.StatusAccepted {
+					var resp responses.TwoFactorChallengeResponse
+					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+					assert.True(t, resp.TwoFactorRequired)
+					assert.Equal(t, "challenge123", resp.ChallengeToken)
+					return
+				}
+
+				var resp responses.SessionResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, userID, resp.User.ID)
+				assert.Equal(t, "token123", resp.Token)
+			}
+		})
+	}
+}
+
+func TestSessionHandler_CompleteTwoFactor(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockSessionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "completes login successfully",
+			requestBody: `{"challenge_token":"challenge123","code":"123456"}`,
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().CompleteTwoFactor(mock.Anything, "challenge123", "123456", mock.Anything).
+					Return(&sqlcgen.User{ID: userID}, &services.SessionTokens{AccessToken: "token123"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "returns error for missing code",
+			requestBody:    `{"challenge_token":"challenge123"}`,
+			setupMock:      func(sessionSvc *mocks.MockSessionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error for invalid code",
+			requestBody: `{"challenge_token":"challenge123","code":"000000"}`,
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().CompleteTwoFactor(mock.Anything, "challenge123", "000000", mock.Anything).
+					Return(nil, nil, apperrors.ErrInvalidTwoFactorCode)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_TWO_FACTOR_CODE",
+		},
+		{
+			name:        "returns error for invalid challenge",
+			requestBody: `{"challenge_token":"expired","code":"123456"}`,
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().CompleteTwoFactor(mock.Anything, "expired", "123456", mock.Anything).
+					Return(nil, nil, apperrors.ErrInvalidTwoFactorChallenge)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TWO_FACTOR_CHALLENGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockSessionSvc)
+
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/sessions/two-factor", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.CompleteTwoFactor(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
 				var resp responses.SessionResponse
 				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
 				assert.Equal(t, userID, resp.User.ID)
@@ -175,7 +283,7 @@ func TestSessionHandler_DeleteCurrent(t *testing.T) {
 			mockSessionSvc := mocks.NewMockSessionService(t)
 			tt.setupMock(mockSessionSvc)
 
-			handler := handlers.NewSessionHandler(mockSessionSvc)
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
 
 			req := httptest.NewRequest(http.MethodDelete, "/sessions/current", nil)
 			rec := httptest.NewRecorder()
@@ -197,3 +305,535 @@ func TestSessionHandler_DeleteCurrent(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionHandler_SessionCookie(t *testing.T) {
+	cfg := &config.Config{SessionCookie: config.SessionCookieConfig{
+		Enabled:           true,
+		Name:              "session",
+		RefreshCookieName: "refresh_token",
+		SameSite:          "strict",
+		CSRFCookieName:    "csrf_token",
+		CSRFHeaderName:    "X-CSRF-Token",
+		RequestHeaderName: "X-Session-Cookie",
+	}}
+	expiresAt := time.Now().Add(time.Hour)
+	refreshExpiresAt := time.Now().Add(24 * time.Hour)
+
+	cookies := func(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+		byName := make(map[string]*http.Cookie)
+		for _, cookie := range rec.Result().Cookies() {
+			byName[cookie.Name] = cookie
+		}
+		return byName
+	}
+
+	t.Run("sets session, refresh and csrf cookies on login", func(t *testing.T) {
+		e := setupEcho()
+		mockSessionSvc := mocks.NewMockSessionService(t)
+		mockSessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "password123", mock.Anything).
+			Return(&services.LoginResult{
+				User: &sqlcgen.User{ID: uuid.New(), Email: "test@example.com"},
+				Tokens: &services.SessionTokens{
+					AccessToken:           "token123",
+					AccessTokenExpiresAt:  expiresAt,
+					RefreshToken:          "refresh123",
+					RefreshTokenExpiresAt: &refreshExpiresAt,
+				},
+			}, nil)
+
+		handler := handlers.NewSessionHandler(cfg, mockSessionSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(`{"email":"test@example.com","password":"password123"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("X-Session-Cookie", "true")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.Create(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		byName := cookies(rec)
+		require.Contains(t, byName, "session")
+		assert.Equal(t, "token123", byName["session"].Value)
+		assert.True(t, byName["session"].HttpOnly)
+		assert.True(t, byName["session"].Secure)
+		assert.Equal(t, http.SameSiteStrictMode, byName["session"].SameSite)
+		require.Contains(t, byName, "refresh_token")
+		assert.Equal(t, "refresh123", byName["refresh_token"].Value)
+		assert.True(t, byName["refresh_token"].HttpOnly)
+		assert.Equal(t, "/sessions/refresh", byName["refresh_token"].Path)
+		require.Contains(t, byName, "csrf_token")
+		assert.NotEmpty(t, byName["csrf_token"].Value)
+		assert.False(t, byName["csrf_token"].HttpOnly)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.NotContains(t, body, "token")
+		assert.NotContains(t, body, "refresh_token")
+		assert.Contains(t, body, "expires_at")
+	})
+
+	t.Run("refreshes with the refresh cookie", func(t *testing.T) {
+		e := setupEcho()
+		mockSessionSvc := mocks.NewMockSessionService(t)
+		mockSessionSvc.EXPECT().Refresh(mock.Anything, "refresh123", mock.Anything).
+			Return(&services.SessionTokens{
+				AccessToken:           "token456",
+				AccessTokenExpiresAt:  expiresAt,
+				RefreshToken:          "refresh456",
+				RefreshTokenExpiresAt: &refreshExpiresAt,
+			}, nil)
+
+		handler := handlers.NewSessionHandler(cfg, mockSessionSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh123"})
+		req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "csrf"})
+		req.Header.Set("X-CSRF-Token", "csrf")
+		req.Header.Set("X-Session-Cookie", "true")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.Refresh(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		byName := cookies(rec)
+		assert.Equal(t, "token456", byName["session"].Value)
+		assert.Equal(t, "refresh456", byName["refresh_token"].Value)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.NotContains(t, body, "token")
+		assert.NotContains(t, body, "refresh_token")
+	})
+
+	t.Run("rejects refresh without the csrf header", func(t *testing.T) {
+		e := setupEcho()
+		handler := handlers.NewSessionHandler(cfg, mocks.NewMockSessionService(t))
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh123"})
+		req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "csrf"})
+		req.Header.Set("X-Session-Cookie", "true")
+		rec := httptest.NewRecorder()
+
+		err := handler.Refresh(e.NewContext(req, rec))
+		assert.ErrorIs(t, err, apperrors.ErrInvalidCSRFToken)
+	})
+
+	t.Run("rejects refresh without the refresh cookie", func(t *testing.T) {
+		e := setupEcho()
+		handler := handlers.NewSessionHandler(cfg, mocks.NewMockSessionService(t))
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions/refresh", strings.NewReader(`{"refresh_token":"refresh123"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "csrf"})
+		req.Header.Set("X-CSRF-Token", "csrf")
+		req.Header.Set("X-Session-Cookie", "true")
+		rec := httptest.NewRecorder()
+
+		err := handler.Refresh(e.NewContext(req, rec))
+		assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns tokens in the body to clients that don't ask for cookies", func(t *testing.T) {
+		e := setupEcho()
+		mockSessionSvc := mocks.NewMockSessionService(t)
+		mockSessionSvc.EXPECT().Create(mock.Anything, "test@example.com", "password123", mock.Anything).
+			Return(&services.LoginResult{
+				User: &sqlcgen.User{ID: uuid.New(), Email: "test@example.com"},
+				Tokens: &services.SessionTokens{
+					AccessToken:           "token123",
+					AccessTokenExpiresAt:  expiresAt,
+					RefreshToken:          "refresh123",
+					RefreshTokenExpiresAt: &refreshExpiresAt,
+				},
+			}, nil)
+
+		handler := handlers.NewSessionHandler(cfg, mockSessionSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(`{"email":"test@example.com","password":"password123"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.Create(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "token123", body["token"])
+		assert.Equal(t, "refresh123", body["refresh_token"])
+	})
+
+	t.Run("refreshes from the body for clients that don't ask for cookies", func(t *testing.T) {
+		e := setupEcho()
+		mockSessionSvc := mocks.NewMockSessionService(t)
+		mockSessionSvc.EXPECT().Refresh(mock.Anything, "refresh123", mock.Anything).
+			Return(&services.SessionTokens{
+				AccessToken:           "token456",
+				AccessTokenExpiresAt:  expiresAt,
+				RefreshToken:          "refresh456",
+				RefreshTokenExpiresAt: &refreshExpiresAt,
+			}, nil)
+
+		handler := handlers.NewSessionHandler(cfg, mockSessionSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/sessions/refresh", strings.NewReader(`{"refresh_token":"refresh123"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.Refresh(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "token456", body["token"])
+		assert.Equal(t, "refresh456", body["refresh_token"])
+	})
+
+	t.Run("clears cookies on logout", func(t *testing.T) {
+		e := setupEcho()
+		mockSessionSvc := mocks.NewMockSessionService(t)
+		mockSessionSvc.EXPECT().Delete(mock.Anything, "token123").Return(nil)
+
+		handler := handlers.NewSessionHandler(cfg, mockSessionSvc)
+
+		req := httptest.NewRequest(http.MethodDelete, "/sessions/current", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		reqctx.SetToken(c, "token123")
+
+		require.NoError(t, handler.DeleteCurrent(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		byName := cookies(rec)
+		require.Contains(t, byName, "session")
+		assert.Empty(t, byName["session"].Value)
+		assert.Negative(t, byName["session"].MaxAge)
+		require.Contains(t, byName, "refresh_token")
+		assert.Negative(t, byName["refresh_token"].MaxAge)
+		assert.Equal(t, "/sessions/refresh", byName["refresh_token"].Path)
+		require.Contains(t, byName, "csrf_token")
+		assert.Negative(t, byName["csrf_token"].MaxAge)
+	})
+
+	t.Run("sets no cookies when disabled", func(t *testing.T) {
+		e := setupEcho()
+		mockSessionSvc := mocks.NewMockSessionService(t)
+		mockSessionSvc.EXPECT().Delete(mock.Anything, "token123").Return(nil)
+
+		handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
+
+		req := httptest.NewRequest(http.MethodDelete, "/sessions/current", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		reqctx.SetToken(c, "token123")
+
+		require.NoError(t, handler.DeleteCurrent(c))
+		assert.Empty(t, rec.Result().Cookies())
+	})
+}
+
+func TestSessionHandler_Refresh(t *testing.T) {
+	refreshExpiresAt := time.Now().UTC().Add(24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockSessionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "refreshes session successfully",
+			requestBody: `{"refresh_token":"refresh123"}`,
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().Refresh(mock.Anything, "refresh123", mock.Anything).
+					Return(&services.SessionTokens{
+						AccessToken:           "token456",
+						AccessTokenExpiresAt:  time.Now().UTC().Add(15 * time.Minute),
+						RefreshToken:          "refresh456",
+						RefreshTokenExpiresAt: &refreshExpiresAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error for missing refresh token",
+			requestBody:    `{}`,
+			setupMock:      func(sessionSvc *mocks.MockSessionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error when refresh token was reused",
+			requestBody: `{"refresh_token":"refresh123"}`,
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().Refresh(mock.Anything, "refresh123", mock.Anything).
+					Return(nil, apperrors.ErrRefreshTokenReused)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "REFRESH_TOKEN_REUSED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockSessionSvc)
+
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/sessions/refresh", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.Refresh(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.RefreshSessionResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "token456", resp.Token)
+				assert.Equal(t, "refresh456", resp.RefreshToken)
+				require.NotNil(t, resp.RefreshTokenExpiresAt)
+			}
+		})
+	}
+}
+
+func TestSessionHandler_List(t *testing.T) {
+	userID := uuid.New()
+	currentID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name           string
+		query          string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockSessionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "lists sessions and marks the current one",
+			query: "",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+				reqctx.SetSessionID(c, currentID)
+			},
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().ListForUser(mock.Anything, userID, int32(20), int32(0)).
+					Return([]sqlcgen.AuthToken{
+						{ID: currentID, UserID: userID, UserAgent: "agent-1"},
+						{ID: otherID, UserID: userID, UserAgent: "agent-2"},
+					}, int64(2), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "applies pagination parameters",
+			query: "?page=3&per_page=5",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+				reqctx.SetSessionID(c, currentID)
+			},
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().ListForUser(mock.Anything, userID, int32(5), int32(10)).
+					Return([]sqlcgen.AuthToken{}, int64(2), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "returns error for invalid per_page",
+			query: "?per_page=1000",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock:      func(sessionSvc *mocks.MockSessionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "returns error when user not in context",
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(sessionSvc *mocks.MockSessionService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockSessionSvc)
+
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/sessions"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.List(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.SessionListResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, int64(2), resp.Pagination.Total)
+				for _, s := range resp.Sessions {
+					assert.Equal(t, s.ID == currentID, s.Current)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionHandler_Delete(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name           string
+		param          string
+		setupMock      func(*mocks.MockSessionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "revokes session successfully",
+			param: sessionID.String(),
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().Revoke(mock.Anything, userID, sessionID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns error for invalid session id",
+			param:          "not-a-uuid",
+			setupMock:      func(sessionSvc *mocks.MockSessionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_SESSION_ID",
+		},
+		{
+			name:  "returns error when session not found",
+			param: sessionID.String(),
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().Revoke(mock.Anything, userID, sessionID).Return(apperrors.ErrSessionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "SESSION_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockSessionSvc)
+
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/sessions/"+tt.param, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(echo.PathValues{{Name: "id", Value: tt.param}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler.Delete(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestSessionHandler_DeleteOthers(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockSessionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "revokes other sessions successfully",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+				reqctx.SetSessionID(c, sessionID)
+			},
+			setupMock: func(sessionSvc *mocks.MockSessionService) {
+				sessionSvc.EXPECT().RevokeOthers(mock.Anything, userID, sessionID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "returns error when session not in context",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock:      func(sessionSvc *mocks.MockSessionService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockSessionSvc)
+
+			handler := handlers.NewSessionHandler(&config.Config{}, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/sessions/others", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.DeleteOthers(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-reasonable-api/api/requests"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// TwoFactorHandler handles TOTP two-factor enrollment for the current user.
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// Enroll starts TOTP enrollment
+// @Summary Start two-factor enrollment
+// @Description Generate a new TOTP secret and otpauth URI. Two-factor authentication is not active until confirmed with a first code.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} responses.TwoFactorEnrollmentResponse
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/two-factor [post]
+func (h *TwoFactorHandler) Enroll(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	enrollment, err := h.twoFactorService.BeginEnrollment(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to begin two factor enrollment")
+	}
+
+	return c.JSON(http.StatusCreated, responses.TwoFactorEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+// Confirm activates TOTP and returns recovery codes
+// @Summary Confirm two-factor enrollment
+// @Description Activate two-factor authentication with a first TOTP code. Returns one-time recovery codes, which are shown only once.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.ConfirmTwoFactorRequest true "Confirm two-factor request"
+// @Success 200 {object} responses.RecoveryCodesResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/two-factor [put]
+func (h *TwoFactorHandler) Confirm(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.ConfirmTwoFactorRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	codes, err := h.twoFactorService.ConfirmEnrollment(c.Request().Context(), userID, req.Code)
+	if err != nil {
+		return eris.Wrap(err, "failed to confirm two factor enrollment")
+	}
+
+	return c.JSON(http.StatusOK, responses.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// Disable turns off TOTP
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication and delete recovery codes. Requires the account password.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.DisableTwoFactorRequest true "Disable two-factor request"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/two-factor [delete]
+func (h *TwoFactorHandler) Disable(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.DisableTwoFactorRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.twoFactorService.Disable(c.Request().Context(), userID, req.Password); err != nil {
+		return eris.Wrap(err, "failed to disable two factor")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,255 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-reasonable-api/api/handlers"
+	"go-reasonable-api/api/responses"
+	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/support/errors"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorHandler_Enroll(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockTwoFactorService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "starts enrollment successfully",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(twoFactorSvc *mocks.MockTwoFactorService) {
+				twoFactorSvc.EXPECT().BeginEnrollment(mock.Anything, userID).
+					Return(&services.TOTPEnrollment{Secret: "SECRET", URI: "otpauth://totp/Test:test@example.com?secret=SECRET"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "returns error when already enabled",
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(twoFactorSvc *mocks.MockTwoFactorService) {
+				twoFactorSvc.EXPECT().BeginEnrollment(mock.Anything, userID).Return(nil, apperrors.ErrTwoFactorAlreadyEnabled)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "TWO_FACTOR_ALREADY_ENABLED",
+		},
+		{
+			name:           "returns error when user not in context",
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(twoFactorSvc *mocks.MockTwoFactorService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockTwoFactorSvc := mocks.NewMockTwoFactorService(t)
+			tt.setupMock(mockTwoFactorSvc)
+
+			handler := handlers.NewTwoFactorHandler(mockTwoFactorSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/two-factor", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Enroll(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.TwoFactorEnrollmentResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "SECRET", resp.Secret)
+				assert.Contains(t, resp.OTPAuthURI, "otpauth://totp/")
+			}
+		})
+	}
+}
+
+func TestTwoFactorHandler_Confirm(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockTwoFactorService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "confirms enrollment and returns recovery codes",
+			requestBody: `{"code":"123456"}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(twoFactorSvc *mocks.MockTwoFactorService) {
+				twoFactorSvc.EXPECT().ConfirmEnrollment(mock.Anything, userID, "123456").
+					Return([]string{"aaaaa-bbbbb", "ccccc-ddddd"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "returns error for missing code",
+			requestBody: `{}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock:      func(twoFactorSvc *mocks.MockTwoFactorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:        "returns error for invalid code",
+			requestBody: `{"code":"000000"}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(twoFactorSvc *mocks.MockTwoFactorService) {
+				twoFactorSvc.EXPECT().ConfirmEnrollment(mock.Anything, userID, "000000").Return(nil, apperrors.ErrInvalidTwoFactorCode)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_TWO_FACTOR_CODE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockTwoFactorSvc := mocks.NewMockTwoFactorService(t)
+			tt.setupMock(mockTwoFactorSvc)
+
+			handler := handlers.NewTwoFactorHandler(mockTwoFactorSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/users/me/two-factor", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Confirm(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.RecoveryCodesResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Len(t, resp.RecoveryCodes, 2)
+			}
+		})
+	}
+}
+
+func TestTwoFactorHandler_Disable(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(*echo.Context)
+		setupMock      func(*mocks.MockTwoFactorService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "disables two factor successfully",
+			requestBody: `{"password":"password123"}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(twoFactorSvc *mocks.MockTwoFactorService) {
+				twoFactorSvc.EXPECT().Disable(mock.Anything, userID, "password123").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "returns error for wrong password",
+			requestBody: `{"password":"wrongpassword"}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock: func(twoFactorSvc *mocks.MockTwoFactorService) {
+				twoFactorSvc.EXPECT().Disable(mock.Anything, userID, "wrongpassword").Return(apperrors.ErrInvalidPassword)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_PASSWORD",
+		},
+		{
+			name:        "returns error for missing password",
+			requestBody: `{}`,
+			setupContext: func(c *echo.Context) {
+				reqctx.SetUserID(c, userID)
+			},
+			setupMock:      func(twoFactorSvc *mocks.MockTwoFactorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			mockTwoFactorSvc := mocks.NewMockTwoFactorService(t)
+			tt.setupMock(mockTwoFactorSvc)
+
+			handler := handlers.NewTwoFactorHandler(mockTwoFactorSvc)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/me/two-factor", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Disable(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"go-reasonable-api/api/requests"
 	"go-reasonable-api/api/responses"
 	apperrors "go-reasonable-api/app/errors"
 	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/http/bind"
 	"go-reasonable-api/support/http/reqctx"
 
@@ -16,12 +19,14 @@ import (
 
 // UserHandler handles user registration and profile operations.
 type UserHandler struct {
+	config         *config.Config
 	userService    services.UserService
 	sessionService services.SessionService
 }
 
-func NewUserHandler(userService services.UserService, sessionService services.SessionService) *UserHandler {
+func NewUserHandler(cfg *config.Config, userService services.UserService, sessionService services.SessionService) *UserHandler {
 	return &UserHandler{
+		config:         cfg,
 		userService:    userService,
 		sessionService: sessionService,
 	}
@@ -29,12 +34,13 @@ func NewUserHandler(userService services.UserService, sessionService services.Se
 
 // Create creates a new user account
 // @Summary Create new user account
-// @Description Register a new user with name, email and password
+// @Description Register a new user with name, email and password. In enumeration-safe mode the response is 202 whether or not the email is taken, and the user must verify their email and log in.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param request body requests.CreateUserRequest true "Create user request"
 // @Success 201 {object} responses.SessionResponse
+// @Success 202 {object} map[string]string
 // @Failure 400 {object} errors.AppError
 // @Failure 422 {object} errors.AppError
 // @Router /users [post]
@@ -45,24 +51,25 @@ func (h *UserHandler) Create(c *echo.Context) error {
 	}
 
 	user, err := h.userService.Create(c.Request().Context(), req.Name, req.Email, req.Password)
+	if h.config.Auth.EnumerationSafe {
+		// A taken email gets the same answer as a new account
+		if err != nil && !eris.Is(err, apperrors.ErrEmailAlreadyExists) {
+			return eris.Wrap(err, "failed to create user")
+		}
+		return c.JSON(http.StatusAccepted, map[string]string{
+			"message": "check your email to continue",
+		})
+	}
 	if err != nil {
 		return eris.Wrap(err, "failed to create user")
 	}
 
-	token, err := h.sessionService.CreateForUser(c.Request().Context(), user.ID)
+	tokens, err := h.sessionService.CreateForUser(c.Request().Context(), user.ID, clientInfo(c))
 	if err != nil {
 		return eris.Wrap(err, "failed to create session")
 	}
 
-	return c.JSON(http.StatusCreated, responses.SessionResponse{
-		User: responses.UserResponse{
-			ID:            user.ID,
-			Name:          user.Name,
-			Email:         user.Email,
-			EmailVerified: user.EmailVerifiedAt != nil,
-		},
-		Token: token,
-	})
+	return sessionCreated(c, h.config, user, tokens)
 }
 
 // Me returns the current authenticated user
@@ -86,18 +93,83 @@ func (h *UserHandler) Me(c *echo.Context) error {
 		return eris.Wrap(err, "failed to get user")
 	}
 
-	return c.JSON(http.StatusOK, responses.UserResponse{
-		ID:                  user.ID,
-		Name:                user.Name,
-		Email:               user.Email,
-		EmailVerified:       user.EmailVerifiedAt != nil,
-		DeletionScheduledAt: user.DeletionScheduledAt,
+	return c.JSON(http.StatusOK, userResponse(user))
+}
+
+// Update partially updates the current user's profile
+// @Summary Update current user
+// @Description Update the current user's profile with a JSON merge patch (RFC 7396). Members left out of the patch are unchanged.
+// @Tags users
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.UpdateUserRequest true "Profile merge patch"
+// @Success 200 {object} responses.UserResponse
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 415 {object} errors.AppError
+// @Router /users/me [patch]
+func (h *UserHandler) Update(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.UpdateUserRequest
+	if err := bind.MergePatch(c, &req); err != nil {
+		return err
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request().Context(), userID, services.ProfileUpdate{
+		Name: req.Name,
 	})
+	if err != nil {
+		return eris.Wrap(err, "failed to update user")
+	}
+
+	return c.JSON(http.StatusOK, userResponse(user))
+}
+
+// UpdatePassword changes the current user's password
+// @Summary Change password
+// @Description Change the current user's password. Every other session is revoked and a notification email is sent.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requests.UpdatePasswordRequest true "Update password request"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 401 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/password [put]
+func (h *UserHandler) UpdatePassword(c *echo.Context) error {
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	sessionID, ok := reqctx.GetSessionID(c)
+	if !ok {
+		return apperrors.ErrInvalidToken
+	}
+
+	var req requests.UpdatePasswordRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.userService.ChangePassword(c.Request().Context(), userID, sessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		return eris.Wrap(err, "failed to change password")
+	}
+
+	return c.NoContent(http.StatusNoContent)
 }
 
 // Delete schedules the current user's account for deletion
 // @Summary Schedule account deletion
-// @Description Schedule the current user's account for deletion after 30 days. All sessions will be revoked.
+// @Description Schedule the current user's account for deletion after 30 days. All sessions and API keys will be revoked. Owners of an organization must transfer or delete it first.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -120,3 +192,44 @@ func (h *UserHandler) Delete(c *echo.Context) error {
 		"message": "account deletion scheduled",
 	})
 }
+
+// CancelDeletion cancels a scheduled account deletion
+// @Summary Cancel account deletion
+// @Description Cancel the current user's scheduled account deletion. Without a session, send the user ID, expiry and signature from the cancellation link in the deletion emails instead.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body requests.CancelDeletionRequest false "Cancellation link (required if not authenticated)"
+// @Success 204
+// @Failure 400 {object} errors.AppError
+// @Failure 422 {object} errors.AppError
+// @Router /users/me/deletion/cancel [post]
+func (h *UserHandler) CancelDeletion(c *echo.Context) error {
+	if userID, ok := reqctx.GetUserID(c); ok {
+		if err := h.userService.CancelDeletion(c.Request().Context(), userID); err != nil {
+			return eris.Wrap(err, "failed to cancel deletion")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	var req requests.CancelDeletionRequest
+	if err := bind.AndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.userService.CancelDeletionWithLink(c.Request().Context(), req.UserID, time.Unix(req.Expires, 0), req.Signature); err != nil {
+		return eris.Wrap(err, "failed to cancel deletion with link")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func userResponse(user *sqlcgen.User) responses.UserResponse {
+	return responses.UserResponse{
+		ID:                  user.ID,
+		Name:                user.Name,
+		Email:               user.Email,
+		EmailVerified:       user.EmailVerifiedAt != nil,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+	}
+}
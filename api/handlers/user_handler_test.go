@@ -4,14 +4,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"go-reasonable-api/api/handlers"
 	"go-reasonable-api/api/responses"
 	apperrors "go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
 	mocks "go-reasonable-api/app/mocks/services"
 	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/errors"
 	zhttp "go-reasonable-api/support/http"
 	"go-reasonable-api/support/http/reqctx"
@@ -50,8 +54,8 @@ func TestUserHandler_Create(t *testing.T) {
 						Name:  "Test User",
 						Email: "test@example.com",
 					}, nil)
-				sessionSvc.EXPECT().CreateForUser(mock.Anything, userID).
-					Return("token123", nil)
+				sessionSvc.EXPECT().CreateForUser(mock.Anything, userID, mock.Anything).
+					Return(&services.SessionTokens{AccessToken: "token123"}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 		},
@@ -91,9 +95,12 @@ func TestUserHandler_Create(t *testing.T) {
 			expectedError:  "VALIDATION_ERROR",
 		},
 		{
-			name:           "returns error for short password",
-			requestBody:    `{"name":"Test User","email":"test@example.com","password":"short"}`,
-			setupMock:      func(userSvc *mocks.MockUserService, sessionSvc *mocks.MockSessionService) {},
+			name:        "leaves password length to the password policy",
+			requestBody: `{"name":"Test User","email":"test@example.com","password":"short"}`,
+			setupMock: func(userSvc *mocks.MockUserService, sessionSvc *mocks.MockSessionService) {
+				userSvc.EXPECT().Create(mock.Anything, "Test User", "test@example.com", "short").
+					Return(nil, errors.NewWithDetails("VALIDATION_ERROR", "validation failed", http.StatusBadRequest, map[string]any{"password": []string{"must be at least 8 characters"}}))
+			},
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "VALIDATION_ERROR",
 		},
@@ -117,8 +124,8 @@ func TestUserHandler_Create(t *testing.T) {
 						Name:  "Test User",
 						Email: "test@example.com",
 					}, nil)
-				sessionSvc.EXPECT().CreateForUser(mock.Anything, userID).
-					Return("", errors.InternalError("SESSION_CREATION_FAILED", "failed to create session"))
+				sessionSvc.EXPECT().CreateForUser(mock.Anything, userID, mock.Anything).
+					Return(nil, errors.InternalError("SESSION_CREATION_FAILED", "failed to create session"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  "SESSION_CREATION_FAILED",
@@ -132,7 +139,7 @@ func TestUserHandler_Create(t *testing.T) {
 			mockSessionSvc := mocks.NewMockSessionService(t)
 			tt.setupMock(mockUserSvc, mockSessionSvc)
 
-			handler := handlers.NewUserHandler(mockUserSvc, mockSessionSvc)
+			handler := handlers.NewUserHandler(&config.Config{}, mockUserSvc, mockSessionSvc)
 
 			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.requestBody))
 			req.Header.Set("Content-Type", "application/json")
@@ -165,6 +172,55 @@ func TestUserHandler_Create(t *testing.T) {
 	}
 }
 
+func TestUserHandler_Create_EnumerationSafe(t *testing.T) {
+	cfg := &config.Config{Auth: config.AuthConfig{EnumerationSafe: true}}
+	body := `{"name":"Test User","email":"test@example.com","password":"password123"}`
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockUserService)
+	}{
+		{
+			name: "new email",
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().Create(mock.Anything, "Test User", "test@example.com", "password123").
+					Return(&sqlcgen.User{ID: uuid.New(), Name: "Test User", Email: "test@example.com"}, nil)
+			},
+		},
+		{
+			name: "taken email",
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().Create(mock.Anything, "Test User", "test@example.com", "password123").
+					Return(nil, apperrors.ErrEmailAlreadyExists)
+			},
+		},
+	}
+
+	var bodies []string
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupUserHandlerEcho()
+			mockUserSvc := mocks.NewMockUserService(t)
+			tt.setupMock(mockUserSvc)
+
+			// No session is created in either case
+			handler := handlers.NewUserHandler(cfg, mockUserSvc, mocks.NewMockSessionService(t))
+
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			require.NoError(t, handler.Create(c))
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+			bodies = append(bodies, rec.Body.String())
+		})
+	}
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
 func TestUserHandler_Me(t *testing.T) {
 	userID := uuid.New()
 
@@ -218,7 +274,7 @@ func TestUserHandler_Me(t *testing.T) {
 			mockSessionSvc := mocks.NewMockSessionService(t)
 			tt.setupMock(mockUserSvc)
 
-			handler := handlers.NewUserHandler(mockUserSvc, mockSessionSvc)
+			handler := handlers.NewUserHandler(&config.Config{}, mockUserSvc, mockSessionSvc)
 
 			req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
 			rec := httptest.NewRecorder()
@@ -246,3 +302,331 @@ func TestUserHandler_Me(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_Update(t *testing.T) {
+	userID := uuid.New()
+	authenticated := func(c *echo.Context) { reqctx.SetUserID(c, userID) }
+	newName := "New Name"
+
+	tests := []struct {
+		name           string
+		contentType    string
+		requestBody    string
+		setupContext   func(c *echo.Context)
+		setupMock      func(*mocks.MockUserService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:         "updates name with merge patch",
+			contentType:  "application/merge-patch+json",
+			requestBody:  `{"name":"New Name"}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().UpdateProfile(mock.Anything, userID, services.ProfileUpdate{Name: &newName}).
+					Return(&sqlcgen.User{ID: userID, Name: "New Name", Email: "test@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:         "accepts plain JSON",
+			contentType:  echo.MIMEApplicationJSON,
+			requestBody:  `{"name":"New Name"}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().UpdateProfile(mock.Anything, userID, services.ProfileUpdate{Name: &newName}).
+					Return(&sqlcgen.User{ID: userID, Name: "New Name", Email: "test@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:         "leaves absent members unchanged",
+			contentType:  "application/merge-patch+json",
+			requestBody:  `{}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().UpdateProfile(mock.Anything, userID, services.ProfileUpdate{}).
+					Return(&sqlcgen.User{ID: userID, Name: "New Name", Email: "test@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns error when user not in context",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"name":"New Name"}`,
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+		{
+			name:           "returns validation error for null name",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"name":null}`,
+			setupContext:   authenticated,
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "returns validation error for empty name",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"name":""}`,
+			setupContext:   authenticated,
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "returns error when patch is not an object",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `["name"]`,
+			setupContext:   authenticated,
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "INVALID_MERGE_PATCH",
+		},
+		{
+			name:           "returns error for unsupported content type",
+			contentType:    echo.MIMEApplicationForm,
+			requestBody:    "name=New+Name",
+			setupContext:   authenticated,
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedError:  "UNSUPPORTED_MEDIA_TYPE",
+		},
+		{
+			name:         "returns error when user not found",
+			contentType:  "application/merge-patch+json",
+			requestBody:  `{"name":"New Name"}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().UpdateProfile(mock.Anything, userID, services.ProfileUpdate{Name: &newName}).
+					Return(nil, apperrors.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "USER_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupUserHandlerEcho()
+			mockUserSvc := mocks.NewMockUserService(t)
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockUserSvc)
+
+			handler := handlers.NewUserHandler(&config.Config{}, mockUserSvc, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodPatch, "/users/me", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.Update(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var resp responses.UserResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, userID, resp.ID)
+				assert.Equal(t, "New Name", resp.Name)
+			}
+		})
+	}
+}
+
+func TestUserHandler_UpdatePassword(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	authenticated := func(c *echo.Context) {
+		reqctx.SetUserID(c, userID)
+		reqctx.SetSessionID(c, sessionID)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(c *echo.Context)
+		setupMock      func(*mocks.MockUserService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:         "changes password successfully",
+			requestBody:  `{"current_password":"oldpassword","new_password":"newpassword"}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().ChangePassword(mock.Anything, userID, sessionID, "oldpassword", "newpassword").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns error when session not in context",
+			requestBody:    `{"current_password":"oldpassword","new_password":"newpassword"}`,
+			setupContext:   func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "INVALID_TOKEN",
+		},
+		{
+			name:         "leaves new password length to the password policy",
+			requestBody:  `{"current_password":"oldpassword","new_password":"short"}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().ChangePassword(mock.Anything, userID, sessionID, "oldpassword", "short").
+					Return(errors.NewWithDetails("VALIDATION_ERROR", "validation failed", http.StatusBadRequest, map[string]any{"password": []string{"must be at least 8 characters"}}))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "returns validation error for missing current password",
+			requestBody:    `{"new_password":"newpassword"}`,
+			setupContext:   authenticated,
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:         "returns error when current password is wrong",
+			requestBody:  `{"current_password":"wrongpassword","new_password":"newpassword"}`,
+			setupContext: authenticated,
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().ChangePassword(mock.Anything, userID, sessionID, "wrongpassword", "newpassword").
+					Return(apperrors.ErrInvalidPassword)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_PASSWORD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupUserHandlerEcho()
+			mockUserSvc := mocks.NewMockUserService(t)
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockUserSvc)
+
+			handler := handlers.NewUserHandler(&config.Config{}, mockUserSvc, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodPut, "/users/me/password", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.UpdatePassword(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUserHandler_CancelDeletion(t *testing.T) {
+	userID := uuid.New()
+	expires := time.Now().Add(24 * time.Hour).Unix()
+	linkBody := `{"user_id":"` + userID.String() + `","expires":` + strconv.FormatInt(expires, 10) + `,"signature":"sig"}`
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupContext   func(c *echo.Context)
+		setupMock      func(*mocks.MockUserService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:         "cancels the signed-in user's deletion",
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().CancelDeletion(mock.Anything, userID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:         "returns error when no deletion is scheduled",
+			setupContext: func(c *echo.Context) { reqctx.SetUserID(c, userID) },
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().CancelDeletion(mock.Anything, userID).Return(apperrors.ErrDeletionNotScheduled)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "DELETION_NOT_SCHEDULED",
+		},
+		{
+			name:         "cancels with a signed link",
+			requestBody:  linkBody,
+			setupContext: func(c *echo.Context) {},
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().CancelDeletionWithLink(mock.Anything, userID, time.Unix(expires, 0), "sig").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:         "returns error for an invalid link",
+			requestBody:  linkBody,
+			setupContext: func(c *echo.Context) {},
+			setupMock: func(userSvc *mocks.MockUserService) {
+				userSvc.EXPECT().CancelDeletionWithLink(mock.Anything, userID, time.Unix(expires, 0), "sig").Return(apperrors.ErrInvalidDeletionLink)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "INVALID_DELETION_LINK",
+		},
+		{
+			name:           "returns validation error without a session or link",
+			requestBody:    `{}`,
+			setupContext:   func(c *echo.Context) {},
+			setupMock:      func(userSvc *mocks.MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupUserHandlerEcho()
+			mockUserSvc := mocks.NewMockUserService(t)
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			tt.setupMock(mockUserSvc)
+
+			handler := handlers.NewUserHandler(&config.Config{}, mockUserSvc, mockSessionSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/me/deletion/cancel", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			tt.setupContext(c)
+
+			err := handler.CancelDeletion(c)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.expectedError, appErr.Code)
+				assert.Equal(t, tt.expectedStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package requests
+
+// ListUsersRequest holds the admin user list query parameters. Q matches a
+// substring of the email or name; absent filters match everyone.
+type ListUsersRequest struct {
+	PaginationRequest
+	Q                 string `query:"q" validate:"max=255"`
+	Verified          *bool  `query:"verified"`
+	DeletionScheduled *bool  `query:"deletion_scheduled"`
+}
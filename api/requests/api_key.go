@@ -0,0 +1,15 @@
+package requests
+
+import "time"
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=255"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,max=16,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitnil,gt"`
+}
+
+// UpdateAPIKeyRequest is a JSON merge patch of an API key. Only the name can
+// change; scopes and expiry are fixed at creation.
+type UpdateAPIKeyRequest struct {
+	Name *string `json:"name" validate:"required,min=1,max=255"`
+}
@@ -0,0 +1,14 @@
+package requests
+
+// CreateDataExportRequest represents the data export request body. The body
+// is optional and Format defaults to zip.
+type CreateDataExportRequest struct {
+	Format string `json:"format" validate:"omitempty,oneof=json zip"`
+}
+
+// DownloadDataExportRequest holds the signed download link's query
+// parameters. Expires is a Unix timestamp.
+type DownloadDataExportRequest struct {
+	Expires   int64  `query:"expires" validate:"required"`
+	Signature string `query:"signature" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package requests
+
+type CreateEmailChangeRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,5 @@
+package requests
+
+type CreateMagicLinkRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
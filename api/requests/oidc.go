@@ -0,0 +1,8 @@
+package requests
+
+// OIDCLoginRequest carries the code and state query parameters the provider
+// redirected back to the frontend with.
+type OIDCLoginRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
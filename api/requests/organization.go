@@ -0,0 +1,28 @@
+package requests
+
+import "github.com/google/uuid"
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// UpdateOrganizationRequest is a JSON merge patch of an organization. Only
+// the name can change.
+type UpdateOrganizationRequest struct {
+	Name *string `json:"name" validate:"required,min=1,max=255"`
+}
+
+// UpdateOrganizationMemberRequest is a JSON merge patch of a membership.
+// Ownership moves with TransferOrganizationRequest instead.
+type UpdateOrganizationMemberRequest struct {
+	Role *string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type TransferOrganizationRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type InviteOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=admin member"`
+}
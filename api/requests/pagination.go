@@ -0,0 +1,40 @@
+package requests
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// PaginationRequest holds page-based pagination query parameters.
+// Zero values fall back to the first page and DefaultPerPage.
+type PaginationRequest struct {
+	Page    int `query:"page" validate:"omitempty,min=1"`
+	PerPage int `query:"per_page" validate:"omitempty,min=1,max=100"`
+}
+
+func (r PaginationRequest) PageOrDefault() int {
+	if r.Page < 1 {
+		return 1
+	}
+	return r.Page
+}
+
+func (r PaginationRequest) PerPageOrDefault() int {
+	if r.PerPage < 1 {
+		return DefaultPerPage
+	}
+	if r.PerPage > MaxPerPage {
+		return MaxPerPage
+	}
+	return r.PerPage
+}
+
+// Limit returns the SQL LIMIT for the requested page.
+func (r PaginationRequest) Limit() int32 {
+	return int32(r.PerPageOrDefault())
+}
+
+// Offset returns the SQL OFFSET for the requested page.
+func (r PaginationRequest) Offset() int32 {
+	return int32((r.PageOrDefault() - 1) * r.PerPageOrDefault())
+}
@@ -0,0 +1,16 @@
+package requests
+
+import "encoding/json"
+
+// Passkey credentials use the JSON encoding of PublicKeyCredential
+// (PublicKeyCredential.toJSON() in browsers) and are passed to the passkey
+// service as received.
+
+type RegisterPasskeyRequest struct {
+	Name       string          `json:"name" validate:"max=255"`
+	Credential json.RawMessage `json:"credential" validate:"required" swaggertype:"object"`
+}
+
+type PasskeyLoginRequest struct {
+	Credential json.RawMessage `json:"credential" validate:"required" swaggertype:"object"`
+}
@@ -5,5 +5,5 @@ type CreatePasswordResetRequest struct {
 }
 
 type UpdatePasswordResetRequest struct {
-	NewPassword string `json:"new_password" validate:"required,min=8"`
+	NewPassword string `json:"new_password" validate:"required"`
 }
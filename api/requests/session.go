@@ -4,3 +4,16 @@ type CreateSessionRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
+
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type CompleteTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type ListSessionsRequest struct {
+	PaginationRequest
+}
@@ -0,0 +1,9 @@
+package requests
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+}
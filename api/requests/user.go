@@ -1,7 +1,29 @@
 package requests
 
+import "github.com/google/uuid"
+
 type CreateUserRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" validate:"required"`
+}
+
+type UpdatePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// UpdateUserRequest is a JSON merge patch of the current user's profile.
+// Absent members are left unchanged.
+type UpdateUserRequest struct {
+	Name *string `json:"name" validate:"omitnil,min=1,max=255"`
+}
+
+// CancelDeletionRequest holds the parameters of the cancellation link in
+// the account deletion emails, for callers without a session. Expires is a
+// Unix timestamp.
+type CancelDeletionRequest struct {
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Expires   int64     `json:"expires" validate:"required"`
+	Signature string    `json:"signature" validate:"required"`
 }
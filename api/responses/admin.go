@@ -0,0 +1,32 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdminUserResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type AdminUserDetailResponse struct {
+	AdminUserResponse
+	ActiveSessions int64 `json:"active_sessions"`
+}
+
+type AdminUserListResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
+
+type ImpersonationResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
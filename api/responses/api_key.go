@@ -0,0 +1,28 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse carries the plaintext key, which is only ever shown
+// in this response.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
@@ -0,0 +1,23 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type IdentityResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Provider    string    `json:"provider"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+type IdentityListResponse struct {
+	Identities []IdentityResponse `json:"identities"`
+}
@@ -0,0 +1,45 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationResponse is an organization along with the current user's
+// role in it.
+type OrganizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationListResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type OrganizationMemberListResponse struct {
+	Members []OrganizationMemberResponse `json:"members"`
+}
+
+type OrganizationInvitationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrganizationInvitationListResponse struct {
+	Invitations []OrganizationInvitationResponse `json:"invitations"`
+}
@@ -0,0 +1,7 @@
+package responses
+
+type PaginationResponse struct {
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+	Total   int64 `json:"total"`
+}
@@ -0,0 +1,70 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ceremony options use the JSON encoding of PublicKeyCredentialCreationOptions
+// and PublicKeyCredentialRequestOptions, so clients can pass them to
+// PublicKeyCredential.parseCreationOptionsFromJSON and
+// parseRequestOptionsFromJSON unchanged.
+
+type PasskeyRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type PasskeyUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type PasskeyCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type PasskeyCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+type PasskeyRegistrationOptionsResponse struct {
+	Challenge              string                        `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUser                   `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                         `json:"timeout"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                        `json:"attestation"`
+}
+
+type PasskeyLoginOptionsResponse struct {
+	Challenge        string `json:"challenge"`
+	RPID             string `json:"rpId"`
+	Timeout          int64  `json:"timeout"`
+	UserVerification string `json:"userVerification"`
+}
+
+type PasskeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+type PasskeyListResponse struct {
+	Passkeys []PasskeyResponse `json:"passkeys"`
+}
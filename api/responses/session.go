@@ -1,6 +1,43 @@
 package responses
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type SessionResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User                  UserResponse `json:"user"`
+	Token                 string       `json:"token,omitempty"`
+	ExpiresAt             time.Time    `json:"expires_at"`
+	RefreshToken          string       `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt *time.Time   `json:"refresh_token_expires_at,omitempty"`
+}
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	ChallengeToken    string    `json:"challenge_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+type RefreshSessionResponse struct {
+	Token                 string     `json:"token,omitempty"`
+	ExpiresAt             time.Time  `json:"expires_at"`
+	RefreshToken          string     `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt *time.Time `json:"refresh_token_expires_at"`
+}
+
+type SessionInfoResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	Current    bool       `json:"current"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+}
+
+type SessionListResponse struct {
+	Sessions   []SessionInfoResponse `json:"sessions"`
+	Pagination PaginationResponse    `json:"pagination"`
 }
@@ -16,20 +16,105 @@ var (
 	ErrInvalidCredentials = errors.Unauthorized("INVALID_CREDENTIALS", "invalid credentials")
 	ErrMissingAuthHeader  = errors.Unauthorized("MISSING_AUTH_HEADER", "missing authorization header")
 	ErrInvalidAuthFormat  = errors.Unauthorized("INVALID_AUTH_FORMAT", "invalid authorization header format")
+	ErrLoginLocked        = errors.TooManyRequests("LOGIN_LOCKED", "too many failed login attempts")
+	ErrInvalidCSRFToken   = errors.Forbidden("INVALID_CSRF_TOKEN", "missing or invalid csrf token")
+)
+
+var (
+	ErrSessionNotFound  = errors.NotFoundf("session")
+	ErrInvalidSessionID = errors.BadRequest("INVALID_SESSION_ID", "invalid session id")
 )
 
 var (
 	ErrInvalidToken             = errors.Unauthorized("INVALID_TOKEN", "invalid token")
 	ErrTokenExpired             = errors.Unauthorized("TOKEN_EXPIRED", "token expired")
 	ErrTokenRevoked             = errors.Unauthorized("TOKEN_REVOKED", "token revoked")
+	ErrInvalidRefreshToken      = errors.Unauthorized("INVALID_REFRESH_TOKEN", "invalid refresh token")
+	ErrRefreshTokenReused       = errors.Unauthorized("REFRESH_TOKEN_REUSED", "refresh token already used; session revoked")
 	ErrTokenAlreadyUsed         = errors.New("TOKEN_ALREADY_USED", "token already used")
 	ErrInvalidResetToken        = errors.New("INVALID_RESET_TOKEN", "invalid or expired reset token")
 	ErrInvalidVerificationToken = errors.New("INVALID_VERIFICATION_TOKEN", "invalid or expired verification token")
+	ErrInvalidMagicLink         = errors.New("INVALID_MAGIC_LINK", "invalid or expired magic link")
+	ErrInvalidEmailChangeToken  = errors.New("INVALID_EMAIL_CHANGE_TOKEN", "invalid or expired email change token")
+)
+
+var (
+	ErrInvalidPassword             = errors.New("INVALID_PASSWORD", "invalid password")
+	ErrTwoFactorAlreadyEnabled     = errors.New("TWO_FACTOR_ALREADY_ENABLED", "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled         = errors.New("TWO_FACTOR_NOT_ENABLED", "two-factor authentication is not enabled")
+	ErrTwoFactorEnrollmentNotFound = errors.New("TWO_FACTOR_ENROLLMENT_NOT_FOUND", "no pending two-factor enrollment")
+	ErrInvalidTwoFactorCode        = errors.New("INVALID_TWO_FACTOR_CODE", "invalid two-factor code")
+	ErrInvalidTwoFactorChallenge   = errors.Unauthorized("INVALID_TWO_FACTOR_CHALLENGE", "invalid or expired two-factor challenge")
+)
+
+var (
+	ErrPasskeyNotFound           = errors.NotFoundf("passkey")
+	ErrInvalidPasskeyID          = errors.BadRequest("INVALID_PASSKEY_ID", "invalid passkey id")
+	ErrInvalidPasskeyResponse    = errors.BadRequest("INVALID_PASSKEY_RESPONSE", "malformed passkey response")
+	ErrPasskeyAlreadyRegistered  = errors.New("PASSKEY_ALREADY_REGISTERED", "passkey is already registered")
+	ErrPasskeyRegistrationFailed = errors.New("PASSKEY_REGISTRATION_FAILED", "passkey registration could not be verified")
+	ErrInvalidPasskey            = errors.Unauthorized("INVALID_PASSKEY", "invalid passkey or expired challenge")
+)
+
+var (
+	ErrAPIKeyNotFound     = errors.NotFound("API_KEY_NOT_FOUND", "api key not found")
+	ErrInvalidAPIKeyID    = errors.BadRequest("INVALID_API_KEY_ID", "invalid api key id")
+	ErrInvalidAPIKeyScope = errors.BadRequest("INVALID_API_KEY_SCOPE", "unknown api key scope")
+	ErrInsufficientScope  = errors.Forbidden("INSUFFICIENT_SCOPE", "api key lacks the required scope")
+	ErrSessionRequired    = errors.Forbidden("SESSION_REQUIRED", "this endpoint cannot be used with an api key")
+)
+
+var (
+	ErrPermissionDenied = errors.Forbidden("PERMISSION_DENIED", "you do not have permission to perform this action")
+	ErrRoleNotFound     = errors.NotFoundf("role")
+	ErrRoleNotAssigned  = errors.New("ROLE_NOT_ASSIGNED", "user does not have this role")
+)
+
+var (
+	ErrImpersonationNotAllowed = errors.Forbidden("IMPERSONATION_NOT_ALLOWED", "this user cannot be impersonated")
+	ErrImpersonationForbidden  = errors.Forbidden("IMPERSONATION_FORBIDDEN", "this action is not allowed while impersonating a user")
+	ErrNotImpersonating        = errors.BadRequest("NOT_IMPERSONATING", "this session is not an impersonation")
+)
+
+var (
+	ErrOIDCProviderNotFound = errors.NotFound("OIDC_PROVIDER_NOT_FOUND", "oidc provider not found")
+	ErrInvalidOIDCLogin     = errors.Unauthorized("INVALID_OIDC_LOGIN", "invalid or expired oidc login")
+	ErrOIDCEmailNotVerified = errors.New("OIDC_EMAIL_NOT_VERIFIED", "the provider did not return a verified email")
+	ErrOIDCEmailConflict    = errors.New("OIDC_EMAIL_CONFLICT", "an account with this email exists but its email is not verified")
+	ErrIdentityNotFound     = errors.NotFoundf("identity")
+	ErrInvalidIdentityID    = errors.BadRequest("INVALID_IDENTITY_ID", "invalid identity id")
+	ErrLastSignInMethod     = errors.New("LAST_SIGN_IN_METHOD", "cannot unlink the only way to sign in")
+)
+
+var (
+	ErrOrganizationNotFound          = errors.NotFoundf("organization")
+	ErrInvalidOrganizationID         = errors.BadRequest("INVALID_ORGANIZATION_ID", "invalid organization id")
+	ErrInvalidOrganizationRole       = errors.BadRequest("INVALID_ORGANIZATION_ROLE", "role must be admin or member")
+	ErrOrganizationOwner             = errors.New("ORGANIZATION_OWNER", "the owner's membership can only change by transferring ownership")
+	ErrOrganizationMemberNotFound    = errors.NotFound("ORGANIZATION_MEMBER_NOT_FOUND", "organization member not found")
+	ErrAlreadyOrganizationMember     = errors.New("ALREADY_ORGANIZATION_MEMBER", "user is already a member of this organization")
+	ErrInvitationNotFound            = errors.NotFoundf("invitation")
+	ErrInvalidInvitationID           = errors.BadRequest("INVALID_INVITATION_ID", "invalid invitation id")
+	ErrInvalidOrganizationInvitation = errors.New("INVALID_ORGANIZATION_INVITATION", "invalid or expired invitation")
+	ErrOwnerDeletionScheduled        = errors.New("OWNER_DELETION_SCHEDULED", "cannot transfer ownership to a user whose account is scheduled for deletion")
+	ErrDeletionScheduled             = errors.New("DELETION_SCHEDULED", "cancel your account's scheduled deletion before owning or administering an organization")
+	ErrInvitationEmailMismatch       = errors.Forbidden("INVITATION_EMAIL_MISMATCH", "this invitation was sent to a different email address")
+)
+
+var (
+	ErrDataExportTooSoon     = errors.TooManyRequests("DATA_EXPORT_TOO_SOON", "a data export was requested recently; try again later")
+	ErrInvalidDataExportID   = errors.BadRequest("INVALID_DATA_EXPORT_ID", "invalid data export id")
+	ErrInvalidDataExportLink = errors.New("INVALID_DATA_EXPORT_LINK", "invalid or expired download link")
 )
 
 var (
 	ErrUserNotFound             = errors.NotFoundf("user")
+	ErrInvalidUserID            = errors.BadRequest("INVALID_USER_ID", "invalid user id")
 	ErrEmailAlreadyExists       = errors.New("EMAIL_ALREADY_EXISTS", "email already exists")
 	ErrEmailAlreadyVerified     = errors.New("EMAIL_ALREADY_VERIFIED", "email already verified")
+	ErrEmailUnchanged           = errors.New("EMAIL_UNCHANGED", "new email must differ from the current email")
 	ErrDeletionAlreadyScheduled = errors.New("DELETION_ALREADY_SCHEDULED", "account deletion is already scheduled")
+	ErrDeletionNotScheduled     = errors.New("DELETION_NOT_SCHEDULED", "account deletion is not scheduled")
+	ErrOwnsOrganization         = errors.New("OWNS_ORGANIZATION", "transfer or delete the organizations you own before deleting your account")
+	ErrInvalidDeletionLink      = errors.New("INVALID_DELETION_LINK", "invalid or expired cancellation link")
 )
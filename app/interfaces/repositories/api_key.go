@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// APIKeyRepository manages personal API key persistence.
+//
+// Keys are stored as keyed hashes. GetByHash accepts the key's candidate
+// hashes (see support.TokenHasher), not the raw key, and UpdateHash moves a
+// key found under an older hash key to the current one. ListActiveForUser returns the user's keys that are not revoked,
+// expired ones included. RenameForUser and RevokeForUser scope the change
+// to the owning user and return a wrapped pgx.ErrNoRows when no active key
+// matches. RevokeAllForUser revokes every key of a user, for when the user
+// is signed out everywhere. Touch records that a key was used.
+type APIKeyRepository interface {
+	WithTx(tx pgx.Tx) APIKeyRepository
+
+	Create(ctx context.Context, userID uuid.UUID, name, keyPrefix, tokenHash string, scopes []string, expiresAt *time.Time) (*sqlcgen.ApiKey, error)
+	GetByHash(ctx context.Context, tokenHashes []string) (*sqlcgen.ApiKey, error)
+	ListActiveForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ApiKey, error)
+	RenameForUser(ctx context.Context, id, userID uuid.UUID, name string) (*sqlcgen.ApiKey, error)
+	Touch(ctx context.Context, id uuid.UUID) error
+	UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error
+	RevokeForUser(ctx context.Context, id, userID uuid.UUID) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
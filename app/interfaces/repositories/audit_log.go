@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuditLogEntry describes a privileged action. ActorID is who acted and
+// SubjectID whose account was acted on; either may be nil. Metadata is
+// stored as JSON.
+type AuditLogEntry struct {
+	ActorID   *uuid.UUID
+	SubjectID *uuid.UUID
+	Action    string
+	Metadata  map[string]any
+	IPAddress string
+	UserAgent string
+}
+
+// AuditLogRepository appends to the audit log. Entries are never updated
+// or deleted by the application. ListForUser returns the entries where the
+// user is either the actor or the subject, oldest first.
+type AuditLogRepository interface {
+	WithTx(tx pgx.Tx) AuditLogRepository
+
+	Create(ctx context.Context, entry AuditLogEntry) error
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.AuditLog, error)
+}
@@ -12,19 +12,47 @@ import (
 
 // AuthTokenRepository manages authentication token persistence.
 //
-// Tokens are stored as SHA-256 hashes. GetByHash accepts the hash,
-// not the raw token. The service layer handles hashing.
+// Tokens are stored as keyed hashes. GetByHash and RevokeByHash accept the
+// token's candidate hashes (see support.TokenHasher), not the raw token, and
+// match a token stored under any of them. The service layer handles hashing
+// and moves tokens found under an older key to the current one with
+// UpdateHash.
 //
-// Revoke marks a token as revoked (soft delete). RevokeAllForUser
-// is used when password changes to invalidate all sessions.
-// DeleteExpiredOrRevoked permanently removes old records.
+// Each token records the user agent and IP of the client that created it,
+// plus last_used_at (updated via Touch), so users can review their sessions.
+// ListActiveForUser and CountActiveForUser only see tokens that are neither
+// revoked nor expired; ListForUser returns every token still stored for the
+// user, oldest first.
+//
+// Every token belongs to a family (one login and its refreshes). Revoke
+// marks a token as revoked (soft delete) together with the rest of its
+// family, including refresh tokens. RevokeForUser scopes the revocation to
+// the owning user and returns a wrapped pgx.ErrNoRows when no active token
+// matches. RevokeFamilyAccessTokens revokes a family's access tokens but
+// not its refresh tokens, so refresh rotation can retire the previous access
+// token and a family shows up as a single session. RevokeAllForUser is used when password changes to invalidate all
+// sessions; RevokeAllForUserExcept keeps one session's family alive.
+// CreateImpersonation creates a single-token family for a session an
+// administrator opened as the user; ImpersonatorID records who.
+// DeleteExpiredOrRevoked permanently removes old records; DeleteIdle removes
+// tokens not used since idleBefore.
 type AuthTokenRepository interface {
 	WithTx(tx pgx.Tx) AuthTokenRepository
 
-	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.AuthToken, error)
-	GetByHash(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, error)
+	Create(ctx context.Context, userID, familyID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent, ipAddress string) (*sqlcgen.AuthToken, error)
+	CreateImpersonation(ctx context.Context, userID, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent, ipAddress string) (*sqlcgen.AuthToken, error)
+	GetByHash(ctx context.Context, tokenHashes []string) (*sqlcgen.AuthToken, error)
+	ListActiveForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]sqlcgen.AuthToken, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.AuthToken, error)
+	CountActiveForUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	Touch(ctx context.Context, id uuid.UUID) error
+	UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error
 	Revoke(ctx context.Context, id uuid.UUID) error
-	RevokeByHash(ctx context.Context, tokenHash string) error
+	RevokeForUser(ctx context.Context, id, userID uuid.UUID) error
+	RevokeByHash(ctx context.Context, tokenHashes []string) error
+	RevokeFamilyAccessTokens(ctx context.Context, familyID uuid.UUID) error
 	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+	RevokeAllForUserExcept(ctx context.Context, userID, exceptID uuid.UUID) error
 	DeleteExpiredOrRevoked(ctx context.Context) (int64, error)
+	DeleteIdle(ctx context.Context, idleBefore time.Time) (int64, error)
 }
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// DataExportRepository stores archives of user data built for download.
+//
+// Create records a requested export before the archive exists. Complete
+// stores the archive and its new expiry once, returning pgx.ErrNoRows if
+// the export was already completed or is gone. CountForUserSince counts
+// the exports a user requested after a point in time, for rate limiting.
+// DeleteExpired removes exports whose expiry has passed, built or not.
+type DataExportRepository interface {
+	WithTx(tx pgx.Tx) DataExportRepository
+
+	Create(ctx context.Context, userID uuid.UUID, format string, expiresAt time.Time) (*sqlcgen.DataExport, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.DataExport, error)
+	Complete(ctx context.Context, id uuid.UUID, archive []byte, expiresAt time.Time) error
+	CountForUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// EmailChangeRepository manages pending and completed email address changes.
+//
+// Each change carries two token hashes: one confirms the change from the new
+// address, the other reverts it from the old address. Lookups match a token
+// stored under any of its candidate hashes (see support.TokenHasher).
+// MarkConfirmed and
+// MarkReverted only succeed once (pgx.ErrNoRows otherwise), and a reverted
+// change can no longer be confirmed. DeletePendingForUser drops unconfirmed
+// requests when a new one supersedes them. DeleteExpired removes changes
+// whose revert window has passed. ListForUser returns every change still
+// stored for the user, oldest first.
+type EmailChangeRepository interface {
+	WithTx(tx pgx.Tx) EmailChangeRepository
+
+	Create(ctx context.Context, userID uuid.UUID, oldEmail, newEmail, tokenHash, revertTokenHash string, expiresAt, revertExpiresAt time.Time) (*sqlcgen.EmailChange, error)
+	GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.EmailChange, error)
+	GetByRevertTokenHash(ctx context.Context, revertTokenHashes []string) (*sqlcgen.EmailChange, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.EmailChange, error)
+	MarkConfirmed(ctx context.Context, id uuid.UUID) error
+	MarkReverted(ctx context.Context, id uuid.UUID) error
+	DeletePendingForUser(ctx context.Context, userID uuid.UUID) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -13,12 +13,13 @@ import (
 // EmailVerificationRepository manages email verification token persistence.
 //
 // Similar lifecycle to PasswordResetRepository: tokens are single-use
-// and previous tokens are invalidated when a new one is created.
+// and previous tokens are invalidated when a new one is created, and
+// GetByTokenHash accepts the token's candidate hashes.
 type EmailVerificationRepository interface {
 	WithTx(tx pgx.Tx) EmailVerificationRepository
 
 	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.EmailVerification, error)
-	GetByTokenHash(ctx context.Context, tokenHash string) (*sqlcgen.EmailVerification, error)
+	GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.EmailVerification, error)
 	MarkUsed(ctx context.Context, id uuid.UUID) error
 	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
 	DeleteExpiredOrUsed(ctx context.Context) (int64, error)
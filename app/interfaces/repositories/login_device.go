@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// LoginDeviceRepository remembers the devices users have signed in from.
+// Devices are identified by an opaque fingerprint chosen by the caller, stored
+// as a keyed hash (see support.TokenHasher).
+type LoginDeviceRepository interface {
+	WithTx(tx pgx.Tx) LoginDeviceRepository
+
+	// Create records fingerprint for the user and reports whether it had
+	// not been recorded before. The device counts as recorded when any of
+	// candidates, the fingerprint's hashes under older keys, is stored.
+	Create(ctx context.Context, userID uuid.UUID, fingerprint string, candidates []string) (bool, error)
+	// ExistsForUser reports whether any device has been recorded for the
+	// user.
+	ExistsForUser(ctx context.Context, userID uuid.UUID) (bool, error)
+	// ListForUser returns the user's recorded devices, newest first.
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.LoginDevice, error)
+}
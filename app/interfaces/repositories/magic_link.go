@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MagicLinkRepository manages passwordless login link persistence.
+//
+// Links are single-use. GetByTokenHash matches a token stored under any of
+// its candidate hashes (see support.TokenHasher). MarkUsed only succeeds on a link that has not been
+// used yet (pgx.ErrNoRows otherwise), so two concurrent redemptions of the
+// same link cannot both start a session. InvalidateAllForUser marks every
+// other pending link as used once one has been redeemed.
+type MagicLinkRepository interface {
+	WithTx(tx pgx.Tx) MagicLinkRepository
+
+	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.MagicLink, error)
+	GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.MagicLink, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
+	DeleteExpiredOrUsed(ctx context.Context) (int64, error)
+}
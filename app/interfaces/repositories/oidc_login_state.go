@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OIDCLoginStateRepository manages pending OpenID Connect authorization
+// requests.
+//
+// The state parameter is stored as a SHA-256 hash. Consume deletes and
+// returns the state in one statement, so each authorization response can be
+// redeemed once; expiry is checked by the caller.
+type OIDCLoginStateRepository interface {
+	WithTx(tx pgx.Tx) OIDCLoginStateRepository
+
+	Create(ctx context.Context, provider, stateHash, codeVerifier, nonce string, expiresAt time.Time) (*sqlcgen.OidcLoginState, error)
+	Consume(ctx context.Context, stateHash, provider string) (*sqlcgen.OidcLoginState, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// OrganizationRepository manages organizations and their members.
+//
+// Members hold one role per organization; the database allows a single
+// owner per organization, so ownership is moved by demoting the owner
+// before promoting the successor. AddMember reports false when the user is
+// already a member. Rename, Delete, GetMemberRole, UpdateMemberRole and
+// RemoveMember return a wrapped pgx.ErrNoRows when the organization or
+// membership does not exist. Deleting an organization deletes its members
+// and invitations. OwnsAny reports whether a user owns an organization.
+type OrganizationRepository interface {
+	WithTx(tx pgx.Tx) OrganizationRepository
+
+	Create(ctx context.Context, name string) (*sqlcgen.Organization, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.Organization, error)
+	Rename(ctx context.Context, id uuid.UUID, name string) (*sqlcgen.Organization, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListOrganizationsForUserRow, error)
+
+	AddMember(ctx context.Context, organizationID, userID uuid.UUID, role string) (bool, error)
+	GetMemberRole(ctx context.Context, organizationID, userID uuid.UUID) (string, error)
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.ListOrganizationMembersRow, error)
+	MemberEmailExists(ctx context.Context, organizationID uuid.UUID, email string) (bool, error)
+	OwnsAny(ctx context.Context, userID uuid.UUID) (bool, error)
+	UpdateMemberRole(ctx context.Context, organizationID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+}
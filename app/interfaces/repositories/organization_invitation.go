@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// OrganizationInvitationRepository manages emailed invitations to join an
+// organization.
+//
+// Invitations are looked up by the token's candidate hashes (see
+// support.TokenHasher). MarkAccepted and MarkDeclined
+// only succeed once (pgx.ErrNoRows otherwise), and an invitation can't be
+// both accepted and declined. ListPending omits expired invitations.
+// DeletePending revokes an invitation that hasn't been answered, returning
+// pgx.ErrNoRows when there is none; DeletePendingForEmail drops the ones a
+// new invitation supersedes. DeleteExpiredOrUsed is for the cleanup task.
+type OrganizationInvitationRepository interface {
+	WithTx(tx pgx.Tx) OrganizationInvitationRepository
+
+	Create(ctx context.Context, organizationID uuid.UUID, email, role, tokenHash string, invitedBy uuid.UUID, expiresAt time.Time) (*sqlcgen.OrganizationInvitation, error)
+	GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.OrganizationInvitation, error)
+	ListPending(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.OrganizationInvitation, error)
+	MarkAccepted(ctx context.Context, id uuid.UUID) error
+	MarkDeclined(ctx context.Context, id uuid.UUID) error
+	DeletePending(ctx context.Context, organizationID, id uuid.UUID) error
+	DeletePendingForEmail(ctx context.Context, organizationID uuid.UUID, email string) error
+	DeleteExpiredOrUsed(ctx context.Context) (int64, error)
+}
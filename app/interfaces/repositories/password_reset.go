@@ -13,13 +13,16 @@ import (
 // PasswordResetRepository manages password reset token persistence.
 //
 // Tokens are single-use. MarkUsed sets used_at, preventing reuse.
+// GetByTokenHash matches a token stored under any of its candidate hashes
+// (see support.TokenHasher); tokens are short-lived, so old hashes are not
+// migrated.
 // InvalidateAllForUser marks all pending resets as used when a new
 // reset is requested or password is changed.
 type PasswordResetRepository interface {
 	WithTx(tx pgx.Tx) PasswordResetRepository
 
 	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.PasswordReset, error)
-	GetByTokenHash(ctx context.Context, tokenHash string) (*sqlcgen.PasswordReset, error)
+	GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.PasswordReset, error)
 	MarkUsed(ctx context.Context, id uuid.UUID) error
 	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
 	DeleteExpiredOrUsed(ctx context.Context) (int64, error)
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RecoveryCodeRepository manages two-factor recovery code persistence.
+//
+// Codes are stored as keyed hashes and are single-use: Use takes the code's
+// candidate hashes (see support.TokenHasher), marks a matching unused code as
+// used and returns false when no such code exists.
+// DeleteAllForUser removes every code, used or not, when codes are regenerated
+// or two-factor authentication is disabled.
+type RecoveryCodeRepository interface {
+	WithTx(tx pgx.Tx) RecoveryCodeRepository
+
+	Create(ctx context.Context, userID uuid.UUID, codeHash string) error
+	Use(ctx context.Context, userID uuid.UUID, codeHashes []string) (bool, error)
+	DeleteAllForUser(ctx context.Context, userID uuid.UUID) error
+}
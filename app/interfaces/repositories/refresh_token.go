@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RefreshTokenRepository manages refresh token persistence.
+//
+// Tokens are stored as keyed hashes and grouped into families, one per
+// login. GetByHashForUpdate accepts the token's candidate hashes (see
+// support.TokenHasher) and locks the matching row, so it must run inside a
+// transaction to serialize concurrent refreshes of the same token. UpdateHash
+// moves a token found under an older key to the current one.
+//
+// Tokens are single-use: MarkRotated records that a token has been exchanged.
+// RevokeFamily revokes every refresh token and auth token in the family.
+// DeleteExpiredOrRevoked permanently removes old records; rotated tokens are
+// kept until they expire so reuse can still be detected.
+type RefreshTokenRepository interface {
+	WithTx(tx pgx.Tx) RefreshTokenRepository
+
+	Create(ctx context.Context, userID, familyID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.RefreshToken, error)
+	GetByHashForUpdate(ctx context.Context, tokenHashes []string) (*sqlcgen.RefreshToken, error)
+	UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error
+	MarkRotated(ctx context.Context, id uuid.UUID) error
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	DeleteExpiredOrRevoked(ctx context.Context) (int64, error)
+}
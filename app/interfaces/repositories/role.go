@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RoleRepository manages roles and their assignment to users.
+//
+// Roles and permissions are seeded by migrations; this repository only
+// reads them. ListAssignmentsForUser returns the user's roles with the time
+// each was assigned. ListPermissionNamesForUser returns the union of
+// permissions across all of the user's roles. AssignToUser is idempotent.
+// RemoveFromUser returns a wrapped pgx.ErrNoRows when the user did not
+// hold the role.
+type RoleRepository interface {
+	WithTx(tx pgx.Tx) RoleRepository
+
+	GetByName(ctx context.Context, name string) (*sqlcgen.Role, error)
+	ListNamesForUser(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListAssignmentsForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListRoleAssignmentsForUserRow, error)
+	ListPermissionNamesForUser(ctx context.Context, userID uuid.UUID) ([]string, error)
+	AssignToUser(ctx context.Context, userID, roleID uuid.UUID) error
+	RemoveFromUser(ctx context.Context, userID, roleID uuid.UUID) error
+}
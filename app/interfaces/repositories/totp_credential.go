@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// TOTPCredentialRepository manages TOTP secret persistence.
+//
+// Each user has at most one credential. UpsertPending stores a new secret for
+// an unconfirmed enrollment and returns false when the user already has a
+// confirmed credential. Confirm activates a pending credential.
+//
+// UpdateLastUsedStep records the time step of an accepted code and returns
+// false when that step (or a later one) was already used, which prevents a
+// code from being replayed within its validity window.
+type TOTPCredentialRepository interface {
+	WithTx(tx pgx.Tx) TOTPCredentialRepository
+
+	UpsertPending(ctx context.Context, userID uuid.UUID, secret string) (bool, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*sqlcgen.TotpCredential, error)
+	Confirm(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	UpdateLastUsedStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
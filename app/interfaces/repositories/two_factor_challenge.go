@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// TwoFactorChallengeRepository manages pending two-factor login persistence.
+//
+// Challenges are stored as keyed hashes and are single-use; lookups match a
+// token stored under any of its candidate hashes (see support.TokenHasher).
+// GetByTokenHash reads a challenge without locking it; GetByTokenHashForUpdate locks the row, so it must run inside a transaction
+// to serialize concurrent attempts against the same challenge.
+// IncrementAttempts records a failed code.
+type TwoFactorChallengeRepository interface {
+	WithTx(tx pgx.Tx) TwoFactorChallengeRepository
+
+	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.TwoFactorChallenge, error)
+	GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.TwoFactorChallenge, error)
+	GetByTokenHashForUpdate(ctx context.Context, tokenHashes []string) (*sqlcgen.TwoFactorChallenge, error)
+	IncrementAttempts(ctx context.Context, id uuid.UUID) error
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	DeleteExpiredOrUsed(ctx context.Context) (int64, error)
+}
@@ -10,13 +10,43 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// UserProfileUpdate holds the mutable profile fields of a user. Nil fields
+// keep their stored value.
+type UserProfileUpdate struct {
+	Name *string
+}
+
+// UserSearch filters users. Query matches a substring of the email or name,
+// case-insensitively. Nil fields do not filter.
+type UserSearch struct {
+	Query             *string
+	Verified          *bool
+	DeletionScheduled *bool
+}
+
 // UserRepository provides user persistence operations.
 //
 // GetByID and GetByEmail return a wrapped pgx.ErrNoRows when the user is not
 // found. Callers should check via eris.Is(err, pgx.ErrNoRows).
 //
-// DeleteScheduledUsers removes users whose deletion_scheduled_at has passed.
-// Returns the count of deleted users for logging purposes.
+// UpdateEmail replaces the address and marks it verified, since callers only
+// use it after the owner has proven control of the address. It returns the
+// database error unchanged when the address is taken (uq_users_email).
+//
+// UpdateProfile applies the non-nil fields of update, bumps updated_at and
+// returns the updated user. It returns a wrapped pgx.ErrNoRows when the user
+// does not exist.
+//
+// Search returns a page of users matching search, newest first; Count
+// returns how many users match in total.
+//
+// ClaimDeletionReminders marks users whose deletion is scheduled before
+// dueBefore, and who have not been reminded yet, as reminded and returns
+// them. Deletions already due are skipped. Scheduling or cancelling a
+// deletion clears the mark.
+//
+// DeleteScheduledUsers removes users whose deletion_scheduled_at has passed
+// and returns them, so callers can tell them their account is gone.
 type UserRepository interface {
 	WithTx(tx pgx.Tx) UserRepository
 
@@ -24,9 +54,14 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.User, error)
 	GetByEmail(ctx context.Context, email string) (*sqlcgen.User, error)
 	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error
+	UpdateProfile(ctx context.Context, userID uuid.UUID, update UserProfileUpdate) (*sqlcgen.User, error)
 	MarkEmailVerified(ctx context.Context, userID uuid.UUID) error
 	EmailExists(ctx context.Context, email string) (bool, error)
 	ScheduleDeletion(ctx context.Context, userID uuid.UUID, scheduledAt time.Time) error
 	CancelDeletion(ctx context.Context, userID uuid.UUID) error
-	DeleteScheduledUsers(ctx context.Context) (int64, error)
+	ClaimDeletionReminders(ctx context.Context, dueBefore time.Time) ([]sqlcgen.User, error)
+	DeleteScheduledUsers(ctx context.Context) ([]sqlcgen.User, error)
+	Search(ctx context.Context, search UserSearch, limit, offset int32) ([]sqlcgen.User, error)
+	Count(ctx context.Context, search UserSearch) (int64, error)
 }
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// UserIdentityRepository manages external identities linked to users.
+//
+// Identities are unique per provider and subject. RecordLogin stores the
+// email the provider last reported and marks the identity as used.
+// DeleteForUser scopes the deletion to the owning user and returns a wrapped
+// pgx.ErrNoRows when nothing matches.
+type UserIdentityRepository interface {
+	WithTx(tx pgx.Tx) UserIdentityRepository
+
+	Create(ctx context.Context, userID uuid.UUID, provider, subject, email string) (*sqlcgen.UserIdentity, error)
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*sqlcgen.UserIdentity, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.UserIdentity, error)
+	CountForUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	RecordLogin(ctx context.Context, id uuid.UUID, email string) error
+	DeleteForUser(ctx context.Context, id, userID uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// WebAuthnChallengeRepository manages passkey ceremony challenge persistence.
+//
+// Challenges are stored as SHA-256 hashes. userID is nil for login
+// challenges. Consume deletes and returns the challenge in one statement, so
+// each challenge can be used once even under concurrent requests; expiry is
+// checked by the caller.
+type WebAuthnChallengeRepository interface {
+	WithTx(tx pgx.Tx) WebAuthnChallengeRepository
+
+	Create(ctx context.Context, userID *uuid.UUID, ceremony, challengeHash string, expiresAt time.Time) (*sqlcgen.WebauthnChallenge, error)
+	Consume(ctx context.Context, challengeHash, ceremony string) (*sqlcgen.WebauthnChallenge, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
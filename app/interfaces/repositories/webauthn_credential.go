@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// WebAuthnCredentialRepository manages passkey persistence.
+//
+// GetByCredentialID looks a passkey up by the authenticator-assigned id sent
+// in login assertions. UpdateSignCount stores the latest signature counter
+// and marks the passkey as used. DeleteForUser scopes the deletion to the
+// owning user and returns a wrapped pgx.ErrNoRows when nothing matches.
+type WebAuthnCredentialRepository interface {
+	WithTx(tx pgx.Tx) WebAuthnCredentialRepository
+
+	Create(ctx context.Context, userID uuid.UUID, credentialID, publicKey []byte, signCount int64, aaguid []byte, backupEligible bool, transports []string, name string) (*sqlcgen.WebauthnCredential, error)
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*sqlcgen.WebauthnCredential, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error)
+	UpdateSignCount(ctx context.Context, id uuid.UUID, signCount int64) error
+	DeleteForUser(ctx context.Context, id, userID uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package services
+
+import "context"
+
+// AccountDeletionService carries out scheduled account deletions for the
+// worker.
+//
+// SendReminders emails users whose deletion falls within
+// auth.account_deletion_reminder a link to cancel it, once per scheduled
+// deletion, and returns how many were reminded. DeleteScheduled removes the
+// users whose deletion is due, tells each of them by email and returns how
+// many were deleted.
+type AccountDeletionService interface {
+	SendReminders(ctx context.Context) (int64, error)
+	DeleteScheduled(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// UserSearch filters the admin user list. Query matches a substring of the
+// email or name, case-insensitively; an empty Query matches everyone. Nil
+// fields do not filter.
+type UserSearch struct {
+	Query             string
+	Verified          *bool
+	DeletionScheduled *bool
+}
+
+// AdminUser is a user as seen by support staff.
+type AdminUser struct {
+	User           *sqlcgen.User
+	ActiveSessions int64
+}
+
+// AdminService provides the user management operations that only
+// administrators may perform and that no other service already offers.
+//
+// ListUsers returns a page of users matching search, newest first, plus
+// the total count. GetUser returns ErrUserNotFound for unknown users.
+// VerifyEmail marks a user's email verified without a token through
+// EmailVerificationService.MarkVerified, so outstanding verification links
+// stop working, and returns ErrEmailAlreadyVerified when it already is.
+// RevokeSessions revokes every session, refresh token and API key of the
+// user in one transaction, then drops the user's cached tokens; it returns
+// ErrUserNotFound for unknown users.
+type AdminService interface {
+	ListUsers(ctx context.Context, search UserSearch, limit, offset int32) ([]sqlcgen.User, int64, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*AdminUser, error)
+	VerifyEmail(ctx context.Context, userID uuid.UUID) error
+	RevokeSessions(ctx context.Context, userID uuid.UUID) error
+}
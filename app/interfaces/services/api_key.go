@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every personal API key, telling keys apart from
+// session tokens in the Authorization header.
+const APIKeyPrefix = "ak_"
+
+// API key scopes. A key can only call endpoints that require one of its
+// scopes; session tokens are not limited by scopes.
+const (
+	ScopeProfileRead  = "profile:read"
+	ScopeProfileWrite = "profile:write"
+)
+
+// APIKeyScopes lists every scope a key may be granted.
+var APIKeyScopes = []string{
+	ScopeProfileRead,
+	ScopeProfileWrite,
+}
+
+// CreatedAPIKey is a newly created API key. Key is the only copy of the
+// plaintext key and cannot be retrieved again.
+type CreatedAPIKey struct {
+	APIKey *sqlcgen.ApiKey
+	Key    string
+}
+
+// APIKeyService manages personal API keys for machine clients.
+//
+// Keys are opaque strings starting with APIKeyPrefix; only their SHA-256
+// hashes are stored. Create rejects scopes missing from APIKeyScopes with
+// ErrInvalidAPIKeyScope. A nil expiresAt creates a key that is valid until
+// revoked. Rename and Revoke return ErrAPIKeyNotFound unless the key
+// belongs to the user and is not revoked.
+//
+// Validate returns the key record when the key is neither revoked nor
+// expired and records its use.
+type APIKeyService interface {
+	Create(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*CreatedAPIKey, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ApiKey, error)
+	Rename(ctx context.Context, userID, keyID uuid.UUID, name string) (*sqlcgen.ApiKey, error)
+	Revoke(ctx context.Context, userID, keyID uuid.UUID) error
+	Validate(ctx context.Context, key string) (*sqlcgen.ApiKey, error)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// Formats a data export can be built in.
+const (
+	DataExportFormatJSON = "json"
+	DataExportFormatZIP  = "zip"
+)
+
+// DataExporter returns one section of a user's data export. The result is
+// encoded as JSON, so exporters should return structs with json tags and
+// leave out secrets such as password and token hashes.
+type DataExporter func(ctx context.Context, userID uuid.UUID) (any, error)
+
+// DataExportService produces copies of everything stored about a user.
+//
+// Request records an export and queues the worker to build it. It returns
+// ErrDataExportTooSoon if the user already requested one within
+// data_export.min_interval. Build runs every registered DataExporter. It
+// stores the result as one JSON document, or as a ZIP archive with a JSON
+// file per exporter, then emails the user a signed download link. The
+// link and the archive expire after data_export.link_ttl.
+//
+// Download checks a link's signature and expiry and returns the built
+// export. Unknown, unbuilt or expired exports return
+// ErrInvalidDataExportLink.
+type DataExportService interface {
+	Request(ctx context.Context, userID uuid.UUID, format string) error
+	Build(ctx context.Context, exportID uuid.UUID) error
+	Download(ctx context.Context, exportID uuid.UUID, expiresAt time.Time, signature string) (*sqlcgen.DataExport, error)
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// EmailChangeService changes a user's email address with confirmation from
+// both sides.
+//
+// Request checks the current password and mails a confirmation link to the
+// new address and a revert link to the old one. A new request replaces any
+// pending one. A new address that is already taken returns
+// ErrEmailAlreadyExists, or nothing is sent and Request succeeds in
+// enumeration-safe mode. Confirm applies the change and marks the new address
+// verified. Revert cancels a pending change, or restores the old address and
+// revokes every session and API key when the change was already confirmed; its link
+// stays valid for longer than the confirmation link.
+//
+// Both Confirm and Revert return ErrEmailAlreadyExists when another account
+// claimed the target address in the meantime.
+type EmailChangeService interface {
+	Request(ctx context.Context, userID uuid.UUID, newEmail, password string) error
+	Confirm(ctx context.Context, token string) error
+	Revert(ctx context.Context, token string) error
+}
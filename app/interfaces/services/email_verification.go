@@ -13,8 +13,14 @@ import (
 //
 // Resend is for unauthenticated users who need a new verification email.
 // It silently succeeds for non-existent emails to prevent enumeration.
+//
+// MarkVerified verifies a user's email without a token, as administrators
+// do, and invalidates the tokens still outstanding. It returns
+// ErrUserNotFound for unknown users and ErrEmailAlreadyVerified when the
+// email already is verified.
 type EmailVerificationService interface {
 	Send(ctx context.Context, userID uuid.UUID) error
 	Verify(ctx context.Context, token string) error
 	Resend(ctx context.Context, email string) error
+	MarkVerified(ctx context.Context, userID uuid.UUID) error
 }
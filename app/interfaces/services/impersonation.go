@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Audit log actions recorded by ImpersonationService.
+const (
+	AuditActionImpersonationStarted = "impersonation.started"
+	AuditActionImpersonationEnded   = "impersonation.ended"
+)
+
+// ImpersonationService lets administrators act as another user.
+//
+// Start opens a session for userID flagged with impersonatorID. It lasts
+// auth.impersonation_ttl, comes without a refresh token, and is refused
+// with ErrImpersonationNotAllowed for the administrator themselves and for
+// users who may impersonate others. End revokes such a session. Both write
+// an audit log entry in the same transaction as the session change.
+type ImpersonationService interface {
+	Start(ctx context.Context, impersonatorID, userID uuid.UUID, client ClientInfo) (*SessionTokens, error)
+	End(ctx context.Context, sessionID, impersonatorID, userID uuid.UUID, client ClientInfo) error
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+)
+
+// LoginAlertService warns users about sign-ins from devices they have not
+// used before.
+//
+// RecordLogin remembers the client of a successful login as a device
+// fingerprint of its user agent and IP address. When the fingerprint is new
+// for a user who has signed in before, the user is emailed the time, the
+// browser and operating system, the IP address and a link to review their
+// sessions. The first device a user is seen on is recorded silently.
+type LoginAlertService interface {
+	RecordLogin(ctx context.Context, user *sqlcgen.User, client ClientInfo) error
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+)
+
+// LoginLockoutService protects password login and its two-factor step
+// against brute force.
+//
+// Failed attempts, wrong passwords and wrong two-factor codes alike, are
+// counted per email and per IP address in an
+// AttemptStore shared by all API replicas. Check returns ErrLoginLocked,
+// with the seconds to wait in the "retry_after" detail, while either is
+// locked. RecordFailure counts a failed attempt against both and returns
+// ErrLoginLocked when it triggers a lock; the account owner is emailed the
+// first time their account is locked within the counting window.
+//
+// RecordSuccess clears the email's count but not the IP's, so an attacker
+// cannot restore their budget by signing in to an account of their own.
+// Unlock clears an email's count and lock; it is meant for administrators.
+type LoginLockoutService interface {
+	Check(ctx context.Context, email, ipAddress string) error
+	RecordFailure(ctx context.Context, email, ipAddress string) error
+	RecordSuccess(ctx context.Context, email string) error
+	Unlock(ctx context.Context, email string) error
+}
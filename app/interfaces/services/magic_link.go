@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+)
+
+// MagicLinkService handles passwordless login via emailed one-time links.
+//
+// Create is idempotent: if email doesn't exist, it silently succeeds
+// to prevent email enumeration attacks. Links are sent via async email task.
+//
+// Execute redeems a link and logs the user in exactly like a password
+// login, including the two-factor challenge. Redeeming a link proves
+// ownership of the address, so it also marks the email as verified.
+type MagicLinkService interface {
+	Create(ctx context.Context, email string) error
+	Execute(ctx context.Context, token string, client ClientInfo) (*LoginResult, error)
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// OIDCService signs users in with external OpenID Connect providers and
+// manages the identities linked to their accounts.
+//
+// BeginLogin stores a single-use state, valid for oidc.state_ttl, and returns
+// the provider's authorization URL. FinishLogin redeems the code and state the
+// provider redirects back with. A known identity signs in its user; a new one
+// is linked to the account with the same email, or a new account is created.
+// New identities need an email verified by the provider, and linking also
+// needs the account's own email to be verified, so nobody can pre-register
+// someone else's address and wait for them to sign in. The result is the same
+// as a password login: tokens, or a two-factor challenge.
+//
+// Unlink refuses to remove the last identity of an account without a
+// password, which would leave no way to sign in.
+type OIDCService interface {
+	BeginLogin(ctx context.Context, provider string) (string, error)
+	FinishLogin(ctx context.Context, provider, code, state string, client ClientInfo) (*LoginResult, error)
+	ListIdentities(ctx context.Context, userID uuid.UUID) ([]sqlcgen.UserIdentity, error)
+	Unlink(ctx context.Context, userID, identityID uuid.UUID) error
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// Roles a user can hold within an organization, from most to least
+// privileged. Every organization has exactly one owner.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+var orgRoleRanks = map[string]int{
+	OrgRoleMember: 1,
+	OrgRoleAdmin:  2,
+	OrgRoleOwner:  3,
+}
+
+// OrgRoleAtLeast reports whether role grants everything required does.
+// Unknown roles grant nothing.
+func OrgRoleAtLeast(role, required string) bool {
+	rank, ok := orgRoleRanks[role]
+	return ok && rank >= orgRoleRanks[required]
+}
+
+// OrganizationService manages organizations, their members and invitations.
+//
+// The user who creates an organization becomes its owner. Only the owner
+// can delete the organization or hand ownership to another member with
+// TransferOwnership, which leaves the previous owner an admin and refuses
+// a new owner whose account is scheduled for deletion. Methods
+// taking an organization ID do not check the caller's role; routes guard
+// them with the RequireOrgRole middleware, which uses GetMemberRole.
+// GetMemberRole returns ErrOrganizationNotFound for non-members so that
+// outsiders cannot tell which organizations exist.
+//
+// UpdateMemberRole and invitations only grant admin or member
+// (ErrInvalidOrganizationRole otherwise), and the owner's membership
+// cannot be changed or removed (ErrOrganizationOwner). RemoveMember lets
+// any member leave, while removing someone else takes an admin.
+//
+// Invite emails a link to accept the invitation, replacing any pending
+// invitation for the same address. AcceptInvitation requires the signed-in
+// user's email to match the invitation (ErrInvitationEmailMismatch);
+// DeclineInvitation needs only the token. Both return
+// ErrInvalidOrganizationInvitation for unknown, expired or answered
+// invitations. Create, and AcceptInvitation for an admin invitation, return
+// ErrDeletionScheduled while the user's account is scheduled for deletion,
+// since admins may be handed ownership and owners can't be deleted.
+type OrganizationService interface {
+	Create(ctx context.Context, userID uuid.UUID, name string) (*sqlcgen.Organization, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListOrganizationsForUserRow, error)
+	Get(ctx context.Context, organizationID uuid.UUID) (*sqlcgen.Organization, error)
+	Rename(ctx context.Context, organizationID uuid.UUID, name string) (*sqlcgen.Organization, error)
+	Delete(ctx context.Context, organizationID uuid.UUID) error
+
+	GetMemberRole(ctx context.Context, organizationID, userID uuid.UUID) (string, error)
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.ListOrganizationMembersRow, error)
+	UpdateMemberRole(ctx context.Context, organizationID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, organizationID, actorID, userID uuid.UUID) error
+	TransferOwnership(ctx context.Context, organizationID, ownerID, newOwnerID uuid.UUID) error
+
+	Invite(ctx context.Context, organizationID, inviterID uuid.UUID, email, role string) (*sqlcgen.OrganizationInvitation, error)
+	ListInvitations(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.OrganizationInvitation, error)
+	RevokeInvitation(ctx context.Context, organizationID, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) error
+	DeclineInvitation(ctx context.Context, token string) error
+}
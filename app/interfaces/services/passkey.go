@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// PasskeyDescriptor identifies an existing passkey to an authenticator.
+type PasskeyDescriptor struct {
+	ID         []byte
+	Transports []string
+}
+
+// PasskeyRegistrationOptions are the parameters for
+// navigator.credentials.create. Challenge is base64url encoded.
+type PasskeyRegistrationOptions struct {
+	Challenge          string
+	RPID               string
+	RPName             string
+	User               *sqlcgen.User
+	Algorithms         []int64
+	ExcludeCredentials []PasskeyDescriptor
+	Timeout            time.Duration
+}
+
+// PasskeyLoginOptions are the parameters for navigator.credentials.get.
+// Challenge is base64url encoded.
+type PasskeyLoginOptions struct {
+	Challenge string
+	RPID      string
+	Timeout   time.Duration
+}
+
+// PasskeyRegistration is an authenticator's response to a registration
+// ceremony. Credential is the PublicKeyCredential in its JSON encoding
+// (PublicKeyCredential.toJSON() in browsers).
+type PasskeyRegistration struct {
+	Name       string
+	Credential []byte
+}
+
+// PasskeyService manages WebAuthn passkeys.
+//
+// Each ceremony is two calls: Begin* issues options with a single-use
+// challenge valid for webauthn.challenge_ttl, and Finish* verifies the
+// authenticator's response against it. Registration challenges are bound
+// to the user who requested them. Passkeys are discoverable, so login needs
+// no email: the credential identifies the user. A credential that is not
+// valid PublicKeyCredential JSON returns errors.ErrInvalidPasskeyResponse.
+//
+// FinishLogin issues a session like a password login but skips the TOTP step since passkeys require user verification
+// and are already multi-factor.
+type PasskeyService interface {
+	BeginRegistration(ctx context.Context, userID uuid.UUID) (*PasskeyRegistrationOptions, error)
+	FinishRegistration(ctx context.Context, userID uuid.UUID, registration PasskeyRegistration) (*sqlcgen.WebauthnCredential, error)
+	BeginLogin(ctx context.Context) (*PasskeyLoginOptions, error)
+	FinishLogin(ctx context.Context, credential []byte, client ClientInfo) (*sqlcgen.User, *SessionTokens, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error)
+	Delete(ctx context.Context, userID, passkeyID uuid.UUID) error
+}
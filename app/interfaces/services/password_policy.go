@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+)
+
+// PasswordPolicyService decides whether a new password is acceptable.
+//
+// Check returns nil or a VALIDATION_ERROR whose "password" detail lists
+// every rule the password breaks: length, required character classes,
+// containing the user's name or email, a strength score below the
+// configured minimum, and appearing in the breached password list.
+//
+// Strength scores a password from 0 (trivial) to 4 (strong) by estimating
+// its entropy from length and character variety, discounting repeated and
+// sequential characters.
+type PasswordPolicyService interface {
+	Check(ctx context.Context, password, name, email string) error
+	Strength(password string) int
+}
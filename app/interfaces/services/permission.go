@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Roles and permissions seeded by migrations. Permission names are
+// "<resource>:<action>".
+const (
+	RoleAdmin = "admin"
+
+	PermissionUsersRead        = "users:read"
+	PermissionUsersWrite       = "users:write"
+	PermissionUsersImpersonate = "users:impersonate"
+)
+
+// Access is a user's effective roles and permissions. Permissions are the
+// union over all of the user's roles.
+type Access struct {
+	Roles       []string
+	Permissions []string
+}
+
+// PermissionService resolves and manages role-based access.
+//
+// GetAccess loads a user's roles and permissions from the database on
+// every call; the RequirePermission and RequireRole middlewares keep the
+// result in the request context so a request loads it at most once.
+// GrantRole and RevokeRole assign roles by email and are meant for the
+// users CLI. GrantRole is idempotent; RevokeRole returns
+// ErrRoleNotAssigned when the user does not hold the role.
+type PermissionService interface {
+	GetAccess(ctx context.Context, userID uuid.UUID) (*Access, error)
+	GrantRole(ctx context.Context, email, role string) error
+	RevokeRole(ctx context.Context, email, role string) error
+}
@@ -2,24 +2,75 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"go-reasonable-api/db/sqlcgen"
 
 	"github.com/google/uuid"
 )
 
+// ClientInfo identifies the client a session is created for. It is stored
+// alongside the token so users can recognise their devices.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// SessionTokens are the credentials issued when a session is created or
+// refreshed. RefreshToken is empty when refresh tokens are disabled.
+type SessionTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt *time.Time
+}
+
+// LoginResult is the outcome of a password login. Exactly one of Tokens and
+// Challenge is set: Challenge when the user has two-factor authentication
+// enabled and must complete the login with CompleteTwoFactor.
+type LoginResult struct {
+	User      *sqlcgen.User
+	Tokens    *SessionTokens
+	Challenge *TwoFactorChallenge
+}
+
 // SessionService manages authentication tokens.
 //
 // Tokens are opaque strings returned to clients. The service stores only
 // SHA-256 hashes, making token theft from the database ineffective.
 //
-// Create validates credentials and returns both user and token on success.
-// CreateForUser generates a token without credential validation (for post-registration).
-// ValidateToken returns the token record if valid; callers must check expiration
-// and revocation status on the returned AuthToken.
+// Create validates credentials and returns the user with either tokens or,
+// when two-factor authentication is enabled, a challenge. Failed attempts
+// count towards a LoginLockoutService lockout and locked logins are
+// rejected with ErrLoginLocked before the password is checked. A correct
+// password whose stored hash is outdated is rehashed. CompleteTwoFactor
+// exchanges the challenge and a TOTP or recovery code for tokens; wrong
+// codes count towards the same lockout, which is only cleared once the
+// login completes. Both report the completed login to a LoginAlertService.
+// StartSession completes a login whose credentials another service already
+// verified (passkeys, OpenID Connect, magic links) the same way Create does,
+// login alert included.
+// CreateForUser issues tokens without credential validation (for post-registration).
+// When auth.refresh_token_ttl is set, access tokens are short-lived and come
+// with a refresh token. Refresh exchanges a refresh token for a new pair in the
+// same family and revokes the previous access token, so a family is one
+// session; presenting an already rotated refresh token revokes the family
+// and returns ErrRefreshTokenReused.
+// ValidateToken returns the token record if valid and records its use;
+// callers must check expiration and revocation status on the returned AuthToken.
+//
+// ListForUser returns a page of the user's active sessions plus the total count.
+// Revoke ends one of the user's sessions by ID; RevokeOthers ends every session
+// except the given one.
 type SessionService interface {
-	Create(ctx context.Context, email, password string) (*sqlcgen.User, string, error)
-	CreateForUser(ctx context.Context, userID uuid.UUID) (string, error)
+	Create(ctx context.Context, email, password string, client ClientInfo) (*LoginResult, error)
+	CompleteTwoFactor(ctx context.Context, challengeToken, code string, client ClientInfo) (*sqlcgen.User, *SessionTokens, error)
+	StartSession(ctx context.Context, user *sqlcgen.User, client ClientInfo) (*SessionTokens, error)
+	CreateForUser(ctx context.Context, userID uuid.UUID, client ClientInfo) (*SessionTokens, error)
+	Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*SessionTokens, error)
 	Delete(ctx context.Context, token string) error
 	ValidateToken(ctx context.Context, token string) (*sqlcgen.AuthToken, error)
+	ListForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]sqlcgen.AuthToken, int64, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeOthers(ctx context.Context, userID, currentSessionID uuid.UUID) error
 }
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TOTPEnrollment is a pending TOTP setup. URI is the otpauth:// key URI for
+// authenticator apps; Secret is the same key for manual entry.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// TwoFactorChallenge is issued by a password login when the user has
+// two-factor authentication enabled. Token is exchanged for a session by
+// presenting a TOTP or recovery code before ExpiresAt.
+type TwoFactorChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// TwoFactorService manages TOTP two-factor authentication (RFC 6238).
+//
+// BeginEnrollment stores a new pending secret, replacing any previous pending
+// one. ConfirmEnrollment activates it once the user proves possession with a
+// first code and returns single-use recovery codes; only their hashes are
+// stored, so they cannot be shown again. Disable requires the account password.
+//
+// CreateChallenge and VerifyChallenge implement the second login step.
+// ChallengeUser returns the user a challenge was issued for without using
+// it, so the caller can apply the login lockout before a code is checked.
+// VerifyChallenge accepts either a TOTP code or an unused recovery code and
+// returns the user the challenge was issued for. Challenges are single-use
+// and stop accepting codes after MaxTwoFactorAttempts failures.
+type TwoFactorService interface {
+	BeginEnrollment(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, password string) error
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	CreateChallenge(ctx context.Context, userID uuid.UUID) (*TwoFactorChallenge, error)
+	ChallengeUser(ctx context.Context, token string) (uuid.UUID, error)
+	VerifyChallenge(ctx context.Context, token, code string) (uuid.UUID, error)
+}
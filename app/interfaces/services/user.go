@@ -2,20 +2,50 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"go-reasonable-api/db/sqlcgen"
 
 	"github.com/google/uuid"
 )
 
+// ProfileUpdate is a partial update of the user's mutable profile fields.
+// Nil fields are left unchanged.
+type ProfileUpdate struct {
+	Name *string
+}
+
 // UserService manages user lifecycle operations.
 //
-// Create hashes passwords using bcrypt before storage.
+// Create and ChangePassword reject passwords that break the
+// PasswordPolicyService rules with a VALIDATION_ERROR.
+// Create hashes passwords with the configured PasswordHasher. With
+// auth.enumeration_safe set it also emails the owner of a taken address
+// before returning ErrEmailAlreadyExists, and sends new accounts a
+// verification email; callers must then answer both cases alike.
+// ChangePassword verifies the current password, stores the new hash and
+// revokes every session except currentSessionID in the same transaction;
+// API keys are kept.
+// UpdateProfile applies a ProfileUpdate and returns the updated user; an
+// empty update returns the user as stored without bumping updated_at.
 // ScheduleDeletion implements soft-delete with a configurable delay period,
-// allowing users to cancel deletion by logging in before the deadline.
+// allowing users to cancel deletion before the deadline, signed in or with
+// the signed link in the email it sends. It revokes the user's sessions
+// and API keys; signing in alone does not cancel the deletion. Owners of
+// an organization get ErrOwnsOrganization until they transfer or delete it,
+// since deleting them would leave the organization without an owner.
+// CancelDeletion clears a scheduled deletion and returns
+// ErrDeletionNotScheduled when none is pending. CancelDeletionWithLink does
+// the same for an unauthenticated caller holding that link, identified by
+// the deletion time it carries, and returns ErrInvalidDeletionLink when the
+// signature is wrong or the deletion it was sent for is no longer pending.
 type UserService interface {
 	Create(ctx context.Context, name, email, password string) (*sqlcgen.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.User, error)
 	GetByEmail(ctx context.Context, email string) (*sqlcgen.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, update ProfileUpdate) (*sqlcgen.User, error)
+	ChangePassword(ctx context.Context, userID, currentSessionID uuid.UUID, currentPassword, newPassword string) error
 	ScheduleDeletion(ctx context.Context, userID uuid.UUID) error
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	CancelDeletionWithLink(ctx context.Context, userID uuid.UUID, scheduledAt time.Time, signature string) error
 }
@@ -0,0 +1,24 @@
+package support
+
+import (
+	"context"
+	"time"
+)
+
+// AttemptStore counts failed attempts and holds locks per key.
+//
+// It must be shared by every API replica so limits apply to the whole
+// deployment rather than to each process. The Redis implementation lives
+// in support/attempts.
+type AttemptStore interface {
+	// Fail records a failed attempt and returns the number of failures
+	// for key. The count is forgotten once window passes without failures.
+	Fail(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Lock locks key for d.
+	Lock(ctx context.Context, key string, d time.Duration) error
+	// LockedFor returns the time left on the lock for key, or zero when
+	// key is not locked.
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Reset clears both the failure count and the lock for key.
+	Reset(ctx context.Context, key string) error
+}
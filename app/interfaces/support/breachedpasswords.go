@@ -0,0 +1,13 @@
+package support
+
+import "context"
+
+// BreachedPasswords reports whether a password appears in a list of
+// passwords exposed in data breaches.
+//
+// Implementations look passwords up by the first five hex characters of
+// their SHA-1 hash (k-anonymity), so the full hash never leaves the
+// process. The file-based implementation lives in support/breached.
+type BreachedPasswords interface {
+	Contains(ctx context.Context, password string) (bool, error)
+}
@@ -1,5 +1,6 @@
 // Package support defines infrastructure contracts used by services.
 //
-// These interfaces abstract external dependencies (email, task queue)
-// enabling services to remain testable without infrastructure coupling.
+// These interfaces abstract external dependencies (email, task queue,
+// shared attempt counters, breached password lists, password and token
+// hashing, link signing, auth token caching) enabling services to remain testable without infrastructure coupling.
 package support
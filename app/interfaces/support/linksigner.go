@@ -0,0 +1,19 @@
+package support
+
+import "time"
+
+// LinkSigner signs links that grant access without a session, such as
+// emailed download links.
+//
+// A signature binds a purpose, a subject and an expiry time, so it can't be
+// reused for another kind of link, another resource or a later deadline.
+// Links must carry the expiry alongside the signature. The HMAC-SHA256
+// implementation lives in support/linksign.
+type LinkSigner interface {
+	// Sign returns the signature of subject for purpose, valid until
+	// expiresAt.
+	Sign(purpose, subject string, expiresAt time.Time) string
+	// Verify reports whether signature was made by Sign with the same
+	// arguments and expiresAt has not passed.
+	Verify(purpose, subject string, expiresAt time.Time, signature string) bool
+}
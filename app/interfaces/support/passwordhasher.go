@@ -0,0 +1,17 @@
+package support
+
+// PasswordHasher hashes and verifies user passwords.
+//
+// Hashes are self-describing, so hashes written under an earlier algorithm
+// or parameters keep verifying after the configuration changes. The argon2id
+// and bcrypt implementation lives in support/passwordhash.
+type PasswordHasher interface {
+	// Hash returns a hash of password using the configured algorithm.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches hash. Malformed hashes,
+	// including the empty hash of a passwordless account, never match.
+	Verify(password, hash string) bool
+	// NeedsRehash reports whether hash was made with an algorithm or
+	// parameters other than the configured ones.
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,32 @@
+package support
+
+import (
+	"context"
+
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+)
+
+// TokenCache holds recently validated auth tokens so authenticated requests
+// can skip the database.
+//
+// Entries are keyed by token hash and expire on their own shortly after
+// they are cached. Cached tokens are still checked for revocation and
+// expiry, but a revocation only reaches the cache through Invalidate, so
+// every path that revokes a user's tokens must call it once the revocation
+// is committed. Set takes the generation Get returned, read before the
+// token was loaded, and skips tokens of a user invalidated since then, so a
+// lookup racing a revocation can't cache the token again. The Redis
+// implementation lives in support/tokencache.
+type TokenCache interface {
+	// Get returns the token cached under tokenHash and the generation to
+	// pass to Set. Cache errors count as misses, so an unavailable cache
+	// falls back to the database.
+	Get(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, int64, bool)
+	// Set caches token under its TokenHash unless its user was invalidated
+	// after generation. Failures are ignored.
+	Set(ctx context.Context, token *sqlcgen.AuthToken, generation int64)
+	// Invalidate drops every cached token of userID.
+	Invalidate(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package support
+
+// TokenHasher hashes opaque tokens before they are stored or looked up.
+//
+// Hash is used when storing a token. Candidates lists every hash a stored
+// token may have been written with, current key first, so lookups keep
+// finding tokens hashed before a key rotation. The HMAC-SHA256 keyring
+// implementation lives in support/tokenhash.
+type TokenHasher interface {
+	// Hash returns the hash of token under the current key.
+	Hash(token string) string
+	// Candidates returns the hashes of token under the current key, each
+	// previous key, and the unkeyed SHA-256 used before keyed hashing.
+	Candidates(token string) []string
+}
@@ -42,9 +42,75 @@ func (_m *MockAuthTokenRepository) EXPECT() *MockAuthTokenRepository_Expecter {
 	return &MockAuthTokenRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountActiveForUser provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) CountActiveForUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountActiveForUser")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthTokenRepository_CountActiveForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountActiveForUser'
+type MockAuthTokenRepository_CountActiveForUser_Call struct {
+	*mock.Call
+}
+
+// CountActiveForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockAuthTokenRepository_Expecter) CountActiveForUser(ctx interface{}, userID interface{}) *MockAuthTokenRepository_CountActiveForUser_Call {
+	return &MockAuthTokenRepository_CountActiveForUser_Call{Call: _e.mock.On("CountActiveForUser", ctx, userID)}
+}
+
+func (_c *MockAuthTokenRepository_CountActiveForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockAuthTokenRepository_CountActiveForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_CountActiveForUser_Call) Return(n int64, err error) *MockAuthTokenRepository_CountActiveForUser_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_CountActiveForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (int64, error)) *MockAuthTokenRepository_CountActiveForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockAuthTokenRepository
-func (_mock *MockAuthTokenRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.AuthToken, error) {
-	ret := _mock.Called(ctx, userID, tokenHash, expiresAt)
+func (_mock *MockAuthTokenRepository) Create(ctx context.Context, userID uuid.UUID, familyID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent string, ipAddress string) (*sqlcgen.AuthToken, error) {
+	ret := _mock.Called(ctx, userID, familyID, tokenHash, expiresAt, userAgent, ipAddress)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -52,18 +118,18 @@ func (_mock *MockAuthTokenRepository) Create(ctx context.Context, userID uuid.UU
 
 	var r0 *sqlcgen.AuthToken
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) (*sqlcgen.AuthToken, error)); ok {
-		return returnFunc(ctx, userID, tokenHash, expiresAt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time, string, string) (*sqlcgen.AuthToken, error)); ok {
+		return returnFunc(ctx, userID, familyID, tokenHash, expiresAt, userAgent, ipAddress)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) *sqlcgen.AuthToken); ok {
-		r0 = returnFunc(ctx, userID, tokenHash, expiresAt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time, string, string) *sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, userID, familyID, tokenHash, expiresAt, userAgent, ipAddress)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sqlcgen.AuthToken)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
-		r1 = returnFunc(ctx, userID, tokenHash, expiresAt)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, familyID, tokenHash, expiresAt, userAgent, ipAddress)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,13 +144,16 @@ type MockAuthTokenRepository_Create_Call struct {
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID uuid.UUID
+//   - familyID uuid.UUID
 //   - tokenHash string
 //   - expiresAt time.Time
-func (_e *MockAuthTokenRepository_Expecter) Create(ctx interface{}, userID interface{}, tokenHash interface{}, expiresAt interface{}) *MockAuthTokenRepository_Create_Call {
-	return &MockAuthTokenRepository_Create_Call{Call: _e.mock.On("Create", ctx, userID, tokenHash, expiresAt)}
+//   - userAgent string
+//   - ipAddress string
+func (_e *MockAuthTokenRepository_Expecter) Create(ctx interface{}, userID interface{}, familyID interface{}, tokenHash interface{}, expiresAt interface{}, userAgent interface{}, ipAddress interface{}) *MockAuthTokenRepository_Create_Call {
+	return &MockAuthTokenRepository_Create_Call{Call: _e.mock.On("Create", ctx, userID, familyID, tokenHash, expiresAt, userAgent, ipAddress)}
 }
 
-func (_c *MockAuthTokenRepository_Create_Call) Run(run func(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time)) *MockAuthTokenRepository_Create_Call {
+func (_c *MockAuthTokenRepository_Create_Call) Run(run func(ctx context.Context, userID uuid.UUID, familyID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent string, ipAddress string)) *MockAuthTokenRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -94,19 +163,34 @@ func (_c *MockAuthTokenRepository_Create_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 string
+		var arg2 uuid.UUID
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(uuid.UUID)
 		}
-		var arg3 time.Time
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(time.Time)
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
+			arg5,
+			arg6,
 		)
 	})
 	return _c
@@ -117,7 +201,105 @@ func (_c *MockAuthTokenRepository_Create_Call) Return(authToken *sqlcgen.AuthTok
 	return _c
 }
 
-func (_c *MockAuthTokenRepository_Create_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.AuthToken, error)) *MockAuthTokenRepository_Create_Call {
+func (_c *MockAuthTokenRepository_Create_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, familyID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent string, ipAddress string) (*sqlcgen.AuthToken, error)) *MockAuthTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateImpersonation provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) CreateImpersonation(ctx context.Context, userID uuid.UUID, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent string, ipAddress string) (*sqlcgen.AuthToken, error) {
+	ret := _mock.Called(ctx, userID, impersonatorID, tokenHash, expiresAt, userAgent, ipAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImpersonation")
+	}
+
+	var r0 *sqlcgen.AuthToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time, string, string) (*sqlcgen.AuthToken, error)); ok {
+		return returnFunc(ctx, userID, impersonatorID, tokenHash, expiresAt, userAgent, ipAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time, string, string) *sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, userID, impersonatorID, tokenHash, expiresAt, userAgent, ipAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, impersonatorID, tokenHash, expiresAt, userAgent, ipAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthTokenRepository_CreateImpersonation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImpersonation'
+type MockAuthTokenRepository_CreateImpersonation_Call struct {
+	*mock.Call
+}
+
+// CreateImpersonation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - impersonatorID uuid.UUID
+//   - tokenHash string
+//   - expiresAt time.Time
+//   - userAgent string
+//   - ipAddress string
+func (_e *MockAuthTokenRepository_Expecter) CreateImpersonation(ctx interface{}, userID interface{}, impersonatorID interface{}, tokenHash interface{}, expiresAt interface{}, userAgent interface{}, ipAddress interface{}) *MockAuthTokenRepository_CreateImpersonation_Call {
+	return &MockAuthTokenRepository_CreateImpersonation_Call{Call: _e.mock.On("CreateImpersonation", ctx, userID, impersonatorID, tokenHash, expiresAt, userAgent, ipAddress)}
+}
+
+func (_c *MockAuthTokenRepository_CreateImpersonation_Call) Run(run func(ctx context.Context, userID uuid.UUID, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent string, ipAddress string)) *MockAuthTokenRepository_CreateImpersonation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_CreateImpersonation_Call) Return(authToken *sqlcgen.AuthToken, err error) *MockAuthTokenRepository_CreateImpersonation_Call {
+	_c.Call.Return(authToken, err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_CreateImpersonation_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent string, ipAddress string) (*sqlcgen.AuthToken, error)) *MockAuthTokenRepository_CreateImpersonation_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -182,9 +364,75 @@ func (_c *MockAuthTokenRepository_DeleteExpiredOrRevoked_Call) RunAndReturn(run
 	return _c
 }
 
+// DeleteIdle provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) DeleteIdle(ctx context.Context, idleBefore time.Time) (int64, error) {
+	ret := _mock.Called(ctx, idleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdle")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, idleBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, idleBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, idleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthTokenRepository_DeleteIdle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdle'
+type MockAuthTokenRepository_DeleteIdle_Call struct {
+	*mock.Call
+}
+
+// DeleteIdle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idleBefore time.Time
+func (_e *MockAuthTokenRepository_Expecter) DeleteIdle(ctx interface{}, idleBefore interface{}) *MockAuthTokenRepository_DeleteIdle_Call {
+	return &MockAuthTokenRepository_DeleteIdle_Call{Call: _e.mock.On("DeleteIdle", ctx, idleBefore)}
+}
+
+func (_c *MockAuthTokenRepository_DeleteIdle_Call) Run(run func(ctx context.Context, idleBefore time.Time)) *MockAuthTokenRepository_DeleteIdle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_DeleteIdle_Call) Return(n int64, err error) *MockAuthTokenRepository_DeleteIdle_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_DeleteIdle_Call) RunAndReturn(run func(ctx context.Context, idleBefore time.Time) (int64, error)) *MockAuthTokenRepository_DeleteIdle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByHash provides a mock function for the type MockAuthTokenRepository
-func (_mock *MockAuthTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, error) {
-	ret := _mock.Called(ctx, tokenHash)
+func (_mock *MockAuthTokenRepository) GetByHash(ctx context.Context, tokenHashes []string) (*sqlcgen.AuthToken, error) {
+	ret := _mock.Called(ctx, tokenHashes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByHash")
@@ -192,18 +440,18 @@ func (_mock *MockAuthTokenRepository) GetByHash(ctx context.Context, tokenHash s
 
 	var r0 *sqlcgen.AuthToken
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*sqlcgen.AuthToken, error)); ok {
-		return returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (*sqlcgen.AuthToken, error)); ok {
+		return returnFunc(ctx, tokenHashes)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *sqlcgen.AuthToken); ok {
-		r0 = returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) *sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, tokenHashes)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sqlcgen.AuthToken)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, tokenHashes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -217,20 +465,20 @@ type MockAuthTokenRepository_GetByHash_Call struct {
 
 // GetByHash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tokenHash string
-func (_e *MockAuthTokenRepository_Expecter) GetByHash(ctx interface{}, tokenHash interface{}) *MockAuthTokenRepository_GetByHash_Call {
-	return &MockAuthTokenRepository_GetByHash_Call{Call: _e.mock.On("GetByHash", ctx, tokenHash)}
+//   - tokenHashes []string
+func (_e *MockAuthTokenRepository_Expecter) GetByHash(ctx interface{}, tokenHashes interface{}) *MockAuthTokenRepository_GetByHash_Call {
+	return &MockAuthTokenRepository_GetByHash_Call{Call: _e.mock.On("GetByHash", ctx, tokenHashes)}
 }
 
-func (_c *MockAuthTokenRepository_GetByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockAuthTokenRepository_GetByHash_Call {
+func (_c *MockAuthTokenRepository_GetByHash_Call) Run(run func(ctx context.Context, tokenHashes []string)) *MockAuthTokenRepository_GetByHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -245,7 +493,155 @@ func (_c *MockAuthTokenRepository_GetByHash_Call) Return(authToken *sqlcgen.Auth
 	return _c
 }
 
-func (_c *MockAuthTokenRepository_GetByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, error)) *MockAuthTokenRepository_GetByHash_Call {
+func (_c *MockAuthTokenRepository_GetByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHashes []string) (*sqlcgen.AuthToken, error)) *MockAuthTokenRepository_GetByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveForUser provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) ListActiveForUser(ctx context.Context, userID uuid.UUID, limit int32, offset int32) ([]sqlcgen.AuthToken, error) {
+	ret := _mock.Called(ctx, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveForUser")
+	}
+
+	var r0 []sqlcgen.AuthToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) ([]sqlcgen.AuthToken, error)); ok {
+		return returnFunc(ctx, userID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) []sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthTokenRepository_ListActiveForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveForUser'
+type MockAuthTokenRepository_ListActiveForUser_Call struct {
+	*mock.Call
+}
+
+// ListActiveForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - limit int32
+//   - offset int32
+func (_e *MockAuthTokenRepository_Expecter) ListActiveForUser(ctx interface{}, userID interface{}, limit interface{}, offset interface{}) *MockAuthTokenRepository_ListActiveForUser_Call {
+	return &MockAuthTokenRepository_ListActiveForUser_Call{Call: _e.mock.On("ListActiveForUser", ctx, userID, limit, offset)}
+}
+
+func (_c *MockAuthTokenRepository_ListActiveForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, limit int32, offset int32)) *MockAuthTokenRepository_ListActiveForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		var arg3 int32
+		if args[3] != nil {
+			arg3 = args[3].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_ListActiveForUser_Call) Return(authTokens []sqlcgen.AuthToken, err error) *MockAuthTokenRepository_ListActiveForUser_Call {
+	_c.Call.Return(authTokens, err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_ListActiveForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, limit int32, offset int32) ([]sqlcgen.AuthToken, error)) *MockAuthTokenRepository_ListActiveForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForUser provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.AuthToken, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForUser")
+	}
+
+	var r0 []sqlcgen.AuthToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]sqlcgen.AuthToken, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthTokenRepository_ListForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForUser'
+type MockAuthTokenRepository_ListForUser_Call struct {
+	*mock.Call
+}
+
+// ListForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockAuthTokenRepository_Expecter) ListForUser(ctx interface{}, userID interface{}) *MockAuthTokenRepository_ListForUser_Call {
+	return &MockAuthTokenRepository_ListForUser_Call{Call: _e.mock.On("ListForUser", ctx, userID)}
+}
+
+func (_c *MockAuthTokenRepository_ListForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockAuthTokenRepository_ListForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_ListForUser_Call) Return(authTokens []sqlcgen.AuthToken, err error) *MockAuthTokenRepository_ListForUser_Call {
+	_c.Call.Return(authTokens, err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_ListForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]sqlcgen.AuthToken, error)) *MockAuthTokenRepository_ListForUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -364,17 +760,80 @@ func (_c *MockAuthTokenRepository_RevokeAllForUser_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// RevokeAllForUserExcept provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) RevokeAllForUserExcept(ctx context.Context, userID uuid.UUID, exceptID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, exceptID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllForUserExcept")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, exceptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthTokenRepository_RevokeAllForUserExcept_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAllForUserExcept'
+type MockAuthTokenRepository_RevokeAllForUserExcept_Call struct {
+	*mock.Call
+}
+
+// RevokeAllForUserExcept is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - exceptID uuid.UUID
+func (_e *MockAuthTokenRepository_Expecter) RevokeAllForUserExcept(ctx interface{}, userID interface{}, exceptID interface{}) *MockAuthTokenRepository_RevokeAllForUserExcept_Call {
+	return &MockAuthTokenRepository_RevokeAllForUserExcept_Call{Call: _e.mock.On("RevokeAllForUserExcept", ctx, userID, exceptID)}
+}
+
+func (_c *MockAuthTokenRepository_RevokeAllForUserExcept_Call) Run(run func(ctx context.Context, userID uuid.UUID, exceptID uuid.UUID)) *MockAuthTokenRepository_RevokeAllForUserExcept_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_RevokeAllForUserExcept_Call) Return(err error) *MockAuthTokenRepository_RevokeAllForUserExcept_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_RevokeAllForUserExcept_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, exceptID uuid.UUID) error) *MockAuthTokenRepository_RevokeAllForUserExcept_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RevokeByHash provides a mock function for the type MockAuthTokenRepository
-func (_mock *MockAuthTokenRepository) RevokeByHash(ctx context.Context, tokenHash string) error {
-	ret := _mock.Called(ctx, tokenHash)
+func (_mock *MockAuthTokenRepository) RevokeByHash(ctx context.Context, tokenHashes []string) error {
+	ret := _mock.Called(ctx, tokenHashes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RevokeByHash")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(ctx, tokenHashes)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -388,20 +847,20 @@ type MockAuthTokenRepository_RevokeByHash_Call struct {
 
 // RevokeByHash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tokenHash string
-func (_e *MockAuthTokenRepository_Expecter) RevokeByHash(ctx interface{}, tokenHash interface{}) *MockAuthTokenRepository_RevokeByHash_Call {
-	return &MockAuthTokenRepository_RevokeByHash_Call{Call: _e.mock.On("RevokeByHash", ctx, tokenHash)}
+//   - tokenHashes []string
+func (_e *MockAuthTokenRepository_Expecter) RevokeByHash(ctx interface{}, tokenHashes interface{}) *MockAuthTokenRepository_RevokeByHash_Call {
+	return &MockAuthTokenRepository_RevokeByHash_Call{Call: _e.mock.On("RevokeByHash", ctx, tokenHashes)}
 }
 
-func (_c *MockAuthTokenRepository_RevokeByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockAuthTokenRepository_RevokeByHash_Call {
+func (_c *MockAuthTokenRepository_RevokeByHash_Call) Run(run func(ctx context.Context, tokenHashes []string)) *MockAuthTokenRepository_RevokeByHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -416,7 +875,247 @@ func (_c *MockAuthTokenRepository_RevokeByHash_Call) Return(err error) *MockAuth
 	return _c
 }
 
-func (_c *MockAuthTokenRepository_RevokeByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) error) *MockAuthTokenRepository_RevokeByHash_Call {
+func (_c *MockAuthTokenRepository_RevokeByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHashes []string) error) *MockAuthTokenRepository_RevokeByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeFamilyAccessTokens provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) RevokeFamilyAccessTokens(ctx context.Context, familyID uuid.UUID) error {
+	ret := _mock.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeFamilyAccessTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthTokenRepository_RevokeFamilyAccessTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeFamilyAccessTokens'
+type MockAuthTokenRepository_RevokeFamilyAccessTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeFamilyAccessTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID uuid.UUID
+func (_e *MockAuthTokenRepository_Expecter) RevokeFamilyAccessTokens(ctx interface{}, familyID interface{}) *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call {
+	return &MockAuthTokenRepository_RevokeFamilyAccessTokens_Call{Call: _e.mock.On("RevokeFamilyAccessTokens", ctx, familyID)}
+}
+
+func (_c *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call) Run(run func(ctx context.Context, familyID uuid.UUID)) *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call) Return(err error) *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call) RunAndReturn(run func(ctx context.Context, familyID uuid.UUID) error) *MockAuthTokenRepository_RevokeFamilyAccessTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeForUser provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) RevokeForUser(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeForUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthTokenRepository_RevokeForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeForUser'
+type MockAuthTokenRepository_RevokeForUser_Call struct {
+	*mock.Call
+}
+
+// RevokeForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+func (_e *MockAuthTokenRepository_Expecter) RevokeForUser(ctx interface{}, id interface{}, userID interface{}) *MockAuthTokenRepository_RevokeForUser_Call {
+	return &MockAuthTokenRepository_RevokeForUser_Call{Call: _e.mock.On("RevokeForUser", ctx, id, userID)}
+}
+
+func (_c *MockAuthTokenRepository_RevokeForUser_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *MockAuthTokenRepository_RevokeForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_RevokeForUser_Call) Return(err error) *MockAuthTokenRepository_RevokeForUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_RevokeForUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error) *MockAuthTokenRepository_RevokeForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthTokenRepository_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockAuthTokenRepository_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAuthTokenRepository_Expecter) Touch(ctx interface{}, id interface{}) *MockAuthTokenRepository_Touch_Call {
+	return &MockAuthTokenRepository_Touch_Call{Call: _e.mock.On("Touch", ctx, id)}
+}
+
+func (_c *MockAuthTokenRepository_Touch_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthTokenRepository_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_Touch_Call) Return(err error) *MockAuthTokenRepository_Touch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_Touch_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockAuthTokenRepository_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateHash provides a mock function for the type MockAuthTokenRepository
+func (_mock *MockAuthTokenRepository) UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	ret := _mock.Called(ctx, id, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateHash")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, id, tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthTokenRepository_UpdateHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateHash'
+type MockAuthTokenRepository_UpdateHash_Call struct {
+	*mock.Call
+}
+
+// UpdateHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - tokenHash string
+func (_e *MockAuthTokenRepository_Expecter) UpdateHash(ctx interface{}, id interface{}, tokenHash interface{}) *MockAuthTokenRepository_UpdateHash_Call {
+	return &MockAuthTokenRepository_UpdateHash_Call{Call: _e.mock.On("UpdateHash", ctx, id, tokenHash)}
+}
+
+func (_c *MockAuthTokenRepository_UpdateHash_Call) Run(run func(ctx context.Context, id uuid.UUID, tokenHash string)) *MockAuthTokenRepository_UpdateHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_UpdateHash_Call) Return(err error) *MockAuthTokenRepository_UpdateHash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthTokenRepository_UpdateHash_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, tokenHash string) error) *MockAuthTokenRepository_UpdateHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
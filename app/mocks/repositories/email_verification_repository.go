@@ -183,8 +183,8 @@ func (_c *MockEmailVerificationRepository_DeleteExpiredOrUsed_Call) RunAndReturn
 }
 
 // GetByTokenHash provides a mock function for the type MockEmailVerificationRepository
-func (_mock *MockEmailVerificationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*sqlcgen.EmailVerification, error) {
-	ret := _mock.Called(ctx, tokenHash)
+func (_mock *MockEmailVerificationRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.EmailVerification, error) {
+	ret := _mock.Called(ctx, tokenHashes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByTokenHash")
@@ -192,18 +192,18 @@ func (_mock *MockEmailVerificationRepository) GetByTokenHash(ctx context.Context
 
 	var r0 *sqlcgen.EmailVerification
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*sqlcgen.EmailVerification, error)); ok {
-		return returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (*sqlcgen.EmailVerification, error)); ok {
+		return returnFunc(ctx, tokenHashes)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *sqlcgen.EmailVerification); ok {
-		r0 = returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) *sqlcgen.EmailVerification); ok {
+		r0 = returnFunc(ctx, tokenHashes)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sqlcgen.EmailVerification)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, tokenHash)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, tokenHashes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -217,20 +217,20 @@ type MockEmailVerificationRepository_GetByTokenHash_Call struct {
 
 // GetByTokenHash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tokenHash string
-func (_e *MockEmailVerificationRepository_Expecter) GetByTokenHash(ctx interface{}, tokenHash interface{}) *MockEmailVerificationRepository_GetByTokenHash_Call {
-	return &MockEmailVerificationRepository_GetByTokenHash_Call{Call: _e.mock.On("GetByTokenHash", ctx, tokenHash)}
+//   - tokenHashes []string
+func (_e *MockEmailVerificationRepository_Expecter) GetByTokenHash(ctx interface{}, tokenHashes interface{}) *MockEmailVerificationRepository_GetByTokenHash_Call {
+	return &MockEmailVerificationRepository_GetByTokenHash_Call{Call: _e.mock.On("GetByTokenHash", ctx, tokenHashes)}
 }
 
-func (_c *MockEmailVerificationRepository_GetByTokenHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockEmailVerificationRepository_GetByTokenHash_Call {
+func (_c *MockEmailVerificationRepository_GetByTokenHash_Call) Run(run func(ctx context.Context, tokenHashes []string)) *MockEmailVerificationRepository_GetByTokenHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -245,7 +245,7 @@ func (_c *MockEmailVerificationRepository_GetByTokenHash_Call) Return(emailVerif
 	return _c
 }
 
-func (_c *MockEmailVerificationRepository_GetByTokenHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*sqlcgen.EmailVerification, error)) *MockEmailVerificationRepository_GetByTokenHash_Call {
+func (_c *MockEmailVerificationRepository_GetByTokenHash_Call) RunAndReturn(run func(ctx context.Context, tokenHashes []string) (*sqlcgen.EmailVerification, error)) *MockEmailVerificationRepository_GetByTokenHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
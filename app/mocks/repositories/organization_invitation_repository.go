@@ -0,0 +1,630 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOrganizationInvitationRepository creates a new instance of MockOrganizationInvitationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrganizationInvitationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrganizationInvitationRepository {
+	mock := &MockOrganizationInvitationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOrganizationInvitationRepository is an autogenerated mock type for the OrganizationInvitationRepository type
+type MockOrganizationInvitationRepository struct {
+	mock.Mock
+}
+
+type MockOrganizationInvitationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrganizationInvitationRepository) EXPECT() *MockOrganizationInvitationRepository_Expecter {
+	return &MockOrganizationInvitationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) Create(ctx context.Context, organizationID uuid.UUID, email string, role string, tokenHash string, invitedBy uuid.UUID, expiresAt time.Time) (*sqlcgen.OrganizationInvitation, error) {
+	ret := _mock.Called(ctx, organizationID, email, role, tokenHash, invitedBy, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *sqlcgen.OrganizationInvitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, string, uuid.UUID, time.Time) (*sqlcgen.OrganizationInvitation, error)); ok {
+		return returnFunc(ctx, organizationID, email, role, tokenHash, invitedBy, expiresAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, string, uuid.UUID, time.Time) *sqlcgen.OrganizationInvitation); ok {
+		r0 = returnFunc(ctx, organizationID, email, role, tokenHash, invitedBy, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.OrganizationInvitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, string, uuid.UUID, time.Time) error); ok {
+		r1 = returnFunc(ctx, organizationID, email, role, tokenHash, invitedBy, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationInvitationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockOrganizationInvitationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - email string
+//   - role string
+//   - tokenHash string
+//   - invitedBy uuid.UUID
+//   - expiresAt time.Time
+func (_e *MockOrganizationInvitationRepository_Expecter) Create(ctx interface{}, organizationID interface{}, email interface{}, role interface{}, tokenHash interface{}, invitedBy interface{}, expiresAt interface{}) *MockOrganizationInvitationRepository_Create_Call {
+	return &MockOrganizationInvitationRepository_Create_Call{Call: _e.mock.On("Create", ctx, organizationID, email, role, tokenHash, invitedBy, expiresAt)}
+}
+
+func (_c *MockOrganizationInvitationRepository_Create_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, email string, role string, tokenHash string, invitedBy uuid.UUID, expiresAt time.Time)) *MockOrganizationInvitationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 uuid.UUID
+		if args[5] != nil {
+			arg5 = args[5].(uuid.UUID)
+		}
+		var arg6 time.Time
+		if args[6] != nil {
+			arg6 = args[6].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_Create_Call) Return(organizationInvitation *sqlcgen.OrganizationInvitation, err error) *MockOrganizationInvitationRepository_Create_Call {
+	_c.Call.Return(organizationInvitation, err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_Create_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, email string, role string, tokenHash string, invitedBy uuid.UUID, expiresAt time.Time) (*sqlcgen.OrganizationInvitation, error)) *MockOrganizationInvitationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredOrUsed provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) DeleteExpiredOrUsed(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredOrUsed")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredOrUsed'
+type MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredOrUsed is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOrganizationInvitationRepository_Expecter) DeleteExpiredOrUsed(ctx interface{}) *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call {
+	return &MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call{Call: _e.mock.On("DeleteExpiredOrUsed", ctx)}
+}
+
+func (_c *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call) Run(run func(ctx context.Context)) *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call) Return(n int64, err error) *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockOrganizationInvitationRepository_DeleteExpiredOrUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePending provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) DeletePending(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, organizationID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePending")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, organizationID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationInvitationRepository_DeletePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePending'
+type MockOrganizationInvitationRepository_DeletePending_Call struct {
+	*mock.Call
+}
+
+// DeletePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockOrganizationInvitationRepository_Expecter) DeletePending(ctx interface{}, organizationID interface{}, id interface{}) *MockOrganizationInvitationRepository_DeletePending_Call {
+	return &MockOrganizationInvitationRepository_DeletePending_Call{Call: _e.mock.On("DeletePending", ctx, organizationID, id)}
+}
+
+func (_c *MockOrganizationInvitationRepository_DeletePending_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, id uuid.UUID)) *MockOrganizationInvitationRepository_DeletePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_DeletePending_Call) Return(err error) *MockOrganizationInvitationRepository_DeletePending_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_DeletePending_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, id uuid.UUID) error) *MockOrganizationInvitationRepository_DeletePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePendingForEmail provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) DeletePendingForEmail(ctx context.Context, organizationID uuid.UUID, email string) error {
+	ret := _mock.Called(ctx, organizationID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingForEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, organizationID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationInvitationRepository_DeletePendingForEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePendingForEmail'
+type MockOrganizationInvitationRepository_DeletePendingForEmail_Call struct {
+	*mock.Call
+}
+
+// DeletePendingForEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - email string
+func (_e *MockOrganizationInvitationRepository_Expecter) DeletePendingForEmail(ctx interface{}, organizationID interface{}, email interface{}) *MockOrganizationInvitationRepository_DeletePendingForEmail_Call {
+	return &MockOrganizationInvitationRepository_DeletePendingForEmail_Call{Call: _e.mock.On("DeletePendingForEmail", ctx, organizationID, email)}
+}
+
+func (_c *MockOrganizationInvitationRepository_DeletePendingForEmail_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, email string)) *MockOrganizationInvitationRepository_DeletePendingForEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_DeletePendingForEmail_Call) Return(err error) *MockOrganizationInvitationRepository_DeletePendingForEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_DeletePendingForEmail_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, email string) error) *MockOrganizationInvitationRepository_DeletePendingForEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTokenHash provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.OrganizationInvitation, error) {
+	ret := _mock.Called(ctx, tokenHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTokenHash")
+	}
+
+	var r0 *sqlcgen.OrganizationInvitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (*sqlcgen.OrganizationInvitation, error)); ok {
+		return returnFunc(ctx, tokenHashes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) *sqlcgen.OrganizationInvitation); ok {
+		r0 = returnFunc(ctx, tokenHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.OrganizationInvitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, tokenHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationInvitationRepository_GetByTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenHash'
+type MockOrganizationInvitationRepository_GetByTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetByTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHashes []string
+func (_e *MockOrganizationInvitationRepository_Expecter) GetByTokenHash(ctx interface{}, tokenHashes interface{}) *MockOrganizationInvitationRepository_GetByTokenHash_Call {
+	return &MockOrganizationInvitationRepository_GetByTokenHash_Call{Call: _e.mock.On("GetByTokenHash", ctx, tokenHashes)}
+}
+
+func (_c *MockOrganizationInvitationRepository_GetByTokenHash_Call) Run(run func(ctx context.Context, tokenHashes []string)) *MockOrganizationInvitationRepository_GetByTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_GetByTokenHash_Call) Return(organizationInvitation *sqlcgen.OrganizationInvitation, err error) *MockOrganizationInvitationRepository_GetByTokenHash_Call {
+	_c.Call.Return(organizationInvitation, err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_GetByTokenHash_Call) RunAndReturn(run func(ctx context.Context, tokenHashes []string) (*sqlcgen.OrganizationInvitation, error)) *MockOrganizationInvitationRepository_GetByTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPending provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) ListPending(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.OrganizationInvitation, error) {
+	ret := _mock.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPending")
+	}
+
+	var r0 []sqlcgen.OrganizationInvitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]sqlcgen.OrganizationInvitation, error)); ok {
+		return returnFunc(ctx, organizationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []sqlcgen.OrganizationInvitation); ok {
+		r0 = returnFunc(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.OrganizationInvitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationInvitationRepository_ListPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPending'
+type MockOrganizationInvitationRepository_ListPending_Call struct {
+	*mock.Call
+}
+
+// ListPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+func (_e *MockOrganizationInvitationRepository_Expecter) ListPending(ctx interface{}, organizationID interface{}) *MockOrganizationInvitationRepository_ListPending_Call {
+	return &MockOrganizationInvitationRepository_ListPending_Call{Call: _e.mock.On("ListPending", ctx, organizationID)}
+}
+
+func (_c *MockOrganizationInvitationRepository_ListPending_Call) Run(run func(ctx context.Context, organizationID uuid.UUID)) *MockOrganizationInvitationRepository_ListPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_ListPending_Call) Return(organizationInvitations []sqlcgen.OrganizationInvitation, err error) *MockOrganizationInvitationRepository_ListPending_Call {
+	_c.Call.Return(organizationInvitations, err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_ListPending_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.OrganizationInvitation, error)) *MockOrganizationInvitationRepository_ListPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAccepted provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) MarkAccepted(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAccepted")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationInvitationRepository_MarkAccepted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAccepted'
+type MockOrganizationInvitationRepository_MarkAccepted_Call struct {
+	*mock.Call
+}
+
+// MarkAccepted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockOrganizationInvitationRepository_Expecter) MarkAccepted(ctx interface{}, id interface{}) *MockOrganizationInvitationRepository_MarkAccepted_Call {
+	return &MockOrganizationInvitationRepository_MarkAccepted_Call{Call: _e.mock.On("MarkAccepted", ctx, id)}
+}
+
+func (_c *MockOrganizationInvitationRepository_MarkAccepted_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockOrganizationInvitationRepository_MarkAccepted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_MarkAccepted_Call) Return(err error) *MockOrganizationInvitationRepository_MarkAccepted_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_MarkAccepted_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockOrganizationInvitationRepository_MarkAccepted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDeclined provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) MarkDeclined(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDeclined")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationInvitationRepository_MarkDeclined_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDeclined'
+type MockOrganizationInvitationRepository_MarkDeclined_Call struct {
+	*mock.Call
+}
+
+// MarkDeclined is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockOrganizationInvitationRepository_Expecter) MarkDeclined(ctx interface{}, id interface{}) *MockOrganizationInvitationRepository_MarkDeclined_Call {
+	return &MockOrganizationInvitationRepository_MarkDeclined_Call{Call: _e.mock.On("MarkDeclined", ctx, id)}
+}
+
+func (_c *MockOrganizationInvitationRepository_MarkDeclined_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockOrganizationInvitationRepository_MarkDeclined_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_MarkDeclined_Call) Return(err error) *MockOrganizationInvitationRepository_MarkDeclined_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_MarkDeclined_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockOrganizationInvitationRepository_MarkDeclined_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function for the type MockOrganizationInvitationRepository
+func (_mock *MockOrganizationInvitationRepository) WithTx(tx pgx.Tx) repositories.OrganizationInvitationRepository {
+	ret := _mock.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repositories.OrganizationInvitationRepository
+	if returnFunc, ok := ret.Get(0).(func(pgx.Tx) repositories.OrganizationInvitationRepository); ok {
+		r0 = returnFunc(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.OrganizationInvitationRepository)
+		}
+	}
+	return r0
+}
+
+// MockOrganizationInvitationRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockOrganizationInvitationRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx pgx.Tx
+func (_e *MockOrganizationInvitationRepository_Expecter) WithTx(tx interface{}) *MockOrganizationInvitationRepository_WithTx_Call {
+	return &MockOrganizationInvitationRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockOrganizationInvitationRepository_WithTx_Call) Run(run func(tx pgx.Tx)) *MockOrganizationInvitationRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 pgx.Tx
+		if args[0] != nil {
+			arg0 = args[0].(pgx.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_WithTx_Call) Return(organizationInvitationRepository repositories.OrganizationInvitationRepository) *MockOrganizationInvitationRepository_WithTx_Call {
+	_c.Call.Return(organizationInvitationRepository)
+	return _c
+}
+
+func (_c *MockOrganizationInvitationRepository_WithTx_Call) RunAndReturn(run func(tx pgx.Tx) repositories.OrganizationInvitationRepository) *MockOrganizationInvitationRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
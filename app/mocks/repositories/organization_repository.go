@@ -0,0 +1,918 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOrganizationRepository creates a new instance of MockOrganizationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrganizationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrganizationRepository {
+	mock := &MockOrganizationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOrganizationRepository is an autogenerated mock type for the OrganizationRepository type
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+type MockOrganizationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrganizationRepository) EXPECT() *MockOrganizationRepository_Expecter {
+	return &MockOrganizationRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) AddMember(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, role string) (bool, error) {
+	ret := _mock.Called(ctx, organizationID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) (bool, error)); ok {
+		return returnFunc(ctx, organizationID, userID, role)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) bool); ok {
+		r0 = returnFunc(ctx, organizationID, userID, role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, organizationID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type MockOrganizationRepository_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - userID uuid.UUID
+//   - role string
+func (_e *MockOrganizationRepository_Expecter) AddMember(ctx interface{}, organizationID interface{}, userID interface{}, role interface{}) *MockOrganizationRepository_AddMember_Call {
+	return &MockOrganizationRepository_AddMember_Call{Call: _e.mock.On("AddMember", ctx, organizationID, userID, role)}
+}
+
+func (_c *MockOrganizationRepository_AddMember_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, role string)) *MockOrganizationRepository_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_AddMember_Call) Return(b bool, err error) *MockOrganizationRepository_AddMember_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_AddMember_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, role string) (bool, error)) *MockOrganizationRepository_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) Create(ctx context.Context, name string) (*sqlcgen.Organization, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *sqlcgen.Organization
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*sqlcgen.Organization, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *sqlcgen.Organization); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.Organization)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockOrganizationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockOrganizationRepository_Expecter) Create(ctx interface{}, name interface{}) *MockOrganizationRepository_Create_Call {
+	return &MockOrganizationRepository_Create_Call{Call: _e.mock.On("Create", ctx, name)}
+}
+
+func (_c *MockOrganizationRepository_Create_Call) Run(run func(ctx context.Context, name string)) *MockOrganizationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_Create_Call) Return(organization *sqlcgen.Organization, err error) *MockOrganizationRepository_Create_Call {
+	_c.Call.Return(organization, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_Create_Call) RunAndReturn(run func(ctx context.Context, name string) (*sqlcgen.Organization, error)) *MockOrganizationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockOrganizationRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockOrganizationRepository_Delete_Call {
+	return &MockOrganizationRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockOrganizationRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockOrganizationRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_Delete_Call) Return(err error) *MockOrganizationRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockOrganizationRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.Organization, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *sqlcgen.Organization
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*sqlcgen.Organization, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *sqlcgen.Organization); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.Organization)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockOrganizationRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockOrganizationRepository_GetByID_Call {
+	return &MockOrganizationRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockOrganizationRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockOrganizationRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_GetByID_Call) Return(organization *sqlcgen.Organization, err error) *MockOrganizationRepository_GetByID_Call {
+	_c.Call.Return(organization, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*sqlcgen.Organization, error)) *MockOrganizationRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMemberRole provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) GetMemberRole(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID) (string, error) {
+	ret := _mock.Called(ctx, organizationID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMemberRole")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (string, error)); ok {
+		return returnFunc(ctx, organizationID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) string); ok {
+		r0 = returnFunc(ctx, organizationID, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, organizationID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_GetMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMemberRole'
+type MockOrganizationRepository_GetMemberRole_Call struct {
+	*mock.Call
+}
+
+// GetMemberRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) GetMemberRole(ctx interface{}, organizationID interface{}, userID interface{}) *MockOrganizationRepository_GetMemberRole_Call {
+	return &MockOrganizationRepository_GetMemberRole_Call{Call: _e.mock.On("GetMemberRole", ctx, organizationID, userID)}
+}
+
+func (_c *MockOrganizationRepository_GetMemberRole_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID)) *MockOrganizationRepository_GetMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_GetMemberRole_Call) Return(s string, err error) *MockOrganizationRepository_GetMemberRole_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_GetMemberRole_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID) (string, error)) *MockOrganizationRepository_GetMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForUser provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListOrganizationsForUserRow, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForUser")
+	}
+
+	var r0 []sqlcgen.ListOrganizationsForUserRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]sqlcgen.ListOrganizationsForUserRow, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []sqlcgen.ListOrganizationsForUserRow); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.ListOrganizationsForUserRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_ListForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForUser'
+type MockOrganizationRepository_ListForUser_Call struct {
+	*mock.Call
+}
+
+// ListForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) ListForUser(ctx interface{}, userID interface{}) *MockOrganizationRepository_ListForUser_Call {
+	return &MockOrganizationRepository_ListForUser_Call{Call: _e.mock.On("ListForUser", ctx, userID)}
+}
+
+func (_c *MockOrganizationRepository_ListForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockOrganizationRepository_ListForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_ListForUser_Call) Return(listOrganizationsForUserRows []sqlcgen.ListOrganizationsForUserRow, err error) *MockOrganizationRepository_ListForUser_Call {
+	_c.Call.Return(listOrganizationsForUserRows, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_ListForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListOrganizationsForUserRow, error)) *MockOrganizationRepository_ListForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMembers provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.ListOrganizationMembersRow, error) {
+	ret := _mock.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMembers")
+	}
+
+	var r0 []sqlcgen.ListOrganizationMembersRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]sqlcgen.ListOrganizationMembersRow, error)); ok {
+		return returnFunc(ctx, organizationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []sqlcgen.ListOrganizationMembersRow); ok {
+		r0 = returnFunc(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.ListOrganizationMembersRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_ListMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMembers'
+type MockOrganizationRepository_ListMembers_Call struct {
+	*mock.Call
+}
+
+// ListMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) ListMembers(ctx interface{}, organizationID interface{}) *MockOrganizationRepository_ListMembers_Call {
+	return &MockOrganizationRepository_ListMembers_Call{Call: _e.mock.On("ListMembers", ctx, organizationID)}
+}
+
+func (_c *MockOrganizationRepository_ListMembers_Call) Run(run func(ctx context.Context, organizationID uuid.UUID)) *MockOrganizationRepository_ListMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_ListMembers_Call) Return(listOrganizationMembersRows []sqlcgen.ListOrganizationMembersRow, err error) *MockOrganizationRepository_ListMembers_Call {
+	_c.Call.Return(listOrganizationMembersRows, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_ListMembers_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.ListOrganizationMembersRow, error)) *MockOrganizationRepository_ListMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MemberEmailExists provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) MemberEmailExists(ctx context.Context, organizationID uuid.UUID, email string) (bool, error) {
+	ret := _mock.Called(ctx, organizationID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MemberEmailExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (bool, error)); ok {
+		return returnFunc(ctx, organizationID, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) bool); ok {
+		r0 = returnFunc(ctx, organizationID, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, organizationID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_MemberEmailExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MemberEmailExists'
+type MockOrganizationRepository_MemberEmailExists_Call struct {
+	*mock.Call
+}
+
+// MemberEmailExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - email string
+func (_e *MockOrganizationRepository_Expecter) MemberEmailExists(ctx interface{}, organizationID interface{}, email interface{}) *MockOrganizationRepository_MemberEmailExists_Call {
+	return &MockOrganizationRepository_MemberEmailExists_Call{Call: _e.mock.On("MemberEmailExists", ctx, organizationID, email)}
+}
+
+func (_c *MockOrganizationRepository_MemberEmailExists_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, email string)) *MockOrganizationRepository_MemberEmailExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_MemberEmailExists_Call) Return(b bool, err error) *MockOrganizationRepository_MemberEmailExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_MemberEmailExists_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, email string) (bool, error)) *MockOrganizationRepository_MemberEmailExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OwnsAny provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) OwnsAny(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OwnsAny")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_OwnsAny_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OwnsAny'
+type MockOrganizationRepository_OwnsAny_Call struct {
+	*mock.Call
+}
+
+// OwnsAny is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) OwnsAny(ctx interface{}, userID interface{}) *MockOrganizationRepository_OwnsAny_Call {
+	return &MockOrganizationRepository_OwnsAny_Call{Call: _e.mock.On("OwnsAny", ctx, userID)}
+}
+
+func (_c *MockOrganizationRepository_OwnsAny_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockOrganizationRepository_OwnsAny_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_OwnsAny_Call) Return(b bool, err error) *MockOrganizationRepository_OwnsAny_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_OwnsAny_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (bool, error)) *MockOrganizationRepository_OwnsAny_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) RemoveMember(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, organizationID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, organizationID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationRepository_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type MockOrganizationRepository_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MockOrganizationRepository_Expecter) RemoveMember(ctx interface{}, organizationID interface{}, userID interface{}) *MockOrganizationRepository_RemoveMember_Call {
+	return &MockOrganizationRepository_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, organizationID, userID)}
+}
+
+func (_c *MockOrganizationRepository_RemoveMember_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID)) *MockOrganizationRepository_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_RemoveMember_Call) Return(err error) *MockOrganizationRepository_RemoveMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_RemoveMember_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID) error) *MockOrganizationRepository_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) Rename(ctx context.Context, id uuid.UUID, name string) (*sqlcgen.Organization, error) {
+	ret := _mock.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 *sqlcgen.Organization
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*sqlcgen.Organization, error)); ok {
+		return returnFunc(ctx, id, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *sqlcgen.Organization); ok {
+		r0 = returnFunc(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.Organization)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrganizationRepository_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockOrganizationRepository_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - name string
+func (_e *MockOrganizationRepository_Expecter) Rename(ctx interface{}, id interface{}, name interface{}) *MockOrganizationRepository_Rename_Call {
+	return &MockOrganizationRepository_Rename_Call{Call: _e.mock.On("Rename", ctx, id, name)}
+}
+
+func (_c *MockOrganizationRepository_Rename_Call) Run(run func(ctx context.Context, id uuid.UUID, name string)) *MockOrganizationRepository_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_Rename_Call) Return(organization *sqlcgen.Organization, err error) *MockOrganizationRepository_Rename_Call {
+	_c.Call.Return(organization, err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_Rename_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, name string) (*sqlcgen.Organization, error)) *MockOrganizationRepository_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMemberRole provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, role string) error {
+	ret := _mock.Called(ctx, organizationID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, organizationID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrganizationRepository_UpdateMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMemberRole'
+type MockOrganizationRepository_UpdateMemberRole_Call struct {
+	*mock.Call
+}
+
+// UpdateMemberRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID uuid.UUID
+//   - userID uuid.UUID
+//   - role string
+func (_e *MockOrganizationRepository_Expecter) UpdateMemberRole(ctx interface{}, organizationID interface{}, userID interface{}, role interface{}) *MockOrganizationRepository_UpdateMemberRole_Call {
+	return &MockOrganizationRepository_UpdateMemberRole_Call{Call: _e.mock.On("UpdateMemberRole", ctx, organizationID, userID, role)}
+}
+
+func (_c *MockOrganizationRepository_UpdateMemberRole_Call) Run(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, role string)) *MockOrganizationRepository_UpdateMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_UpdateMemberRole_Call) Return(err error) *MockOrganizationRepository_UpdateMemberRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_UpdateMemberRole_Call) RunAndReturn(run func(ctx context.Context, organizationID uuid.UUID, userID uuid.UUID, role string) error) *MockOrganizationRepository_UpdateMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function for the type MockOrganizationRepository
+func (_mock *MockOrganizationRepository) WithTx(tx pgx.Tx) repositories.OrganizationRepository {
+	ret := _mock.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repositories.OrganizationRepository
+	if returnFunc, ok := ret.Get(0).(func(pgx.Tx) repositories.OrganizationRepository); ok {
+		r0 = returnFunc(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.OrganizationRepository)
+		}
+	}
+	return r0
+}
+
+// MockOrganizationRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockOrganizationRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx pgx.Tx
+func (_e *MockOrganizationRepository_Expecter) WithTx(tx interface{}) *MockOrganizationRepository_WithTx_Call {
+	return &MockOrganizationRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockOrganizationRepository_WithTx_Call) Run(run func(tx pgx.Tx)) *MockOrganizationRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 pgx.Tx
+		if args[0] != nil {
+			arg0 = args[0].(pgx.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrganizationRepository_WithTx_Call) Return(organizationRepository repositories.OrganizationRepository) *MockOrganizationRepository_WithTx_Call {
+	_c.Call.Return(organizationRepository)
+	return _c
+}
+
+func (_c *MockOrganizationRepository_WithTx_Call) RunAndReturn(run func(tx pgx.Tx) repositories.OrganizationRepository) *MockOrganizationRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -99,6 +99,140 @@ func (_c *MockUserRepository_CancelDeletion_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// ClaimDeletionReminders provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ClaimDeletionReminders(ctx context.Context, dueBefore time.Time) ([]sqlcgen.User, error) {
+	ret := _mock.Called(ctx, dueBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeletionReminders")
+	}
+
+	var r0 []sqlcgen.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]sqlcgen.User, error)); ok {
+		return returnFunc(ctx, dueBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []sqlcgen.User); ok {
+		r0 = returnFunc(ctx, dueBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, dueBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ClaimDeletionReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeletionReminders'
+type MockUserRepository_ClaimDeletionReminders_Call struct {
+	*mock.Call
+}
+
+// ClaimDeletionReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dueBefore time.Time
+func (_e *MockUserRepository_Expecter) ClaimDeletionReminders(ctx interface{}, dueBefore interface{}) *MockUserRepository_ClaimDeletionReminders_Call {
+	return &MockUserRepository_ClaimDeletionReminders_Call{Call: _e.mock.On("ClaimDeletionReminders", ctx, dueBefore)}
+}
+
+func (_c *MockUserRepository_ClaimDeletionReminders_Call) Run(run func(ctx context.Context, dueBefore time.Time)) *MockUserRepository_ClaimDeletionReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ClaimDeletionReminders_Call) Return(users []sqlcgen.User, err error) *MockUserRepository_ClaimDeletionReminders_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ClaimDeletionReminders_Call) RunAndReturn(run func(ctx context.Context, dueBefore time.Time) ([]sqlcgen.User, error)) *MockUserRepository_ClaimDeletionReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Count(ctx context.Context, search repositories.UserSearch) (int64, error) {
+	ret := _mock.Called(ctx, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repositories.UserSearch) (int64, error)); ok {
+		return returnFunc(ctx, search)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repositories.UserSearch) int64); ok {
+		r0 = returnFunc(ctx, search)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repositories.UserSearch) error); ok {
+		r1 = returnFunc(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockUserRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - search repositories.UserSearch
+func (_e *MockUserRepository_Expecter) Count(ctx interface{}, search interface{}) *MockUserRepository_Count_Call {
+	return &MockUserRepository_Count_Call{Call: _e.mock.On("Count", ctx, search)}
+}
+
+func (_c *MockUserRepository_Count_Call) Run(run func(ctx context.Context, search repositories.UserSearch)) *MockUserRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repositories.UserSearch
+		if args[1] != nil {
+			arg1 = args[1].(repositories.UserSearch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Count_Call) Return(n int64, err error) *MockUserRepository_Count_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepository_Count_Call) RunAndReturn(run func(ctx context.Context, search repositories.UserSearch) (int64, error)) *MockUserRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) Create(ctx context.Context, name string, email string, passwordHash string) (*sqlcgen.User, error) {
 	ret := _mock.Called(ctx, name, email, passwordHash)
@@ -180,22 +314,24 @@ func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(ctx context.Cont
 }
 
 // DeleteScheduledUsers provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) DeleteScheduledUsers(ctx context.Context) (int64, error) {
+func (_mock *MockUserRepository) DeleteScheduledUsers(ctx context.Context) ([]sqlcgen.User, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteScheduledUsers")
 	}
 
-	var r0 int64
+	var r0 []sqlcgen.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]sqlcgen.User, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []sqlcgen.User); ok {
 		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.User)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
 		r1 = returnFunc(ctx)
@@ -229,12 +365,12 @@ func (_c *MockUserRepository_DeleteScheduledUsers_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *MockUserRepository_DeleteScheduledUsers_Call) Return(n int64, err error) *MockUserRepository_DeleteScheduledUsers_Call {
-	_c.Call.Return(n, err)
+func (_c *MockUserRepository_DeleteScheduledUsers_Call) Return(users []sqlcgen.User, err error) *MockUserRepository_DeleteScheduledUsers_Call {
+	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *MockUserRepository_DeleteScheduledUsers_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockUserRepository_DeleteScheduledUsers_Call {
+func (_c *MockUserRepository_DeleteScheduledUsers_Call) RunAndReturn(run func(ctx context.Context) ([]sqlcgen.User, error)) *MockUserRepository_DeleteScheduledUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -561,6 +697,149 @@ func (_c *MockUserRepository_ScheduleDeletion_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// Search provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Search(ctx context.Context, search repositories.UserSearch, limit int32, offset int32) ([]sqlcgen.User, error) {
+	ret := _mock.Called(ctx, search, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []sqlcgen.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repositories.UserSearch, int32, int32) ([]sqlcgen.User, error)); ok {
+		return returnFunc(ctx, search, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repositories.UserSearch, int32, int32) []sqlcgen.User); ok {
+		r0 = returnFunc(ctx, search, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repositories.UserSearch, int32, int32) error); ok {
+		r1 = returnFunc(ctx, search, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockUserRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - search repositories.UserSearch
+//   - limit int32
+//   - offset int32
+func (_e *MockUserRepository_Expecter) Search(ctx interface{}, search interface{}, limit interface{}, offset interface{}) *MockUserRepository_Search_Call {
+	return &MockUserRepository_Search_Call{Call: _e.mock.On("Search", ctx, search, limit, offset)}
+}
+
+func (_c *MockUserRepository_Search_Call) Run(run func(ctx context.Context, search repositories.UserSearch, limit int32, offset int32)) *MockUserRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repositories.UserSearch
+		if args[1] != nil {
+			arg1 = args[1].(repositories.UserSearch)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		var arg3 int32
+		if args[3] != nil {
+			arg3 = args[3].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Search_Call) Return(users []sqlcgen.User, err error) *MockUserRepository_Search_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepository_Search_Call) RunAndReturn(run func(ctx context.Context, search repositories.UserSearch, limit int32, offset int32) ([]sqlcgen.User, error)) *MockUserRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmail provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	ret := _mock.Called(ctx, userID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UpdateEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmail'
+type MockUserRepository_UpdateEmail_Call struct {
+	*mock.Call
+}
+
+// UpdateEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - email string
+func (_e *MockUserRepository_Expecter) UpdateEmail(ctx interface{}, userID interface{}, email interface{}) *MockUserRepository_UpdateEmail_Call {
+	return &MockUserRepository_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, userID, email)}
+}
+
+func (_c *MockUserRepository_UpdateEmail_Call) Run(run func(ctx context.Context, userID uuid.UUID, email string)) *MockUserRepository_UpdateEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateEmail_Call) Return(err error) *MockUserRepository_UpdateEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateEmail_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, email string) error) *MockUserRepository_UpdateEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePassword provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
 	ret := _mock.Called(ctx, userID, passwordHash)
@@ -624,6 +903,80 @@ func (_c *MockUserRepository_UpdatePassword_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// UpdateProfile provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, update repositories.UserProfileUpdate) (*sqlcgen.User, error) {
+	ret := _mock.Called(ctx, userID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *sqlcgen.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repositories.UserProfileUpdate) (*sqlcgen.User, error)); ok {
+		return returnFunc(ctx, userID, update)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repositories.UserProfileUpdate) *sqlcgen.User); ok {
+		r0 = returnFunc(ctx, userID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, repositories.UserProfileUpdate) error); ok {
+		r1 = returnFunc(ctx, userID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockUserRepository_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - update repositories.UserProfileUpdate
+func (_e *MockUserRepository_Expecter) UpdateProfile(ctx interface{}, userID interface{}, update interface{}) *MockUserRepository_UpdateProfile_Call {
+	return &MockUserRepository_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, userID, update)}
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) Run(run func(ctx context.Context, userID uuid.UUID, update repositories.UserProfileUpdate)) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 repositories.UserProfileUpdate
+		if args[2] != nil {
+			arg2 = args[2].(repositories.UserProfileUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) Return(user *sqlcgen.User, err error) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, update repositories.UserProfileUpdate) (*sqlcgen.User, error)) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithTx provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) WithTx(tx pgx.Tx) repositories.UserRepository {
 	ret := _mock.Called(tx)
@@ -0,0 +1,316 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebAuthnChallengeRepository creates a new instance of MockWebAuthnChallengeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebAuthnChallengeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebAuthnChallengeRepository {
+	mock := &MockWebAuthnChallengeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebAuthnChallengeRepository is an autogenerated mock type for the WebAuthnChallengeRepository type
+type MockWebAuthnChallengeRepository struct {
+	mock.Mock
+}
+
+type MockWebAuthnChallengeRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebAuthnChallengeRepository) EXPECT() *MockWebAuthnChallengeRepository_Expecter {
+	return &MockWebAuthnChallengeRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function for the type MockWebAuthnChallengeRepository
+func (_mock *MockWebAuthnChallengeRepository) Consume(ctx context.Context, challengeHash string, ceremony string) (*sqlcgen.WebauthnChallenge, error) {
+	ret := _mock.Called(ctx, challengeHash, ceremony)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 *sqlcgen.WebauthnChallenge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*sqlcgen.WebauthnChallenge, error)); ok {
+		return returnFunc(ctx, challengeHash, ceremony)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *sqlcgen.WebauthnChallenge); ok {
+		r0 = returnFunc(ctx, challengeHash, ceremony)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.WebauthnChallenge)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, challengeHash, ceremony)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebAuthnChallengeRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type MockWebAuthnChallengeRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeHash string
+//   - ceremony string
+func (_e *MockWebAuthnChallengeRepository_Expecter) Consume(ctx interface{}, challengeHash interface{}, ceremony interface{}) *MockWebAuthnChallengeRepository_Consume_Call {
+	return &MockWebAuthnChallengeRepository_Consume_Call{Call: _e.mock.On("Consume", ctx, challengeHash, ceremony)}
+}
+
+func (_c *MockWebAuthnChallengeRepository_Consume_Call) Run(run func(ctx context.Context, challengeHash string, ceremony string)) *MockWebAuthnChallengeRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_Consume_Call) Return(webauthnChallenge *sqlcgen.WebauthnChallenge, err error) *MockWebAuthnChallengeRepository_Consume_Call {
+	_c.Call.Return(webauthnChallenge, err)
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_Consume_Call) RunAndReturn(run func(ctx context.Context, challengeHash string, ceremony string) (*sqlcgen.WebauthnChallenge, error)) *MockWebAuthnChallengeRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockWebAuthnChallengeRepository
+func (_mock *MockWebAuthnChallengeRepository) Create(ctx context.Context, userID *uuid.UUID, ceremony string, challengeHash string, expiresAt time.Time) (*sqlcgen.WebauthnChallenge, error) {
+	ret := _mock.Called(ctx, userID, ceremony, challengeHash, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *sqlcgen.WebauthnChallenge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uuid.UUID, string, string, time.Time) (*sqlcgen.WebauthnChallenge, error)); ok {
+		return returnFunc(ctx, userID, ceremony, challengeHash, expiresAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uuid.UUID, string, string, time.Time) *sqlcgen.WebauthnChallenge); ok {
+		r0 = returnFunc(ctx, userID, ceremony, challengeHash, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.WebauthnChallenge)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *uuid.UUID, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, ceremony, challengeHash, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebAuthnChallengeRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebAuthnChallengeRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID *uuid.UUID
+//   - ceremony string
+//   - challengeHash string
+//   - expiresAt time.Time
+func (_e *MockWebAuthnChallengeRepository_Expecter) Create(ctx interface{}, userID interface{}, ceremony interface{}, challengeHash interface{}, expiresAt interface{}) *MockWebAuthnChallengeRepository_Create_Call {
+	return &MockWebAuthnChallengeRepository_Create_Call{Call: _e.mock.On("Create", ctx, userID, ceremony, challengeHash, expiresAt)}
+}
+
+func (_c *MockWebAuthnChallengeRepository_Create_Call) Run(run func(ctx context.Context, userID *uuid.UUID, ceremony string, challengeHash string, expiresAt time.Time)) *MockWebAuthnChallengeRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(*uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_Create_Call) Return(webauthnChallenge *sqlcgen.WebauthnChallenge, err error) *MockWebAuthnChallengeRepository_Create_Call {
+	_c.Call.Return(webauthnChallenge, err)
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_Create_Call) RunAndReturn(run func(ctx context.Context, userID *uuid.UUID, ceremony string, challengeHash string, expiresAt time.Time) (*sqlcgen.WebauthnChallenge, error)) *MockWebAuthnChallengeRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function for the type MockWebAuthnChallengeRepository
+func (_mock *MockWebAuthnChallengeRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebAuthnChallengeRepository_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type MockWebAuthnChallengeRepository_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebAuthnChallengeRepository_Expecter) DeleteExpired(ctx interface{}) *MockWebAuthnChallengeRepository_DeleteExpired_Call {
+	return &MockWebAuthnChallengeRepository_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx)}
+}
+
+func (_c *MockWebAuthnChallengeRepository_DeleteExpired_Call) Run(run func(ctx context.Context)) *MockWebAuthnChallengeRepository_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_DeleteExpired_Call) Return(n int64, err error) *MockWebAuthnChallengeRepository_DeleteExpired_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_DeleteExpired_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockWebAuthnChallengeRepository_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function for the type MockWebAuthnChallengeRepository
+func (_mock *MockWebAuthnChallengeRepository) WithTx(tx pgx.Tx) repositories.WebAuthnChallengeRepository {
+	ret := _mock.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repositories.WebAuthnChallengeRepository
+	if returnFunc, ok := ret.Get(0).(func(pgx.Tx) repositories.WebAuthnChallengeRepository); ok {
+		r0 = returnFunc(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.WebAuthnChallengeRepository)
+		}
+	}
+	return r0
+}
+
+// MockWebAuthnChallengeRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockWebAuthnChallengeRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx pgx.Tx
+func (_e *MockWebAuthnChallengeRepository_Expecter) WithTx(tx interface{}) *MockWebAuthnChallengeRepository_WithTx_Call {
+	return &MockWebAuthnChallengeRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockWebAuthnChallengeRepository_WithTx_Call) Run(run func(tx pgx.Tx)) *MockWebAuthnChallengeRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 pgx.Tx
+		if args[0] != nil {
+			arg0 = args[0].(pgx.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_WithTx_Call) Return(webAuthnChallengeRepository repositories.WebAuthnChallengeRepository) *MockWebAuthnChallengeRepository_WithTx_Call {
+	_c.Call.Return(webAuthnChallengeRepository)
+	return _c
+}
+
+func (_c *MockWebAuthnChallengeRepository_WithTx_Call) RunAndReturn(run func(tx pgx.Tx) repositories.WebAuthnChallengeRepository) *MockWebAuthnChallengeRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
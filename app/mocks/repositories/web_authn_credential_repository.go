@@ -0,0 +1,467 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebAuthnCredentialRepository creates a new instance of MockWebAuthnCredentialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebAuthnCredentialRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebAuthnCredentialRepository {
+	mock := &MockWebAuthnCredentialRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebAuthnCredentialRepository is an autogenerated mock type for the WebAuthnCredentialRepository type
+type MockWebAuthnCredentialRepository struct {
+	mock.Mock
+}
+
+type MockWebAuthnCredentialRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebAuthnCredentialRepository) EXPECT() *MockWebAuthnCredentialRepository_Expecter {
+	return &MockWebAuthnCredentialRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockWebAuthnCredentialRepository
+func (_mock *MockWebAuthnCredentialRepository) Create(ctx context.Context, userID uuid.UUID, credentialID []byte, publicKey []byte, signCount int64, aaguid []byte, backupEligible bool, transports []string, name string) (*sqlcgen.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, userID, credentialID, publicKey, signCount, aaguid, backupEligible, transports, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *sqlcgen.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte, []byte, int64, []byte, bool, []string, string) (*sqlcgen.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, userID, credentialID, publicKey, signCount, aaguid, backupEligible, transports, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte, []byte, int64, []byte, bool, []string, string) *sqlcgen.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, userID, credentialID, publicKey, signCount, aaguid, backupEligible, transports, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.WebauthnCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, []byte, []byte, int64, []byte, bool, []string, string) error); ok {
+		r1 = returnFunc(ctx, userID, credentialID, publicKey, signCount, aaguid, backupEligible, transports, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebAuthnCredentialRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebAuthnCredentialRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - credentialID []byte
+//   - publicKey []byte
+//   - signCount int64
+//   - aaguid []byte
+//   - backupEligible bool
+//   - transports []string
+//   - name string
+func (_e *MockWebAuthnCredentialRepository_Expecter) Create(ctx interface{}, userID interface{}, credentialID interface{}, publicKey interface{}, signCount interface{}, aaguid interface{}, backupEligible interface{}, transports interface{}, name interface{}) *MockWebAuthnCredentialRepository_Create_Call {
+	return &MockWebAuthnCredentialRepository_Create_Call{Call: _e.mock.On("Create", ctx, userID, credentialID, publicKey, signCount, aaguid, backupEligible, transports, name)}
+}
+
+func (_c *MockWebAuthnCredentialRepository_Create_Call) Run(run func(ctx context.Context, userID uuid.UUID, credentialID []byte, publicKey []byte, signCount int64, aaguid []byte, backupEligible bool, transports []string, name string)) *MockWebAuthnCredentialRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		var arg4 int64
+		if args[4] != nil {
+			arg4 = args[4].(int64)
+		}
+		var arg5 []byte
+		if args[5] != nil {
+			arg5 = args[5].([]byte)
+		}
+		var arg6 bool
+		if args[6] != nil {
+			arg6 = args[6].(bool)
+		}
+		var arg7 []string
+		if args[7] != nil {
+			arg7 = args[7].([]string)
+		}
+		var arg8 string
+		if args[8] != nil {
+			arg8 = args[8].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+			arg7,
+			arg8,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_Create_Call) Return(webauthnCredential *sqlcgen.WebauthnCredential, err error) *MockWebAuthnCredentialRepository_Create_Call {
+	_c.Call.Return(webauthnCredential, err)
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_Create_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, credentialID []byte, publicKey []byte, signCount int64, aaguid []byte, backupEligible bool, transports []string, name string) (*sqlcgen.WebauthnCredential, error)) *MockWebAuthnCredentialRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteForUser provides a mock function for the type MockWebAuthnCredentialRepository
+func (_mock *MockWebAuthnCredentialRepository) DeleteForUser(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteForUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebAuthnCredentialRepository_DeleteForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteForUser'
+type MockWebAuthnCredentialRepository_DeleteForUser_Call struct {
+	*mock.Call
+}
+
+// DeleteForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+func (_e *MockWebAuthnCredentialRepository_Expecter) DeleteForUser(ctx interface{}, id interface{}, userID interface{}) *MockWebAuthnCredentialRepository_DeleteForUser_Call {
+	return &MockWebAuthnCredentialRepository_DeleteForUser_Call{Call: _e.mock.On("DeleteForUser", ctx, id, userID)}
+}
+
+func (_c *MockWebAuthnCredentialRepository_DeleteForUser_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *MockWebAuthnCredentialRepository_DeleteForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_DeleteForUser_Call) Return(err error) *MockWebAuthnCredentialRepository_DeleteForUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_DeleteForUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error) *MockWebAuthnCredentialRepository_DeleteForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByCredentialID provides a mock function for the type MockWebAuthnCredentialRepository
+func (_mock *MockWebAuthnCredentialRepository) GetByCredentialID(ctx context.Context, credentialID []byte) (*sqlcgen.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, credentialID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByCredentialID")
+	}
+
+	var r0 *sqlcgen.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) (*sqlcgen.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, credentialID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) *sqlcgen.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, credentialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.WebauthnCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = returnFunc(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebAuthnCredentialRepository_GetByCredentialID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByCredentialID'
+type MockWebAuthnCredentialRepository_GetByCredentialID_Call struct {
+	*mock.Call
+}
+
+// GetByCredentialID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credentialID []byte
+func (_e *MockWebAuthnCredentialRepository_Expecter) GetByCredentialID(ctx interface{}, credentialID interface{}) *MockWebAuthnCredentialRepository_GetByCredentialID_Call {
+	return &MockWebAuthnCredentialRepository_GetByCredentialID_Call{Call: _e.mock.On("GetByCredentialID", ctx, credentialID)}
+}
+
+func (_c *MockWebAuthnCredentialRepository_GetByCredentialID_Call) Run(run func(ctx context.Context, credentialID []byte)) *MockWebAuthnCredentialRepository_GetByCredentialID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_GetByCredentialID_Call) Return(webauthnCredential *sqlcgen.WebauthnCredential, err error) *MockWebAuthnCredentialRepository_GetByCredentialID_Call {
+	_c.Call.Return(webauthnCredential, err)
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_GetByCredentialID_Call) RunAndReturn(run func(ctx context.Context, credentialID []byte) (*sqlcgen.WebauthnCredential, error)) *MockWebAuthnCredentialRepository_GetByCredentialID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForUser provides a mock function for the type MockWebAuthnCredentialRepository
+func (_mock *MockWebAuthnCredentialRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForUser")
+	}
+
+	var r0 []sqlcgen.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]sqlcgen.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []sqlcgen.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.WebauthnCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebAuthnCredentialRepository_ListForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForUser'
+type MockWebAuthnCredentialRepository_ListForUser_Call struct {
+	*mock.Call
+}
+
+// ListForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockWebAuthnCredentialRepository_Expecter) ListForUser(ctx interface{}, userID interface{}) *MockWebAuthnCredentialRepository_ListForUser_Call {
+	return &MockWebAuthnCredentialRepository_ListForUser_Call{Call: _e.mock.On("ListForUser", ctx, userID)}
+}
+
+func (_c *MockWebAuthnCredentialRepository_ListForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockWebAuthnCredentialRepository_ListForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_ListForUser_Call) Return(webauthnCredentials []sqlcgen.WebauthnCredential, err error) *MockWebAuthnCredentialRepository_ListForUser_Call {
+	_c.Call.Return(webauthnCredentials, err)
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_ListForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error)) *MockWebAuthnCredentialRepository_ListForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSignCount provides a mock function for the type MockWebAuthnCredentialRepository
+func (_mock *MockWebAuthnCredentialRepository) UpdateSignCount(ctx context.Context, id uuid.UUID, signCount int64) error {
+	ret := _mock.Called(ctx, id, signCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSignCount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = returnFunc(ctx, id, signCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebAuthnCredentialRepository_UpdateSignCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSignCount'
+type MockWebAuthnCredentialRepository_UpdateSignCount_Call struct {
+	*mock.Call
+}
+
+// UpdateSignCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - signCount int64
+func (_e *MockWebAuthnCredentialRepository_Expecter) UpdateSignCount(ctx interface{}, id interface{}, signCount interface{}) *MockWebAuthnCredentialRepository_UpdateSignCount_Call {
+	return &MockWebAuthnCredentialRepository_UpdateSignCount_Call{Call: _e.mock.On("UpdateSignCount", ctx, id, signCount)}
+}
+
+func (_c *MockWebAuthnCredentialRepository_UpdateSignCount_Call) Run(run func(ctx context.Context, id uuid.UUID, signCount int64)) *MockWebAuthnCredentialRepository_UpdateSignCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_UpdateSignCount_Call) Return(err error) *MockWebAuthnCredentialRepository_UpdateSignCount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_UpdateSignCount_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, signCount int64) error) *MockWebAuthnCredentialRepository_UpdateSignCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTx provides a mock function for the type MockWebAuthnCredentialRepository
+func (_mock *MockWebAuthnCredentialRepository) WithTx(tx pgx.Tx) repositories.WebAuthnCredentialRepository {
+	ret := _mock.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 repositories.WebAuthnCredentialRepository
+	if returnFunc, ok := ret.Get(0).(func(pgx.Tx) repositories.WebAuthnCredentialRepository); ok {
+		r0 = returnFunc(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.WebAuthnCredentialRepository)
+		}
+	}
+	return r0
+}
+
+// MockWebAuthnCredentialRepository_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type MockWebAuthnCredentialRepository_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//   - tx pgx.Tx
+func (_e *MockWebAuthnCredentialRepository_Expecter) WithTx(tx interface{}) *MockWebAuthnCredentialRepository_WithTx_Call {
+	return &MockWebAuthnCredentialRepository_WithTx_Call{Call: _e.mock.On("WithTx", tx)}
+}
+
+func (_c *MockWebAuthnCredentialRepository_WithTx_Call) Run(run func(tx pgx.Tx)) *MockWebAuthnCredentialRepository_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 pgx.Tx
+		if args[0] != nil {
+			arg0 = args[0].(pgx.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_WithTx_Call) Return(webAuthnCredentialRepository repositories.WebAuthnCredentialRepository) *MockWebAuthnCredentialRepository_WithTx_Call {
+	_c.Call.Return(webAuthnCredentialRepository)
+	return _c
+}
+
+func (_c *MockWebAuthnCredentialRepository_WithTx_Call) RunAndReturn(run func(tx pgx.Tx) repositories.WebAuthnCredentialRepository) *MockWebAuthnCredentialRepository_WithTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
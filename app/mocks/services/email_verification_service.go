@@ -38,6 +38,63 @@ func (_m *MockEmailVerificationService) EXPECT() *MockEmailVerificationService_E
 	return &MockEmailVerificationService_Expecter{mock: &_m.Mock}
 }
 
+// MarkVerified provides a mock function for the type MockEmailVerificationService
+func (_mock *MockEmailVerificationService) MarkVerified(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkVerified")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmailVerificationService_MarkVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkVerified'
+type MockEmailVerificationService_MarkVerified_Call struct {
+	*mock.Call
+}
+
+// MarkVerified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockEmailVerificationService_Expecter) MarkVerified(ctx interface{}, userID interface{}) *MockEmailVerificationService_MarkVerified_Call {
+	return &MockEmailVerificationService_MarkVerified_Call{Call: _e.mock.On("MarkVerified", ctx, userID)}
+}
+
+func (_c *MockEmailVerificationService_MarkVerified_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockEmailVerificationService_MarkVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmailVerificationService_MarkVerified_Call) Return(err error) *MockEmailVerificationService_MarkVerified_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmailVerificationService_MarkVerified_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockEmailVerificationService_MarkVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Resend provides a mock function for the type MockEmailVerificationService
 func (_mock *MockEmailVerificationService) Resend(ctx context.Context, email string) error {
 	ret := _mock.Called(ctx, email)
@@ -0,0 +1,458 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockPasskeyService creates a new instance of MockPasskeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasskeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasskeyService {
+	mock := &MockPasskeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPasskeyService is an autogenerated mock type for the PasskeyService type
+type MockPasskeyService struct {
+	mock.Mock
+}
+
+type MockPasskeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPasskeyService) EXPECT() *MockPasskeyService_Expecter {
+	return &MockPasskeyService_Expecter{mock: &_m.Mock}
+}
+
+// BeginLogin provides a mock function for the type MockPasskeyService
+func (_mock *MockPasskeyService) BeginLogin(ctx context.Context) (*services.PasskeyLoginOptions, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginLogin")
+	}
+
+	var r0 *services.PasskeyLoginOptions
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*services.PasskeyLoginOptions, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *services.PasskeyLoginOptions); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.PasskeyLoginOptions)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasskeyService_BeginLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginLogin'
+type MockPasskeyService_BeginLogin_Call struct {
+	*mock.Call
+}
+
+// BeginLogin is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPasskeyService_Expecter) BeginLogin(ctx interface{}) *MockPasskeyService_BeginLogin_Call {
+	return &MockPasskeyService_BeginLogin_Call{Call: _e.mock.On("BeginLogin", ctx)}
+}
+
+func (_c *MockPasskeyService_BeginLogin_Call) Run(run func(ctx context.Context)) *MockPasskeyService_BeginLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasskeyService_BeginLogin_Call) Return(passkeyLoginOptions *services.PasskeyLoginOptions, err error) *MockPasskeyService_BeginLogin_Call {
+	_c.Call.Return(passkeyLoginOptions, err)
+	return _c
+}
+
+func (_c *MockPasskeyService_BeginLogin_Call) RunAndReturn(run func(ctx context.Context) (*services.PasskeyLoginOptions, error)) *MockPasskeyService_BeginLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BeginRegistration provides a mock function for the type MockPasskeyService
+func (_mock *MockPasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (*services.PasskeyRegistrationOptions, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginRegistration")
+	}
+
+	var r0 *services.PasskeyRegistrationOptions
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*services.PasskeyRegistrationOptions, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *services.PasskeyRegistrationOptions); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.PasskeyRegistrationOptions)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasskeyService_BeginRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginRegistration'
+type MockPasskeyService_BeginRegistration_Call struct {
+	*mock.Call
+}
+
+// BeginRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockPasskeyService_Expecter) BeginRegistration(ctx interface{}, userID interface{}) *MockPasskeyService_BeginRegistration_Call {
+	return &MockPasskeyService_BeginRegistration_Call{Call: _e.mock.On("BeginRegistration", ctx, userID)}
+}
+
+func (_c *MockPasskeyService_BeginRegistration_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockPasskeyService_BeginRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasskeyService_BeginRegistration_Call) Return(passkeyRegistrationOptions *services.PasskeyRegistrationOptions, err error) *MockPasskeyService_BeginRegistration_Call {
+	_c.Call.Return(passkeyRegistrationOptions, err)
+	return _c
+}
+
+func (_c *MockPasskeyService_BeginRegistration_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (*services.PasskeyRegistrationOptions, error)) *MockPasskeyService_BeginRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockPasskeyService
+func (_mock *MockPasskeyService) Delete(ctx context.Context, userID uuid.UUID, passkeyID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, passkeyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, passkeyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPasskeyService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockPasskeyService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - passkeyID uuid.UUID
+func (_e *MockPasskeyService_Expecter) Delete(ctx interface{}, userID interface{}, passkeyID interface{}) *MockPasskeyService_Delete_Call {
+	return &MockPasskeyService_Delete_Call{Call: _e.mock.On("Delete", ctx, userID, passkeyID)}
+}
+
+func (_c *MockPasskeyService_Delete_Call) Run(run func(ctx context.Context, userID uuid.UUID, passkeyID uuid.UUID)) *MockPasskeyService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasskeyService_Delete_Call) Return(err error) *MockPasskeyService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPasskeyService_Delete_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, passkeyID uuid.UUID) error) *MockPasskeyService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishLogin provides a mock function for the type MockPasskeyService
+func (_mock *MockPasskeyService) FinishLogin(ctx context.Context, credential []byte, client services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error) {
+	ret := _mock.Called(ctx, credential, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishLogin")
+	}
+
+	var r0 *sqlcgen.User
+	var r1 *services.SessionTokens
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error)); ok {
+		return returnFunc(ctx, credential, client)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, services.ClientInfo) *sqlcgen.User); ok {
+		r0 = returnFunc(ctx, credential, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, services.ClientInfo) *services.SessionTokens); ok {
+		r1 = returnFunc(ctx, credential, client)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*services.SessionTokens)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, []byte, services.ClientInfo) error); ok {
+		r2 = returnFunc(ctx, credential, client)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockPasskeyService_FinishLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishLogin'
+type MockPasskeyService_FinishLogin_Call struct {
+	*mock.Call
+}
+
+// FinishLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credential []byte
+//   - client services.ClientInfo
+func (_e *MockPasskeyService_Expecter) FinishLogin(ctx interface{}, credential interface{}, client interface{}) *MockPasskeyService_FinishLogin_Call {
+	return &MockPasskeyService_FinishLogin_Call{Call: _e.mock.On("FinishLogin", ctx, credential, client)}
+}
+
+func (_c *MockPasskeyService_FinishLogin_Call) Run(run func(ctx context.Context, credential []byte, client services.ClientInfo)) *MockPasskeyService_FinishLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 services.ClientInfo
+		if args[2] != nil {
+			arg2 = args[2].(services.ClientInfo)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasskeyService_FinishLogin_Call) Return(user *sqlcgen.User, sessionTokens *services.SessionTokens, err error) *MockPasskeyService_FinishLogin_Call {
+	_c.Call.Return(user, sessionTokens, err)
+	return _c
+}
+
+func (_c *MockPasskeyService_FinishLogin_Call) RunAndReturn(run func(ctx context.Context, credential []byte, client services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error)) *MockPasskeyService_FinishLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishRegistration provides a mock function for the type MockPasskeyService
+func (_mock *MockPasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUID, registration services.PasskeyRegistration) (*sqlcgen.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, userID, registration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishRegistration")
+	}
+
+	var r0 *sqlcgen.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.PasskeyRegistration) (*sqlcgen.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, userID, registration)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.PasskeyRegistration) *sqlcgen.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, userID, registration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.WebauthnCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, services.PasskeyRegistration) error); ok {
+		r1 = returnFunc(ctx, userID, registration)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasskeyService_FinishRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishRegistration'
+type MockPasskeyService_FinishRegistration_Call struct {
+	*mock.Call
+}
+
+// FinishRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - registration services.PasskeyRegistration
+func (_e *MockPasskeyService_Expecter) FinishRegistration(ctx interface{}, userID interface{}, registration interface{}) *MockPasskeyService_FinishRegistration_Call {
+	return &MockPasskeyService_FinishRegistration_Call{Call: _e.mock.On("FinishRegistration", ctx, userID, registration)}
+}
+
+func (_c *MockPasskeyService_FinishRegistration_Call) Run(run func(ctx context.Context, userID uuid.UUID, registration services.PasskeyRegistration)) *MockPasskeyService_FinishRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 services.PasskeyRegistration
+		if args[2] != nil {
+			arg2 = args[2].(services.PasskeyRegistration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasskeyService_FinishRegistration_Call) Return(webauthnCredential *sqlcgen.WebauthnCredential, err error) *MockPasskeyService_FinishRegistration_Call {
+	_c.Call.Return(webauthnCredential, err)
+	return _c
+}
+
+func (_c *MockPasskeyService_FinishRegistration_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, registration services.PasskeyRegistration) (*sqlcgen.WebauthnCredential, error)) *MockPasskeyService_FinishRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForUser provides a mock function for the type MockPasskeyService
+func (_mock *MockPasskeyService) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForUser")
+	}
+
+	var r0 []sqlcgen.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]sqlcgen.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []sqlcgen.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.WebauthnCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasskeyService_ListForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForUser'
+type MockPasskeyService_ListForUser_Call struct {
+	*mock.Call
+}
+
+// ListForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockPasskeyService_Expecter) ListForUser(ctx interface{}, userID interface{}) *MockPasskeyService_ListForUser_Call {
+	return &MockPasskeyService_ListForUser_Call{Call: _e.mock.On("ListForUser", ctx, userID)}
+}
+
+func (_c *MockPasskeyService_ListForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockPasskeyService_ListForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasskeyService_ListForUser_Call) Return(webauthnCredentials []sqlcgen.WebauthnCredential, err error) *MockPasskeyService_ListForUser_Call {
+	_c.Call.Return(webauthnCredentials, err)
+	return _c
+}
+
+func (_c *MockPasskeyService_ListForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error)) *MockPasskeyService_ListForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
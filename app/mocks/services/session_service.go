@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"go-reasonable-api/app/interfaces/services"
 	"go-reasonable-api/db/sqlcgen"
 
 	"github.com/google/uuid"
@@ -39,40 +40,122 @@ func (_m *MockSessionService) EXPECT() *MockSessionService_Expecter {
 	return &MockSessionService_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockSessionService
-func (_mock *MockSessionService) Create(ctx context.Context, email string, password string) (*sqlcgen.User, string, error) {
-	ret := _mock.Called(ctx, email, password)
+// CompleteTwoFactor provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) CompleteTwoFactor(ctx context.Context, challengeToken string, code string, client services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error) {
+	ret := _mock.Called(ctx, challengeToken, code, client)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for CompleteTwoFactor")
 	}
 
 	var r0 *sqlcgen.User
-	var r1 string
+	var r1 *services.SessionTokens
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*sqlcgen.User, string, error)); ok {
-		return returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error)); ok {
+		return returnFunc(ctx, challengeToken, code, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *sqlcgen.User); ok {
-		r0 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.ClientInfo) *sqlcgen.User); ok {
+		r0 = returnFunc(ctx, challengeToken, code, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sqlcgen.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, services.ClientInfo) *services.SessionTokens); ok {
+		r1 = returnFunc(ctx, challengeToken, code, client)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*services.SessionTokens)
+		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, services.ClientInfo) error); ok {
+		r2 = returnFunc(ctx, challengeToken, code, client)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
+// MockSessionService_CompleteTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteTwoFactor'
+type MockSessionService_CompleteTwoFactor_Call struct {
+	*mock.Call
+}
+
+// CompleteTwoFactor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeToken string
+//   - code string
+//   - client services.ClientInfo
+func (_e *MockSessionService_Expecter) CompleteTwoFactor(ctx interface{}, challengeToken interface{}, code interface{}, client interface{}) *MockSessionService_CompleteTwoFactor_Call {
+	return &MockSessionService_CompleteTwoFactor_Call{Call: _e.mock.On("CompleteTwoFactor", ctx, challengeToken, code, client)}
+}
+
+func (_c *MockSessionService_CompleteTwoFactor_Call) Run(run func(ctx context.Context, challengeToken string, code string, client services.ClientInfo)) *MockSessionService_CompleteTwoFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 services.ClientInfo
+		if args[3] != nil {
+			arg3 = args[3].(services.ClientInfo)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionService_CompleteTwoFactor_Call) Return(user *sqlcgen.User, sessionTokens *services.SessionTokens, err error) *MockSessionService_CompleteTwoFactor_Call {
+	_c.Call.Return(user, sessionTokens, err)
+	return _c
+}
+
+func (_c *MockSessionService_CompleteTwoFactor_Call) RunAndReturn(run func(ctx context.Context, challengeToken string, code string, client services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error)) *MockSessionService_CompleteTwoFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) Create(ctx context.Context, email string, password string, client services.ClientInfo) (*services.LoginResult, error) {
+	ret := _mock.Called(ctx, email, password, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *services.LoginResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.ClientInfo) (*services.LoginResult, error)); ok {
+		return returnFunc(ctx, email, password, client)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, services.ClientInfo) *services.LoginResult); ok {
+		r0 = returnFunc(ctx, email, password, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.LoginResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, services.ClientInfo) error); ok {
+		r1 = returnFunc(ctx, email, password, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockSessionService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
 type MockSessionService_Create_Call struct {
 	*mock.Call
@@ -82,11 +165,12 @@ type MockSessionService_Create_Call struct {
 //   - ctx context.Context
 //   - email string
 //   - password string
-func (_e *MockSessionService_Expecter) Create(ctx interface{}, email interface{}, password interface{}) *MockSessionService_Create_Call {
-	return &MockSessionService_Create_Call{Call: _e.mock.On("Create", ctx, email, password)}
+//   - client services.ClientInfo
+func (_e *MockSessionService_Expecter) Create(ctx interface{}, email interface{}, password interface{}, client interface{}) *MockSessionService_Create_Call {
+	return &MockSessionService_Create_Call{Call: _e.mock.On("Create", ctx, email, password, client)}
 }
 
-func (_c *MockSessionService_Create_Call) Run(run func(ctx context.Context, email string, password string)) *MockSessionService_Create_Call {
+func (_c *MockSessionService_Create_Call) Run(run func(ctx context.Context, email string, password string, client services.ClientInfo)) *MockSessionService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -100,45 +184,52 @@ func (_c *MockSessionService_Create_Call) Run(run func(ctx context.Context, emai
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 services.ClientInfo
+		if args[3] != nil {
+			arg3 = args[3].(services.ClientInfo)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessionService_Create_Call) Return(user *sqlcgen.User, s string, err error) *MockSessionService_Create_Call {
-	_c.Call.Return(user, s, err)
+func (_c *MockSessionService_Create_Call) Return(loginResult *services.LoginResult, err error) *MockSessionService_Create_Call {
+	_c.Call.Return(loginResult, err)
 	return _c
 }
 
-func (_c *MockSessionService_Create_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (*sqlcgen.User, string, error)) *MockSessionService_Create_Call {
+func (_c *MockSessionService_Create_Call) RunAndReturn(run func(ctx context.Context, email string, password string, client services.ClientInfo) (*services.LoginResult, error)) *MockSessionService_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CreateForUser provides a mock function for the type MockSessionService
-func (_mock *MockSessionService) CreateForUser(ctx context.Context, userID uuid.UUID) (string, error) {
-	ret := _mock.Called(ctx, userID)
+func (_mock *MockSessionService) CreateForUser(ctx context.Context, userID uuid.UUID, client services.ClientInfo) (*services.SessionTokens, error) {
+	ret := _mock.Called(ctx, userID, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateForUser")
 	}
 
-	var r0 string
+	var r0 *services.SessionTokens
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.ClientInfo) (*services.SessionTokens, error)); ok {
+		return returnFunc(ctx, userID, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.ClientInfo) *services.SessionTokens); ok {
+		r0 = returnFunc(ctx, userID, client)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.SessionTokens)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, services.ClientInfo) error); ok {
+		r1 = returnFunc(ctx, userID, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -153,11 +244,12 @@ type MockSessionService_CreateForUser_Call struct {
 // CreateForUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID uuid.UUID
-func (_e *MockSessionService_Expecter) CreateForUser(ctx interface{}, userID interface{}) *MockSessionService_CreateForUser_Call {
-	return &MockSessionService_CreateForUser_Call{Call: _e.mock.On("CreateForUser", ctx, userID)}
+//   - client services.ClientInfo
+func (_e *MockSessionService_Expecter) CreateForUser(ctx interface{}, userID interface{}, client interface{}) *MockSessionService_CreateForUser_Call {
+	return &MockSessionService_CreateForUser_Call{Call: _e.mock.On("CreateForUser", ctx, userID, client)}
 }
 
-func (_c *MockSessionService_CreateForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSessionService_CreateForUser_Call {
+func (_c *MockSessionService_CreateForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, client services.ClientInfo)) *MockSessionService_CreateForUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -167,20 +259,25 @@ func (_c *MockSessionService_CreateForUser_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 services.ClientInfo
+		if args[2] != nil {
+			arg2 = args[2].(services.ClientInfo)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessionService_CreateForUser_Call) Return(s string, err error) *MockSessionService_CreateForUser_Call {
-	_c.Call.Return(s, err)
+func (_c *MockSessionService_CreateForUser_Call) Return(sessionTokens *services.SessionTokens, err error) *MockSessionService_CreateForUser_Call {
+	_c.Call.Return(sessionTokens, err)
 	return _c
 }
 
-func (_c *MockSessionService_CreateForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (string, error)) *MockSessionService_CreateForUser_Call {
+func (_c *MockSessionService_CreateForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, client services.ClientInfo) (*services.SessionTokens, error)) *MockSessionService_CreateForUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -242,6 +339,366 @@ func (_c *MockSessionService_Delete_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// ListForUser provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) ListForUser(ctx context.Context, userID uuid.UUID, limit int32, offset int32) ([]sqlcgen.AuthToken, int64, error) {
+	ret := _mock.Called(ctx, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForUser")
+	}
+
+	var r0 []sqlcgen.AuthToken
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) ([]sqlcgen.AuthToken, int64, error)); ok {
+		return returnFunc(ctx, userID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) []sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlcgen.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int32, int32) int64); ok {
+		r1 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uuid.UUID, int32, int32) error); ok {
+		r2 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSessionService_ListForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForUser'
+type MockSessionService_ListForUser_Call struct {
+	*mock.Call
+}
+
+// ListForUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - limit int32
+//   - offset int32
+func (_e *MockSessionService_Expecter) ListForUser(ctx interface{}, userID interface{}, limit interface{}, offset interface{}) *MockSessionService_ListForUser_Call {
+	return &MockSessionService_ListForUser_Call{Call: _e.mock.On("ListForUser", ctx, userID, limit, offset)}
+}
+
+func (_c *MockSessionService_ListForUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, limit int32, offset int32)) *MockSessionService_ListForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		var arg3 int32
+		if args[3] != nil {
+			arg3 = args[3].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionService_ListForUser_Call) Return(authTokens []sqlcgen.AuthToken, n int64, err error) *MockSessionService_ListForUser_Call {
+	_c.Call.Return(authTokens, n, err)
+	return _c
+}
+
+func (_c *MockSessionService_ListForUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, limit int32, offset int32) ([]sqlcgen.AuthToken, int64, error)) *MockSessionService_ListForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) Refresh(ctx context.Context, refreshToken string, client services.ClientInfo) (*services.SessionTokens, error) {
+	ret := _mock.Called(ctx, refreshToken, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *services.SessionTokens
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.ClientInfo) (*services.SessionTokens, error)); ok {
+		return returnFunc(ctx, refreshToken, client)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, services.ClientInfo) *services.SessionTokens); ok {
+		r0 = returnFunc(ctx, refreshToken, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.SessionTokens)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, services.ClientInfo) error); ok {
+		r1 = returnFunc(ctx, refreshToken, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockSessionService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+//   - client services.ClientInfo
+func (_e *MockSessionService_Expecter) Refresh(ctx interface{}, refreshToken interface{}, client interface{}) *MockSessionService_Refresh_Call {
+	return &MockSessionService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken, client)}
+}
+
+func (_c *MockSessionService_Refresh_Call) Run(run func(ctx context.Context, refreshToken string, client services.ClientInfo)) *MockSessionService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 services.ClientInfo
+		if args[2] != nil {
+			arg2 = args[2].(services.ClientInfo)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionService_Refresh_Call) Return(sessionTokens *services.SessionTokens, err error) *MockSessionService_Refresh_Call {
+	_c.Call.Return(sessionTokens, err)
+	return _c
+}
+
+func (_c *MockSessionService_Refresh_Call) RunAndReturn(run func(ctx context.Context, refreshToken string, client services.ClientInfo) (*services.SessionTokens, error)) *MockSessionService_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) Revoke(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionService_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockSessionService_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - sessionID uuid.UUID
+func (_e *MockSessionService_Expecter) Revoke(ctx interface{}, userID interface{}, sessionID interface{}) *MockSessionService_Revoke_Call {
+	return &MockSessionService_Revoke_Call{Call: _e.mock.On("Revoke", ctx, userID, sessionID)}
+}
+
+func (_c *MockSessionService_Revoke_Call) Run(run func(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID)) *MockSessionService_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionService_Revoke_Call) Return(err error) *MockSessionService_Revoke_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionService_Revoke_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error) *MockSessionService_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOthers provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) RevokeOthers(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOthers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, currentSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionService_RevokeOthers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOthers'
+type MockSessionService_RevokeOthers_Call struct {
+	*mock.Call
+}
+
+// RevokeOthers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - currentSessionID uuid.UUID
+func (_e *MockSessionService_Expecter) RevokeOthers(ctx interface{}, userID interface{}, currentSessionID interface{}) *MockSessionService_RevokeOthers_Call {
+	return &MockSessionService_RevokeOthers_Call{Call: _e.mock.On("RevokeOthers", ctx, userID, currentSessionID)}
+}
+
+func (_c *MockSessionService_RevokeOthers_Call) Run(run func(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID)) *MockSessionService_RevokeOthers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionService_RevokeOthers_Call) Return(err error) *MockSessionService_RevokeOthers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionService_RevokeOthers_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) error) *MockSessionService_RevokeOthers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartSession provides a mock function for the type MockSessionService
+func (_mock *MockSessionService) StartSession(ctx context.Context, user *sqlcgen.User, client services.ClientInfo) (*services.SessionTokens, error) {
+	ret := _mock.Called(ctx, user, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartSession")
+	}
+
+	var r0 *services.SessionTokens
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqlcgen.User, services.ClientInfo) (*services.SessionTokens, error)); ok {
+		return returnFunc(ctx, user, client)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqlcgen.User, services.ClientInfo) *services.SessionTokens); ok {
+		r0 = returnFunc(ctx, user, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.SessionTokens)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *sqlcgen.User, services.ClientInfo) error); ok {
+		r1 = returnFunc(ctx, user, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionService_StartSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartSession'
+type MockSessionService_StartSession_Call struct {
+	*mock.Call
+}
+
+// StartSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *sqlcgen.User
+//   - client services.ClientInfo
+func (_e *MockSessionService_Expecter) StartSession(ctx interface{}, user interface{}, client interface{}) *MockSessionService_StartSession_Call {
+	return &MockSessionService_StartSession_Call{Call: _e.mock.On("StartSession", ctx, user, client)}
+}
+
+func (_c *MockSessionService_StartSession_Call) Run(run func(ctx context.Context, user *sqlcgen.User, client services.ClientInfo)) *MockSessionService_StartSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *sqlcgen.User
+		if args[1] != nil {
+			arg1 = args[1].(*sqlcgen.User)
+		}
+		var arg2 services.ClientInfo
+		if args[2] != nil {
+			arg2 = args[2].(services.ClientInfo)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionService_StartSession_Call) Return(sessionTokens *services.SessionTokens, err error) *MockSessionService_StartSession_Call {
+	_c.Call.Return(sessionTokens, err)
+	return _c
+}
+
+func (_c *MockSessionService_StartSession_Call) RunAndReturn(run func(ctx context.Context, user *sqlcgen.User, client services.ClientInfo) (*services.SessionTokens, error)) *MockSessionService_StartSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateToken provides a mock function for the type MockSessionService
 func (_mock *MockSessionService) ValidateToken(ctx context.Context, token string) (*sqlcgen.AuthToken, error) {
 	ret := _mock.Called(ctx, token)
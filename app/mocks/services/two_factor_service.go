@@ -0,0 +1,521 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/app/interfaces/services"
+
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTwoFactorService creates a new instance of MockTwoFactorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTwoFactorService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTwoFactorService {
+	mock := &MockTwoFactorService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTwoFactorService is an autogenerated mock type for the TwoFactorService type
+type MockTwoFactorService struct {
+	mock.Mock
+}
+
+type MockTwoFactorService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTwoFactorService) EXPECT() *MockTwoFactorService_Expecter {
+	return &MockTwoFactorService_Expecter{mock: &_m.Mock}
+}
+
+// BeginEnrollment provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*services.TOTPEnrollment, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginEnrollment")
+	}
+
+	var r0 *services.TOTPEnrollment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*services.TOTPEnrollment, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *services.TOTPEnrollment); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.TOTPEnrollment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorService_BeginEnrollment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginEnrollment'
+type MockTwoFactorService_BeginEnrollment_Call struct {
+	*mock.Call
+}
+
+// BeginEnrollment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockTwoFactorService_Expecter) BeginEnrollment(ctx interface{}, userID interface{}) *MockTwoFactorService_BeginEnrollment_Call {
+	return &MockTwoFactorService_BeginEnrollment_Call{Call: _e.mock.On("BeginEnrollment", ctx, userID)}
+}
+
+func (_c *MockTwoFactorService_BeginEnrollment_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockTwoFactorService_BeginEnrollment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_BeginEnrollment_Call) Return(tOTPEnrollment *services.TOTPEnrollment, err error) *MockTwoFactorService_BeginEnrollment_Call {
+	_c.Call.Return(tOTPEnrollment, err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_BeginEnrollment_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (*services.TOTPEnrollment, error)) *MockTwoFactorService_BeginEnrollment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChallengeUser provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) ChallengeUser(ctx context.Context, token string) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChallengeUser")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorService_ChallengeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChallengeUser'
+type MockTwoFactorService_ChallengeUser_Call struct {
+	*mock.Call
+}
+
+// ChallengeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockTwoFactorService_Expecter) ChallengeUser(ctx interface{}, token interface{}) *MockTwoFactorService_ChallengeUser_Call {
+	return &MockTwoFactorService_ChallengeUser_Call{Call: _e.mock.On("ChallengeUser", ctx, token)}
+}
+
+func (_c *MockTwoFactorService_ChallengeUser_Call) Run(run func(ctx context.Context, token string)) *MockTwoFactorService_ChallengeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_ChallengeUser_Call) Return(uUID uuid.UUID, err error) *MockTwoFactorService_ChallengeUser_Call {
+	_c.Call.Return(uUID, err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_ChallengeUser_Call) RunAndReturn(run func(ctx context.Context, token string) (uuid.UUID, error)) *MockTwoFactorService_ChallengeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmEnrollment provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEnrollment")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]string, error)); ok {
+		return returnFunc(ctx, userID, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []string); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorService_ConfirmEnrollment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEnrollment'
+type MockTwoFactorService_ConfirmEnrollment_Call struct {
+	*mock.Call
+}
+
+// ConfirmEnrollment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - code string
+func (_e *MockTwoFactorService_Expecter) ConfirmEnrollment(ctx interface{}, userID interface{}, code interface{}) *MockTwoFactorService_ConfirmEnrollment_Call {
+	return &MockTwoFactorService_ConfirmEnrollment_Call{Call: _e.mock.On("ConfirmEnrollment", ctx, userID, code)}
+}
+
+func (_c *MockTwoFactorService_ConfirmEnrollment_Call) Run(run func(ctx context.Context, userID uuid.UUID, code string)) *MockTwoFactorService_ConfirmEnrollment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_ConfirmEnrollment_Call) Return(strings []string, err error) *MockTwoFactorService_ConfirmEnrollment_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_ConfirmEnrollment_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, code string) ([]string, error)) *MockTwoFactorService_ConfirmEnrollment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChallenge provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) CreateChallenge(ctx context.Context, userID uuid.UUID) (*services.TwoFactorChallenge, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChallenge")
+	}
+
+	var r0 *services.TwoFactorChallenge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*services.TwoFactorChallenge, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *services.TwoFactorChallenge); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.TwoFactorChallenge)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorService_CreateChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChallenge'
+type MockTwoFactorService_CreateChallenge_Call struct {
+	*mock.Call
+}
+
+// CreateChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockTwoFactorService_Expecter) CreateChallenge(ctx interface{}, userID interface{}) *MockTwoFactorService_CreateChallenge_Call {
+	return &MockTwoFactorService_CreateChallenge_Call{Call: _e.mock.On("CreateChallenge", ctx, userID)}
+}
+
+func (_c *MockTwoFactorService_CreateChallenge_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockTwoFactorService_CreateChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_CreateChallenge_Call) Return(twoFactorChallenge *services.TwoFactorChallenge, err error) *MockTwoFactorService_CreateChallenge_Call {
+	_c.Call.Return(twoFactorChallenge, err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_CreateChallenge_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (*services.TwoFactorChallenge, error)) *MockTwoFactorService_CreateChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Disable provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) Disable(ctx context.Context, userID uuid.UUID, password string) error {
+	ret := _mock.Called(ctx, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTwoFactorService_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
+type MockTwoFactorService_Disable_Call struct {
+	*mock.Call
+}
+
+// Disable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - password string
+func (_e *MockTwoFactorService_Expecter) Disable(ctx interface{}, userID interface{}, password interface{}) *MockTwoFactorService_Disable_Call {
+	return &MockTwoFactorService_Disable_Call{Call: _e.mock.On("Disable", ctx, userID, password)}
+}
+
+func (_c *MockTwoFactorService_Disable_Call) Run(run func(ctx context.Context, userID uuid.UUID, password string)) *MockTwoFactorService_Disable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_Disable_Call) Return(err error) *MockTwoFactorService_Disable_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_Disable_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, password string) error) *MockTwoFactorService_Disable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsEnabled provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsEnabled")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorService_IsEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEnabled'
+type MockTwoFactorService_IsEnabled_Call struct {
+	*mock.Call
+}
+
+// IsEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockTwoFactorService_Expecter) IsEnabled(ctx interface{}, userID interface{}) *MockTwoFactorService_IsEnabled_Call {
+	return &MockTwoFactorService_IsEnabled_Call{Call: _e.mock.On("IsEnabled", ctx, userID)}
+}
+
+func (_c *MockTwoFactorService_IsEnabled_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockTwoFactorService_IsEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_IsEnabled_Call) Return(b bool, err error) *MockTwoFactorService_IsEnabled_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_IsEnabled_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (bool, error)) *MockTwoFactorService_IsEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyChallenge provides a mock function for the type MockTwoFactorService
+func (_mock *MockTwoFactorService) VerifyChallenge(ctx context.Context, token string, code string) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, token, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyChallenge")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, token, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) uuid.UUID); ok {
+		r0 = returnFunc(ctx, token, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorService_VerifyChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyChallenge'
+type MockTwoFactorService_VerifyChallenge_Call struct {
+	*mock.Call
+}
+
+// VerifyChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - code string
+func (_e *MockTwoFactorService_Expecter) VerifyChallenge(ctx interface{}, token interface{}, code interface{}) *MockTwoFactorService_VerifyChallenge_Call {
+	return &MockTwoFactorService_VerifyChallenge_Call{Call: _e.mock.On("VerifyChallenge", ctx, token, code)}
+}
+
+func (_c *MockTwoFactorService_VerifyChallenge_Call) Run(run func(ctx context.Context, token string, code string)) *MockTwoFactorService_VerifyChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorService_VerifyChallenge_Call) Return(uUID uuid.UUID, err error) *MockTwoFactorService_VerifyChallenge_Call {
+	_c.Call.Return(uUID, err)
+	return _c
+}
+
+func (_c *MockTwoFactorService_VerifyChallenge_Call) RunAndReturn(run func(ctx context.Context, token string, code string) (uuid.UUID, error)) *MockTwoFactorService_VerifyChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
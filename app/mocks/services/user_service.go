@@ -6,7 +6,9 @@ package mocks
 
 import (
 	"context"
+	"go-reasonable-api/app/interfaces/services"
 	"go-reasonable-api/db/sqlcgen"
+	"time"
 
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
@@ -39,6 +41,207 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 	return &MockUserService_Expecter{mock: &_m.Mock}
 }
 
+// CancelDeletion provides a mock function for the type MockUserService
+func (_mock *MockUserService) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelDeletion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_CancelDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeletion'
+type MockUserService_CancelDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockUserService_Expecter) CancelDeletion(ctx interface{}, userID interface{}) *MockUserService_CancelDeletion_Call {
+	return &MockUserService_CancelDeletion_Call{Call: _e.mock.On("CancelDeletion", ctx, userID)}
+}
+
+func (_c *MockUserService_CancelDeletion_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUserService_CancelDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_CancelDeletion_Call) Return(err error) *MockUserService_CancelDeletion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_CancelDeletion_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockUserService_CancelDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelDeletionWithLink provides a mock function for the type MockUserService
+func (_mock *MockUserService) CancelDeletionWithLink(ctx context.Context, userID uuid.UUID, scheduledAt time.Time, signature string) error {
+	ret := _mock.Called(ctx, userID, scheduledAt, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelDeletionWithLink")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, string) error); ok {
+		r0 = returnFunc(ctx, userID, scheduledAt, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_CancelDeletionWithLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeletionWithLink'
+type MockUserService_CancelDeletionWithLink_Call struct {
+	*mock.Call
+}
+
+// CancelDeletionWithLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - scheduledAt time.Time
+//   - signature string
+func (_e *MockUserService_Expecter) CancelDeletionWithLink(ctx interface{}, userID interface{}, scheduledAt interface{}, signature interface{}) *MockUserService_CancelDeletionWithLink_Call {
+	return &MockUserService_CancelDeletionWithLink_Call{Call: _e.mock.On("CancelDeletionWithLink", ctx, userID, scheduledAt, signature)}
+}
+
+func (_c *MockUserService_CancelDeletionWithLink_Call) Run(run func(ctx context.Context, userID uuid.UUID, scheduledAt time.Time, signature string)) *MockUserService_CancelDeletionWithLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_CancelDeletionWithLink_Call) Return(err error) *MockUserService_CancelDeletionWithLink_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_CancelDeletionWithLink_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, scheduledAt time.Time, signature string) error) *MockUserService_CancelDeletionWithLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangePassword provides a mock function for the type MockUserService
+func (_mock *MockUserService) ChangePassword(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID, currentPassword string, newPassword string) error {
+	ret := _mock.Called(ctx, userID, currentSessionID, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, currentSessionID, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockUserService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - currentSessionID uuid.UUID
+//   - currentPassword string
+//   - newPassword string
+func (_e *MockUserService_Expecter) ChangePassword(ctx interface{}, userID interface{}, currentSessionID interface{}, currentPassword interface{}, newPassword interface{}) *MockUserService_ChangePassword_Call {
+	return &MockUserService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, userID, currentSessionID, currentPassword, newPassword)}
+}
+
+func (_c *MockUserService_ChangePassword_Call) Run(run func(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID, currentPassword string, newPassword string)) *MockUserService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ChangePassword_Call) Return(err error) *MockUserService_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID, currentPassword string, newPassword string) error) *MockUserService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockUserService
 func (_mock *MockUserService) Create(ctx context.Context, name string, email string, password string) (*sqlcgen.User, error) {
 	ret := _mock.Called(ctx, name, email, password)
@@ -311,3 +514,77 @@ func (_c *MockUserService_ScheduleDeletion_Call) RunAndReturn(run func(ctx conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) UpdateProfile(ctx context.Context, userID uuid.UUID, update services.ProfileUpdate) (*sqlcgen.User, error) {
+	ret := _mock.Called(ctx, userID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *sqlcgen.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.ProfileUpdate) (*sqlcgen.User, error)); ok {
+		return returnFunc(ctx, userID, update)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, services.ProfileUpdate) *sqlcgen.User); ok {
+		r0 = returnFunc(ctx, userID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, services.ProfileUpdate) error); ok {
+		r1 = returnFunc(ctx, userID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockUserService_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - update services.ProfileUpdate
+func (_e *MockUserService_Expecter) UpdateProfile(ctx interface{}, userID interface{}, update interface{}) *MockUserService_UpdateProfile_Call {
+	return &MockUserService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, userID, update)}
+}
+
+func (_c *MockUserService_UpdateProfile_Call) Run(run func(ctx context.Context, userID uuid.UUID, update services.ProfileUpdate)) *MockUserService_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 services.ProfileUpdate
+		if args[2] != nil {
+			arg2 = args[2].(services.ProfileUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_UpdateProfile_Call) Return(user *sqlcgen.User, err error) *MockUserService_UpdateProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, update services.ProfileUpdate) (*sqlcgen.User, error)) *MockUserService_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,297 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAttemptStore creates a new instance of MockAttemptStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttemptStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttemptStore {
+	mock := &MockAttemptStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAttemptStore is an autogenerated mock type for the AttemptStore type
+type MockAttemptStore struct {
+	mock.Mock
+}
+
+type MockAttemptStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttemptStore) EXPECT() *MockAttemptStore_Expecter {
+	return &MockAttemptStore_Expecter{mock: &_m.Mock}
+}
+
+// Fail provides a mock function for the type MockAttemptStore
+func (_mock *MockAttemptStore) Fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ret := _mock.Called(ctx, key, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fail")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return returnFunc(ctx, key, window)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = returnFunc(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttemptStore_Fail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fail'
+type MockAttemptStore_Fail_Call struct {
+	*mock.Call
+}
+
+// Fail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - window time.Duration
+func (_e *MockAttemptStore_Expecter) Fail(ctx interface{}, key interface{}, window interface{}) *MockAttemptStore_Fail_Call {
+	return &MockAttemptStore_Fail_Call{Call: _e.mock.On("Fail", ctx, key, window)}
+}
+
+func (_c *MockAttemptStore_Fail_Call) Run(run func(ctx context.Context, key string, window time.Duration)) *MockAttemptStore_Fail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptStore_Fail_Call) Return(n int64, err error) *MockAttemptStore_Fail_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAttemptStore_Fail_Call) RunAndReturn(run func(ctx context.Context, key string, window time.Duration) (int64, error)) *MockAttemptStore_Fail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function for the type MockAttemptStore
+func (_mock *MockAttemptStore) Lock(ctx context.Context, key string, d time.Duration) error {
+	ret := _mock.Called(ctx, key, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, key, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAttemptStore_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockAttemptStore_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - d time.Duration
+func (_e *MockAttemptStore_Expecter) Lock(ctx interface{}, key interface{}, d interface{}) *MockAttemptStore_Lock_Call {
+	return &MockAttemptStore_Lock_Call{Call: _e.mock.On("Lock", ctx, key, d)}
+}
+
+func (_c *MockAttemptStore_Lock_Call) Run(run func(ctx context.Context, key string, d time.Duration)) *MockAttemptStore_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptStore_Lock_Call) Return(err error) *MockAttemptStore_Lock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAttemptStore_Lock_Call) RunAndReturn(run func(ctx context.Context, key string, d time.Duration) error) *MockAttemptStore_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockedFor provides a mock function for the type MockAttemptStore
+func (_mock *MockAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockedFor")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (time.Duration, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttemptStore_LockedFor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockedFor'
+type MockAttemptStore_LockedFor_Call struct {
+	*mock.Call
+}
+
+// LockedFor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockAttemptStore_Expecter) LockedFor(ctx interface{}, key interface{}) *MockAttemptStore_LockedFor_Call {
+	return &MockAttemptStore_LockedFor_Call{Call: _e.mock.On("LockedFor", ctx, key)}
+}
+
+func (_c *MockAttemptStore_LockedFor_Call) Run(run func(ctx context.Context, key string)) *MockAttemptStore_LockedFor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptStore_LockedFor_Call) Return(duration time.Duration, err error) *MockAttemptStore_LockedFor_Call {
+	_c.Call.Return(duration, err)
+	return _c
+}
+
+func (_c *MockAttemptStore_LockedFor_Call) RunAndReturn(run func(ctx context.Context, key string) (time.Duration, error)) *MockAttemptStore_LockedFor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function for the type MockAttemptStore
+func (_mock *MockAttemptStore) Reset(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAttemptStore_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockAttemptStore_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockAttemptStore_Expecter) Reset(ctx interface{}, key interface{}) *MockAttemptStore_Reset_Call {
+	return &MockAttemptStore_Reset_Call{Call: _e.mock.On("Reset", ctx, key)}
+}
+
+func (_c *MockAttemptStore_Reset_Call) Run(run func(ctx context.Context, key string)) *MockAttemptStore_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptStore_Reset_Call) Return(err error) *MockAttemptStore_Reset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAttemptStore_Reset_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockAttemptStore_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
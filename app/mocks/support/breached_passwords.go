@@ -0,0 +1,104 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBreachedPasswords creates a new instance of MockBreachedPasswords. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBreachedPasswords(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBreachedPasswords {
+	mock := &MockBreachedPasswords{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBreachedPasswords is an autogenerated mock type for the BreachedPasswords type
+type MockBreachedPasswords struct {
+	mock.Mock
+}
+
+type MockBreachedPasswords_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBreachedPasswords) EXPECT() *MockBreachedPasswords_Expecter {
+	return &MockBreachedPasswords_Expecter{mock: &_m.Mock}
+}
+
+// Contains provides a mock function for the type MockBreachedPasswords
+func (_mock *MockBreachedPasswords) Contains(ctx context.Context, password string) (bool, error) {
+	ret := _mock.Called(ctx, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Contains")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBreachedPasswords_Contains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Contains'
+type MockBreachedPasswords_Contains_Call struct {
+	*mock.Call
+}
+
+// Contains is a helper method to define mock.On call
+//   - ctx context.Context
+//   - password string
+func (_e *MockBreachedPasswords_Expecter) Contains(ctx interface{}, password interface{}) *MockBreachedPasswords_Contains_Call {
+	return &MockBreachedPasswords_Contains_Call{Call: _e.mock.On("Contains", ctx, password)}
+}
+
+func (_c *MockBreachedPasswords_Contains_Call) Run(run func(ctx context.Context, password string)) *MockBreachedPasswords_Contains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBreachedPasswords_Contains_Call) Return(b bool, err error) *MockBreachedPasswords_Contains_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockBreachedPasswords_Contains_Call) RunAndReturn(run func(ctx context.Context, password string) (bool, error)) *MockBreachedPasswords_Contains_Call {
+	_c.Call.Return(run)
+	return _c
+}
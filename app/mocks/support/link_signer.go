@@ -0,0 +1,170 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockLinkSigner creates a new instance of MockLinkSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLinkSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLinkSigner {
+	mock := &MockLinkSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLinkSigner is an autogenerated mock type for the LinkSigner type
+type MockLinkSigner struct {
+	mock.Mock
+}
+
+type MockLinkSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLinkSigner) EXPECT() *MockLinkSigner_Expecter {
+	return &MockLinkSigner_Expecter{mock: &_m.Mock}
+}
+
+// Sign provides a mock function for the type MockLinkSigner
+func (_mock *MockLinkSigner) Sign(purpose string, subject string, expiresAt time.Time) string {
+	ret := _mock.Called(purpose, subject, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Time) string); ok {
+		r0 = returnFunc(purpose, subject, expiresAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockLinkSigner_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type MockLinkSigner_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - purpose string
+//   - subject string
+//   - expiresAt time.Time
+func (_e *MockLinkSigner_Expecter) Sign(purpose interface{}, subject interface{}, expiresAt interface{}) *MockLinkSigner_Sign_Call {
+	return &MockLinkSigner_Sign_Call{Call: _e.mock.On("Sign", purpose, subject, expiresAt)}
+}
+
+func (_c *MockLinkSigner_Sign_Call) Run(run func(purpose string, subject string, expiresAt time.Time)) *MockLinkSigner_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkSigner_Sign_Call) Return(s string) *MockLinkSigner_Sign_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockLinkSigner_Sign_Call) RunAndReturn(run func(purpose string, subject string, expiresAt time.Time) string) *MockLinkSigner_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockLinkSigner
+func (_mock *MockLinkSigner) Verify(purpose string, subject string, expiresAt time.Time, signature string) bool {
+	ret := _mock.Called(purpose, subject, expiresAt, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Time, string) bool); ok {
+		r0 = returnFunc(purpose, subject, expiresAt, signature)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockLinkSigner_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockLinkSigner_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - purpose string
+//   - subject string
+//   - expiresAt time.Time
+//   - signature string
+func (_e *MockLinkSigner_Expecter) Verify(purpose interface{}, subject interface{}, expiresAt interface{}, signature interface{}) *MockLinkSigner_Verify_Call {
+	return &MockLinkSigner_Verify_Call{Call: _e.mock.On("Verify", purpose, subject, expiresAt, signature)}
+}
+
+func (_c *MockLinkSigner_Verify_Call) Run(run func(purpose string, subject string, expiresAt time.Time, signature string)) *MockLinkSigner_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkSigner_Verify_Call) Return(b bool) *MockLinkSigner_Verify_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockLinkSigner_Verify_Call) RunAndReturn(run func(purpose string, subject string, expiresAt time.Time, signature string) bool) *MockLinkSigner_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,223 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTokenCache creates a new instance of MockTokenCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTokenCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTokenCache {
+	mock := &MockTokenCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTokenCache is an autogenerated mock type for the TokenCache type
+type MockTokenCache struct {
+	mock.Mock
+}
+
+type MockTokenCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTokenCache) EXPECT() *MockTokenCache_Expecter {
+	return &MockTokenCache_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockTokenCache
+func (_mock *MockTokenCache) Get(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, int64, bool) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *sqlcgen.AuthToken
+	var r1 int64
+	var r2 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*sqlcgen.AuthToken, int64, bool)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *sqlcgen.AuthToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlcgen.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) int64); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) bool); ok {
+		r2 = returnFunc(ctx, tokenHash)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	return r0, r1, r2
+}
+
+// MockTokenCache_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockTokenCache_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockTokenCache_Expecter) Get(ctx interface{}, tokenHash interface{}) *MockTokenCache_Get_Call {
+	return &MockTokenCache_Get_Call{Call: _e.mock.On("Get", ctx, tokenHash)}
+}
+
+func (_c *MockTokenCache_Get_Call) Run(run func(ctx context.Context, tokenHash string)) *MockTokenCache_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenCache_Get_Call) Return(authToken *sqlcgen.AuthToken, n int64, b bool) *MockTokenCache_Get_Call {
+	_c.Call.Return(authToken, n, b)
+	return _c
+}
+
+func (_c *MockTokenCache_Get_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, int64, bool)) *MockTokenCache_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invalidate provides a mock function for the type MockTokenCache
+func (_mock *MockTokenCache) Invalidate(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invalidate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTokenCache_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
+type MockTokenCache_Invalidate_Call struct {
+	*mock.Call
+}
+
+// Invalidate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockTokenCache_Expecter) Invalidate(ctx interface{}, userID interface{}) *MockTokenCache_Invalidate_Call {
+	return &MockTokenCache_Invalidate_Call{Call: _e.mock.On("Invalidate", ctx, userID)}
+}
+
+func (_c *MockTokenCache_Invalidate_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockTokenCache_Invalidate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenCache_Invalidate_Call) Return(err error) *MockTokenCache_Invalidate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTokenCache_Invalidate_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockTokenCache_Invalidate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockTokenCache
+func (_mock *MockTokenCache) Set(ctx context.Context, token *sqlcgen.AuthToken, generation int64) {
+	_mock.Called(ctx, token, generation)
+	return
+}
+
+// MockTokenCache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockTokenCache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *sqlcgen.AuthToken
+//   - generation int64
+func (_e *MockTokenCache_Expecter) Set(ctx interface{}, token interface{}, generation interface{}) *MockTokenCache_Set_Call {
+	return &MockTokenCache_Set_Call{Call: _e.mock.On("Set", ctx, token, generation)}
+}
+
+func (_c *MockTokenCache_Set_Call) Run(run func(ctx context.Context, token *sqlcgen.AuthToken, generation int64)) *MockTokenCache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *sqlcgen.AuthToken
+		if args[1] != nil {
+			arg1 = args[1].(*sqlcgen.AuthToken)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenCache_Set_Call) Return() *MockTokenCache_Set_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTokenCache_Set_Call) RunAndReturn(run func(ctx context.Context, token *sqlcgen.AuthToken, generation int64)) *MockTokenCache_Set_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type APIKeyRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *APIKeyRepository) WithTx(tx pgx.Tx) repositories.APIKeyRepository {
+	return &APIKeyRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, userID uuid.UUID, name, keyPrefix, tokenHash string, scopes []string, expiresAt *time.Time) (*sqlcgen.ApiKey, error) {
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	key := sqlcgen.ApiKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		KeyPrefix: keyPrefix,
+		TokenHash: tokenHash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateAPIKey(ctx, sqlcgen.CreateAPIKeyParams{
+		ID:        key.ID,
+		UserID:    key.UserID,
+		Name:      key.Name,
+		KeyPrefix: key.KeyPrefix,
+		TokenHash: key.TokenHash,
+		Scopes:    key.Scopes,
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: key.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create api key")
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, tokenHashes []string) (*sqlcgen.ApiKey, error) {
+	key, err := r.queries.GetAPIKeyByHash(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get api key by hash")
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepository) ListActiveForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ApiKey, error) {
+	keys, err := r.queries.ListActiveAPIKeysForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list active api keys for user")
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) RenameForUser(ctx context.Context, id, userID uuid.UUID, name string) (*sqlcgen.ApiKey, error) {
+	key, err := r.queries.RenameAPIKeyForUser(ctx, sqlcgen.RenameAPIKeyForUserParams{
+		Name:   name,
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to rename api key for user")
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.TouchAPIKey(ctx, sqlcgen.TouchAPIKeyParams{
+		LastUsedAt: &now,
+		ID:         id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to touch api key")
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	if err := r.queries.UpdateAPIKeyHash(ctx, sqlcgen.UpdateAPIKeyHashParams{
+		TokenHash: tokenHash,
+		ID:        id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to update api key hash")
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) RevokeForUser(ctx context.Context, id, userID uuid.UUID) error {
+	now := time.Now().UTC()
+	revoked, err := r.queries.RevokeAPIKeyForUser(ctx, sqlcgen.RevokeAPIKeyForUserParams{
+		RevokedAt: &now,
+		ID:        id,
+		UserID:    userID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to revoke api key for user")
+	}
+	if revoked == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no active api key for user")
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.RevokeAllAPIKeysForUser(ctx, sqlcgen.RevokeAllAPIKeysForUserParams{
+		RevokedAt: &now,
+		UserID:    userID,
+	}); err != nil {
+		return eris.Wrap(err, "failed to revoke all api keys for user")
+	}
+	return nil
+}
+
+var _ repositories.APIKeyRepository = (*APIKeyRepository)(nil)
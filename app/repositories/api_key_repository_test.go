@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewAPIKeyRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+		expiresAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Microsecond)
+
+		key, err := repo.Create(ctx, userID, "CI", "ak_12345678", "hash-create", []string{"profile:read"}, &expiresAt)
+		require.NoError(t, err)
+		assert.NotEmpty(t, key.ID)
+		assert.Equal(t, userID, key.UserID)
+		assert.Equal(t, "CI", key.Name)
+		assert.Equal(t, []string{"profile:read"}, key.Scopes)
+		assert.Nil(t, key.LastUsedAt)
+	})
+
+	t.Run("GetByHash", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, "Deploy", "ak_abcdefgh", "hash-get", nil, nil)
+		require.NoError(t, err)
+
+		found, err := repo.GetByHash(ctx, []string{"hash-get"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, "ak_abcdefgh", found.KeyPrefix)
+		assert.Empty(t, found.Scopes)
+		assert.Nil(t, found.ExpiresAt)
+	})
+
+	t.Run("GetByHash_NotFound", func(t *testing.T) {
+		key, err := repo.GetByHash(ctx, []string{"missing"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, key)
+	})
+
+	t.Run("ListActiveForUser", func(t *testing.T) {
+		userID := createUser(t)
+		otherID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "One", "ak_1", "hash-list-1", nil, nil)
+		require.NoError(t, err)
+		revoked, err := repo.Create(ctx, userID, "Two", "ak_2", "hash-list-2", nil, nil)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, otherID, "Other", "ak_3", "hash-list-3", nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.RevokeForUser(ctx, revoked.ID, userID))
+
+		keys, err := repo.ListActiveForUser(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "One", keys[0].Name)
+	})
+
+	t.Run("RenameForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		key, err := repo.Create(ctx, userID, "Old", "ak_r", "hash-rename", nil, nil)
+		require.NoError(t, err)
+
+		renamed, err := repo.RenameForUser(ctx, key.ID, userID, "New")
+		require.NoError(t, err)
+		assert.Equal(t, "New", renamed.Name)
+
+		_, err = repo.RenameForUser(ctx, key.ID, createUser(t), "Stolen")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Touch", func(t *testing.T) {
+		userID := createUser(t)
+
+		key, err := repo.Create(ctx, userID, "Touch", "ak_t", "hash-touch", nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.Touch(ctx, key.ID))
+
+		found, err := repo.GetByHash(ctx, []string{"hash-touch"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.LastUsedAt)
+	})
+
+	t.Run("UpdateHash", func(t *testing.T) {
+		userID := createUser(t)
+
+		key, err := repo.Create(ctx, userID, "Rehash", "ak_r", "hash-update-old", nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateHash(ctx, key.ID, "hash-update-new"))
+
+		found, err := repo.GetByHash(ctx, []string{"hash-update-new"})
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, found.ID)
+
+		_, err = repo.GetByHash(ctx, []string{"hash-update-old"})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("RevokeForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		key, err := repo.Create(ctx, userID, "Revoke", "ak_v", "hash-revoke", nil, nil)
+		require.NoError(t, err)
+
+		err = repo.RevokeForUser(ctx, key.ID, createUser(t))
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		require.NoError(t, repo.RevokeForUser(ctx, key.ID, userID))
+		found, err := repo.GetByHash(ctx, []string{"hash-revoke"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.RevokedAt)
+
+		err = repo.RevokeForUser(ctx, key.ID, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("RevokeAllForUser", func(t *testing.T) {
+		userID := createUser(t)
+		otherUserID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "First", "ak_a", "hash-revokeall-1", nil, nil)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, "Second", "ak_b", "hash-revokeall-2", nil, nil)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, otherUserID, "Other", "ak_c", "hash-revokeall-3", nil, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RevokeAllForUser(ctx, userID))
+
+		keys, err := repo.ListActiveForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+
+		keys, err = repo.ListActiveForUser(ctx, otherUserID)
+		require.NoError(t, err)
+		assert.Len(t, keys, 1)
+	})
+}
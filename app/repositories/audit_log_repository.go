@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type AuditLogRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewAuditLogRepository(pool *pgxpool.Pool) *AuditLogRepository {
+	return &AuditLogRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *AuditLogRepository) WithTx(tx pgx.Tx) repositories.AuditLogRepository {
+	return &AuditLogRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, entry repositories.AuditLogEntry) error {
+	metadata := entry.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return eris.Wrap(err, "failed to encode audit log metadata")
+	}
+
+	if err := r.queries.CreateAuditLog(ctx, sqlcgen.CreateAuditLogParams{
+		ID:        uuid.New(),
+		ActorID:   entry.ActorID,
+		SubjectID: entry.SubjectID,
+		Action:    entry.Action,
+		Metadata:  metadataJSON,
+		IpAddress: entry.IPAddress,
+		UserAgent: entry.UserAgent,
+		CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		return eris.Wrap(err, "failed to create audit log")
+	}
+	return nil
+}
+
+func (r *AuditLogRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.AuditLog, error) {
+	entries, err := r.queries.ListAuditLogsForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list audit logs for user")
+	}
+	return entries, nil
+}
+
+var _ repositories.AuditLogRepository = (*AuditLogRepository)(nil)
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go-reasonable-api/app/interfaces/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewAuditLogRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		actorID := createUser(t)
+		subjectID := createUser(t)
+
+		err := repo.Create(ctx, repositories.AuditLogEntry{
+			ActorID:   &actorID,
+			SubjectID: &subjectID,
+			Action:    "impersonation.started",
+			Metadata:  map[string]any{"session_id": uuid.NewString()},
+			IPAddress: "10.0.0.1",
+			UserAgent: "admin-agent",
+		})
+		require.NoError(t, err)
+
+		var action string
+		var metadata map[string]any
+		err = tx.QueryRow(ctx, "SELECT action, metadata FROM audit_logs WHERE actor_id = $1", actorID).Scan(&action, &metadata)
+		require.NoError(t, err)
+		assert.Equal(t, "impersonation.started", action)
+		assert.Contains(t, metadata, "session_id")
+	})
+
+	t.Run("ListForUser", func(t *testing.T) {
+		adminID := createUser(t)
+		userID := createUser(t)
+
+		require.NoError(t, repo.Create(ctx, repositories.AuditLogEntry{ActorID: &adminID, SubjectID: &userID, Action: "impersonation.started"}))
+		require.NoError(t, repo.Create(ctx, repositories.AuditLogEntry{ActorID: &userID, Action: "account.updated"}))
+		require.NoError(t, repo.Create(ctx, repositories.AuditLogEntry{ActorID: &adminID, Action: "system.test"}))
+
+		entries, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			involved := (entry.ActorID != nil && *entry.ActorID == userID) || (entry.SubjectID != nil && *entry.SubjectID == userID)
+			assert.True(t, involved)
+		}
+	})
+
+	t.Run("Create_WithoutActor", func(t *testing.T) {
+		err := repo.Create(ctx, repositories.AuditLogEntry{Action: "system.test"})
+		assert.NoError(t, err)
+	})
+}
@@ -29,20 +29,26 @@ func (r *AuthTokenRepository) WithTx(tx pgx.Tx) repositories.AuthTokenRepository
 	}
 }
 
-func (r *AuthTokenRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.AuthToken, error) {
+func (r *AuthTokenRepository) Create(ctx context.Context, userID, familyID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent, ipAddress string) (*sqlcgen.AuthToken, error) {
 	token := sqlcgen.AuthToken{
 		ID:        uuid.New(),
 		UserID:    userID,
+		FamilyID:  familyID,
 		TokenHash: tokenHash,
 		ExpiresAt: expiresAt,
 		CreatedAt: time.Now().UTC(),
+		UserAgent: userAgent,
+		IpAddress: ipAddress,
 	}
 
 	if err := r.queries.CreateAuthToken(ctx, sqlcgen.CreateAuthTokenParams{
 		ID:        token.ID,
 		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
 		TokenHash: token.TokenHash,
 		ExpiresAt: token.ExpiresAt,
+		UserAgent: token.UserAgent,
+		IpAddress: token.IpAddress,
 		CreatedAt: token.CreatedAt,
 	}); err != nil {
 		return nil, eris.Wrap(err, "failed to create auth token")
@@ -51,8 +57,39 @@ func (r *AuthTokenRepository) Create(ctx context.Context, userID uuid.UUID, toke
 	return &token, nil
 }
 
-func (r *AuthTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, error) {
-	token, err := r.queries.GetAuthTokenByHash(ctx, tokenHash)
+func (r *AuthTokenRepository) CreateImpersonation(ctx context.Context, userID, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time, userAgent, ipAddress string) (*sqlcgen.AuthToken, error) {
+	id := uuid.New()
+	token := sqlcgen.AuthToken{
+		ID:             id,
+		UserID:         userID,
+		FamilyID:       id,
+		TokenHash:      tokenHash,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      time.Now().UTC(),
+		UserAgent:      userAgent,
+		IpAddress:      ipAddress,
+		ImpersonatorID: &impersonatorID,
+	}
+
+	if err := r.queries.CreateImpersonationAuthToken(ctx, sqlcgen.CreateImpersonationAuthTokenParams{
+		ID:             token.ID,
+		UserID:         token.UserID,
+		FamilyID:       token.FamilyID,
+		TokenHash:      token.TokenHash,
+		ExpiresAt:      token.ExpiresAt,
+		UserAgent:      token.UserAgent,
+		IpAddress:      token.IpAddress,
+		CreatedAt:      token.CreatedAt,
+		ImpersonatorID: token.ImpersonatorID,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create impersonation auth token")
+	}
+
+	return &token, nil
+}
+
+func (r *AuthTokenRepository) GetByHash(ctx context.Context, tokenHashes []string) (*sqlcgen.AuthToken, error) {
+	token, err := r.queries.GetAuthTokenByHash(ctx, tokenHashes)
 	if err != nil {
 		return nil, eris.Wrap(err, "failed to get auth token by hash")
 	}
@@ -60,6 +97,59 @@ func (r *AuthTokenRepository) GetByHash(ctx context.Context, tokenHash string) (
 	return &token, nil
 }
 
+func (r *AuthTokenRepository) ListActiveForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]sqlcgen.AuthToken, error) {
+	tokens, err := r.queries.ListActiveAuthTokensForUser(ctx, sqlcgen.ListActiveAuthTokensForUserParams{
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC(),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list active auth tokens for user")
+	}
+	return tokens, nil
+}
+
+func (r *AuthTokenRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.AuthToken, error) {
+	tokens, err := r.queries.ListAuthTokensForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list auth tokens for user")
+	}
+	return tokens, nil
+}
+
+func (r *AuthTokenRepository) CountActiveForUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := r.queries.CountActiveAuthTokensForUser(ctx, sqlcgen.CountActiveAuthTokensForUserParams{
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to count active auth tokens for user")
+	}
+	return count, nil
+}
+
+func (r *AuthTokenRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.TouchAuthToken(ctx, sqlcgen.TouchAuthTokenParams{
+		LastUsedAt: &now,
+		ID:         id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to touch auth token")
+	}
+	return nil
+}
+
+func (r *AuthTokenRepository) UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	if err := r.queries.UpdateAuthTokenHash(ctx, sqlcgen.UpdateAuthTokenHashParams{
+		TokenHash: tokenHash,
+		ID:        id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to update auth token hash")
+	}
+	return nil
+}
+
 func (r *AuthTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
 	now := time.Now().UTC()
 	if err := r.queries.RevokeAuthToken(ctx, sqlcgen.RevokeAuthTokenParams{
@@ -71,17 +161,44 @@ func (r *AuthTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *AuthTokenRepository) RevokeByHash(ctx context.Context, tokenHash string) error {
+func (r *AuthTokenRepository) RevokeForUser(ctx context.Context, id, userID uuid.UUID) error {
 	now := time.Now().UTC()
-	if err := r.queries.RevokeAuthTokenByHash(ctx, sqlcgen.RevokeAuthTokenByHashParams{
+	revoked, err := r.queries.RevokeAuthTokenForUser(ctx, sqlcgen.RevokeAuthTokenForUserParams{
 		RevokedAt: &now,
-		TokenHash: tokenHash,
+		ID:        id,
+		UserID:    userID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to revoke auth token for user")
+	}
+	if revoked == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no active auth token for user")
+	}
+	return nil
+}
+
+func (r *AuthTokenRepository) RevokeByHash(ctx context.Context, tokenHashes []string) error {
+	now := time.Now().UTC()
+	if err := r.queries.RevokeAuthTokenByHash(ctx, sqlcgen.RevokeAuthTokenByHashParams{
+		TokenHashes: tokenHashes,
+		RevokedAt:   &now,
 	}); err != nil {
 		return eris.Wrap(err, "failed to revoke auth token by hash")
 	}
 	return nil
 }
 
+func (r *AuthTokenRepository) RevokeFamilyAccessTokens(ctx context.Context, familyID uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.RevokeFamilyAuthTokens(ctx, sqlcgen.RevokeFamilyAuthTokensParams{
+		RevokedAt: &now,
+		FamilyID:  familyID,
+	}); err != nil {
+		return eris.Wrap(err, "failed to revoke family auth tokens")
+	}
+	return nil
+}
+
 func (r *AuthTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
 	now := time.Now().UTC()
 	if err := r.queries.RevokeAllAuthTokensForUser(ctx, sqlcgen.RevokeAllAuthTokensForUserParams{
@@ -93,6 +210,18 @@ func (r *AuthTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.
 	return nil
 }
 
+func (r *AuthTokenRepository) RevokeAllForUserExcept(ctx context.Context, userID, exceptID uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.RevokeOtherAuthTokensForUser(ctx, sqlcgen.RevokeOtherAuthTokensForUserParams{
+		RevokedAt: &now,
+		UserID:    userID,
+		ID:        exceptID,
+	}); err != nil {
+		return eris.Wrap(err, "failed to revoke other auth tokens for user")
+	}
+	return nil
+}
+
 func (r *AuthTokenRepository) DeleteExpiredOrRevoked(ctx context.Context) (int64, error) {
 	deleted, err := r.queries.DeleteExpiredOrRevokedAuthTokens(ctx, time.Now().UTC())
 	if err != nil {
@@ -101,4 +230,12 @@ func (r *AuthTokenRepository) DeleteExpiredOrRevoked(ctx context.Context) (int64
 	return deleted, nil
 }
 
+func (r *AuthTokenRepository) DeleteIdle(ctx context.Context, idleBefore time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteIdleAuthTokens(ctx, idleBefore)
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete idle auth tokens")
+	}
+	return deleted, nil
+}
+
 var _ repositories.AuthTokenRepository = (*AuthTokenRepository)(nil)
@@ -27,73 +27,256 @@ func TestAuthTokenRepository(t *testing.T) {
 		userID := createUser(t)
 		expiresAt := time.Now().Add(24 * time.Hour)
 
-		token, err := repo.Create(ctx, userID, "tokenhash123", expiresAt)
+		token, err := repo.Create(ctx, userID, uuid.New(), "tokenhash123", expiresAt, "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 		assert.NotEmpty(t, token.ID)
 		assert.Equal(t, userID, token.UserID)
 		assert.Equal(t, "tokenhash123", token.TokenHash)
+		assert.Equal(t, "test-agent", token.UserAgent)
+		assert.Equal(t, "127.0.0.1", token.IpAddress)
 		assert.NotZero(t, token.CreatedAt)
 	})
 
+	t.Run("CreateImpersonation", func(t *testing.T) {
+		userID := createUser(t)
+		adminID := createUser(t)
+		expiresAt := time.Now().Add(15 * time.Minute)
+
+		created, err := repo.CreateImpersonation(ctx, userID, adminID, "impersonationhash", expiresAt, "admin-agent", "10.0.0.1")
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, created.FamilyID)
+
+		found, err := repo.GetByHash(ctx, []string{"impersonationhash"})
+		require.NoError(t, err)
+		assert.Equal(t, userID, found.UserID)
+		require.NotNil(t, found.ImpersonatorID)
+		assert.Equal(t, adminID, *found.ImpersonatorID)
+	})
+
 	t.Run("GetByHash", func(t *testing.T) {
 		userID := createUser(t)
 		expiresAt := time.Now().Add(24 * time.Hour)
 
-		created, err := repo.Create(ctx, userID, "findhash123", expiresAt)
+		created, err := repo.Create(ctx, userID, uuid.New(), "findhash123", expiresAt, "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 
-		found, err := repo.GetByHash(ctx, "findhash123")
+		found, err := repo.GetByHash(ctx, []string{"findhash123"})
 		require.NoError(t, err)
 		assert.Equal(t, created.ID, found.ID)
 		assert.Equal(t, created.TokenHash, found.TokenHash)
+		assert.Nil(t, found.ImpersonatorID)
+	})
+
+	t.Run("GetByHash_OlderHash", func(t *testing.T) {
+		userID := createUser(t)
+		created, err := repo.Create(ctx, userID, uuid.New(), "olderhash", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		found, err := repo.GetByHash(ctx, []string{"currenthash", "olderhash"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, "olderhash", found.TokenHash)
 	})
 
 	t.Run("GetByHash_NotFound", func(t *testing.T) {
-		token, err := repo.GetByHash(ctx, "nonexistenthash")
+		token, err := repo.GetByHash(ctx, []string{"nonexistenthash"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
 		assert.Nil(t, token)
 	})
 
+	t.Run("ListActiveForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, uuid.New(), "listactive1", time.Now().Add(time.Hour), "agent-1", "10.0.0.1")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, uuid.New(), "listactive2", time.Now().Add(time.Hour), "agent-2", "10.0.0.2")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, uuid.New(), "listexpired", time.Now().Add(-time.Hour), "agent-3", "10.0.0.3")
+		require.NoError(t, err)
+		revoked, err := repo.Create(ctx, userID, uuid.New(), "listrevoked", time.Now().Add(time.Hour), "agent-4", "10.0.0.4")
+		require.NoError(t, err)
+		require.NoError(t, repo.Revoke(ctx, revoked.ID))
+
+		tokens, err := repo.ListActiveForUser(ctx, userID, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, tokens, 2)
+
+		page, err := repo.ListActiveForUser(ctx, userID, 1, 1)
+		require.NoError(t, err)
+		assert.Len(t, page, 1)
+
+		count, err := repo.CountActiveForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("ListForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, uuid.New(), "listall1", time.Now().Add(time.Hour), "agent-1", "10.0.0.1")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, uuid.New(), "listallexpired", time.Now().Add(-time.Hour), "agent-2", "10.0.0.2")
+		require.NoError(t, err)
+		revoked, err := repo.Create(ctx, userID, uuid.New(), "listallrevoked", time.Now().Add(time.Hour), "agent-3", "10.0.0.3")
+		require.NoError(t, err)
+		require.NoError(t, repo.Revoke(ctx, revoked.ID))
+		_, err = repo.Create(ctx, createUser(t), uuid.New(), "listallother", time.Now().Add(time.Hour), "agent-4", "10.0.0.4")
+		require.NoError(t, err)
+
+		tokens, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, tokens, 3)
+	})
+
+	t.Run("Touch", func(t *testing.T) {
+		userID := createUser(t)
+		token, err := repo.Create(ctx, userID, uuid.New(), "touchhash", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+		assert.Nil(t, token.LastUsedAt)
+
+		err = repo.Touch(ctx, token.ID)
+		require.NoError(t, err)
+
+		found, err := repo.GetByHash(ctx, []string{"touchhash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.LastUsedAt)
+	})
+
+	t.Run("UpdateHash", func(t *testing.T) {
+		userID := createUser(t)
+		token, err := repo.Create(ctx, userID, uuid.New(), "updatehash-old", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		err = repo.UpdateHash(ctx, token.ID, "updatehash-new")
+		require.NoError(t, err)
+
+		found, err := repo.GetByHash(ctx, []string{"updatehash-new"})
+		require.NoError(t, err)
+		assert.Equal(t, token.ID, found.ID)
+
+		_, err = repo.GetByHash(ctx, []string{"updatehash-old"})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
 	t.Run("Revoke", func(t *testing.T) {
 		userID := createUser(t)
-		token, err := repo.Create(ctx, userID, "revokehash", time.Now().Add(time.Hour))
+		token, err := repo.Create(ctx, userID, uuid.New(), "revokehash", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 		assert.Nil(t, token.RevokedAt)
 
 		err = repo.Revoke(ctx, token.ID)
 		require.NoError(t, err)
 
-		found, err := repo.GetByHash(ctx, "revokehash")
+		found, err := repo.GetByHash(ctx, []string{"revokehash"})
 		require.NoError(t, err)
 		assert.NotNil(t, found.RevokedAt)
 	})
 
+	t.Run("Revoke_RevokesFamily", func(t *testing.T) {
+		userID := createUser(t)
+		familyID := uuid.New()
+		refreshRepo := NewRefreshTokenRepository(testPool).WithTx(tx)
+
+		token, err := repo.Create(ctx, userID, familyID, "familyhash1", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, familyID, "familyhash2", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+		_, err = refreshRepo.Create(ctx, userID, familyID, "familyrefresh", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		err = repo.Revoke(ctx, token.ID)
+		require.NoError(t, err)
+
+		sibling, err := repo.GetByHash(ctx, []string{"familyhash2"})
+		require.NoError(t, err)
+		assert.NotNil(t, sibling.RevokedAt)
+
+		refresh, err := refreshRepo.GetByHashForUpdate(ctx, []string{"familyrefresh"})
+		require.NoError(t, err)
+		assert.NotNil(t, refresh.RevokedAt)
+	})
+
 	t.Run("RevokeByHash", func(t *testing.T) {
 		userID := createUser(t)
-		_, err := repo.Create(ctx, userID, "revokebyhash", time.Now().Add(time.Hour))
+		_, err := repo.Create(ctx, userID, uuid.New(), "revokebyhash", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		err = repo.RevokeByHash(ctx, []string{"revokebyhash"})
+		require.NoError(t, err)
+
+		found, err := repo.GetByHash(ctx, []string{"revokebyhash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.RevokedAt)
+	})
+
+	t.Run("RevokeForUser", func(t *testing.T) {
+		userID := createUser(t)
+		otherUserID := createUser(t)
+		token, err := repo.Create(ctx, userID, uuid.New(), "revokeforuser", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 
-		err = repo.RevokeByHash(ctx, "revokebyhash")
+		err = repo.RevokeForUser(ctx, token.ID, otherUserID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		err = repo.RevokeForUser(ctx, token.ID, userID)
 		require.NoError(t, err)
 
-		found, err := repo.GetByHash(ctx, "revokebyhash")
+		found, err := repo.GetByHash(ctx, []string{"revokeforuser"})
 		require.NoError(t, err)
 		assert.NotNil(t, found.RevokedAt)
+
+		err = repo.RevokeForUser(ctx, token.ID, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("RevokeFamilyAccessTokens", func(t *testing.T) {
+		userID := createUser(t)
+		familyID := uuid.New()
+
+		_, err := repo.Create(ctx, userID, familyID, "revokefamily1", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, uuid.New(), "revokefamily2", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		err = repo.RevokeFamilyAccessTokens(ctx, familyID)
+		require.NoError(t, err)
+
+		t1, _ := repo.GetByHash(ctx, []string{"revokefamily1"})
+		t2, _ := repo.GetByHash(ctx, []string{"revokefamily2"})
+		assert.NotNil(t, t1.RevokedAt)
+		assert.Nil(t, t2.RevokedAt)
+	})
+
+	t.Run("RevokeAllForUserExcept", func(t *testing.T) {
+		userID := createUser(t)
+
+		kept, err := repo.Create(ctx, userID, uuid.New(), "revokeexcept1", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, uuid.New(), "revokeexcept2", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		err = repo.RevokeAllForUserExcept(ctx, userID, kept.ID)
+		require.NoError(t, err)
+
+		t1, _ := repo.GetByHash(ctx, []string{"revokeexcept1"})
+		t2, _ := repo.GetByHash(ctx, []string{"revokeexcept2"})
+		assert.Nil(t, t1.RevokedAt)
+		assert.NotNil(t, t2.RevokedAt)
 	})
 
 	t.Run("RevokeAllForUser", func(t *testing.T) {
 		userID := createUser(t)
 
-		_, err := repo.Create(ctx, userID, "revokeall1", time.Now().Add(time.Hour))
+		_, err := repo.Create(ctx, userID, uuid.New(), "revokeall1", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
-		_, err = repo.Create(ctx, userID, "revokeall2", time.Now().Add(time.Hour))
+		_, err = repo.Create(ctx, userID, uuid.New(), "revokeall2", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 
 		err = repo.RevokeAllForUser(ctx, userID)
 		require.NoError(t, err)
 
-		t1, _ := repo.GetByHash(ctx, "revokeall1")
-		t2, _ := repo.GetByHash(ctx, "revokeall2")
+		t1, _ := repo.GetByHash(ctx, []string{"revokeall1"})
+		t2, _ := repo.GetByHash(ctx, []string{"revokeall2"})
 		assert.NotNil(t, t1.RevokedAt)
 		assert.NotNil(t, t2.RevokedAt)
 	})
@@ -102,17 +285,17 @@ func TestAuthTokenRepository(t *testing.T) {
 		userID := createUser(t)
 
 		// Create expired token
-		_, err := repo.Create(ctx, userID, "expired", time.Now().Add(-time.Hour))
+		_, err := repo.Create(ctx, userID, uuid.New(), "expired", time.Now().Add(-time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 
 		// Create revoked token
-		revoked, err := repo.Create(ctx, userID, "revoked", time.Now().Add(time.Hour))
+		revoked, err := repo.Create(ctx, userID, uuid.New(), "revoked", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 		err = repo.Revoke(ctx, revoked.ID)
 		require.NoError(t, err)
 
 		// Create valid token
-		_, err = repo.Create(ctx, userID, "valid", time.Now().Add(time.Hour))
+		_, err = repo.Create(ctx, userID, uuid.New(), "valid", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
 		require.NoError(t, err)
 
 		deleted, err := repo.DeleteExpiredOrRevoked(ctx)
@@ -120,13 +303,42 @@ func TestAuthTokenRepository(t *testing.T) {
 		assert.GreaterOrEqual(t, deleted, int64(2))
 
 		// Valid token should still exist
-		_, err = repo.GetByHash(ctx, "valid")
+		_, err = repo.GetByHash(ctx, []string{"valid"})
 		assert.NoError(t, err)
 
 		// Expired and revoked should be deleted
-		_, err = repo.GetByHash(ctx, "expired")
+		_, err = repo.GetByHash(ctx, []string{"expired"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByHash(ctx, []string{"revoked"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("DeleteIdle", func(t *testing.T) {
+		userID := createUser(t)
+
+		// Create a token that was never used, then touch it
+		used, err := repo.Create(ctx, userID, uuid.New(), "idle-used", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+		err = repo.Touch(ctx, used.ID)
+		require.NoError(t, err)
+
+		// Create a token that is never used
+		_, err = repo.Create(ctx, userID, uuid.New(), "idle-unused", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		// Nothing is idle relative to a cutoff in the past
+		deleted, err := repo.DeleteIdle(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), deleted)
+
+		// Everything is idle relative to a cutoff in the future
+		deleted, err = repo.DeleteIdle(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(2))
+
+		_, err = repo.GetByHash(ctx, []string{"idle-used"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
-		_, err = repo.GetByHash(ctx, "revoked")
+		_, err = repo.GetByHash(ctx, []string{"idle-unused"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
 	})
 }
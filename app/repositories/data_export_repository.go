@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type DataExportRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewDataExportRepository(pool *pgxpool.Pool) *DataExportRepository {
+	return &DataExportRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *DataExportRepository) WithTx(tx pgx.Tx) repositories.DataExportRepository {
+	return &DataExportRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *DataExportRepository) Create(ctx context.Context, userID uuid.UUID, format string, expiresAt time.Time) (*sqlcgen.DataExport, error) {
+	export := sqlcgen.DataExport{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Format:    format,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateDataExport(ctx, sqlcgen.CreateDataExportParams{
+		ID:        export.ID,
+		UserID:    export.UserID,
+		Format:    export.Format,
+		ExpiresAt: export.ExpiresAt,
+		CreatedAt: export.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create data export")
+	}
+
+	return &export, nil
+}
+
+func (r *DataExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.DataExport, error) {
+	export, err := r.queries.GetDataExportByID(ctx, id)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get data export by id")
+	}
+
+	return &export, nil
+}
+
+func (r *DataExportRepository) Complete(ctx context.Context, id uuid.UUID, archive []byte, expiresAt time.Time) error {
+	now := time.Now().UTC()
+	rows, err := r.queries.CompleteDataExport(ctx, sqlcgen.CompleteDataExportParams{
+		Archive:     archive,
+		ExpiresAt:   expiresAt,
+		CompletedAt: &now,
+		ID:          id,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to complete data export")
+	}
+	if rows == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no pending data export")
+	}
+	return nil
+}
+
+func (r *DataExportRepository) CountForUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	count, err := r.queries.CountDataExportsForUserSince(ctx, sqlcgen.CountDataExportsForUserSinceParams{
+		UserID:    userID,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to count data exports for user")
+	}
+	return count, nil
+}
+
+func (r *DataExportRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredDataExports(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired data exports")
+	}
+	return deleted, nil
+}
+
+var _ repositories.DataExportRepository = (*DataExportRepository)(nil)
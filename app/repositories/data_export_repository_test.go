@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewDataExportRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+
+		export, err := repo.Create(ctx, userID, "zip", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.NotEmpty(t, export.ID)
+		assert.Equal(t, userID, export.UserID)
+		assert.Equal(t, "zip", export.Format)
+		assert.Nil(t, export.Archive)
+		assert.Nil(t, export.CompletedAt)
+	})
+
+	t.Run("GetByID_NotFound", func(t *testing.T) {
+		export, err := repo.GetByID(ctx, uuid.New())
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, export)
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		export, err := repo.Create(ctx, createUser(t), "json", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Microsecond)
+		require.NoError(t, repo.Complete(ctx, export.ID, []byte(`{"profile":{}}`), expiresAt))
+
+		found, err := repo.GetByID(ctx, export.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`{"profile":{}}`), found.Archive)
+		assert.NotNil(t, found.CompletedAt)
+		assert.True(t, expiresAt.Equal(found.ExpiresAt))
+
+		err = repo.Complete(ctx, export.ID, []byte(`{}`), expiresAt)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("CountForUserSince", func(t *testing.T) {
+		userID := createUser(t)
+		since := time.Now().Add(-time.Minute)
+
+		count, err := repo.CountForUserSince(ctx, userID, since)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		_, err = repo.Create(ctx, userID, "zip", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, createUser(t), "zip", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		count, err = repo.CountForUserSince(ctx, userID, since)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = repo.CountForUserSince(ctx, userID, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		userID := createUser(t)
+		expired, err := repo.Create(ctx, userID, "zip", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		valid, err := repo.Create(ctx, userID, "zip", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteExpired(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		_, err = repo.GetByID(ctx, expired.ID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByID(ctx, valid.ID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type EmailChangeRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewEmailChangeRepository(pool *pgxpool.Pool) *EmailChangeRepository {
+	return &EmailChangeRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *EmailChangeRepository) WithTx(tx pgx.Tx) repositories.EmailChangeRepository {
+	return &EmailChangeRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *EmailChangeRepository) Create(ctx context.Context, userID uuid.UUID, oldEmail, newEmail, tokenHash, revertTokenHash string, expiresAt, revertExpiresAt time.Time) (*sqlcgen.EmailChange, error) {
+	change := sqlcgen.EmailChange{
+		ID:              uuid.New(),
+		UserID:          userID,
+		OldEmail:        oldEmail,
+		NewEmail:        newEmail,
+		TokenHash:       tokenHash,
+		RevertTokenHash: revertTokenHash,
+		ExpiresAt:       expiresAt,
+		RevertExpiresAt: revertExpiresAt,
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateEmailChange(ctx, sqlcgen.CreateEmailChangeParams{
+		ID:              change.ID,
+		UserID:          change.UserID,
+		OldEmail:        change.OldEmail,
+		NewEmail:        change.NewEmail,
+		TokenHash:       change.TokenHash,
+		RevertTokenHash: change.RevertTokenHash,
+		ExpiresAt:       change.ExpiresAt,
+		RevertExpiresAt: change.RevertExpiresAt,
+		CreatedAt:       change.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create email change")
+	}
+
+	return &change, nil
+}
+
+func (r *EmailChangeRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.EmailChange, error) {
+	change, err := r.queries.GetEmailChangeByTokenHash(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get email change by token hash")
+	}
+
+	return &change, nil
+}
+
+func (r *EmailChangeRepository) GetByRevertTokenHash(ctx context.Context, revertTokenHashes []string) (*sqlcgen.EmailChange, error) {
+	change, err := r.queries.GetEmailChangeByRevertTokenHash(ctx, revertTokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get email change by revert token hash")
+	}
+
+	return &change, nil
+}
+
+func (r *EmailChangeRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.EmailChange, error) {
+	changes, err := r.queries.ListEmailChangesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list email changes for user")
+	}
+	return changes, nil
+}
+
+func (r *EmailChangeRepository) MarkConfirmed(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	rows, err := r.queries.MarkEmailChangeConfirmed(ctx, sqlcgen.MarkEmailChangeConfirmedParams{
+		ConfirmedAt: &now,
+		ID:          id,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to mark email change as confirmed")
+	}
+	if rows == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no pending email change")
+	}
+	return nil
+}
+
+func (r *EmailChangeRepository) MarkReverted(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	rows, err := r.queries.MarkEmailChangeReverted(ctx, sqlcgen.MarkEmailChangeRevertedParams{
+		RevertedAt: &now,
+		ID:         id,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to mark email change as reverted")
+	}
+	if rows == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no unreverted email change")
+	}
+	return nil
+}
+
+func (r *EmailChangeRepository) DeletePendingForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeletePendingEmailChangesForUser(ctx, userID); err != nil {
+		return eris.Wrap(err, "failed to delete pending email changes for user")
+	}
+	return nil
+}
+
+func (r *EmailChangeRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredEmailChanges(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired email changes")
+	}
+	return deleted, nil
+}
+
+var _ repositories.EmailChangeRepository = (*EmailChangeRepository)(nil)
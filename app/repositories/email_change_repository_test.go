@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailChangeRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewEmailChangeRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	create := func(t *testing.T, userID uuid.UUID, tokenHash string, revertExpiresAt time.Time) uuid.UUID {
+		change, err := repo.Create(ctx, userID, "old@example.com", "new@example.com", tokenHash, "revert-"+tokenHash, time.Now().Add(time.Hour), revertExpiresAt)
+		require.NoError(t, err)
+		return change.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+
+		change, err := repo.Create(ctx, userID, "old@example.com", "new@example.com", "changehash", "reverthash", time.Now().Add(time.Hour), time.Now().Add(24*time.Hour))
+		require.NoError(t, err)
+		assert.NotEmpty(t, change.ID)
+		assert.Equal(t, userID, change.UserID)
+		assert.Equal(t, "old@example.com", change.OldEmail)
+		assert.Equal(t, "new@example.com", change.NewEmail)
+		assert.Nil(t, change.ConfirmedAt)
+		assert.Nil(t, change.RevertedAt)
+	})
+
+	t.Run("GetByTokenHash", func(t *testing.T) {
+		id := create(t, createUser(t), "findchangehash", time.Now().Add(24*time.Hour))
+
+		found, err := repo.GetByTokenHash(ctx, []string{"findchangehash"})
+		require.NoError(t, err)
+		assert.Equal(t, id, found.ID)
+
+		found, err = repo.GetByRevertTokenHash(ctx, []string{"revert-findchangehash"})
+		require.NoError(t, err)
+		assert.Equal(t, id, found.ID)
+	})
+
+	t.Run("ListForUser", func(t *testing.T) {
+		userID := createUser(t)
+		first := create(t, userID, "listchangehash1", time.Now().Add(24*time.Hour))
+		second := create(t, userID, "listchangehash2", time.Now().Add(24*time.Hour))
+		require.NoError(t, repo.MarkConfirmed(ctx, first))
+		create(t, createUser(t), "listchangehash3", time.Now().Add(24*time.Hour))
+
+		changes, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, first, changes[0].ID)
+		assert.NotNil(t, changes[0].ConfirmedAt)
+		assert.Equal(t, second, changes[1].ID)
+	})
+
+	t.Run("GetByTokenHash_NotFound", func(t *testing.T) {
+		change, err := repo.GetByTokenHash(ctx, []string{"nonexistentchangehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, change)
+
+		_, err = repo.GetByRevertTokenHash(ctx, []string{"nonexistentchangehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("MarkConfirmed", func(t *testing.T) {
+		id := create(t, createUser(t), "confirmchangehash", time.Now().Add(24*time.Hour))
+
+		require.NoError(t, repo.MarkConfirmed(ctx, id))
+
+		found, err := repo.GetByTokenHash(ctx, []string{"confirmchangehash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.ConfirmedAt)
+
+		err = repo.MarkConfirmed(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("MarkReverted", func(t *testing.T) {
+		id := create(t, createUser(t), "revertchangehash", time.Now().Add(24*time.Hour))
+
+		require.NoError(t, repo.MarkReverted(ctx, id))
+
+		found, err := repo.GetByRevertTokenHash(ctx, []string{"revert-revertchangehash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.RevertedAt)
+
+		err = repo.MarkReverted(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		// A reverted change can no longer be confirmed
+		err = repo.MarkConfirmed(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("DeletePendingForUser", func(t *testing.T) {
+		userID := createUser(t)
+		create(t, userID, "pendingchangehash", time.Now().Add(24*time.Hour))
+		confirmed := create(t, userID, "confirmedchangehash", time.Now().Add(24*time.Hour))
+		require.NoError(t, repo.MarkConfirmed(ctx, confirmed))
+
+		require.NoError(t, repo.DeletePendingForUser(ctx, userID))
+
+		_, err := repo.GetByTokenHash(ctx, []string{"pendingchangehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByTokenHash(ctx, []string{"confirmedchangehash"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		userID := createUser(t)
+		create(t, userID, "expiredchangehash", time.Now().Add(-time.Hour))
+		create(t, userID, "validchangehash", time.Now().Add(24*time.Hour))
+
+		deleted, err := repo.DeleteExpired(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		_, err = repo.GetByTokenHash(ctx, []string{"expiredchangehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByTokenHash(ctx, []string{"validchangehash"})
+		assert.NoError(t, err)
+	})
+}
@@ -51,8 +51,8 @@ func (r *EmailVerificationRepository) Create(ctx context.Context, userID uuid.UU
 	return &verification, nil
 }
 
-func (r *EmailVerificationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*sqlcgen.EmailVerification, error) {
-	verification, err := r.queries.GetEmailVerificationByTokenHash(ctx, tokenHash)
+func (r *EmailVerificationRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.EmailVerification, error) {
+	verification, err := r.queries.GetEmailVerificationByTokenHash(ctx, tokenHashes)
 	if err != nil {
 		return nil, eris.Wrap(err, "failed to get email verification by token hash")
 	}
@@ -43,14 +43,24 @@ func TestEmailVerificationRepository(t *testing.T) {
 		created, err := repo.Create(ctx, userID, "findverifyhash", expiresAt)
 		require.NoError(t, err)
 
-		found, err := repo.GetByTokenHash(ctx, "findverifyhash")
+		found, err := repo.GetByTokenHash(ctx, []string{"findverifyhash"})
 		require.NoError(t, err)
 		assert.Equal(t, created.ID, found.ID)
 		assert.Equal(t, created.TokenHash, found.TokenHash)
 	})
 
+	t.Run("GetByTokenHash_OlderHash", func(t *testing.T) {
+		userID := createUser(t)
+		created, err := repo.Create(ctx, userID, "olderverifyhash", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHash(ctx, []string{"currentverifyhash", "olderverifyhash"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+	})
+
 	t.Run("GetByTokenHash_NotFound", func(t *testing.T) {
-		verification, err := repo.GetByTokenHash(ctx, "nonexistenthash")
+		verification, err := repo.GetByTokenHash(ctx, []string{"nonexistenthash"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
 		assert.Nil(t, verification)
 	})
@@ -64,7 +74,7 @@ func TestEmailVerificationRepository(t *testing.T) {
 		err = repo.MarkUsed(ctx, verification.ID)
 		require.NoError(t, err)
 
-		found, err := repo.GetByTokenHash(ctx, "markusedhash")
+		found, err := repo.GetByTokenHash(ctx, []string{"markusedhash"})
 		require.NoError(t, err)
 		assert.NotNil(t, found.UsedAt)
 	})
@@ -80,8 +90,8 @@ func TestEmailVerificationRepository(t *testing.T) {
 		err = repo.InvalidateAllForUser(ctx, userID)
 		require.NoError(t, err)
 
-		v1, _ := repo.GetByTokenHash(ctx, "invalidate1")
-		v2, _ := repo.GetByTokenHash(ctx, "invalidate2")
+		v1, _ := repo.GetByTokenHash(ctx, []string{"invalidate1"})
+		v2, _ := repo.GetByTokenHash(ctx, []string{"invalidate2"})
 		assert.NotNil(t, v1.UsedAt)
 		assert.NotNil(t, v2.UsedAt)
 	})
@@ -108,13 +118,13 @@ func TestEmailVerificationRepository(t *testing.T) {
 		assert.GreaterOrEqual(t, deleted, int64(2))
 
 		// Valid verification should still exist
-		_, err = repo.GetByTokenHash(ctx, "validverify")
+		_, err = repo.GetByTokenHash(ctx, []string{"validverify"})
 		assert.NoError(t, err)
 
 		// Expired and used should be deleted
-		_, err = repo.GetByTokenHash(ctx, "expiredverify")
+		_, err = repo.GetByTokenHash(ctx, []string{"expiredverify"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
-		_, err = repo.GetByTokenHash(ctx, "usedverify")
+		_, err = repo.GetByTokenHash(ctx, []string{"usedverify"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
 	})
 }
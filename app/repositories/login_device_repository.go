@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type LoginDeviceRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewLoginDeviceRepository(pool *pgxpool.Pool) *LoginDeviceRepository {
+	return &LoginDeviceRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *LoginDeviceRepository) WithTx(tx pgx.Tx) repositories.LoginDeviceRepository {
+	return &LoginDeviceRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *LoginDeviceRepository) Create(ctx context.Context, userID uuid.UUID, fingerprint string, candidates []string) (bool, error) {
+	rows, err := r.queries.CreateLoginDevice(ctx, sqlcgen.CreateLoginDeviceParams{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Fingerprint:  fingerprint,
+		CreatedAt:    time.Now().UTC(),
+		Fingerprints: candidates,
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to create login device")
+	}
+	return rows > 0, nil
+}
+
+func (r *LoginDeviceRepository) ExistsForUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	exists, err := r.queries.LoginDeviceExistsForUser(ctx, userID)
+	if err != nil {
+		return false, eris.Wrap(err, "failed to check login devices")
+	}
+	return exists, nil
+}
+
+func (r *LoginDeviceRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.LoginDevice, error) {
+	devices, err := r.queries.ListLoginDevicesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list login devices for user")
+	}
+	return devices, nil
+}
+
+var _ repositories.LoginDeviceRepository = (*LoginDeviceRepository)(nil)
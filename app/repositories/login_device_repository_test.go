@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginDeviceRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewLoginDeviceRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create_FirstSeen", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+
+	t.Run("Create_AlreadySeen", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+
+		created, err := repo.Create(ctx, userID, "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("Create_SeenUnderOlderHash", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "olderfingerprint", []string{"olderfingerprint"})
+		require.NoError(t, err)
+
+		created, err := repo.Create(ctx, userID, "currentfingerprint", []string{"currentfingerprint", "olderfingerprint"})
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		devices, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, devices, 1)
+	})
+
+	t.Run("Create_ScopedToUser", func(t *testing.T) {
+		_, err := repo.Create(ctx, createUser(t), "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+
+		created, err := repo.Create(ctx, createUser(t), "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+
+	t.Run("ExistsForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		exists, err := repo.ExistsForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = repo.Create(ctx, userID, "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+
+		exists, err = repo.ExistsForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+	t.Run("ListForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		devices, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, devices)
+
+		_, err = repo.Create(ctx, userID, "fingerprint", []string{"fingerprint"})
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, createUser(t), "other", []string{"other"})
+		require.NoError(t, err)
+
+		devices, err = repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, devices, 1)
+		assert.Equal(t, "fingerprint", devices[0].Fingerprint)
+	})
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type MagicLinkRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewMagicLinkRepository(pool *pgxpool.Pool) *MagicLinkRepository {
+	return &MagicLinkRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *MagicLinkRepository) WithTx(tx pgx.Tx) repositories.MagicLinkRepository {
+	return &MagicLinkRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *MagicLinkRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.MagicLink, error) {
+	link := sqlcgen.MagicLink{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateMagicLink(ctx, sqlcgen.CreateMagicLinkParams{
+		ID:        link.ID,
+		UserID:    link.UserID,
+		TokenHash: link.TokenHash,
+		ExpiresAt: link.ExpiresAt,
+		CreatedAt: link.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create magic link")
+	}
+
+	return &link, nil
+}
+
+func (r *MagicLinkRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.MagicLink, error) {
+	link, err := r.queries.GetMagicLinkByTokenHash(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get magic link by token hash")
+	}
+
+	return &link, nil
+}
+
+func (r *MagicLinkRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	rows, err := r.queries.MarkMagicLinkUsed(ctx, sqlcgen.MarkMagicLinkUsedParams{
+		UsedAt: &now,
+		ID:     id,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to mark magic link as used")
+	}
+	if rows == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no unused magic link")
+	}
+	return nil
+}
+
+func (r *MagicLinkRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.InvalidateAllMagicLinksForUser(ctx, sqlcgen.InvalidateAllMagicLinksForUserParams{
+		UsedAt: &now,
+		UserID: userID,
+	}); err != nil {
+		return eris.Wrap(err, "failed to invalidate all magic links for user")
+	}
+	return nil
+}
+
+func (r *MagicLinkRepository) DeleteExpiredOrUsed(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredOrUsedMagicLinks(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired or used magic links")
+	}
+	return deleted, nil
+}
+
+var _ repositories.MagicLinkRepository = (*MagicLinkRepository)(nil)
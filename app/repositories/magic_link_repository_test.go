@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicLinkRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewMagicLinkRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+		expiresAt := time.Now().Add(15 * time.Minute)
+
+		link, err := repo.Create(ctx, userID, "magichash123", expiresAt)
+		require.NoError(t, err)
+		assert.NotEmpty(t, link.ID)
+		assert.Equal(t, userID, link.UserID)
+		assert.Equal(t, "magichash123", link.TokenHash)
+		assert.Nil(t, link.UsedAt)
+		assert.NotZero(t, link.CreatedAt)
+	})
+
+	t.Run("GetByTokenHash", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, "findmagichash", time.Now().Add(15*time.Minute))
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHash(ctx, []string{"findmagichash"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, created.TokenHash, found.TokenHash)
+	})
+
+	t.Run("GetByTokenHash_OlderHash", func(t *testing.T) {
+		userID := createUser(t)
+		created, err := repo.Create(ctx, userID, "oldermagichash", time.Now().Add(15*time.Minute))
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHash(ctx, []string{"currentmagichash", "oldermagichash"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+	})
+
+	t.Run("GetByTokenHash_NotFound", func(t *testing.T) {
+		link, err := repo.GetByTokenHash(ctx, []string{"nonexistentmagichash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, link)
+	})
+
+	t.Run("MarkUsed", func(t *testing.T) {
+		userID := createUser(t)
+		link, err := repo.Create(ctx, userID, "markusedmagichash", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		err = repo.MarkUsed(ctx, link.ID)
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHash(ctx, []string{"markusedmagichash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.UsedAt)
+	})
+
+	t.Run("MarkUsed_AlreadyUsed", func(t *testing.T) {
+		userID := createUser(t)
+		link, err := repo.Create(ctx, userID, "doubleusemagichash", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.MarkUsed(ctx, link.ID))
+
+		err = repo.MarkUsed(ctx, link.ID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("InvalidateAllForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "invalidatemagic1", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, "invalidatemagic2", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		err = repo.InvalidateAllForUser(ctx, userID)
+		require.NoError(t, err)
+
+		l1, _ := repo.GetByTokenHash(ctx, []string{"invalidatemagic1"})
+		l2, _ := repo.GetByTokenHash(ctx, []string{"invalidatemagic2"})
+		assert.NotNil(t, l1.UsedAt)
+		assert.NotNil(t, l2.UsedAt)
+	})
+
+	t.Run("DeleteExpiredOrUsed", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "expiredmagic", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		used, err := repo.Create(ctx, userID, "usedmagic", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, repo.MarkUsed(ctx, used.ID))
+
+		_, err = repo.Create(ctx, userID, "validmagic", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteExpiredOrUsed(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(2))
+
+		_, err = repo.GetByTokenHash(ctx, []string{"validmagic"})
+		assert.NoError(t, err)
+
+		_, err = repo.GetByTokenHash(ctx, []string{"expiredmagic"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByTokenHash(ctx, []string{"usedmagic"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
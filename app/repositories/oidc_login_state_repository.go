@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type OIDCLoginStateRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewOIDCLoginStateRepository(pool *pgxpool.Pool) *OIDCLoginStateRepository {
+	return &OIDCLoginStateRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *OIDCLoginStateRepository) WithTx(tx pgx.Tx) repositories.OIDCLoginStateRepository {
+	return &OIDCLoginStateRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *OIDCLoginStateRepository) Create(ctx context.Context, provider, stateHash, codeVerifier, nonce string, expiresAt time.Time) (*sqlcgen.OidcLoginState, error) {
+	state := sqlcgen.OidcLoginState{
+		ID:           uuid.New(),
+		Provider:     provider,
+		StateHash:    stateHash,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateOIDCLoginState(ctx, sqlcgen.CreateOIDCLoginStateParams{
+		ID:           state.ID,
+		Provider:     state.Provider,
+		StateHash:    state.StateHash,
+		CodeVerifier: state.CodeVerifier,
+		Nonce:        state.Nonce,
+		ExpiresAt:    state.ExpiresAt,
+		CreatedAt:    state.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create oidc login state")
+	}
+
+	return &state, nil
+}
+
+func (r *OIDCLoginStateRepository) Consume(ctx context.Context, stateHash, provider string) (*sqlcgen.OidcLoginState, error) {
+	state, err := r.queries.ConsumeOIDCLoginState(ctx, sqlcgen.ConsumeOIDCLoginStateParams{
+		StateHash: stateHash,
+		Provider:  provider,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to consume oidc login state")
+	}
+
+	return &state, nil
+}
+
+func (r *OIDCLoginStateRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredOIDCLoginStates(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired oidc login states")
+	}
+	return deleted, nil
+}
+
+var _ repositories.OIDCLoginStateRepository = (*OIDCLoginStateRepository)(nil)
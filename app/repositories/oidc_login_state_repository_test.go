@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCLoginStateRepository(t *testing.T) {
+	tx := setupTest(t)
+	repo := NewOIDCLoginStateRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		state, err := repo.Create(ctx, "google", "oidcstatehash1", "verifier", "nonce", time.Now().Add(10*time.Minute))
+		require.NoError(t, err)
+		assert.NotEmpty(t, state.ID)
+		assert.Equal(t, "google", state.Provider)
+	})
+
+	t.Run("Consume", func(t *testing.T) {
+		_, err := repo.Create(ctx, "google", "oidcstatehash2", "verifier", "nonce", time.Now().Add(10*time.Minute))
+		require.NoError(t, err)
+
+		state, err := repo.Consume(ctx, "oidcstatehash2", "google")
+		require.NoError(t, err)
+		assert.Equal(t, "verifier", state.CodeVerifier)
+		assert.Equal(t, "nonce", state.Nonce)
+
+		_, err = repo.Consume(ctx, "oidcstatehash2", "google")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Consume_WrongProvider", func(t *testing.T) {
+		_, err := repo.Create(ctx, "google", "oidcstatehash3", "verifier", "nonce", time.Now().Add(10*time.Minute))
+		require.NoError(t, err)
+
+		_, err = repo.Consume(ctx, "oidcstatehash3", "github")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		_, err = repo.Consume(ctx, "oidcstatehash3", "google")
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		_, err := repo.Create(ctx, "google", "oidcexpired", "verifier", "nonce", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "google", "oidcvalid", "verifier", "nonce", time.Now().Add(10*time.Minute))
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteExpired(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		_, err = repo.Consume(ctx, "oidcexpired", "google")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.Consume(ctx, "oidcvalid", "google")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type OrganizationInvitationRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewOrganizationInvitationRepository(pool *pgxpool.Pool) *OrganizationInvitationRepository {
+	return &OrganizationInvitationRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *OrganizationInvitationRepository) WithTx(tx pgx.Tx) repositories.OrganizationInvitationRepository {
+	return &OrganizationInvitationRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *OrganizationInvitationRepository) Create(ctx context.Context, organizationID uuid.UUID, email, role, tokenHash string, invitedBy uuid.UUID, expiresAt time.Time) (*sqlcgen.OrganizationInvitation, error) {
+	invitation := sqlcgen.OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      tokenHash,
+		InvitedBy:      &invitedBy,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateOrganizationInvitation(ctx, sqlcgen.CreateOrganizationInvitationParams{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		TokenHash:      invitation.TokenHash,
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+		CreatedAt:      invitation.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create organization invitation")
+	}
+
+	return &invitation, nil
+}
+
+func (r *OrganizationInvitationRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.OrganizationInvitation, error) {
+	invitation, err := r.queries.GetOrganizationInvitationByTokenHash(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get organization invitation by token hash")
+	}
+
+	return &invitation, nil
+}
+
+func (r *OrganizationInvitationRepository) ListPending(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.OrganizationInvitation, error) {
+	invitations, err := r.queries.ListPendingOrganizationInvitations(ctx, sqlcgen.ListPendingOrganizationInvitationsParams{
+		OrganizationID: organizationID,
+		ExpiresAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list pending organization invitations")
+	}
+	return invitations, nil
+}
+
+func (r *OrganizationInvitationRepository) MarkAccepted(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	rows, err := r.queries.MarkOrganizationInvitationAccepted(ctx, sqlcgen.MarkOrganizationInvitationAcceptedParams{
+		AcceptedAt: &now,
+		ID:         id,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to mark organization invitation as accepted")
+	}
+	if rows == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no pending organization invitation")
+	}
+	return nil
+}
+
+func (r *OrganizationInvitationRepository) MarkDeclined(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	rows, err := r.queries.MarkOrganizationInvitationDeclined(ctx, sqlcgen.MarkOrganizationInvitationDeclinedParams{
+		DeclinedAt: &now,
+		ID:         id,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to mark organization invitation as declined")
+	}
+	if rows == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no pending organization invitation")
+	}
+	return nil
+}
+
+func (r *OrganizationInvitationRepository) DeletePending(ctx context.Context, organizationID, id uuid.UUID) error {
+	deleted, err := r.queries.DeletePendingOrganizationInvitation(ctx, sqlcgen.DeletePendingOrganizationInvitationParams{
+		ID:             id,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to delete organization invitation")
+	}
+	if deleted == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no pending organization invitation")
+	}
+	return nil
+}
+
+func (r *OrganizationInvitationRepository) DeletePendingForEmail(ctx context.Context, organizationID uuid.UUID, email string) error {
+	if err := r.queries.DeletePendingOrganizationInvitationsForEmail(ctx, sqlcgen.DeletePendingOrganizationInvitationsForEmailParams{
+		OrganizationID: organizationID,
+		Email:          email,
+	}); err != nil {
+		return eris.Wrap(err, "failed to delete pending organization invitations for email")
+	}
+	return nil
+}
+
+func (r *OrganizationInvitationRepository) DeleteExpiredOrUsed(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredOrUsedOrganizationInvitations(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired or used organization invitations")
+	}
+	return deleted, nil
+}
+
+var _ repositories.OrganizationInvitationRepository = (*OrganizationInvitationRepository)(nil)
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationInvitationRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	orgRepo := NewOrganizationRepository(testPool).WithTx(tx)
+	repo := NewOrganizationInvitationRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	user, err := userRepo.Create(ctx, "Inviter", "inviter@example.com", "hash")
+	require.NoError(t, err)
+
+	createOrganization := func(t *testing.T) uuid.UUID {
+		organization, err := orgRepo.Create(ctx, "Acme")
+		require.NoError(t, err)
+		return organization.ID
+	}
+
+	create := func(t *testing.T, organizationID uuid.UUID, email, tokenHash string, expiresAt time.Time) uuid.UUID {
+		invitation, err := repo.Create(ctx, organizationID, email, "member", tokenHash, user.ID, expiresAt)
+		require.NoError(t, err)
+		return invitation.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		organizationID := createOrganization(t)
+
+		invitation, err := repo.Create(ctx, organizationID, "new@example.com", "admin", "createinvitehash", user.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.NotEmpty(t, invitation.ID)
+		assert.Equal(t, organizationID, invitation.OrganizationID)
+		assert.Equal(t, "admin", invitation.Role)
+		require.NotNil(t, invitation.InvitedBy)
+		assert.Equal(t, user.ID, *invitation.InvitedBy)
+		assert.Nil(t, invitation.AcceptedAt)
+		assert.Nil(t, invitation.DeclinedAt)
+	})
+
+	t.Run("GetByTokenHash", func(t *testing.T) {
+		id := create(t, createOrganization(t), "find@example.com", "findinvitehash", time.Now().Add(time.Hour))
+
+		found, err := repo.GetByTokenHash(ctx, []string{"findinvitehash"})
+		require.NoError(t, err)
+		assert.Equal(t, id, found.ID)
+		assert.Equal(t, "find@example.com", found.Email)
+	})
+
+	t.Run("GetByTokenHash_NotFound", func(t *testing.T) {
+		invitation, err := repo.GetByTokenHash(ctx, []string{"nonexistentinvitehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, invitation)
+	})
+
+	t.Run("ListPending", func(t *testing.T) {
+		organizationID := createOrganization(t)
+		pendingID := create(t, organizationID, "pending@example.com", "pendinginvitehash", time.Now().Add(time.Hour))
+		create(t, organizationID, "expired@example.com", "expiredlistinvitehash", time.Now().Add(-time.Hour))
+		acceptedID := create(t, organizationID, "accepted@example.com", "acceptedlistinvitehash", time.Now().Add(time.Hour))
+		require.NoError(t, repo.MarkAccepted(ctx, acceptedID))
+		create(t, createOrganization(t), "other@example.com", "otherorginvitehash", time.Now().Add(time.Hour))
+
+		invitations, err := repo.ListPending(ctx, organizationID)
+		require.NoError(t, err)
+		require.Len(t, invitations, 1)
+		assert.Equal(t, pendingID, invitations[0].ID)
+	})
+
+	t.Run("MarkAccepted", func(t *testing.T) {
+		id := create(t, createOrganization(t), "accept@example.com", "acceptinvitehash", time.Now().Add(time.Hour))
+
+		require.NoError(t, repo.MarkAccepted(ctx, id))
+
+		found, err := repo.GetByTokenHash(ctx, []string{"acceptinvitehash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.AcceptedAt)
+
+		err = repo.MarkAccepted(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		err = repo.MarkDeclined(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("MarkDeclined", func(t *testing.T) {
+		id := create(t, createOrganization(t), "decline@example.com", "declineinvitehash", time.Now().Add(time.Hour))
+
+		require.NoError(t, repo.MarkDeclined(ctx, id))
+
+		found, err := repo.GetByTokenHash(ctx, []string{"declineinvitehash"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.DeclinedAt)
+
+		err = repo.MarkAccepted(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("DeletePending", func(t *testing.T) {
+		organizationID := createOrganization(t)
+		id := create(t, organizationID, "revoke@example.com", "revokeinvitehash", time.Now().Add(time.Hour))
+
+		err := repo.DeletePending(ctx, createOrganization(t), id)
+		require.ErrorIs(t, err, pgx.ErrNoRows, "invitations are scoped to their organization")
+
+		require.NoError(t, repo.DeletePending(ctx, organizationID, id))
+
+		_, err = repo.GetByTokenHash(ctx, []string{"revokeinvitehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("DeletePendingForEmail", func(t *testing.T) {
+		organizationID := createOrganization(t)
+		create(t, organizationID, "again@example.com", "againinvitehash", time.Now().Add(time.Hour))
+
+		require.NoError(t, repo.DeletePendingForEmail(ctx, organizationID, "again@example.com"))
+
+		_, err := repo.GetByTokenHash(ctx, []string{"againinvitehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("DeleteExpiredOrUsed", func(t *testing.T) {
+		organizationID := createOrganization(t)
+		create(t, organizationID, "stale@example.com", "staleinvitehash", time.Now().Add(-time.Hour))
+		declinedID := create(t, organizationID, "used@example.com", "usedinvitehash", time.Now().Add(time.Hour))
+		require.NoError(t, repo.MarkDeclined(ctx, declinedID))
+		create(t, organizationID, "fresh@example.com", "freshinvitehash", time.Now().Add(time.Hour))
+
+		deleted, err := repo.DeleteExpiredOrUsed(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(2))
+
+		_, err = repo.GetByTokenHash(ctx, []string{"staleinvitehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByTokenHash(ctx, []string{"usedinvitehash"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByTokenHash(ctx, []string{"freshinvitehash"})
+		require.NoError(t, err)
+	})
+}
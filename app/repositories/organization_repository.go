@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type OrganizationRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewOrganizationRepository(pool *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *OrganizationRepository) WithTx(tx pgx.Tx) repositories.OrganizationRepository {
+	return &OrganizationRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, name string) (*sqlcgen.Organization, error) {
+	now := time.Now().UTC()
+	organization := sqlcgen.Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := r.queries.CreateOrganization(ctx, sqlcgen.CreateOrganizationParams{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create organization")
+	}
+
+	return &organization, nil
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*sqlcgen.Organization, error) {
+	organization, err := r.queries.GetOrganizationByID(ctx, id)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get organization by id")
+	}
+
+	return &organization, nil
+}
+
+func (r *OrganizationRepository) Rename(ctx context.Context, id uuid.UUID, name string) (*sqlcgen.Organization, error) {
+	organization, err := r.queries.RenameOrganization(ctx, sqlcgen.RenameOrganizationParams{
+		Name:      name,
+		UpdatedAt: time.Now().UTC(),
+		ID:        id,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to rename organization")
+	}
+
+	return &organization, nil
+}
+
+func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteOrganization(ctx, id)
+	if err != nil {
+		return eris.Wrap(err, "failed to delete organization")
+	}
+	if deleted == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "organization not found")
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListOrganizationsForUserRow, error) {
+	organizations, err := r.queries.ListOrganizationsForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list organizations for user")
+	}
+	return organizations, nil
+}
+
+func (r *OrganizationRepository) AddMember(ctx context.Context, organizationID, userID uuid.UUID, role string) (bool, error) {
+	added, err := r.queries.AddOrganizationMember(ctx, sqlcgen.AddOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to add organization member")
+	}
+	return added > 0, nil
+}
+
+func (r *OrganizationRepository) GetMemberRole(ctx context.Context, organizationID, userID uuid.UUID) (string, error) {
+	role, err := r.queries.GetOrganizationMemberRole(ctx, sqlcgen.GetOrganizationMemberRoleParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return "", eris.Wrap(err, "failed to get organization member role")
+	}
+	return role, nil
+}
+
+func (r *OrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]sqlcgen.ListOrganizationMembersRow, error) {
+	members, err := r.queries.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list organization members")
+	}
+	return members, nil
+}
+
+func (r *OrganizationRepository) MemberEmailExists(ctx context.Context, organizationID uuid.UUID, email string) (bool, error) {
+	exists, err := r.queries.OrganizationMemberEmailExists(ctx, sqlcgen.OrganizationMemberEmailExistsParams{
+		OrganizationID: organizationID,
+		Email:          email,
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to check organization member email")
+	}
+	return exists, nil
+}
+
+func (r *OrganizationRepository) OwnsAny(ctx context.Context, userID uuid.UUID) (bool, error) {
+	owns, err := r.queries.UserOwnsOrganization(ctx, userID)
+	if err != nil {
+		return false, eris.Wrap(err, "failed to check organization ownership")
+	}
+	return owns, nil
+}
+
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID uuid.UUID, role string) error {
+	updated, err := r.queries.UpdateOrganizationMemberRole(ctx, sqlcgen.UpdateOrganizationMemberRoleParams{
+		Role:           role,
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to update organization member role")
+	}
+	if updated == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "organization member not found")
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	removed, err := r.queries.DeleteOrganizationMember(ctx, sqlcgen.DeleteOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to remove organization member")
+	}
+	if removed == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "organization member not found")
+	}
+	return nil
+}
+
+var _ repositories.OrganizationRepository = (*OrganizationRepository)(nil)
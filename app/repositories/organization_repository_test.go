@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewOrganizationRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) (uuid.UUID, string) {
+		email := uuid.NewString() + "@example.com"
+		user, err := userRepo.Create(ctx, "Test User", email, "hash")
+		require.NoError(t, err)
+		return user.ID, email
+	}
+
+	createOrganization := func(t *testing.T, name string) uuid.UUID {
+		organization, err := repo.Create(ctx, name)
+		require.NoError(t, err)
+		return organization.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		organization, err := repo.Create(ctx, "Acme")
+		require.NoError(t, err)
+		assert.NotEmpty(t, organization.ID)
+		assert.Equal(t, "Acme", organization.Name)
+
+		found, err := repo.GetByID(ctx, organization.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Acme", found.Name)
+	})
+
+	t.Run("GetByID_NotFound", func(t *testing.T) {
+		organization, err := repo.GetByID(ctx, uuid.New())
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, organization)
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		id := createOrganization(t, "Before")
+
+		organization, err := repo.Rename(ctx, id, "After")
+		require.NoError(t, err)
+		assert.Equal(t, "After", organization.Name)
+
+		_, err = repo.Rename(ctx, uuid.New(), "Missing")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		userID, _ := createUser(t)
+		id := createOrganization(t, "Doomed")
+		_, err := repo.AddMember(ctx, id, userID, "owner")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, id))
+
+		_, err = repo.GetByID(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetMemberRole(ctx, id, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		err = repo.Delete(ctx, id)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		ownerID, _ := createUser(t)
+		memberID, memberEmail := createUser(t)
+		id := createOrganization(t, "Team")
+
+		added, err := repo.AddMember(ctx, id, ownerID, "owner")
+		require.NoError(t, err)
+		assert.True(t, added)
+		added, err = repo.AddMember(ctx, id, memberID, "member")
+		require.NoError(t, err)
+		assert.True(t, added)
+
+		added, err = repo.AddMember(ctx, id, memberID, "admin")
+		require.NoError(t, err)
+		assert.False(t, added, "adding an existing member is a no-op")
+
+		role, err := repo.GetMemberRole(ctx, id, memberID)
+		require.NoError(t, err)
+		assert.Equal(t, "member", role)
+
+		members, err := repo.ListMembers(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		assert.Equal(t, ownerID, members[0].UserID)
+		assert.Equal(t, memberEmail, members[1].Email)
+
+		exists, err := repo.MemberEmailExists(ctx, id, memberEmail)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = repo.MemberEmailExists(ctx, id, "stranger@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		organizations, err := repo.ListForUser(ctx, memberID)
+		require.NoError(t, err)
+		require.Len(t, organizations, 1)
+		assert.Equal(t, id, organizations[0].ID)
+		assert.Equal(t, "member", organizations[0].Role)
+
+		owns, err := repo.OwnsAny(ctx, ownerID)
+		require.NoError(t, err)
+		assert.True(t, owns)
+		owns, err = repo.OwnsAny(ctx, memberID)
+		require.NoError(t, err)
+		assert.False(t, owns)
+	})
+
+	t.Run("UpdateMemberRole", func(t *testing.T) {
+		userID, _ := createUser(t)
+		id := createOrganization(t, "Promotions")
+		_, err := repo.AddMember(ctx, id, userID, "member")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.UpdateMemberRole(ctx, id, userID, "admin"))
+
+		role, err := repo.GetMemberRole(ctx, id, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "admin", role)
+
+		err = repo.UpdateMemberRole(ctx, id, uuid.New(), "admin")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("UpdateMemberRole_SingleOwner", func(t *testing.T) {
+		ownerID, _ := createUser(t)
+		otherID, _ := createUser(t)
+		id := createOrganization(t, "One Owner")
+		_, err := repo.AddMember(ctx, id, ownerID, "owner")
+		require.NoError(t, err)
+		_, err = repo.AddMember(ctx, id, otherID, "admin")
+		require.NoError(t, err)
+
+		// Use a savepoint so the failed update does not abort the test transaction
+		nested, err := tx.Begin(ctx)
+		require.NoError(t, err)
+		err = repo.WithTx(nested).UpdateMemberRole(ctx, id, otherID, "owner")
+		assert.True(t, db.IsUniqueViolation(err, "uq_organization_members_owner"))
+		require.NoError(t, nested.Rollback(ctx))
+	})
+
+	t.Run("RemoveMember", func(t *testing.T) {
+		userID, _ := createUser(t)
+		id := createOrganization(t, "Leavers")
+		_, err := repo.AddMember(ctx, id, userID, "member")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RemoveMember(ctx, id, userID))
+
+		_, err = repo.GetMemberRole(ctx, id, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		err = repo.RemoveMember(ctx, id, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
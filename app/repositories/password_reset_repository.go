@@ -51,8 +51,8 @@ func (r *PasswordResetRepository) Create(ctx context.Context, userID uuid.UUID,
 	return &reset, nil
 }
 
-func (r *PasswordResetRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*sqlcgen.PasswordReset, error) {
-	reset, err := r.queries.GetPasswordResetByTokenHash(ctx, tokenHash)
+func (r *PasswordResetRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.PasswordReset, error) {
+	reset, err := r.queries.GetPasswordResetByTokenHash(ctx, tokenHashes)
 	if err != nil {
 		return nil, eris.Wrap(err, "failed to get password reset by token hash")
 	}
@@ -43,14 +43,24 @@ func TestPasswordResetRepository(t *testing.T) {
 		created, err := repo.Create(ctx, userID, "findresethash", expiresAt)
 		require.NoError(t, err)
 
-		found, err := repo.GetByTokenHash(ctx, "findresethash")
+		found, err := repo.GetByTokenHash(ctx, []string{"findresethash"})
 		require.NoError(t, err)
 		assert.Equal(t, created.ID, found.ID)
 		assert.Equal(t, created.TokenHash, found.TokenHash)
 	})
 
+	t.Run("GetByTokenHash_OlderHash", func(t *testing.T) {
+		userID := createUser(t)
+		created, err := repo.Create(ctx, userID, "olderresethash", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHash(ctx, []string{"currentresethash", "olderresethash"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+	})
+
 	t.Run("GetByTokenHash_NotFound", func(t *testing.T) {
-		reset, err := repo.GetByTokenHash(ctx, "nonexistenthash")
+		reset, err := repo.GetByTokenHash(ctx, []string{"nonexistenthash"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
 		assert.Nil(t, reset)
 	})
@@ -64,7 +74,7 @@ func TestPasswordResetRepository(t *testing.T) {
 		err = repo.MarkUsed(ctx, reset.ID)
 		require.NoError(t, err)
 
-		found, err := repo.GetByTokenHash(ctx, "markusedresethash")
+		found, err := repo.GetByTokenHash(ctx, []string{"markusedresethash"})
 		require.NoError(t, err)
 		assert.NotNil(t, found.UsedAt)
 	})
@@ -80,8 +90,8 @@ func TestPasswordResetRepository(t *testing.T) {
 		err = repo.InvalidateAllForUser(ctx, userID)
 		require.NoError(t, err)
 
-		r1, _ := repo.GetByTokenHash(ctx, "invalidatereset1")
-		r2, _ := repo.GetByTokenHash(ctx, "invalidatereset2")
+		r1, _ := repo.GetByTokenHash(ctx, []string{"invalidatereset1"})
+		r2, _ := repo.GetByTokenHash(ctx, []string{"invalidatereset2"})
 		assert.NotNil(t, r1.UsedAt)
 		assert.NotNil(t, r2.UsedAt)
 	})
@@ -108,13 +118,13 @@ func TestPasswordResetRepository(t *testing.T) {
 		assert.GreaterOrEqual(t, deleted, int64(2))
 
 		// Valid reset should still exist
-		_, err = repo.GetByTokenHash(ctx, "validreset")
+		_, err = repo.GetByTokenHash(ctx, []string{"validreset"})
 		assert.NoError(t, err)
 
 		// Expired and used should be deleted
-		_, err = repo.GetByTokenHash(ctx, "expiredreset")
+		_, err = repo.GetByTokenHash(ctx, []string{"expiredreset"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
-		_, err = repo.GetByTokenHash(ctx, "usedreset")
+		_, err = repo.GetByTokenHash(ctx, []string{"usedreset"})
 		require.ErrorIs(t, err, pgx.ErrNoRows)
 	})
 }
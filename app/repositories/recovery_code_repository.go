@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type RecoveryCodeRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewRecoveryCodeRepository(pool *pgxpool.Pool) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *RecoveryCodeRepository) WithTx(tx pgx.Tx) repositories.RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *RecoveryCodeRepository) Create(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	if err := r.queries.CreateRecoveryCode(ctx, sqlcgen.CreateRecoveryCodeParams{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CodeHash:  codeHash,
+		CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		return eris.Wrap(err, "failed to create recovery code")
+	}
+	return nil
+}
+
+func (r *RecoveryCodeRepository) Use(ctx context.Context, userID uuid.UUID, codeHashes []string) (bool, error) {
+	now := time.Now().UTC()
+	rows, err := r.queries.UseRecoveryCode(ctx, sqlcgen.UseRecoveryCodeParams{
+		UsedAt:     &now,
+		UserID:     userID,
+		CodeHashes: codeHashes,
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to use recovery code")
+	}
+	return rows > 0, nil
+}
+
+func (r *RecoveryCodeRepository) DeleteAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteRecoveryCodesForUser(ctx, userID); err != nil {
+		return eris.Wrap(err, "failed to delete recovery codes for user")
+	}
+	return nil
+}
+
+var _ repositories.RecoveryCodeRepository = (*RecoveryCodeRepository)(nil)
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryCodeRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewRecoveryCodeRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Use", func(t *testing.T) {
+		userID := createUser(t)
+
+		err := repo.Create(ctx, userID, "codehash1")
+		require.NoError(t, err)
+
+		ok, err := repo.Use(ctx, userID, []string{"codehash1"})
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.Use(ctx, userID, []string{"codehash1"})
+		require.NoError(t, err)
+		assert.False(t, ok, "code must be single-use")
+	})
+
+	t.Run("Use_OtherUser", func(t *testing.T) {
+		userID := createUser(t)
+		otherUserID := createUser(t)
+
+		err := repo.Create(ctx, userID, "codehash2")
+		require.NoError(t, err)
+
+		ok, err := repo.Use(ctx, otherUserID, []string{"codehash2"})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("DeleteAllForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		require.NoError(t, repo.Create(ctx, userID, "codehash3"))
+		require.NoError(t, repo.Create(ctx, userID, "codehash4"))
+
+		err := repo.DeleteAllForUser(ctx, userID)
+		require.NoError(t, err)
+
+		ok, err := repo.Use(ctx, userID, []string{"codehash3"})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type RefreshTokenRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewRefreshTokenRepository(pool *pgxpool.Pool) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *RefreshTokenRepository) WithTx(tx pgx.Tx) repositories.RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, userID, familyID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.RefreshToken, error) {
+	token := sqlcgen.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateRefreshToken(ctx, sqlcgen.CreateRefreshTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create refresh token")
+	}
+
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) GetByHashForUpdate(ctx context.Context, tokenHashes []string) (*sqlcgen.RefreshToken, error) {
+	token, err := r.queries.GetRefreshTokenByHashForUpdate(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get refresh token by hash")
+	}
+
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) UpdateHash(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	if err := r.queries.UpdateRefreshTokenHash(ctx, sqlcgen.UpdateRefreshTokenHashParams{
+		TokenHash: tokenHash,
+		ID:        id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to update refresh token hash")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) MarkRotated(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.MarkRefreshTokenRotated(ctx, sqlcgen.MarkRefreshTokenRotatedParams{
+		RotatedAt: &now,
+		ID:        id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to mark refresh token as rotated")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.RevokeRefreshTokenFamily(ctx, sqlcgen.RevokeRefreshTokenFamilyParams{
+		RevokedAt: &now,
+		FamilyID:  familyID,
+	}); err != nil {
+		return eris.Wrap(err, "failed to revoke refresh token family")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredOrRevoked(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredOrRevokedRefreshTokens(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired or revoked refresh tokens")
+	}
+	return deleted, nil
+}
+
+var _ repositories.RefreshTokenRepository = (*RefreshTokenRepository)(nil)
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	authTokenRepo := NewAuthTokenRepository(testPool).WithTx(tx)
+	repo := NewRefreshTokenRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+		familyID := uuid.New()
+
+		token, err := repo.Create(ctx, userID, familyID, "refreshhash123", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.NotEmpty(t, token.ID)
+		assert.Equal(t, userID, token.UserID)
+		assert.Equal(t, familyID, token.FamilyID)
+		assert.Equal(t, "refreshhash123", token.TokenHash)
+		assert.Nil(t, token.RotatedAt)
+		assert.Nil(t, token.RevokedAt)
+	})
+
+	t.Run("GetByHashForUpdate", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, uuid.New(), "refreshfind", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		found, err := repo.GetByHashForUpdate(ctx, []string{"refreshfind"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+	})
+
+	t.Run("GetByHashForUpdate_NotFound", func(t *testing.T) {
+		token, err := repo.GetByHashForUpdate(ctx, []string{"nonexistentrefresh"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, token)
+	})
+
+	t.Run("UpdateHash", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, uuid.New(), "refreshupdatehash-old", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		err = repo.UpdateHash(ctx, created.ID, "refreshupdatehash-new")
+		require.NoError(t, err)
+
+		found, err := repo.GetByHashForUpdate(ctx, []string{"refreshupdatehash-new"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+
+		_, err = repo.GetByHashForUpdate(ctx, []string{"refreshupdatehash-old"})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("MarkRotated", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, uuid.New(), "refreshrotate", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		err = repo.MarkRotated(ctx, created.ID)
+		require.NoError(t, err)
+
+		found, err := repo.GetByHashForUpdate(ctx, []string{"refreshrotate"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.RotatedAt)
+		assert.Nil(t, found.RevokedAt)
+	})
+
+	t.Run("RevokeFamily", func(t *testing.T) {
+		userID := createUser(t)
+		familyID := uuid.New()
+		otherFamilyID := uuid.New()
+
+		_, err := repo.Create(ctx, userID, familyID, "refreshfamily1", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, familyID, "refreshfamily2", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, otherFamilyID, "refreshotherfamily", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, err = authTokenRepo.Create(ctx, userID, familyID, "accessfamily", time.Now().Add(time.Hour), "test-agent", "127.0.0.1")
+		require.NoError(t, err)
+
+		err = repo.RevokeFamily(ctx, familyID)
+		require.NoError(t, err)
+
+		r1, _ := repo.GetByHashForUpdate(ctx, []string{"refreshfamily1"})
+		r2, _ := repo.GetByHashForUpdate(ctx, []string{"refreshfamily2"})
+		other, _ := repo.GetByHashForUpdate(ctx, []string{"refreshotherfamily"})
+		access, _ := authTokenRepo.GetByHash(ctx, []string{"accessfamily"})
+
+		assert.NotNil(t, r1.RevokedAt)
+		assert.NotNil(t, r2.RevokedAt)
+		assert.Nil(t, other.RevokedAt)
+		assert.NotNil(t, access.RevokedAt)
+	})
+
+	t.Run("DeleteExpiredOrRevoked", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, uuid.New(), "refreshexpired", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		revokedFamily := uuid.New()
+		_, err = repo.Create(ctx, userID, revokedFamily, "refreshrevoked", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		err = repo.RevokeFamily(ctx, revokedFamily)
+		require.NoError(t, err)
+
+		rotated, err := repo.Create(ctx, userID, uuid.New(), "refreshrotatedvalid", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		err = repo.MarkRotated(ctx, rotated.ID)
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteExpiredOrRevoked(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(2))
+
+		// Rotated tokens are kept until they expire to detect reuse
+		_, err = repo.GetByHashForUpdate(ctx, []string{"refreshrotatedvalid"})
+		assert.NoError(t, err)
+
+		_, err = repo.GetByHashForUpdate(ctx, []string{"refreshexpired"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByHashForUpdate(ctx, []string{"refreshrevoked"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
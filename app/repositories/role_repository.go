@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type RoleRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewRoleRepository(pool *pgxpool.Pool) *RoleRepository {
+	return &RoleRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *RoleRepository) WithTx(tx pgx.Tx) repositories.RoleRepository {
+	return &RoleRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *RoleRepository) GetByName(ctx context.Context, name string) (*sqlcgen.Role, error) {
+	role, err := r.queries.GetRoleByName(ctx, name)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get role by name")
+	}
+	return &role, nil
+}
+
+func (r *RoleRepository) ListNamesForUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	names, err := r.queries.ListRoleNamesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list roles for user")
+	}
+	return names, nil
+}
+
+func (r *RoleRepository) ListAssignmentsForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ListRoleAssignmentsForUserRow, error) {
+	assignments, err := r.queries.ListRoleAssignmentsForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list role assignments for user")
+	}
+	return assignments, nil
+}
+
+func (r *RoleRepository) ListPermissionNamesForUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	names, err := r.queries.ListPermissionNamesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list permissions for user")
+	}
+	return names, nil
+}
+
+func (r *RoleRepository) AssignToUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	if err := r.queries.AssignRoleToUser(ctx, sqlcgen.AssignRoleToUserParams{
+		UserID:    userID,
+		RoleID:    roleID,
+		CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		return eris.Wrap(err, "failed to assign role to user")
+	}
+	return nil
+}
+
+func (r *RoleRepository) RemoveFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	removed, err := r.queries.RemoveRoleFromUser(ctx, sqlcgen.RemoveRoleFromUserParams{
+		UserID: userID,
+		RoleID: roleID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to remove role from user")
+	}
+	if removed == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "user does not have role")
+	}
+	return nil
+}
+
+var _ repositories.RoleRepository = (*RoleRepository)(nil)
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewRoleRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("GetByName", func(t *testing.T) {
+		role, err := repo.GetByName(ctx, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, "admin", role.Name)
+		assert.NotEmpty(t, role.ID)
+	})
+
+	t.Run("GetByName_NotFound", func(t *testing.T) {
+		role, err := repo.GetByName(ctx, "missing")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, role)
+	})
+
+	t.Run("AssignToUser", func(t *testing.T) {
+		userID := createUser(t)
+		role, err := repo.GetByName(ctx, "admin")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.AssignToUser(ctx, userID, role.ID))
+		require.NoError(t, repo.AssignToUser(ctx, userID, role.ID), "assigning twice is a no-op")
+
+		roles, err := repo.ListNamesForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, roles)
+
+		assignments, err := repo.ListAssignmentsForUser(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, assignments, 1)
+		assert.Equal(t, "admin", assignments[0].Name)
+		assert.False(t, assignments[0].CreatedAt.IsZero())
+
+		permissions, err := repo.ListPermissionNamesForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Contains(t, permissions, "users:read")
+		assert.Contains(t, permissions, "users:write")
+	})
+
+	t.Run("ListForUser_NoRoles", func(t *testing.T) {
+		userID := createUser(t)
+
+		roles, err := repo.ListNamesForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, roles)
+
+		assignments, err := repo.ListAssignmentsForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, assignments)
+
+		permissions, err := repo.ListPermissionNamesForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+	})
+
+	t.Run("RemoveFromUser", func(t *testing.T) {
+		userID := createUser(t)
+		role, err := repo.GetByName(ctx, "admin")
+		require.NoError(t, err)
+		require.NoError(t, repo.AssignToUser(ctx, userID, role.ID))
+
+		require.NoError(t, repo.RemoveFromUser(ctx, userID, role.ID))
+
+		roles, err := repo.ListNamesForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, roles)
+
+		err = repo.RemoveFromUser(ctx, userID, role.ID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type TOTPCredentialRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewTOTPCredentialRepository(pool *pgxpool.Pool) *TOTPCredentialRepository {
+	return &TOTPCredentialRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *TOTPCredentialRepository) WithTx(tx pgx.Tx) repositories.TOTPCredentialRepository {
+	return &TOTPCredentialRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *TOTPCredentialRepository) UpsertPending(ctx context.Context, userID uuid.UUID, secret string) (bool, error) {
+	rows, err := r.queries.UpsertPendingTOTPCredential(ctx, sqlcgen.UpsertPendingTOTPCredentialParams{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to upsert pending totp credential")
+	}
+	return rows > 0, nil
+}
+
+func (r *TOTPCredentialRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*sqlcgen.TotpCredential, error) {
+	credential, err := r.queries.GetTOTPCredentialByUserID(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get totp credential by user id")
+	}
+
+	return &credential, nil
+}
+
+func (r *TOTPCredentialRepository) Confirm(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	now := time.Now().UTC()
+	rows, err := r.queries.ConfirmTOTPCredential(ctx, sqlcgen.ConfirmTOTPCredentialParams{
+		ConfirmedAt:  &now,
+		LastUsedStep: step,
+		UserID:       userID,
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to confirm totp credential")
+	}
+	return rows > 0, nil
+}
+
+func (r *TOTPCredentialRepository) UpdateLastUsedStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	rows, err := r.queries.UpdateTOTPCredentialLastUsedStep(ctx, sqlcgen.UpdateTOTPCredentialLastUsedStepParams{
+		LastUsedStep: step,
+		UserID:       userID,
+	})
+	if err != nil {
+		return false, eris.Wrap(err, "failed to update totp credential last used step")
+	}
+	return rows > 0, nil
+}
+
+func (r *TOTPCredentialRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteTOTPCredential(ctx, userID); err != nil {
+		return eris.Wrap(err, "failed to delete totp credential")
+	}
+	return nil
+}
+
+var _ repositories.TOTPCredentialRepository = (*TOTPCredentialRepository)(nil)
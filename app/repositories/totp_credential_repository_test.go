@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCredentialRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewTOTPCredentialRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("UpsertPending", func(t *testing.T) {
+		userID := createUser(t)
+
+		ok, err := repo.UpsertPending(ctx, userID, "SECRET1")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		credential, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET1", credential.Secret)
+		assert.Nil(t, credential.ConfirmedAt)
+	})
+
+	t.Run("UpsertPending_ReplacesPendingSecret", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.UpsertPending(ctx, userID, "SECRET1")
+		require.NoError(t, err)
+		ok, err := repo.UpsertPending(ctx, userID, "SECRET2")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		credential, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET2", credential.Secret)
+	})
+
+	t.Run("UpsertPending_KeepsConfirmedSecret", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.UpsertPending(ctx, userID, "SECRET1")
+		require.NoError(t, err)
+		_, err = repo.Confirm(ctx, userID, 100)
+		require.NoError(t, err)
+
+		ok, err := repo.UpsertPending(ctx, userID, "SECRET2")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		credential, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET1", credential.Secret)
+	})
+
+	t.Run("GetByUserID_NotFound", func(t *testing.T) {
+		credential, err := repo.GetByUserID(ctx, uuid.New())
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, credential)
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.UpsertPending(ctx, userID, "SECRET1")
+		require.NoError(t, err)
+
+		ok, err := repo.Confirm(ctx, userID, 100)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		credential, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.NotNil(t, credential.ConfirmedAt)
+		assert.Equal(t, int64(100), credential.LastUsedStep)
+
+		ok, err = repo.Confirm(ctx, userID, 101)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("UpdateLastUsedStep", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.UpsertPending(ctx, userID, "SECRET1")
+		require.NoError(t, err)
+		_, err = repo.Confirm(ctx, userID, 100)
+		require.NoError(t, err)
+
+		ok, err := repo.UpdateLastUsedStep(ctx, userID, 100)
+		require.NoError(t, err)
+		assert.False(t, ok, "same step must not be accepted twice")
+
+		ok, err = repo.UpdateLastUsedStep(ctx, userID, 101)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.UpdateLastUsedStep(ctx, userID, 99)
+		require.NoError(t, err)
+		assert.False(t, ok, "earlier step must not be accepted")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.UpsertPending(ctx, userID, "SECRET1")
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, userID)
+		require.NoError(t, err)
+
+		_, err = repo.GetByUserID(ctx, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
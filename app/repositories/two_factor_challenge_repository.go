@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type TwoFactorChallengeRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewTwoFactorChallengeRepository(pool *pgxpool.Pool) *TwoFactorChallengeRepository {
+	return &TwoFactorChallengeRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *TwoFactorChallengeRepository) WithTx(tx pgx.Tx) repositories.TwoFactorChallengeRepository {
+	return &TwoFactorChallengeRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *TwoFactorChallengeRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*sqlcgen.TwoFactorChallenge, error) {
+	challenge := sqlcgen.TwoFactorChallenge{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateTwoFactorChallenge(ctx, sqlcgen.CreateTwoFactorChallengeParams{
+		ID:        challenge.ID,
+		UserID:    challenge.UserID,
+		TokenHash: challenge.TokenHash,
+		ExpiresAt: challenge.ExpiresAt,
+		CreatedAt: challenge.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create two factor challenge")
+	}
+
+	return &challenge, nil
+}
+
+func (r *TwoFactorChallengeRepository) GetByTokenHash(ctx context.Context, tokenHashes []string) (*sqlcgen.TwoFactorChallenge, error) {
+	challenge, err := r.queries.GetTwoFactorChallengeByTokenHash(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get two factor challenge by token hash")
+	}
+
+	return &challenge, nil
+}
+
+func (r *TwoFactorChallengeRepository) GetByTokenHashForUpdate(ctx context.Context, tokenHashes []string) (*sqlcgen.TwoFactorChallenge, error) {
+	challenge, err := r.queries.GetTwoFactorChallengeByTokenHashForUpdate(ctx, tokenHashes)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get two factor challenge by token hash")
+	}
+
+	return &challenge, nil
+}
+
+func (r *TwoFactorChallengeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.IncrementTwoFactorChallengeAttempts(ctx, id); err != nil {
+		return eris.Wrap(err, "failed to increment two factor challenge attempts")
+	}
+	return nil
+}
+
+func (r *TwoFactorChallengeRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	if err := r.queries.MarkTwoFactorChallengeUsed(ctx, sqlcgen.MarkTwoFactorChallengeUsedParams{
+		UsedAt: &now,
+		ID:     id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to mark two factor challenge as used")
+	}
+	return nil
+}
+
+func (r *TwoFactorChallengeRepository) DeleteExpiredOrUsed(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredOrUsedTwoFactorChallenges(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired or used two factor challenges")
+	}
+	return deleted, nil
+}
+
+var _ repositories.TwoFactorChallengeRepository = (*TwoFactorChallengeRepository)(nil)
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorChallengeRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewTwoFactorChallengeRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+
+		challenge, err := repo.Create(ctx, userID, "challengehash1", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+		assert.NotEmpty(t, challenge.ID)
+		assert.Equal(t, userID, challenge.UserID)
+		assert.Equal(t, int32(0), challenge.Attempts)
+		assert.Nil(t, challenge.UsedAt)
+	})
+
+	t.Run("GetByTokenHash", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, "challengehash4", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHash(ctx, []string{"challengehash4"})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, userID, found.UserID)
+
+		_, err = repo.GetByTokenHash(ctx, []string{"nonexistentchallenge"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("GetByTokenHashForUpdate_NotFound", func(t *testing.T) {
+		challenge, err := repo.GetByTokenHashForUpdate(ctx, []string{"nonexistentchallenge"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("IncrementAttempts", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, "challengehash2", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		require.NoError(t, repo.IncrementAttempts(ctx, created.ID))
+		require.NoError(t, repo.IncrementAttempts(ctx, created.ID))
+
+		found, err := repo.GetByTokenHashForUpdate(ctx, []string{"challengehash2"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), found.Attempts)
+	})
+
+	t.Run("MarkUsed", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, "challengehash3", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		err = repo.MarkUsed(ctx, created.ID)
+		require.NoError(t, err)
+
+		found, err := repo.GetByTokenHashForUpdate(ctx, []string{"challengehash3"})
+		require.NoError(t, err)
+		assert.NotNil(t, found.UsedAt)
+	})
+
+	t.Run("DeleteExpiredOrUsed", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, "challengeexpired", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		used, err := repo.Create(ctx, userID, "challengeused", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, repo.MarkUsed(ctx, used.ID))
+		_, err = repo.Create(ctx, userID, "challengevalid", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteExpiredOrUsed(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(2))
+
+		_, err = repo.GetByTokenHashForUpdate(ctx, []string{"challengeexpired"})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByTokenHashForUpdate(ctx, []string{"challengevalid"})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type UserIdentityRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewUserIdentityRepository(pool *pgxpool.Pool) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *UserIdentityRepository) WithTx(tx pgx.Tx) repositories.UserIdentityRepository {
+	return &UserIdentityRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *UserIdentityRepository) Create(ctx context.Context, userID uuid.UUID, provider, subject, email string) (*sqlcgen.UserIdentity, error) {
+	now := time.Now().UTC()
+	identity := sqlcgen.UserIdentity{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Provider:    provider,
+		Subject:     subject,
+		Email:       email,
+		LastLoginAt: now,
+		CreatedAt:   now,
+	}
+
+	if err := r.queries.CreateUserIdentity(ctx, sqlcgen.CreateUserIdentityParams{
+		ID:          identity.ID,
+		UserID:      identity.UserID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: identity.LastLoginAt,
+		CreatedAt:   identity.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create user identity")
+	}
+
+	return &identity, nil
+}
+
+func (r *UserIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*sqlcgen.UserIdentity, error) {
+	identity, err := r.queries.GetUserIdentityByProviderSubject(ctx, sqlcgen.GetUserIdentityByProviderSubjectParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get user identity by provider subject")
+	}
+
+	return &identity, nil
+}
+
+func (r *UserIdentityRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.UserIdentity, error) {
+	identities, err := r.queries.ListUserIdentitiesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list user identities for user")
+	}
+	return identities, nil
+}
+
+func (r *UserIdentityRepository) CountForUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := r.queries.CountUserIdentitiesForUser(ctx, userID)
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to count user identities for user")
+	}
+	return count, nil
+}
+
+func (r *UserIdentityRepository) RecordLogin(ctx context.Context, id uuid.UUID, email string) error {
+	if err := r.queries.UpdateUserIdentityLogin(ctx, sqlcgen.UpdateUserIdentityLoginParams{
+		Email:       email,
+		LastLoginAt: time.Now().UTC(),
+		ID:          id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to record user identity login")
+	}
+	return nil
+}
+
+func (r *UserIdentityRepository) DeleteForUser(ctx context.Context, id, userID uuid.UUID) error {
+	deleted, err := r.queries.DeleteUserIdentityForUser(ctx, sqlcgen.DeleteUserIdentityForUserParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to delete user identity for user")
+	}
+	if deleted == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no user identity for user")
+	}
+	return nil
+}
+
+var _ repositories.UserIdentityRepository = (*UserIdentityRepository)(nil)
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIdentityRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewUserIdentityRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+
+		identity, err := repo.Create(ctx, userID, "google", uuid.NewString(), "user@example.com")
+		require.NoError(t, err)
+		assert.NotEmpty(t, identity.ID)
+		assert.Equal(t, userID, identity.UserID)
+		assert.Equal(t, "google", identity.Provider)
+	})
+
+	t.Run("Create_DuplicateSubject", func(t *testing.T) {
+		subject := uuid.NewString()
+		_, err := repo.Create(ctx, createUser(t), "google", subject, "")
+		require.NoError(t, err)
+
+		otherID := createUser(t)
+
+		// Use a savepoint so the failed insert does not abort the test transaction
+		nested, err := tx.Begin(ctx)
+		require.NoError(t, err)
+		_, err = repo.WithTx(nested).Create(ctx, otherID, "google", subject, "")
+		assert.Error(t, err)
+		require.NoError(t, nested.Rollback(ctx))
+	})
+
+	t.Run("GetByProviderSubject", func(t *testing.T) {
+		userID := createUser(t)
+		subject := uuid.NewString()
+		created, err := repo.Create(ctx, userID, "google", subject, "user@example.com")
+		require.NoError(t, err)
+
+		identity, err := repo.GetByProviderSubject(ctx, "google", subject)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, identity.ID)
+
+		_, err = repo.GetByProviderSubject(ctx, "github", subject)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("ListForUser_And_CountForUser", func(t *testing.T) {
+		userID := createUser(t)
+		_, err := repo.Create(ctx, userID, "google", uuid.NewString(), "")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, "github", uuid.NewString(), "")
+		require.NoError(t, err)
+
+		identities, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, identities, 2)
+
+		count, err := repo.CountForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("RecordLogin", func(t *testing.T) {
+		subject := uuid.NewString()
+		created, err := repo.Create(ctx, createUser(t), "google", subject, "old@example.com")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RecordLogin(ctx, created.ID, "new@example.com"))
+
+		identity, err := repo.GetByProviderSubject(ctx, "google", subject)
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", identity.Email)
+		assert.False(t, identity.LastLoginAt.Before(created.LastLoginAt))
+	})
+
+	t.Run("DeleteForUser", func(t *testing.T) {
+		userID := createUser(t)
+		identity, err := repo.Create(ctx, userID, "google", uuid.NewString(), "")
+		require.NoError(t, err)
+
+		err = repo.DeleteForUser(ctx, identity.ID, createUser(t))
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		require.NoError(t, repo.DeleteForUser(ctx, identity.ID, userID))
+
+		err = repo.DeleteForUser(ctx, identity.ID, userID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
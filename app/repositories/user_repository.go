@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"go-reasonable-api/app/interfaces/repositories"
@@ -76,6 +77,32 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, p
 	return nil
 }
 
+func (r *UserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	now := time.Now().UTC()
+	if err := r.queries.UpdateUserEmail(ctx, sqlcgen.UpdateUserEmailParams{
+		Email:           email,
+		EmailVerifiedAt: &now,
+		UpdatedAt:       now,
+		ID:              userID,
+	}); err != nil {
+		return eris.Wrap(err, "failed to update user email")
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, update repositories.UserProfileUpdate) (*sqlcgen.User, error) {
+	user, err := r.queries.UpdateUserProfile(ctx, sqlcgen.UpdateUserProfileParams{
+		Name:      update.Name,
+		UpdatedAt: time.Now().UTC(),
+		ID:        userID,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to update user profile")
+	}
+
+	return &user, nil
+}
+
 func (r *UserRepository) MarkEmailVerified(ctx context.Context, userID uuid.UUID) error {
 	now := time.Now().UTC()
 	if err := r.queries.MarkUserEmailVerified(ctx, sqlcgen.MarkUserEmailVerifiedParams{
@@ -117,13 +144,63 @@ func (r *UserRepository) CancelDeletion(ctx context.Context, userID uuid.UUID) e
 	return nil
 }
 
-func (r *UserRepository) DeleteScheduledUsers(ctx context.Context) (int64, error) {
+func (r *UserRepository) ClaimDeletionReminders(ctx context.Context, dueBefore time.Time) ([]sqlcgen.User, error) {
+	now := time.Now().UTC()
+	users, err := r.queries.ClaimUserDeletionReminders(ctx, sqlcgen.ClaimUserDeletionRemindersParams{
+		SentAt:    &now,
+		DueBefore: &dueBefore,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to claim user deletion reminders")
+	}
+	return users, nil
+}
+
+func (r *UserRepository) DeleteScheduledUsers(ctx context.Context) ([]sqlcgen.User, error) {
 	now := time.Now().UTC()
-	deleted, err := r.queries.DeleteScheduledUsers(ctx, &now)
+	users, err := r.queries.DeleteScheduledUsers(ctx, &now)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to delete scheduled users")
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Search(ctx context.Context, search repositories.UserSearch, limit, offset int32) ([]sqlcgen.User, error) {
+	users, err := r.queries.SearchUsers(ctx, sqlcgen.SearchUsersParams{
+		Search:            escapeLike(search.Query),
+		Verified:          search.Verified,
+		DeletionScheduled: search.DeletionScheduled,
+		Limit:             limit,
+		Offset:            offset,
+	})
 	if err != nil {
-		return 0, eris.Wrap(err, "failed to delete scheduled users")
+		return nil, eris.Wrap(err, "failed to search users")
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Count(ctx context.Context, search repositories.UserSearch) (int64, error) {
+	count, err := r.queries.CountSearchUsers(ctx, sqlcgen.CountSearchUsersParams{
+		Search:            escapeLike(search.Query),
+		Verified:          search.Verified,
+		DeletionScheduled: search.DeletionScheduled,
+	})
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to count users")
+	}
+	return count, nil
+}
+
+// likeEscaper escapes the LIKE wildcards so that a search term only
+// matches itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s *string) *string {
+	if s == nil {
+		return nil
 	}
-	return deleted, nil
+	escaped := likeEscaper.Replace(*s)
+	return &escaped
 }
 
 var _ repositories.UserRepository = (*UserRepository)(nil)
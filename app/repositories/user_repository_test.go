@@ -3,6 +3,10 @@ package repositories
 import (
 	"context"
 	"testing"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/support/db"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -84,6 +88,61 @@ func TestUserRepository(t *testing.T) {
 		assert.NotNil(t, updated.EmailVerifiedAt)
 	})
 
+	t.Run("UpdateEmail", func(t *testing.T) {
+		user, err := repo.Create(ctx, "Erin", "erin@example.com", "pass")
+		require.NoError(t, err)
+
+		err = repo.UpdateEmail(ctx, user.ID, "erin.new@example.com")
+		require.NoError(t, err)
+
+		updated, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "erin.new@example.com", updated.Email)
+		assert.NotNil(t, updated.EmailVerifiedAt)
+	})
+
+	t.Run("UpdateEmail_Taken", func(t *testing.T) {
+		_, err := repo.Create(ctx, "Frank", "frank@example.com", "pass")
+		require.NoError(t, err)
+		user, err := repo.Create(ctx, "Grace", "grace@example.com", "pass")
+		require.NoError(t, err)
+
+		// Use a savepoint so the failed update does not abort the test transaction
+		nested, err := tx.Begin(ctx)
+		require.NoError(t, err)
+		err = repo.WithTx(nested).UpdateEmail(ctx, user.ID, "frank@example.com")
+		assert.True(t, db.IsUniqueViolation(err, "uq_users_email"))
+		require.NoError(t, nested.Rollback(ctx))
+	})
+
+	t.Run("UpdateProfile", func(t *testing.T) {
+		user, err := repo.Create(ctx, "Heidi", "heidi@example.com", "pass")
+		require.NoError(t, err)
+
+		name := "Heidi Klum"
+		updated, err := repo.UpdateProfile(ctx, user.ID, repositories.UserProfileUpdate{Name: &name})
+		require.NoError(t, err)
+		assert.Equal(t, "Heidi Klum", updated.Name)
+		assert.Equal(t, user.Email, updated.Email)
+		assert.True(t, updated.UpdatedAt.After(user.UpdatedAt))
+	})
+
+	t.Run("UpdateProfile_NilFieldsUnchanged", func(t *testing.T) {
+		user, err := repo.Create(ctx, "Ivan", "ivan@example.com", "pass")
+		require.NoError(t, err)
+
+		updated, err := repo.UpdateProfile(ctx, user.ID, repositories.UserProfileUpdate{})
+		require.NoError(t, err)
+		assert.Equal(t, "Ivan", updated.Name)
+	})
+
+	t.Run("UpdateProfile_NotFound", func(t *testing.T) {
+		name := "Nobody"
+		user, err := repo.UpdateProfile(ctx, uuid.New(), repositories.UserProfileUpdate{Name: &name})
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, user)
+	})
+
 	t.Run("EmailExists", func(t *testing.T) {
 		_, err := repo.Create(ctx, "Dave", "dave@example.com", "pass")
 		require.NoError(t, err)
@@ -96,4 +155,84 @@ func TestUserRepository(t *testing.T) {
 		require.NoError(t, err)
 		assert.False(t, exists)
 	})
+
+	t.Run("ClaimDeletionReminders", func(t *testing.T) {
+		due, err := repo.Create(ctx, "Due Soon", "due-soon@example.com", "pass")
+		require.NoError(t, err)
+		require.NoError(t, repo.ScheduleDeletion(ctx, due.ID, time.Now().UTC().Add(48*time.Hour)))
+		later, err := repo.Create(ctx, "Due Later", "due-later@example.com", "pass")
+		require.NoError(t, err)
+		require.NoError(t, repo.ScheduleDeletion(ctx, later.ID, time.Now().UTC().Add(240*time.Hour)))
+
+		users, err := repo.ClaimDeletionReminders(ctx, time.Now().UTC().Add(72*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, due.ID, users[0].ID)
+		assert.NotNil(t, users[0].DeletionReminderSentAt)
+
+		users, err = repo.ClaimDeletionReminders(ctx, time.Now().UTC().Add(72*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, users, "users are reminded once")
+
+		require.NoError(t, repo.CancelDeletion(ctx, due.ID))
+		cancelled, err := repo.GetByID(ctx, due.ID)
+		require.NoError(t, err)
+		assert.Nil(t, cancelled.DeletionReminderSentAt)
+	})
+
+	t.Run("DeleteScheduledUsers", func(t *testing.T) {
+		due, err := repo.Create(ctx, "Past Due", "past-due@example.com", "pass")
+		require.NoError(t, err)
+		require.NoError(t, repo.ScheduleDeletion(ctx, due.ID, time.Now().UTC().Add(-time.Minute)))
+		kept, err := repo.Create(ctx, "Not Due", "not-due@example.com", "pass")
+		require.NoError(t, err)
+		require.NoError(t, repo.ScheduleDeletion(ctx, kept.ID, time.Now().UTC().Add(time.Hour)))
+
+		users, err := repo.DeleteScheduledUsers(ctx)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, due.ID, users[0].ID)
+		assert.Equal(t, "past-due@example.com", users[0].Email)
+
+		_, err = repo.GetByID(ctx, due.ID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.GetByID(ctx, kept.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		verified, err := repo.Create(ctx, "Searchable Verified", "search-verified@example.com", "pass")
+		require.NoError(t, err)
+		require.NoError(t, repo.MarkEmailVerified(ctx, verified.ID))
+		unverified, err := repo.Create(ctx, "Searchable Unverified", "search-unverified@example.com", "pass")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Someone Else", "search_literal@example.com", "pass")
+		require.NoError(t, err)
+
+		query := "searchable"
+		users, err := repo.Search(ctx, repositories.UserSearch{Query: &query}, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, users, 2)
+		count, err := repo.Count(ctx, repositories.UserSearch{Query: &query})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		notVerified := false
+		users, err = repo.Search(ctx, repositories.UserSearch{Query: &query, Verified: &notVerified}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, unverified.ID, users[0].ID)
+
+		byEmail := "SEARCH-VERIFIED@"
+		users, err = repo.Search(ctx, repositories.UserSearch{Query: &byEmail}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, verified.ID, users[0].ID)
+
+		wildcard := "search_"
+		users, err = repo.Search(ctx, repositories.UserSearch{Query: &wildcard}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, users, 1, "underscore must match literally")
+		assert.Equal(t, "search_literal@example.com", users[0].Email)
+	})
 }
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type WebAuthnChallengeRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewWebAuthnChallengeRepository(pool *pgxpool.Pool) *WebAuthnChallengeRepository {
+	return &WebAuthnChallengeRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *WebAuthnChallengeRepository) WithTx(tx pgx.Tx) repositories.WebAuthnChallengeRepository {
+	return &WebAuthnChallengeRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *WebAuthnChallengeRepository) Create(ctx context.Context, userID *uuid.UUID, ceremony, challengeHash string, expiresAt time.Time) (*sqlcgen.WebauthnChallenge, error) {
+	challenge := sqlcgen.WebauthnChallenge{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Ceremony:      ceremony,
+		ChallengeHash: challengeHash,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateWebAuthnChallenge(ctx, sqlcgen.CreateWebAuthnChallengeParams{
+		ID:            challenge.ID,
+		UserID:        challenge.UserID,
+		Ceremony:      challenge.Ceremony,
+		ChallengeHash: challenge.ChallengeHash,
+		ExpiresAt:     challenge.ExpiresAt,
+		CreatedAt:     challenge.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create webauthn challenge")
+	}
+
+	return &challenge, nil
+}
+
+func (r *WebAuthnChallengeRepository) Consume(ctx context.Context, challengeHash, ceremony string) (*sqlcgen.WebauthnChallenge, error) {
+	challenge, err := r.queries.ConsumeWebAuthnChallenge(ctx, sqlcgen.ConsumeWebAuthnChallengeParams{
+		ChallengeHash: challengeHash,
+		Ceremony:      ceremony,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to consume webauthn challenge")
+	}
+
+	return &challenge, nil
+}
+
+func (r *WebAuthnChallengeRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.queries.DeleteExpiredWebAuthnChallenges(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to delete expired webauthn challenges")
+	}
+	return deleted, nil
+}
+
+var _ repositories.WebAuthnChallengeRepository = (*WebAuthnChallengeRepository)(nil)
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnChallengeRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewWebAuthnChallengeRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+
+		challenge, err := repo.Create(ctx, &userID, "registration", "webauthnhash1", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+		assert.NotEmpty(t, challenge.ID)
+		require.NotNil(t, challenge.UserID)
+		assert.Equal(t, userID, *challenge.UserID)
+	})
+
+	t.Run("Consume", func(t *testing.T) {
+		_, err := repo.Create(ctx, nil, "login", "webauthnhash2", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		challenge, err := repo.Consume(ctx, "webauthnhash2", "login")
+		require.NoError(t, err)
+		assert.Nil(t, challenge.UserID)
+		assert.Equal(t, "login", challenge.Ceremony)
+
+		_, err = repo.Consume(ctx, "webauthnhash2", "login")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Consume_WrongCeremony", func(t *testing.T) {
+		_, err := repo.Create(ctx, nil, "login", "webauthnhash3", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		_, err = repo.Consume(ctx, "webauthnhash3", "registration")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		_, err = repo.Consume(ctx, "webauthnhash3", "login")
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		_, err := repo.Create(ctx, nil, "login", "webauthnexpired", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, nil, "login", "webauthnvalid", time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		deleted, err := repo.DeleteExpired(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		_, err = repo.Consume(ctx, "webauthnexpired", "login")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		_, err = repo.Consume(ctx, "webauthnvalid", "login")
+		require.NoError(t, err)
+	})
+}
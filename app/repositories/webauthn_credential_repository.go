@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rotisserie/eris"
+)
+
+type WebAuthnCredentialRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewWebAuthnCredentialRepository(pool *pgxpool.Pool) *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{
+		queries: sqlcgen.New(pool),
+	}
+}
+
+func (r *WebAuthnCredentialRepository) WithTx(tx pgx.Tx) repositories.WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{
+		queries: sqlcgen.New(tx),
+	}
+}
+
+func (r *WebAuthnCredentialRepository) Create(ctx context.Context, userID uuid.UUID, credentialID, publicKey []byte, signCount int64, aaguid []byte, backupEligible bool, transports []string, name string) (*sqlcgen.WebauthnCredential, error) {
+	if transports == nil {
+		transports = []string{}
+	}
+
+	credential := sqlcgen.WebauthnCredential{
+		ID:             uuid.New(),
+		UserID:         userID,
+		CredentialID:   credentialID,
+		PublicKey:      publicKey,
+		SignCount:      signCount,
+		Aaguid:         aaguid,
+		BackupEligible: backupEligible,
+		Transports:     transports,
+		Name:           name,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := r.queries.CreateWebAuthnCredential(ctx, sqlcgen.CreateWebAuthnCredentialParams{
+		ID:             credential.ID,
+		UserID:         credential.UserID,
+		CredentialID:   credential.CredentialID,
+		PublicKey:      credential.PublicKey,
+		SignCount:      credential.SignCount,
+		Aaguid:         credential.Aaguid,
+		BackupEligible: credential.BackupEligible,
+		Transports:     credential.Transports,
+		Name:           credential.Name,
+		CreatedAt:      credential.CreatedAt,
+	}); err != nil {
+		return nil, eris.Wrap(err, "failed to create webauthn credential")
+	}
+
+	return &credential, nil
+}
+
+func (r *WebAuthnCredentialRepository) GetByCredentialID(ctx context.Context, credentialID []byte) (*sqlcgen.WebauthnCredential, error) {
+	credential, err := r.queries.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to get webauthn credential by credential id")
+	}
+
+	return &credential, nil
+}
+
+func (r *WebAuthnCredentialRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error) {
+	credentials, err := r.queries.ListWebAuthnCredentialsForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list webauthn credentials for user")
+	}
+	return credentials, nil
+}
+
+func (r *WebAuthnCredentialRepository) UpdateSignCount(ctx context.Context, id uuid.UUID, signCount int64) error {
+	now := time.Now().UTC()
+	if err := r.queries.UpdateWebAuthnCredentialSignCount(ctx, sqlcgen.UpdateWebAuthnCredentialSignCountParams{
+		SignCount:  signCount,
+		LastUsedAt: &now,
+		ID:         id,
+	}); err != nil {
+		return eris.Wrap(err, "failed to update webauthn credential sign count")
+	}
+	return nil
+}
+
+func (r *WebAuthnCredentialRepository) DeleteForUser(ctx context.Context, id, userID uuid.UUID) error {
+	deleted, err := r.queries.DeleteWebAuthnCredentialForUser(ctx, sqlcgen.DeleteWebAuthnCredentialForUserParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return eris.Wrap(err, "failed to delete webauthn credential for user")
+	}
+	if deleted == 0 {
+		return eris.Wrap(pgx.ErrNoRows, "no webauthn credential for user")
+	}
+	return nil
+}
+
+var _ repositories.WebAuthnCredentialRepository = (*WebAuthnCredentialRepository)(nil)
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnCredentialRepository(t *testing.T) {
+	tx := setupTest(t)
+	userRepo := NewUserRepository(testPool).WithTx(tx)
+	repo := NewWebAuthnCredentialRepository(testPool).WithTx(tx)
+	ctx := context.Background()
+
+	createUser := func(t *testing.T) uuid.UUID {
+		user, err := userRepo.Create(ctx, "Test User", uuid.NewString()+"@example.com", "hash")
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	aaguid := make([]byte, 16)
+
+	t.Run("Create", func(t *testing.T) {
+		userID := createUser(t)
+
+		credential, err := repo.Create(ctx, userID, []byte("credential-1"), []byte("key"), 0, aaguid, false, []string{"internal", "hybrid"}, "Laptop")
+		require.NoError(t, err)
+		assert.NotEmpty(t, credential.ID)
+		assert.Equal(t, userID, credential.UserID)
+		assert.Equal(t, "Laptop", credential.Name)
+		assert.Nil(t, credential.LastUsedAt)
+	})
+
+	t.Run("GetByCredentialID", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, []byte("credential-2"), []byte("key"), 3, aaguid, true, nil, "Phone")
+		require.NoError(t, err)
+
+		found, err := repo.GetByCredentialID(ctx, []byte("credential-2"))
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, []byte("key"), found.PublicKey)
+		assert.Equal(t, int64(3), found.SignCount)
+		assert.True(t, found.BackupEligible)
+		assert.Empty(t, found.Transports)
+	})
+
+	t.Run("GetByCredentialID_NotFound", func(t *testing.T) {
+		credential, err := repo.GetByCredentialID(ctx, []byte("missing"))
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.Nil(t, credential)
+	})
+
+	t.Run("Create_DuplicateCredentialID", func(t *testing.T) {
+		userID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, []byte("credential-dup"), []byte("key"), 0, aaguid, false, nil, "First")
+		require.NoError(t, err)
+
+		// Use a savepoint so the failed insert does not abort the test transaction
+		nested, err := tx.Begin(ctx)
+		require.NoError(t, err)
+		_, err = repo.WithTx(nested).Create(ctx, userID, []byte("credential-dup"), []byte("key"), 0, aaguid, false, nil, "Second")
+		assert.Error(t, err)
+		require.NoError(t, nested.Rollback(ctx))
+	})
+
+	t.Run("ListForUser", func(t *testing.T) {
+		userID := createUser(t)
+		otherID := createUser(t)
+
+		_, err := repo.Create(ctx, userID, []byte("credential-3"), []byte("key"), 0, aaguid, false, nil, "One")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, userID, []byte("credential-4"), []byte("key"), 0, aaguid, false, nil, "Two")
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, otherID, []byte("credential-5"), []byte("key"), 0, aaguid, false, nil, "Other")
+		require.NoError(t, err)
+
+		credentials, err := repo.ListForUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, credentials, 2)
+	})
+
+	t.Run("UpdateSignCount", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, []byte("credential-6"), []byte("key"), 1, aaguid, false, nil, "Key")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.UpdateSignCount(ctx, created.ID, 7))
+
+		found, err := repo.GetByCredentialID(ctx, []byte("credential-6"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), found.SignCount)
+		assert.NotNil(t, found.LastUsedAt)
+	})
+
+	t.Run("DeleteForUser", func(t *testing.T) {
+		userID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, []byte("credential-7"), []byte("key"), 0, aaguid, false, nil, "Key")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeleteForUser(ctx, created.ID, userID))
+
+		_, err = repo.GetByCredentialID(ctx, []byte("credential-7"))
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("DeleteForUser_OtherUser", func(t *testing.T) {
+		userID := createUser(t)
+		otherID := createUser(t)
+
+		created, err := repo.Create(ctx, userID, []byte("credential-8"), []byte("key"), 0, aaguid, false, nil, "Key")
+		require.NoError(t, err)
+
+		err = repo.DeleteForUser(ctx, created.ID, otherID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		_, err = repo.GetByCredentialID(ctx, []byte("credential-8"))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/linksign"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDeletionService_SendReminders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("does nothing when reminders are disabled", func(t *testing.T) {
+		service := services.NewAccountDeletionService(newTestConfig(), mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t), nil)
+
+		sent, err := service.SendReminders(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("emails a signed cancellation link", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Auth.AccountDeletionReminder = 72 * time.Hour
+		cfg.App.BaseURL = "https://app.example.com"
+		userID := uuid.New()
+		scheduledAt := time.Now().UTC().Add(48 * time.Hour)
+
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+		mockRepo.EXPECT().ClaimDeletionReminders(mock.Anything, mock.AnythingOfType("time.Time")).Return([]sqlcgen.User{
+			{ID: userID, Name: "Test User", Email: "test@example.com", DeletionScheduledAt: &scheduledAt},
+		}, nil)
+		var payload tasks.EmailPayload
+		mockTaskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+				payload = p.(tasks.EmailPayload)
+			})
+
+		service := services.NewAccountDeletionService(cfg, mockRepo, mockTaskClient, linksign.NewSigner(cfg))
+		sent, err := service.SendReminders(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), sent)
+		assert.Equal(t, "test@example.com", payload.To)
+		assert.Equal(t, "account-deletion-reminder", payload.Template)
+		assert.Equal(t, 2, payload.Data["DaysLeft"])
+
+		link, err := url.Parse(payload.Data["CancelLink"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, "/cancel-deletion", link.Path)
+		assert.Equal(t, userID.String(), link.Query().Get("user_id"))
+		expires, err := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, scheduledAt.Unix(), expires)
+		assert.True(t, linksign.NewSigner(cfg).Verify("account-deletion-cancel", userID.String(), time.Unix(expires, 0), link.Query().Get("signature")))
+	})
+
+	t.Run("returns error when claiming fails", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Auth.AccountDeletionReminder = 72 * time.Hour
+
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockRepo.EXPECT().ClaimDeletionReminders(mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
+
+		service := services.NewAccountDeletionService(cfg, mockRepo, mocksSupport.NewMockTaskClient(t), linksign.NewSigner(cfg))
+		_, err := service.SendReminders(ctx)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestAccountDeletionService_DeleteScheduled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("emails every deleted user", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+		mockRepo.EXPECT().DeleteScheduledUsers(mock.Anything).Return([]sqlcgen.User{
+			{ID: uuid.New(), Name: "First", Email: "first@example.com"},
+			{ID: uuid.New(), Name: "Second", Email: "second@example.com"},
+		}, nil)
+		var recipients []string
+		mockTaskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+				payload := p.(tasks.EmailPayload)
+				assert.Equal(t, "account-deleted", payload.Template)
+				recipients = append(recipients, payload.To)
+			})
+
+		service := services.NewAccountDeletionService(newTestConfig(), mockRepo, mockTaskClient, nil)
+		deleted, err := service.DeleteScheduled(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+		assert.Equal(t, []string{"first@example.com", "second@example.com"}, recipients)
+	})
+
+	t.Run("returns error when deletion fails", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockRepo.EXPECT().DeleteScheduledUsers(mock.Anything).Return(nil, assert.AnError)
+
+		service := services.NewAccountDeletionService(newTestConfig(), mockRepo, mocksSupport.NewMockTaskClient(t), nil)
+		_, err := service.DeleteScheduled(ctx)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
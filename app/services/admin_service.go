@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+type AdminService struct {
+	txManager                *db.TxManager
+	userRepo                 repositories.UserRepository
+	authTokenRepo            repositories.AuthTokenRepository
+	apiKeyRepo               repositories.APIKeyRepository
+	emailVerificationService services.EmailVerificationService
+	tokenCache               support.TokenCache
+}
+
+func NewAdminService(txManager *db.TxManager, userRepo repositories.UserRepository, authTokenRepo repositories.AuthTokenRepository, apiKeyRepo repositories.APIKeyRepository, emailVerificationService services.EmailVerificationService, tokenCache support.TokenCache) *AdminService {
+	return &AdminService{
+		txManager:                txManager,
+		userRepo:                 userRepo,
+		authTokenRepo:            authTokenRepo,
+		apiKeyRepo:               apiKeyRepo,
+		emailVerificationService: emailVerificationService,
+		tokenCache:               tokenCache,
+	}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, search services.UserSearch, limit, offset int32) ([]sqlcgen.User, int64, error) {
+	filter := repositories.UserSearch{
+		Verified:          search.Verified,
+		DeletionScheduled: search.DeletionScheduled,
+	}
+	if search.Query != "" {
+		filter.Query = &search.Query
+	}
+
+	users, err := s.userRepo.Search(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, eris.Wrap(err, "failed to search users")
+	}
+
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, eris.Wrap(err, "failed to count users")
+	}
+
+	return users, total, nil
+}
+
+func (s *AdminService) GetUser(ctx context.Context, userID uuid.UUID) (*services.AdminUser, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.authTokenRepo.CountActiveForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to count sessions")
+	}
+
+	return &services.AdminUser{User: user, ActiveSessions: sessions}, nil
+}
+
+func (s *AdminService) VerifyEmail(ctx context.Context, userID uuid.UUID) error {
+	return s.emailVerificationService.MarkVerified(ctx, userID)
+}
+
+func (s *AdminService) RevokeSessions(ctx context.Context, userID uuid.UUID) error {
+	err := s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.userRepo.WithTx(tx).GetByID(ctx, userID); err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return errors.ErrUserNotFound
+			}
+			return eris.Wrap(err, "failed to get user by ID")
+		}
+
+		if err := s.authTokenRepo.WithTx(tx).RevokeAllForUser(ctx, userID); err != nil {
+			return eris.Wrap(err, "failed to revoke all auth tokens for user")
+		}
+		if err := s.apiKeyRepo.WithTx(tx).RevokeAllForUser(ctx, userID); err != nil {
+			return eris.Wrap(err, "failed to revoke all api keys for user")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenCache.Invalidate(ctx, userID); err != nil {
+		return eris.Wrap(err, "failed to invalidate token cache")
+	}
+	return nil
+}
+
+func (s *AdminService) getUser(ctx context.Context, userID uuid.UUID) (*sqlcgen.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, eris.Wrap(err, "failed to get user by ID")
+	}
+	return user, nil
+}
+
+var _ services.AdminService = (*AdminService)(nil)
@@ -0,0 +1,199 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	verified := true
+
+	t.Run("passes search through to repository", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository(t)
+		matchesFilter := mock.MatchedBy(func(f repositories.UserSearch) bool {
+			return f.Query != nil && *f.Query == "alice" && f.Verified == &verified && f.DeletionScheduled == nil
+		})
+		userRepo.EXPECT().Search(mock.Anything, matchesFilter, int32(20), int32(40)).Return([]sqlcgen.User{{Name: "Alice"}}, nil)
+		userRepo.EXPECT().Count(mock.Anything, matchesFilter).Return(int64(41), nil)
+
+		svc := services.NewAdminService(nil, userRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, newTestTokenCache())
+		users, total, err := svc.ListUsers(ctx, ifaces.UserSearch{Query: "alice", Verified: &verified}, 20, 40)
+
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, int64(41), total)
+	})
+
+	t.Run("empty query matches everyone", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository(t)
+		userRepo.EXPECT().Search(mock.Anything, repositories.UserSearch{}, int32(20), int32(0)).Return([]sqlcgen.User{}, nil)
+		userRepo.EXPECT().Count(mock.Anything, repositories.UserSearch{}).Return(int64(0), nil)
+
+		svc := services.NewAdminService(nil, userRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, newTestTokenCache())
+		_, _, err := svc.ListUsers(ctx, ifaces.UserSearch{}, 20, 0)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestAdminService_GetUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("returns user with active session count", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository(t)
+		authTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+		authTokenRepo.EXPECT().CountActiveForUser(mock.Anything, userID).Return(int64(3), nil)
+
+		svc := services.NewAdminService(nil, userRepo, authTokenRepo, nil, nil, newTestTokenCache())
+		user, err := svc.GetUser(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, user.User.ID)
+		assert.Equal(t, int64(3), user.ActiveSessions)
+	})
+
+	t.Run("returns error when user not found", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository(t)
+		userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+
+		svc := services.NewAdminService(nil, userRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, newTestTokenCache())
+		_, err := svc.GetUser(ctx, userID)
+
+		assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	})
+}
+
+func TestAdminService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocksServices.MockEmailVerificationService)
+		expectedErr error
+	}{
+		{
+			name: "marks email verified through the verification service",
+			setupMock: func(emailVerificationSvc *mocksServices.MockEmailVerificationService) {
+				emailVerificationSvc.EXPECT().MarkVerified(mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name: "returns error when already verified",
+			setupMock: func(emailVerificationSvc *mocksServices.MockEmailVerificationService) {
+				emailVerificationSvc.EXPECT().MarkVerified(mock.Anything, userID).Return(errors.ErrEmailAlreadyVerified)
+			},
+			expectedErr: errors.ErrEmailAlreadyVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEmailVerificationSvc := mocksServices.NewMockEmailVerificationService(t)
+			tt.setupMock(mockEmailVerificationSvc)
+
+			svc := services.NewAdminService(nil, mocks.NewMockUserRepository(t), mocks.NewMockAuthTokenRepository(t), mocks.NewMockAPIKeyRepository(t), mockEmailVerificationSvc, newTestTokenCache())
+			err := svc.VerifyEmail(ctx, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminService_RevokeSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockAuthTokenRepository, *mocks.MockAPIKeyRepository, *mocksSupport.MockTokenCache)
+		expectedErr error
+	}{
+		{
+			name: "revokes sessions and api keys, then drops cached tokens",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository, tokenCache *mocksSupport.MockTokenCache) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+				apiKeyRepo.EXPECT().WithTx(mock.Anything).Return(apiKeyRepo)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				authTokenRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+				apiKeyRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+				tokenCache.EXPECT().Invalidate(mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name: "returns error when user not found",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository, tokenCache *mocksSupport.MockTokenCache) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrUserNotFound,
+		},
+		{
+			name: "keeps sessions when revoking api keys fails",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository, tokenCache *mocksSupport.MockTokenCache) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+				apiKeyRepo.EXPECT().WithTx(mock.Anything).Return(apiKeyRepo)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				authTokenRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+				apiKeyRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+			mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+			mockTokenCache := mocksSupport.NewMockTokenCache(t)
+			tt.setupMock(mockPool, mockUserRepo, mockAuthTokenRepo, mockAPIKeyRepo, mockTokenCache)
+
+			svc := services.NewAdminService(db.NewTxManager(mockPool), mockUserRepo, mockAuthTokenRepo, mockAPIKeyRepo, mocksServices.NewMockEmailVerificationService(t), mockTokenCache)
+			err = svc.RevokeSessions(ctx, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
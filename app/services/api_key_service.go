@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+// apiKeyDisplayLength is how many characters of a key after APIKeyPrefix
+// are kept in key_prefix to identify it in listings.
+const apiKeyDisplayLength = 8
+
+type APIKeyService struct {
+	apiKeyRepo  repositories.APIKeyRepository
+	tokenHasher support.TokenHasher
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, tokenHasher support.TokenHasher) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo:  apiKeyRepo,
+		tokenHasher: tokenHasher,
+	}
+}
+
+func (s *APIKeyService) Create(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*services.CreatedAPIKey, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(services.APIKeyScopes, scope) {
+			return nil, errors.ErrInvalidAPIKeyScope.WithDetail("scope", scope)
+		}
+	}
+
+	token, err := GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	key := services.APIKeyPrefix + token
+	keyPrefix := key[:len(services.APIKeyPrefix)+apiKeyDisplayLength]
+
+	scopes = slices.Compact(slices.Sorted(slices.Values(scopes)))
+	apiKey, err := s.apiKeyRepo.Create(ctx, userID, name, keyPrefix, s.tokenHasher.Hash(key), scopes, expiresAt)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to create api key")
+	}
+
+	return &services.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *APIKeyService) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.ApiKey, error) {
+	keys, err := s.apiKeyRepo.ListActiveForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list api keys")
+	}
+	return keys, nil
+}
+
+func (s *APIKeyService) Rename(ctx context.Context, userID, keyID uuid.UUID, name string) (*sqlcgen.ApiKey, error) {
+	apiKey, err := s.apiKeyRepo.RenameForUser(ctx, keyID, userID, name)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrAPIKeyNotFound
+		}
+		return nil, eris.Wrap(err, "failed to rename api key")
+	}
+	return apiKey, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, userID, keyID uuid.UUID) error {
+	if err := s.apiKeyRepo.RevokeForUser(ctx, keyID, userID); err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return errors.ErrAPIKeyNotFound
+		}
+		return eris.Wrap(err, "failed to revoke api key")
+	}
+	return nil
+}
+
+func (s *APIKeyService) Validate(ctx context.Context, key string) (*sqlcgen.ApiKey, error) {
+	tokenHashes := s.tokenHasher.Candidates(key)
+
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, tokenHashes)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, eris.Wrap(err, "failed to get api key")
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, errors.ErrTokenRevoked
+	}
+
+	now := time.Now().UTC()
+	if apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt) {
+		return nil, errors.ErrTokenExpired
+	}
+
+	// Move keys found under a previous key, or stored before keys were
+	// hashed with one, to the current key so that key can be retired
+	if apiKey.TokenHash != tokenHashes[0] {
+		if err := s.apiKeyRepo.UpdateHash(ctx, apiKey.ID, tokenHashes[0]); err != nil {
+			return nil, eris.Wrap(err, "failed to rehash api key")
+		}
+		apiKey.TokenHash = tokenHashes[0]
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= AuthTokenTouchInterval {
+		if err := s.apiKeyRepo.Touch(ctx, apiKey.ID); err != nil {
+			return nil, eris.Wrap(err, "failed to touch api key")
+		}
+	}
+
+	return apiKey, nil
+}
+
+var _ services.APIKeyService = (*APIKeyService)(nil)
@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	supporterrors "go-reasonable-api/support/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("stores hash of generated key with sorted scopes", func(t *testing.T) {
+		apiKeyRepo := mocks.NewMockAPIKeyRepository(t)
+
+		var storedPrefix, storedHash string
+		apiKeyRepo.EXPECT().Create(mock.Anything, userID, "CI", mock.Anything, mock.Anything,
+			[]string{ifaces.ScopeProfileRead, ifaces.ScopeProfileWrite}, (*time.Time)(nil)).
+			RunAndReturn(func(_ context.Context, _ uuid.UUID, name, keyPrefix, tokenHash string, scopes []string, _ *time.Time) (*sqlcgen.ApiKey, error) {
+				storedPrefix, storedHash = keyPrefix, tokenHash
+				return &sqlcgen.ApiKey{ID: uuid.New(), UserID: userID, Name: name, KeyPrefix: keyPrefix, TokenHash: tokenHash, Scopes: scopes}, nil
+			})
+
+		svc := services.NewAPIKeyService(apiKeyRepo, newTestTokenHasher())
+		created, err := svc.Create(ctx, userID, "CI", []string{ifaces.ScopeProfileWrite, ifaces.ScopeProfileRead, ifaces.ScopeProfileWrite}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, ifaces.APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(created.Key, storedPrefix))
+		assert.Len(t, storedPrefix, len(ifaces.APIKeyPrefix)+8)
+		assert.Equal(t, newTestTokenHasher().Hash(created.Key), storedHash)
+		assert.NotEqual(t, created.Key, storedHash)
+	})
+
+	t.Run("rejects unknown scope", func(t *testing.T) {
+		apiKeyRepo := mocks.NewMockAPIKeyRepository(t)
+
+		svc := services.NewAPIKeyService(apiKeyRepo, newTestTokenHasher())
+		_, err := svc.Create(ctx, userID, "CI", []string{ifaces.ScopeProfileRead, "admin"}, nil)
+
+		var appErr *supporterrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "INVALID_API_KEY_SCOPE", appErr.Code)
+		assert.Equal(t, "admin", appErr.Details["scope"])
+	})
+}
+
+func TestAPIKeyService_Validate(t *testing.T) {
+	ctx := context.Background()
+	key := ifaces.APIKeyPrefix + "secret"
+	keyID := uuid.New()
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	recent := now.Add(-time.Minute)
+	tokenHasher := newTestTokenHasher()
+	currentHash := tokenHasher.Hash(key)
+
+	tests := []struct {
+		name         string
+		apiKey       *sqlcgen.ApiKey
+		getErr       error
+		expectTouch  bool
+		expectRehash bool
+		expectedErr  error
+	}{
+		{
+			name:        "touches key on first use",
+			apiKey:      &sqlcgen.ApiKey{ID: keyID, TokenHash: currentHash},
+			expectTouch: true,
+		},
+		{
+			name:        "touches key when last use is stale",
+			apiKey:      &sqlcgen.ApiKey{ID: keyID, TokenHash: currentHash, LastUsedAt: &past, ExpiresAt: &future},
+			expectTouch: true,
+		},
+		{
+			name:   "skips touch when recently used",
+			apiKey: &sqlcgen.ApiKey{ID: keyID, TokenHash: currentHash, LastUsedAt: &recent},
+		},
+		{
+			name:         "rehashes key stored under the unkeyed hash",
+			apiKey:       &sqlcgen.ApiKey{ID: keyID, TokenHash: services.HashToken(key), LastUsedAt: &recent},
+			expectRehash: true,
+		},
+		{
+			name:        "returns error for unknown key",
+			getErr:      eris.Wrap(pgx.ErrNoRows, "failed to get api key"),
+			expectedErr: errors.ErrInvalidToken,
+		},
+		{
+			name:        "returns error for revoked key",
+			apiKey:      &sqlcgen.ApiKey{ID: keyID, RevokedAt: &past},
+			expectedErr: errors.ErrTokenRevoked,
+		},
+		{
+			name:        "returns error for expired key",
+			apiKey:      &sqlcgen.ApiKey{ID: keyID, ExpiresAt: &past},
+			expectedErr: errors.ErrTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyRepo := mocks.NewMockAPIKeyRepository(t)
+			apiKeyRepo.EXPECT().GetByHash(mock.Anything, tokenHasher.Candidates(key)).Return(tt.apiKey, tt.getErr)
+			if tt.expectRehash {
+				apiKeyRepo.EXPECT().UpdateHash(mock.Anything, keyID, currentHash).Return(nil)
+			}
+			if tt.expectTouch {
+				apiKeyRepo.EXPECT().Touch(mock.Anything, keyID).Return(nil)
+			}
+
+			svc := services.NewAPIKeyService(apiKeyRepo, tokenHasher)
+			apiKey, err := svc.Validate(ctx, key)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, apiKey)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, keyID, apiKey.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_NotFound(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	keyID := uuid.New()
+	notFound := eris.Wrap(pgx.ErrNoRows, "not found")
+
+	t.Run("rename", func(t *testing.T) {
+		apiKeyRepo := mocks.NewMockAPIKeyRepository(t)
+		apiKeyRepo.EXPECT().RenameForUser(mock.Anything, keyID, userID, "Deploy").Return(nil, notFound)
+
+		svc := services.NewAPIKeyService(apiKeyRepo, newTestTokenHasher())
+		_, err := svc.Rename(ctx, userID, keyID, "Deploy")
+		assert.ErrorIs(t, err, errors.ErrAPIKeyNotFound)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		apiKeyRepo := mocks.NewMockAPIKeyRepository(t)
+		apiKeyRepo.EXPECT().RevokeForUser(mock.Anything, keyID, userID).Return(notFound)
+
+		svc := services.NewAPIKeyService(apiKeyRepo, newTestTokenHasher())
+		err := svc.Revoke(ctx, userID, keyID)
+		assert.ErrorIs(t, err, errors.ErrAPIKeyNotFound)
+	})
+}
@@ -22,6 +22,8 @@ func GenerateSecureToken(length int) (string, error) {
 // HashToken generates a SHA-256 hash of the given token.
 // This is used to store tokens securely in the database - only the hash
 // is stored, never the original token. The returned hash is hex-encoded.
+// Auth, password reset and email verification tokens are hashed with the
+// keyed support.TokenHasher instead.
 func HashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
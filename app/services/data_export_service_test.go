@@ -0,0 +1,334 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/linksign"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDataExportTestConfig() *config.Config {
+	cfg := newTestConfig()
+	cfg.Auth.Secret = "test-secret"
+	cfg.App.BaseURL = "https://app.example.com"
+	cfg.DataExport.LinkTTL = 24 * time.Hour
+	cfg.DataExport.MinInterval = time.Hour
+	return cfg
+}
+
+func TestDataExportService_Request(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exportID := uuid.New()
+
+	tests := []struct {
+		name        string
+		format      string
+		setupMock   func(*mocks.MockDataExportRepository, *mocksSupport.MockTaskClient)
+		expectedErr error
+	}{
+		{
+			name:   "records the export and queues the build",
+			format: ifaces.DataExportFormatJSON,
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, taskClient *mocksSupport.MockTaskClient) {
+				exportRepo.EXPECT().CountForUserSince(mock.Anything, userID, mock.MatchedBy(func(since time.Time) bool {
+					return time.Since(since) >= time.Hour
+				})).Return(int64(0), nil)
+				exportRepo.EXPECT().Create(mock.Anything, userID, ifaces.DataExportFormatJSON, mock.AnythingOfType("time.Time")).
+					Return(&sqlcgen.DataExport{ID: exportID}, nil)
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeDataExport, tasks.DataExportPayload{ExportID: exportID}, mock.Anything, mock.Anything)
+			},
+		},
+		{
+			name:   "rejects a second export within the interval",
+			format: ifaces.DataExportFormatZIP,
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, taskClient *mocksSupport.MockTaskClient) {
+				exportRepo.EXPECT().CountForUserSince(mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+			},
+			expectedErr: errors.ErrDataExportTooSoon,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExportRepo := mocks.NewMockDataExportRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(mockExportRepo, mockTaskClient)
+
+			cfg := newDataExportTestConfig()
+			service := services.NewDataExportService(cfg, mockExportRepo, mocks.NewMockUserRepository(t), mocks.NewMockAuthTokenRepository(t), mockTaskClient, linksign.NewSigner(cfg))
+			err := service.Request(ctx, userID, tt.format)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDataExportService_Build(t *testing.T) {
+	ctx := context.Background()
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com", PasswordHash: "secret-hash"}
+	sessions := []sqlcgen.AuthToken{{ID: uuid.New(), UserID: user.ID, TokenHash: "token-hash", UserAgent: "Firefox"}}
+	completedAt := time.Now()
+	customExporter := func(ctx context.Context, userID uuid.UUID) (any, error) {
+		return map[string]string{"user": userID.String()}, nil
+	}
+	brokenExporter := func(ctx context.Context, userID uuid.UUID) (any, error) {
+		return nil, assert.AnError
+	}
+
+	var archive []byte
+	var expiresAt time.Time
+	var payload tasks.EmailPayload
+
+	tests := []struct {
+		name          string
+		export        *sqlcgen.DataExport
+		exporters     map[string]ifaces.DataExporter
+		setupMock     func(*mocks.MockDataExportRepository, *mocks.MockUserRepository, *mocks.MockAuthTokenRepository, *mocksSupport.MockTaskClient, *sqlcgen.DataExport)
+		expectArchive bool
+		expectedErr   error
+	}{
+		{
+			name:      "builds a JSON export without secrets and emails a signed link",
+			export:    &sqlcgen.DataExport{ID: uuid.New(), UserID: user.ID, Format: ifaces.DataExportFormatJSON},
+			exporters: map[string]ifaces.DataExporter{"custom": customExporter},
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, taskClient *mocksSupport.MockTaskClient, export *sqlcgen.DataExport) {
+				exportRepo.EXPECT().GetByID(mock.Anything, export.ID).Return(export, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				authTokenRepo.EXPECT().ListForUser(mock.Anything, user.ID).Return(sessions, nil)
+				exportRepo.EXPECT().Complete(mock.Anything, export.ID, mock.Anything, mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _ uuid.UUID, a []byte, e time.Time) error {
+						archive, expiresAt = a, e
+						return nil
+					})
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+						payload = p.(tasks.EmailPayload)
+					})
+			},
+			expectArchive: true,
+		},
+		{
+			name:   "builds a ZIP archive with a file per exporter",
+			export: &sqlcgen.DataExport{ID: uuid.New(), UserID: user.ID, Format: ifaces.DataExportFormatZIP},
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, taskClient *mocksSupport.MockTaskClient, export *sqlcgen.DataExport) {
+				exportRepo.EXPECT().GetByID(mock.Anything, export.ID).Return(export, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				authTokenRepo.EXPECT().ListForUser(mock.Anything, user.ID).Return(sessions, nil)
+				exportRepo.EXPECT().Complete(mock.Anything, export.ID, mock.Anything, mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _ uuid.UUID, a []byte, e time.Time) error {
+						archive, expiresAt = a, e
+						return nil
+					})
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			},
+			expectArchive: true,
+		},
+		{
+			name:   "does nothing for exports deleted with their user",
+			export: &sqlcgen.DataExport{ID: uuid.New()},
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, taskClient *mocksSupport.MockTaskClient, export *sqlcgen.DataExport) {
+				exportRepo.EXPECT().GetByID(mock.Anything, export.ID).Return(nil, eris.Wrap(pgx.ErrNoRows, "not found"))
+			},
+		},
+		{
+			name:   "does nothing for built exports",
+			export: &sqlcgen.DataExport{ID: uuid.New(), UserID: user.ID, CompletedAt: &completedAt},
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, taskClient *mocksSupport.MockTaskClient, export *sqlcgen.DataExport) {
+				exportRepo.EXPECT().GetByID(mock.Anything, export.ID).Return(export, nil)
+			},
+		},
+		{
+			name:      "returns error when an exporter fails",
+			export:    &sqlcgen.DataExport{ID: uuid.New(), UserID: user.ID, Format: ifaces.DataExportFormatJSON},
+			exporters: map[string]ifaces.DataExporter{"broken": brokenExporter},
+			setupMock: func(exportRepo *mocks.MockDataExportRepository, userRepo *mocks.MockUserRepository, authTokenRepo *mocks.MockAuthTokenRepository, taskClient *mocksSupport.MockTaskClient, export *sqlcgen.DataExport) {
+				exportRepo.EXPECT().GetByID(mock.Anything, export.ID).Return(export, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, expiresAt, payload = nil, time.Time{}, tasks.EmailPayload{}
+
+			mockExportRepo := mocks.NewMockDataExportRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(mockExportRepo, mockUserRepo, mockAuthTokenRepo, mockTaskClient, tt.export)
+
+			cfg := newDataExportTestConfig()
+			service := services.NewDataExportService(cfg, mockExportRepo, mockUserRepo, mockAuthTokenRepo, mockTaskClient, linksign.NewSigner(cfg))
+			for name, exporter := range tt.exporters {
+				service.RegisterExporter(name, exporter)
+			}
+			err := service.Build(ctx, tt.export.ID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if !tt.expectArchive {
+				assert.Nil(t, archive)
+				return
+			}
+
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt, time.Minute)
+
+			switch tt.export.Format {
+			case ifaces.DataExportFormatJSON:
+				var document map[string]json.RawMessage
+				require.NoError(t, json.Unmarshal(archive, &document))
+				assert.Contains(t, document, "profile")
+				assert.Contains(t, document, "sessions")
+				assert.JSONEq(t, `{"user":"`+user.ID.String()+`"}`, string(document["custom"]))
+				assert.NotContains(t, string(archive), "secret-hash")
+				assert.NotContains(t, string(archive), "token-hash")
+
+				assert.Equal(t, "jane@example.com", payload.To)
+				assert.Equal(t, "data-export-ready", payload.Template)
+				assert.Equal(t, 24, payload.Data["ExpiresInHours"])
+
+				link, err := url.Parse(payload.Data["DownloadLink"].(string))
+				require.NoError(t, err)
+				assert.Equal(t, "/data-export", link.Path)
+				assert.Equal(t, tt.export.ID.String(), link.Query().Get("id"))
+				assert.Equal(t, strconv.FormatInt(expiresAt.Unix(), 10), link.Query().Get("expires"))
+				assert.True(t, linksign.NewSigner(cfg).Verify("data-export", tt.export.ID.String(), time.Unix(expiresAt.Unix(), 0), link.Query().Get("signature")))
+			case ifaces.DataExportFormatZIP:
+				zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+				require.NoError(t, err)
+				require.Len(t, zr.File, 2)
+				assert.Equal(t, "profile.json", zr.File[0].Name)
+				assert.Equal(t, "sessions.json", zr.File[1].Name)
+
+				f, err := zr.File[0].Open()
+				require.NoError(t, err)
+				defer f.Close()
+				profile, err := io.ReadAll(f)
+				require.NoError(t, err)
+				assert.Contains(t, string(profile), "jane@example.com")
+			}
+		})
+	}
+}
+
+func TestDataExportService_RegisterExporter(t *testing.T) {
+	cfg := newDataExportTestConfig()
+	service := services.NewDataExportService(cfg, mocks.NewMockDataExportRepository(t), mocks.NewMockUserRepository(t), mocks.NewMockAuthTokenRepository(t), mocksSupport.NewMockTaskClient(t), linksign.NewSigner(cfg))
+
+	assert.Panics(t, func() {
+		service.RegisterExporter("profile", func(ctx context.Context, userID uuid.UUID) (any, error) {
+			return nil, nil
+		})
+	})
+}
+
+func TestDataExportService_Download(t *testing.T) {
+	ctx := context.Background()
+	exportID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	signature := linksign.NewSigner(newDataExportTestConfig()).Sign("data-export", exportID.String(), expiresAt)
+	completedAt := time.Now()
+	built := &sqlcgen.DataExport{ID: exportID, Archive: []byte("{}"), ExpiresAt: expiresAt, CompletedAt: &completedAt}
+
+	tests := []struct {
+		name        string
+		exportID    uuid.UUID
+		signature   string
+		setupMock   func(*mocks.MockDataExportRepository)
+		expectedErr error
+	}{
+		{
+			name:      "returns the built export",
+			exportID:  exportID,
+			signature: signature,
+			setupMock: func(exportRepo *mocks.MockDataExportRepository) {
+				exportRepo.EXPECT().GetByID(mock.Anything, exportID).Return(built, nil)
+			},
+		},
+		{
+			name:        "rejects a bad signature",
+			exportID:    exportID,
+			signature:   "forged",
+			setupMock:   func(exportRepo *mocks.MockDataExportRepository) {},
+			expectedErr: errors.ErrInvalidDataExportLink,
+		},
+		{
+			name:        "rejects a link for another export",
+			exportID:    uuid.New(),
+			signature:   signature,
+			setupMock:   func(exportRepo *mocks.MockDataExportRepository) {},
+			expectedErr: errors.ErrInvalidDataExportLink,
+		},
+		{
+			name:      "rejects exports that were deleted",
+			exportID:  exportID,
+			signature: signature,
+			setupMock: func(exportRepo *mocks.MockDataExportRepository) {
+				exportRepo.EXPECT().GetByID(mock.Anything, exportID).Return(nil, eris.Wrap(pgx.ErrNoRows, "not found"))
+			},
+			expectedErr: errors.ErrInvalidDataExportLink,
+		},
+		{
+			name:      "rejects exports that are not built",
+			exportID:  exportID,
+			signature: signature,
+			setupMock: func(exportRepo *mocks.MockDataExportRepository) {
+				exportRepo.EXPECT().GetByID(mock.Anything, exportID).Return(&sqlcgen.DataExport{ID: exportID, ExpiresAt: expiresAt}, nil)
+			},
+			expectedErr: errors.ErrInvalidDataExportLink,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExportRepo := mocks.NewMockDataExportRepository(t)
+			tt.setupMock(mockExportRepo)
+
+			cfg := newDataExportTestConfig()
+			service := services.NewDataExportService(cfg, mockExportRepo, mocks.NewMockUserRepository(t), mocks.NewMockAuthTokenRepository(t), mocksSupport.NewMockTaskClient(t), linksign.NewSigner(cfg))
+			found, err := service.Download(ctx, tt.exportID, expiresAt, tt.signature)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, found)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, built, found)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+// The exporters below map stored rows onto export records so that password
+// hashes, token hashes and key material never reach an archive.
+
+type profileExport struct {
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// ProfileExporter exports the user's account details.
+func ProfileExporter(userRepo repositories.UserRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		user, err := userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to get user")
+		}
+		return profileExport{
+			ID:                  user.ID,
+			Name:                user.Name,
+			Email:               user.Email,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
+		}, nil
+	}
+}
+
+type sessionExport struct {
+	ID           uuid.UUID  `json:"id"`
+	UserAgent    string     `json:"user_agent,omitempty"`
+	IPAddress    string     `json:"ip_address,omitempty"`
+	Impersonated bool       `json:"impersonated"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+}
+
+// SessionsExporter exports every session still stored for the user,
+// including revoked and expired ones. Sessions an administrator opened as
+// the user are flagged, and the administrator's user agent and IP address
+// are left out.
+func SessionsExporter(authTokenRepo repositories.AuthTokenRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		tokens, err := authTokenRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list sessions")
+		}
+		sessions := make([]sessionExport, 0, len(tokens))
+		for _, token := range tokens {
+			session := sessionExport{
+				ID:           token.ID,
+				Impersonated: token.ImpersonatorID != nil,
+				CreatedAt:    token.CreatedAt,
+				LastUsedAt:   token.LastUsedAt,
+				ExpiresAt:    token.ExpiresAt,
+				RevokedAt:    token.RevokedAt,
+			}
+			if !session.Impersonated {
+				session.UserAgent = token.UserAgent
+				session.IPAddress = token.IpAddress
+			}
+			sessions = append(sessions, session)
+		}
+		return sessions, nil
+	}
+}
+
+// OrganizationsExporter exports the organizations the user belongs to and
+// their role in each.
+func OrganizationsExporter(orgRepo repositories.OrganizationRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		organizations, err := orgRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list organizations")
+		}
+		return organizations, nil
+	}
+}
+
+// IdentitiesExporter exports the OIDC identities linked to the user.
+func IdentitiesExporter(identityRepo repositories.UserIdentityRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		identities, err := identityRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list identities")
+		}
+		return identities, nil
+	}
+}
+
+type passkeyExport struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// PasskeysExporter exports the user's registered passkeys without their
+// key material.
+func PasskeysExporter(credentialRepo repositories.WebAuthnCredentialRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		credentials, err := credentialRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list passkeys")
+		}
+		passkeys := make([]passkeyExport, 0, len(credentials))
+		for _, credential := range credentials {
+			passkeys = append(passkeys, passkeyExport{
+				ID:         credential.ID,
+				Name:       credential.Name,
+				Transports: credential.Transports,
+				LastUsedAt: credential.LastUsedAt,
+				CreatedAt:  credential.CreatedAt,
+			})
+		}
+		return passkeys, nil
+	}
+}
+
+type apiKeyExport struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeysExporter exports the user's active API keys without their hashes.
+func APIKeysExporter(apiKeyRepo repositories.APIKeyRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		keys, err := apiKeyRepo.ListActiveForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list api keys")
+		}
+		apiKeys := make([]apiKeyExport, 0, len(keys))
+		for _, key := range keys {
+			apiKeys = append(apiKeys, apiKeyExport{
+				ID:         key.ID,
+				Name:       key.Name,
+				KeyPrefix:  key.KeyPrefix,
+				Scopes:     key.Scopes,
+				ExpiresAt:  key.ExpiresAt,
+				LastUsedAt: key.LastUsedAt,
+				CreatedAt:  key.CreatedAt,
+			})
+		}
+		return apiKeys, nil
+	}
+}
+
+type loginDeviceExport struct {
+	ID          uuid.UUID `json:"id"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LoginDevicesExporter exports the devices the user has signed in from.
+// Devices are only stored as keyed fingerprints of the user agent and IP
+// address.
+func LoginDevicesExporter(loginDeviceRepo repositories.LoginDeviceRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		devices, err := loginDeviceRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list login devices")
+		}
+		loginDevices := make([]loginDeviceExport, 0, len(devices))
+		for _, device := range devices {
+			loginDevices = append(loginDevices, loginDeviceExport{
+				ID:          device.ID,
+				Fingerprint: device.Fingerprint,
+				CreatedAt:   device.CreatedAt,
+			})
+		}
+		return loginDevices, nil
+	}
+}
+
+type auditLogExport struct {
+	ID        uuid.UUID       `json:"id"`
+	ActorID   *uuid.UUID      `json:"actor_id"`
+	SubjectID *uuid.UUID      `json:"subject_id"`
+	Action    string          `json:"action"`
+	Metadata  json.RawMessage `json:"metadata"`
+	IPAddress string          `json:"ip_address,omitempty"`
+	UserAgent string          `json:"user_agent,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AuditLogsExporter exports the audit log entries the user acted in or was
+// the subject of. The client details of entries recorded for someone else,
+// such as an administrator acting on the account, are left out.
+func AuditLogsExporter(auditLogRepo repositories.AuditLogRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		entries, err := auditLogRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list audit logs")
+		}
+		auditLogs := make([]auditLogExport, 0, len(entries))
+		for _, entry := range entries {
+			auditLog := auditLogExport{
+				ID:        entry.ID,
+				ActorID:   entry.ActorID,
+				SubjectID: entry.SubjectID,
+				Action:    entry.Action,
+				Metadata:  entry.Metadata,
+				CreatedAt: entry.CreatedAt,
+			}
+			if entry.ActorID != nil && *entry.ActorID == userID {
+				auditLog.IPAddress = entry.IpAddress
+				auditLog.UserAgent = entry.UserAgent
+			}
+			auditLogs = append(auditLogs, auditLog)
+		}
+		return auditLogs, nil
+	}
+}
+
+type emailChangeExport struct {
+	ID              uuid.UUID  `json:"id"`
+	OldEmail        string     `json:"old_email"`
+	NewEmail        string     `json:"new_email"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	RevertExpiresAt time.Time  `json:"revert_expires_at"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	RevertedAt      *time.Time `json:"reverted_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// EmailChangesExporter exports the user's email address changes that are
+// still within their revert window, without their token hashes.
+func EmailChangesExporter(emailChangeRepo repositories.EmailChangeRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		changes, err := emailChangeRepo.ListForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list email changes")
+		}
+		emailChanges := make([]emailChangeExport, 0, len(changes))
+		for _, change := range changes {
+			emailChanges = append(emailChanges, emailChangeExport{
+				ID:              change.ID,
+				OldEmail:        change.OldEmail,
+				NewEmail:        change.NewEmail,
+				ExpiresAt:       change.ExpiresAt,
+				RevertExpiresAt: change.RevertExpiresAt,
+				ConfirmedAt:     change.ConfirmedAt,
+				RevertedAt:      change.RevertedAt,
+				CreatedAt:       change.CreatedAt,
+			})
+		}
+		return emailChanges, nil
+	}
+}
+
+type twoFactorExport struct {
+	Enabled     bool       `json:"enabled"`
+	EnrolledAt  *time.Time `json:"enrolled_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+}
+
+// TwoFactorExporter exports the user's two-factor enrollment without the
+// TOTP secret.
+func TwoFactorExporter(totpRepo repositories.TOTPCredentialRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		credential, err := totpRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return twoFactorExport{}, nil
+			}
+			return nil, eris.Wrap(err, "failed to get totp credential")
+		}
+		return twoFactorExport{
+			Enabled:     credential.ConfirmedAt != nil,
+			EnrolledAt:  &credential.CreatedAt,
+			ConfirmedAt: credential.ConfirmedAt,
+		}, nil
+	}
+}
+
+type roleExport struct {
+	Name       string    `json:"name"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// RolesExporter exports the roles assigned to the user.
+func RolesExporter(roleRepo repositories.RoleRepository) services.DataExporter {
+	return func(ctx context.Context, userID uuid.UUID) (any, error) {
+		assignments, err := roleRepo.ListAssignmentsForUser(ctx, userID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to list role assignments")
+		}
+		roles := make([]roleExport, 0, len(assignments))
+		for _, assignment := range assignments {
+			roles = append(roles, roleExport{
+				Name:       assignment.Name,
+				AssignedAt: assignment.CreatedAt,
+			})
+		}
+		return roles, nil
+	}
+}
@@ -0,0 +1,434 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newEmailChangeTestConfig() *config.Config {
+	cfg := newTestConfig()
+	cfg.App.BaseURL = "https://app.example.com"
+	cfg.Auth.EmailChangeTokenTTL = 24 * time.Hour
+	cfg.Auth.EmailChangeRevertTTL = 7 * 24 * time.Hour
+	return cfg
+}
+
+func emailTakenError() error {
+	return eris.Wrap(&pgconn.PgError{Code: "23505", ConstraintName: "uq_users_email"}, "failed to update user email")
+}
+
+func TestEmailChangeService_Request(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com", PasswordHash: string(hash)}
+
+	var tokenHash, revertTokenHash string
+	var expiresAt, revertExpiresAt time.Time
+	payloads := map[string]tasks.EmailPayload{}
+
+	tests := []struct {
+		name         string
+		newEmail     string
+		password     string
+		setupMock    func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockEmailChangeRepository, *mocksSupport.MockTaskClient)
+		expectEmails bool
+		expectedErr  error
+	}{
+		{
+			name:     "returns error when password is wrong",
+			newEmail: "new@example.com",
+			password: "wrongpassword",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+			},
+			expectedErr: errors.ErrInvalidPassword,
+		},
+		{
+			name:     "returns error when new email matches current email",
+			newEmail: "Jane@Example.com",
+			password: "password123",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+			},
+			expectedErr: errors.ErrEmailUnchanged,
+		},
+		{
+			name:     "returns error when new email is taken",
+			newEmail: "taken@example.com",
+			password: "password123",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				userRepo.EXPECT().EmailExists(mock.Anything, "taken@example.com").Return(true, nil)
+			},
+			expectedErr: errors.ErrEmailAlreadyExists,
+		},
+		{
+			name:     "returns error when user not found",
+			newEmail: "new@example.com",
+			password: "password123",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrUserNotFound,
+		},
+		{
+			name:     "emails confirmation and revert links matching the stored hashes",
+			newEmail: "new@example.com",
+			password: "password123",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, taskClient *mocksSupport.MockTaskClient) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				userRepo.EXPECT().EmailExists(mock.Anything, "new@example.com").Return(false, nil)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				emailChangeRepo.EXPECT().DeletePendingForUser(mock.Anything, user.ID).Return(nil)
+				emailChangeRepo.EXPECT().Create(mock.Anything, user.ID, "jane@example.com", "new@example.com", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _ uuid.UUID, _, _, th, rth string, exp, revertExp time.Time) (*sqlcgen.EmailChange, error) {
+						tokenHash, revertTokenHash = th, rth
+						expiresAt, revertExpiresAt = exp, revertExp
+						return &sqlcgen.EmailChange{}, nil
+					})
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+						payload := p.(tasks.EmailPayload)
+						payloads[payload.Template] = payload
+					}).Times(2)
+			},
+			expectEmails: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockEmailChangeRepo := mocks.NewMockEmailChangeRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(mockPool, mockUserRepo, mockEmailChangeRepo, mockTaskClient)
+
+			service := services.NewEmailChangeService(newEmailChangeTestConfig(), mockUserRepo, mockEmailChangeRepo, mocks.NewMockAuthTokenRepository(t), mocks.NewMockAPIKeyRepository(t), db.NewTxManager(mockPool), mockTaskClient, newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			err = service.Request(ctx, user.ID, tt.newEmail, tt.password)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, mockPool.ExpectationsWereMet())
+			if tt.expectEmails {
+				assert.True(t, revertExpiresAt.After(expiresAt))
+
+				confirmation := payloads["email-change-confirmation"]
+				assert.Equal(t, "new@example.com", confirmation.To)
+				link, err := url.Parse(confirmation.Data["ConfirmationLink"].(string))
+				require.NoError(t, err)
+				assert.Equal(t, "/confirm-email-change", link.Path)
+				assert.Equal(t, tokenHash, newTestTokenHasher().Hash(link.Query().Get("token")))
+
+				notice := payloads["email-change-requested"]
+				assert.Equal(t, "jane@example.com", notice.To)
+				assert.Equal(t, "new@example.com", notice.Data["NewEmail"])
+				link, err = url.Parse(notice.Data["RevertLink"].(string))
+				require.NoError(t, err)
+				assert.Equal(t, "/revert-email-change", link.Path)
+				assert.Equal(t, revertTokenHash, newTestTokenHasher().Hash(link.Query().Get("token")))
+			}
+		})
+	}
+}
+
+func TestEmailChangeService_Confirm(t *testing.T) {
+	ctx := context.Background()
+	token := "confirm-token"
+	userID := uuid.New()
+	changeID := uuid.New()
+
+	pending := func() *sqlcgen.EmailChange {
+		return &sqlcgen.EmailChange{
+			ID:              changeID,
+			UserID:          userID,
+			OldEmail:        "old@example.com",
+			NewEmail:        "new@example.com",
+			ExpiresAt:       time.Now().Add(time.Hour),
+			RevertExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockEmailChangeRepository)
+		expectedErr error
+	}{
+		{
+			name: "updates the email",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(pending(), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				emailChangeRepo.EXPECT().MarkConfirmed(mock.Anything, changeID).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "old@example.com"}, nil)
+				userRepo.EXPECT().UpdateEmail(mock.Anything, userID, "new@example.com").Return(nil)
+			},
+		},
+		{
+			name: "returns error for unknown token",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "returns error for expired token",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				change := pending()
+				change.ExpiresAt = time.Now().Add(-time.Minute)
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(change, nil)
+			},
+			expectedErr: errors.ErrTokenExpired,
+		},
+		{
+			name: "returns error for reverted change",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				change := pending()
+				now := time.Now()
+				change.RevertedAt = &now
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(change, nil)
+			},
+			expectedErr: errors.ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "returns error when a concurrent request confirmed first",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(pending(), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				emailChangeRepo.EXPECT().MarkConfirmed(mock.Anything, changeID).Return(eris.Wrap(pgx.ErrNoRows, "no pending email change"))
+			},
+			expectedErr: errors.ErrTokenAlreadyUsed,
+		},
+		{
+			name: "returns error when the new email was claimed meanwhile",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(pending(), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				emailChangeRepo.EXPECT().MarkConfirmed(mock.Anything, changeID).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "old@example.com"}, nil)
+				userRepo.EXPECT().UpdateEmail(mock.Anything, userID, "new@example.com").Return(emailTakenError())
+			},
+			expectedErr: errors.ErrEmailAlreadyExists,
+		},
+		{
+			name: "returns error when the email changed since the request",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				emailChangeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(pending(), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				emailChangeRepo.EXPECT().MarkConfirmed(mock.Anything, changeID).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "other@example.com"}, nil)
+			},
+			expectedErr: errors.ErrInvalidEmailChangeToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockEmailChangeRepo := mocks.NewMockEmailChangeRepository(t)
+			tt.setupMock(mockPool, mockUserRepo, mockEmailChangeRepo)
+
+			service := services.NewEmailChangeService(newEmailChangeTestConfig(), mockUserRepo, mockEmailChangeRepo, mocks.NewMockAuthTokenRepository(t), mocks.NewMockAPIKeyRepository(t), db.NewTxManager(mockPool), mocksSupport.NewMockTaskClient(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			err = service.Confirm(ctx, token)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEmailChangeService_Revert(t *testing.T) {
+	ctx := context.Background()
+	token := "revert-token"
+	userID := uuid.New()
+	changeID := uuid.New()
+
+	change := func(confirmed bool) *sqlcgen.EmailChange {
+		c := &sqlcgen.EmailChange{
+			ID:              changeID,
+			UserID:          userID,
+			OldEmail:        "old@example.com",
+			NewEmail:        "new@example.com",
+			ExpiresAt:       time.Now().Add(-time.Hour),
+			RevertExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+		}
+		if confirmed {
+			now := time.Now()
+			c.ConfirmedAt = &now
+		}
+		return c
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockEmailChangeRepository, *mocks.MockAuthTokenRepository, *mocks.MockAPIKeyRepository)
+		expectedErr error
+	}{
+		{
+			name: "cancels a pending change",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(change(false), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+				apiKeyRepo.EXPECT().WithTx(mock.Anything).Return(apiKeyRepo)
+				emailChangeRepo.EXPECT().MarkReverted(mock.Anything, changeID).Return(nil)
+			},
+		},
+		{
+			name: "restores the old email and revokes sessions and api keys",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(change(true), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+				apiKeyRepo.EXPECT().WithTx(mock.Anything).Return(apiKeyRepo)
+				emailChangeRepo.EXPECT().MarkReverted(mock.Anything, changeID).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "new@example.com"}, nil)
+				userRepo.EXPECT().UpdateEmail(mock.Anything, userID, "old@example.com").Return(nil)
+				authTokenRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+				apiKeyRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name: "returns error when revoking api keys fails",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(change(true), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+				apiKeyRepo.EXPECT().WithTx(mock.Anything).Return(apiKeyRepo)
+				emailChangeRepo.EXPECT().MarkReverted(mock.Anything, changeID).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "new@example.com"}, nil)
+				userRepo.EXPECT().UpdateEmail(mock.Anything, userID, "old@example.com").Return(nil)
+				authTokenRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+				apiKeyRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "returns error for unknown token",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "returns error for already reverted change",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				c := change(true)
+				now := time.Now()
+				c.RevertedAt = &now
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(c, nil)
+			},
+			expectedErr: errors.ErrTokenAlreadyUsed,
+		},
+		{
+			name: "returns error once the revert window has passed",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				c := change(true)
+				c.RevertExpiresAt = time.Now().Add(-time.Minute)
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(c, nil)
+			},
+			expectedErr: errors.ErrTokenExpired,
+		},
+		{
+			name: "returns error when the old email was claimed meanwhile",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, emailChangeRepo *mocks.MockEmailChangeRepository, authTokenRepo *mocks.MockAuthTokenRepository, apiKeyRepo *mocks.MockAPIKeyRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				emailChangeRepo.EXPECT().GetByRevertTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(change(true), nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				emailChangeRepo.EXPECT().WithTx(mock.Anything).Return(emailChangeRepo)
+				authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+				apiKeyRepo.EXPECT().WithTx(mock.Anything).Return(apiKeyRepo)
+				emailChangeRepo.EXPECT().MarkReverted(mock.Anything, changeID).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "new@example.com"}, nil)
+				userRepo.EXPECT().UpdateEmail(mock.Anything, userID, "old@example.com").Return(emailTakenError())
+			},
+			expectedErr: errors.ErrEmailAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockEmailChangeRepo := mocks.NewMockEmailChangeRepository(t)
+			mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+			mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+			tt.setupMock(mockPool, mockUserRepo, mockEmailChangeRepo, mockAuthTokenRepo, mockAPIKeyRepo)
+
+			service := services.NewEmailChangeService(newEmailChangeTestConfig(), mockUserRepo, mockEmailChangeRepo, mockAuthTokenRepo, mockAPIKeyRepo, db.NewTxManager(mockPool), mocksSupport.NewMockTaskClient(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			err = service.Revert(ctx, token)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
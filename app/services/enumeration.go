@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-reasonable-api/app/interfaces/support"
+)
+
+// dummyPassword is hashed once to give logins for unknown emails a real
+// password hash to compare against.
+const dummyPassword = "enumeration-safe-dummy-password"
+
+// newDummyPasswordHash returns a function yielding a hash from hasher,
+// computed on first use. Verifying a password against it costs as much as
+// checking a real user's password.
+func newDummyPasswordHash(hasher support.PasswordHasher) func() string {
+	return sync.OnceValue(func() string {
+		hash, err := hasher.Hash(dummyPassword)
+		if err != nil {
+			return ""
+		}
+		return hash
+	})
+}
+
+// padResponseTime blocks until at least d has passed since start, so that a
+// request takes as long for an unknown email as for a registered one. It
+// returns early when ctx is done.
+func padResponseTime(ctx context.Context, start time.Time, d time.Duration) {
+	remaining := d - time.Since(start)
+	if remaining <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
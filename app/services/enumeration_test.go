@@ -0,0 +1,162 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestEnumerationSafe_EmailRequestsTakeMinResponseTime(t *testing.T) {
+	ctx := context.Background()
+	minResponseTime := 50 * time.Millisecond
+
+	// Each request is made for an email the user repository doesn't know
+	tests := []struct {
+		name    string
+		request func(t *testing.T, safe bool, userRepo *mocks.MockUserRepository) error
+	}{
+		{
+			name: "password reset",
+			request: func(t *testing.T, safe bool, userRepo *mocks.MockUserRepository) error {
+				service := services.NewPasswordResetService(enumerationTestConfig(safe, minResponseTime), userRepo, mocks.NewMockPasswordResetRepository(t), mocks.NewMockAuthTokenRepository(t), mocks.NewMockAPIKeyRepository(t), nil, mocksSupport.NewMockTaskClient(t), nil, newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+				return service.Create(ctx, "unknown@example.com")
+			},
+		},
+		{
+			name: "verification resend",
+			request: func(t *testing.T, safe bool, userRepo *mocks.MockUserRepository) error {
+				service := services.NewEmailVerificationService(enumerationTestConfig(safe, minResponseTime), userRepo, mocks.NewMockEmailVerificationRepository(t), nil, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+				return service.Resend(ctx, "unknown@example.com")
+			},
+		},
+		{
+			name: "magic link",
+			request: func(t *testing.T, safe bool, userRepo *mocks.MockUserRepository) error {
+				service := services.NewMagicLinkService(enumerationTestConfig(safe, minResponseTime), userRepo, mocks.NewMockMagicLinkRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockSessionService(t), nil, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+				return service.Create(ctx, "unknown@example.com")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" is padded when enumeration safe", func(t *testing.T) {
+			userRepo := mocks.NewMockUserRepository(t)
+			userRepo.EXPECT().GetByEmail(mock.Anything, "unknown@example.com").Return(nil, pgx.ErrNoRows)
+
+			start := time.Now()
+			err := tt.request(t, true, userRepo)
+
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, time.Since(start), minResponseTime)
+		})
+
+		t.Run(tt.name+" is not padded by default", func(t *testing.T) {
+			userRepo := mocks.NewMockUserRepository(t)
+			userRepo.EXPECT().GetByEmail(mock.Anything, "unknown@example.com").Return(nil, pgx.ErrNoRows)
+
+			start := time.Now()
+			err := tt.request(t, false, userRepo)
+
+			require.NoError(t, err)
+			assert.Less(t, time.Since(start), minResponseTime)
+		})
+	}
+}
+
+func TestEnumerationSafe_TakenEmailsTakeMinResponseTime(t *testing.T) {
+	ctx := context.Background()
+	minResponseTime := 50 * time.Millisecond
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com", PasswordHash: string(hash)}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockUserRepository, *mocksSupport.MockTaskClient)
+		request     func(t *testing.T, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) error
+		expectedErr error
+	}{
+		{
+			name: "signup",
+			setupMock: func(userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "taken@example.com").
+					Return(&sqlcgen.User{ID: uuid.New(), Email: "taken@example.com"}, nil)
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			},
+			request: func(t *testing.T, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) error {
+				service := services.NewUserService(enumerationTestConfig(true, minResponseTime), nil, userRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, taskClient, mocksServices.NewMockEmailVerificationService(t), allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+				_, err := service.Create(ctx, "Test User", "taken@example.com", "password123")
+				return err
+			},
+			expectedErr: errors.ErrEmailAlreadyExists,
+		},
+		{
+			name: "email change answers as if the change was requested",
+			setupMock: func(userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				userRepo.EXPECT().EmailExists(mock.Anything, "taken@example.com").Return(true, nil)
+			},
+			request: func(t *testing.T, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) error {
+				service := services.NewEmailChangeService(enumerationTestConfig(true, minResponseTime), userRepo, mocks.NewMockEmailChangeRepository(t), mocks.NewMockAuthTokenRepository(t), mocks.NewMockAPIKeyRepository(t), nil, taskClient, newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+				return service.Request(ctx, user.ID, "taken@example.com", "password123")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mocks.NewMockUserRepository(t)
+			taskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(userRepo, taskClient)
+
+			start := time.Now()
+			err := tt.request(t, userRepo, taskClient)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.GreaterOrEqual(t, time.Since(start), minResponseTime)
+		})
+	}
+}
+
+func TestEnumerationSafe_VerificationResendPadsVerifiedUsers(t *testing.T) {
+	minResponseTime := 50 * time.Millisecond
+	now := time.Now()
+	userRepo := mocks.NewMockUserRepository(t)
+	userRepo.EXPECT().GetByEmail(mock.Anything, "verified@example.com").
+		Return(&sqlcgen.User{ID: uuid.New(), Email: "verified@example.com", EmailVerifiedAt: &now}, nil)
+
+	service := services.NewEmailVerificationService(enumerationTestConfig(true, minResponseTime), userRepo, mocks.NewMockEmailVerificationRepository(t), nil, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+
+	start := time.Now()
+	err := service.Resend(context.Background(), "verified@example.com")
+
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), minResponseTime)
+}
+
+func enumerationTestConfig(safe bool, minResponseTime time.Duration) *config.Config {
+	cfg := newTestConfig()
+	cfg.Auth.EnumerationSafe = safe
+	cfg.Auth.MinResponseTime = minResponseTime
+	return cfg
+}
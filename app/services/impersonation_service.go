@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+type ImpersonationService struct {
+	config        *config.Config
+	txManager     *db.TxManager
+	userRepo      repositories.UserRepository
+	roleRepo      repositories.RoleRepository
+	authTokenRepo repositories.AuthTokenRepository
+	auditLogRepo  repositories.AuditLogRepository
+	tokenHasher   support.TokenHasher
+	tokenCache    support.TokenCache
+}
+
+func NewImpersonationService(cfg *config.Config, txManager *db.TxManager, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, authTokenRepo repositories.AuthTokenRepository, auditLogRepo repositories.AuditLogRepository, tokenHasher support.TokenHasher, tokenCache support.TokenCache) *ImpersonationService {
+	return &ImpersonationService{
+		config:        cfg,
+		txManager:     txManager,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		authTokenRepo: authTokenRepo,
+		auditLogRepo:  auditLogRepo,
+		tokenHasher:   tokenHasher,
+		tokenCache:    tokenCache,
+	}
+}
+
+func (s *ImpersonationService) Start(ctx context.Context, impersonatorID, userID uuid.UUID, client services.ClientInfo) (*services.SessionTokens, error) {
+	if impersonatorID == userID {
+		return nil, errors.ErrImpersonationNotAllowed
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, eris.Wrap(err, "failed to get user by ID")
+	}
+
+	// An impersonator acting as another impersonator could escalate to
+	// whatever that user's roles allow
+	permissions, err := s.roleRepo.ListPermissionNamesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list permissions")
+	}
+	if slices.Contains(permissions, services.PermissionUsersImpersonate) {
+		return nil, errors.ErrImpersonationNotAllowed
+	}
+
+	token, err := GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().UTC().Add(s.config.Auth.ImpersonationTTL)
+
+	var sessionID uuid.UUID
+	err = s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		authToken, err := s.authTokenRepo.WithTx(tx).CreateImpersonation(ctx, userID, impersonatorID, s.tokenHasher.Hash(token), expiresAt, client.UserAgent, client.IPAddress)
+		if err != nil {
+			return eris.Wrap(err, "failed to create impersonation session")
+		}
+		sessionID = authToken.ID
+
+		return s.audit(ctx, s.auditLogRepo.WithTx(tx), services.AuditActionImpersonationStarted, impersonatorID, userID, client, map[string]any{
+			"session_id": sessionID,
+			"expires_at": expiresAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.SessionTokens{
+		AccessToken:          token,
+		AccessTokenExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *ImpersonationService) End(ctx context.Context, sessionID, impersonatorID, userID uuid.UUID, client services.ClientInfo) error {
+	err := s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		if err := s.authTokenRepo.WithTx(tx).Revoke(ctx, sessionID); err != nil {
+			return eris.Wrap(err, "failed to revoke impersonation session")
+		}
+
+		return s.audit(ctx, s.auditLogRepo.WithTx(tx), services.AuditActionImpersonationEnded, impersonatorID, userID, client, map[string]any{
+			"session_id": sessionID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenCache.Invalidate(ctx, userID); err != nil {
+		return eris.Wrap(err, "failed to invalidate token cache")
+	}
+	return nil
+}
+
+func (s *ImpersonationService) audit(ctx context.Context, auditLogRepo repositories.AuditLogRepository, action string, impersonatorID, userID uuid.UUID, client services.ClientInfo, metadata map[string]any) error {
+	if err := auditLogRepo.Create(ctx, repositories.AuditLogEntry{
+		ActorID:   &impersonatorID,
+		SubjectID: &userID,
+		Action:    action,
+		Metadata:  metadata,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+	}); err != nil {
+		return eris.Wrap(err, "failed to write audit log")
+	}
+	return nil
+}
+
+var _ services.ImpersonationService = (*ImpersonationService)(nil)
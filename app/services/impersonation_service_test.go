@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newImpersonationTestConfig() *config.Config {
+	cfg := newTestConfig()
+	cfg.Auth.ImpersonationTTL = 15 * time.Minute
+	return cfg
+}
+
+func TestImpersonationService_Start(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+	userID := uuid.New()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+
+	t.Run("rejects impersonating yourself", func(t *testing.T) {
+		svc := services.NewImpersonationService(newImpersonationTestConfig(), nil, nil, nil, nil, nil, newTestTokenHasher(), newTestTokenCache())
+		_, err := svc.Start(ctx, adminID, adminID, client)
+
+		assert.ErrorIs(t, err, errors.ErrImpersonationNotAllowed)
+	})
+
+	t.Run("returns error for unknown user", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository(t)
+		userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, eris.Wrap(pgx.ErrNoRows, "not found"))
+
+		svc := services.NewImpersonationService(newImpersonationTestConfig(), nil, userRepo, nil, nil, nil, newTestTokenHasher(), newTestTokenCache())
+		_, err := svc.Start(ctx, adminID, userID, client)
+
+		assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	})
+
+	t.Run("rejects users who can impersonate", func(t *testing.T) {
+		userRepo := mocks.NewMockUserRepository(t)
+		roleRepo := mocks.NewMockRoleRepository(t)
+		userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+		roleRepo.EXPECT().ListPermissionNamesForUser(mock.Anything, userID).Return([]string{ifaces.PermissionUsersImpersonate}, nil)
+
+		svc := services.NewImpersonationService(newImpersonationTestConfig(), nil, userRepo, roleRepo, nil, nil, newTestTokenHasher(), newTestTokenCache())
+		_, err := svc.Start(ctx, adminID, userID, client)
+
+		assert.ErrorIs(t, err, errors.ErrImpersonationNotAllowed)
+	})
+
+	t.Run("creates session and writes audit log", func(t *testing.T) {
+		mockPool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mockPool.Close()
+
+		mockPool.ExpectBegin()
+		mockPool.ExpectCommit()
+
+		sessionID := uuid.New()
+		userRepo := mocks.NewMockUserRepository(t)
+		roleRepo := mocks.NewMockRoleRepository(t)
+		authTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		auditLogRepo := mocks.NewMockAuditLogRepository(t)
+
+		userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+		roleRepo.EXPECT().ListPermissionNamesForUser(mock.Anything, userID).Return(nil, nil)
+		authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+		auditLogRepo.EXPECT().WithTx(mock.Anything).Return(auditLogRepo)
+		authTokenRepo.EXPECT().CreateImpersonation(mock.Anything, userID, adminID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+			Return(&sqlcgen.AuthToken{ID: sessionID}, nil)
+		auditLogRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e repositories.AuditLogEntry) bool {
+			return e.Action == ifaces.AuditActionImpersonationStarted &&
+				*e.ActorID == adminID && *e.SubjectID == userID &&
+				e.Metadata["session_id"] == sessionID
+		})).Return(nil)
+
+		svc := services.NewImpersonationService(newImpersonationTestConfig(), db.NewTxManager(mockPool), userRepo, roleRepo, authTokenRepo, auditLogRepo, newTestTokenHasher(), newTestTokenCache())
+		tokens, err := svc.Start(ctx, adminID, userID, client)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.Empty(t, tokens.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), tokens.AccessTokenExpiresAt, time.Minute)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back session when audit log fails", func(t *testing.T) {
+		mockPool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mockPool.Close()
+
+		mockPool.ExpectBegin()
+		mockPool.ExpectRollback()
+
+		userRepo := mocks.NewMockUserRepository(t)
+		roleRepo := mocks.NewMockRoleRepository(t)
+		authTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		auditLogRepo := mocks.NewMockAuditLogRepository(t)
+
+		userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+		roleRepo.EXPECT().ListPermissionNamesForUser(mock.Anything, userID).Return(nil, nil)
+		authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+		auditLogRepo.EXPECT().WithTx(mock.Anything).Return(auditLogRepo)
+		authTokenRepo.EXPECT().CreateImpersonation(mock.Anything, userID, adminID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
+		auditLogRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		svc := services.NewImpersonationService(newImpersonationTestConfig(), db.NewTxManager(mockPool), userRepo, roleRepo, authTokenRepo, auditLogRepo, newTestTokenHasher(), newTestTokenCache())
+		_, err = svc.Start(ctx, adminID, userID, client)
+
+		require.Error(t, err)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestImpersonationService_End(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	mockPool.ExpectBegin()
+	mockPool.ExpectCommit()
+
+	authTokenRepo := mocks.NewMockAuthTokenRepository(t)
+	auditLogRepo := mocks.NewMockAuditLogRepository(t)
+	authTokenRepo.EXPECT().WithTx(mock.Anything).Return(authTokenRepo)
+	auditLogRepo.EXPECT().WithTx(mock.Anything).Return(auditLogRepo)
+	authTokenRepo.EXPECT().Revoke(mock.Anything, sessionID).Return(nil)
+	auditLogRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e repositories.AuditLogEntry) bool {
+		return e.Action == ifaces.AuditActionImpersonationEnded && *e.ActorID == adminID && *e.SubjectID == userID
+	})).Return(nil)
+
+	svc := services.NewImpersonationService(newImpersonationTestConfig(), db.NewTxManager(mockPool), nil, nil, authTokenRepo, auditLogRepo, newTestTokenHasher(), newTestTokenCache())
+	err = svc.End(ctx, sessionID, adminID, userID, ifaces.ClientInfo{})
+
+	require.NoError(t, err)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAlertService_RecordLogin(t *testing.T) {
+	ctx := context.Background()
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane", Email: "jane@example.com"}
+	client := ifaces.ClientInfo{
+		UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_6) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.6 Safari/605.1.15",
+		IPAddress: "203.0.113.7",
+	}
+	key := client.UserAgent + "\n" + client.IPAddress
+	fingerprint := newTestTokenHasher().Hash(key)
+	candidates := newTestTokenHasher().Candidates(key)
+
+	tests := []struct {
+		name       string
+		seenBefore bool
+		newDevice  bool
+		notifies   bool
+	}{
+		{
+			name:       "notifies about a new device",
+			seenBefore: true,
+			newDevice:  true,
+			notifies:   true,
+		},
+		{
+			name:       "does not notify about a known device",
+			seenBefore: true,
+			newDevice:  false,
+		},
+		{
+			name:       "does not notify about the first device",
+			seenBefore: false,
+			newDevice:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeviceRepo := mocks.NewMockLoginDeviceRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+			mockDeviceRepo.EXPECT().ExistsForUser(mock.Anything, user.ID).Return(tt.seenBefore, nil)
+			mockDeviceRepo.EXPECT().Create(mock.Anything, user.ID, fingerprint, candidates).Return(tt.newDevice, nil)
+
+			var payload tasks.EmailPayload
+			if tt.notifies {
+				mockTaskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+						payload = p.(tasks.EmailPayload)
+					})
+			}
+
+			service := services.NewLoginAlertService(newTestConfig(), mockDeviceRepo, mockTaskClient, newTestTokenHasher())
+			err := service.RecordLogin(ctx, user, client)
+
+			require.NoError(t, err)
+			if tt.notifies {
+				assert.Equal(t, "jane@example.com", payload.To)
+				assert.Equal(t, "new-sign-in", payload.Template)
+				assert.Equal(t, "Safari on macOS", payload.Data["Device"])
+				assert.Equal(t, "203.0.113.7", payload.Data["IPAddress"])
+				assert.Contains(t, payload.Data["SessionsLink"], "/settings/sessions")
+			}
+		})
+	}
+
+	t.Run("fingerprint depends on the ip address", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewMockLoginDeviceRepository(t)
+		other := ifaces.ClientInfo{UserAgent: client.UserAgent, IPAddress: "198.51.100.2"}
+
+		mockDeviceRepo.EXPECT().ExistsForUser(mock.Anything, user.ID).Return(false, nil)
+		mockDeviceRepo.EXPECT().Create(mock.Anything, user.ID, mock.MatchedBy(func(fp string) bool {
+			return fp != fingerprint
+		}), mock.AnythingOfType("[]string")).Return(true, nil)
+
+		service := services.NewLoginAlertService(newTestConfig(), mockDeviceRepo, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+		require.NoError(t, service.RecordLogin(ctx, user, other))
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewMockLoginDeviceRepository(t)
+		mockDeviceRepo.EXPECT().ExistsForUser(mock.Anything, user.ID).Return(false, assert.AnError)
+
+		service := services.NewLoginAlertService(newTestConfig(), mockDeviceRepo, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+		assert.ErrorIs(t, service.RecordLogin(ctx, user, client), assert.AnError)
+	})
+}
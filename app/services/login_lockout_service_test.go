@@ -0,0 +1,235 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	supporterrors "go-reasonable-api/support/errors"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lockoutEmailKey = "login:email:jane@example.com"
+	lockoutIPKey    = "login:ip:127.0.0.1"
+)
+
+func newLockoutTestConfig(enabled bool) *config.Config {
+	cfg := newTestConfig()
+	cfg.Lockout = config.LockoutConfig{
+		Enabled:          enabled,
+		MaxEmailAttempts: 5,
+		MaxIPAttempts:    20,
+		BaseDuration:     time.Minute,
+		MaxDuration:      10 * time.Minute,
+		Window:           24 * time.Hour,
+	}
+	return cfg
+}
+
+func assertRetryAfter(t *testing.T, err error, seconds int) {
+	t.Helper()
+	require.ErrorIs(t, err, errors.ErrLoginLocked)
+	appErr, ok := supporterrors.Is(err)
+	require.True(t, ok)
+	assert.Equal(t, seconds, appErr.Details["retry_after"])
+}
+
+func TestLoginLockoutService_Check(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		email              string
+		disabled           bool
+		setupMock          func(*mocksSupport.MockAttemptStore)
+		expectedRetryAfter int
+	}{
+		{
+			name:  "allows login when nothing is locked",
+			email: " Jane@Example.com",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore) {
+				attemptStore.EXPECT().LockedFor(mock.Anything, lockoutEmailKey).Return(0, nil)
+				attemptStore.EXPECT().LockedFor(mock.Anything, lockoutIPKey).Return(0, nil)
+			},
+		},
+		{
+			name:  "returns the time left when the email is locked",
+			email: "jane@example.com",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore) {
+				attemptStore.EXPECT().LockedFor(mock.Anything, lockoutEmailKey).Return(90*time.Second+time.Millisecond, nil)
+			},
+			expectedRetryAfter: 91,
+		},
+		{
+			name:  "returns error when the ip is locked",
+			email: "jane@example.com",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore) {
+				attemptStore.EXPECT().LockedFor(mock.Anything, lockoutEmailKey).Return(0, nil)
+				attemptStore.EXPECT().LockedFor(mock.Anything, lockoutIPKey).Return(time.Minute, nil)
+			},
+			expectedRetryAfter: 60,
+		},
+		{
+			name:      "does nothing when disabled",
+			email:     "jane@example.com",
+			disabled:  true,
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAttemptStore := mocksSupport.NewMockAttemptStore(t)
+			tt.setupMock(mockAttemptStore)
+
+			service := services.NewLoginLockoutService(newLockoutTestConfig(!tt.disabled), mockAttemptStore, mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t))
+			err := service.Check(ctx, tt.email, "127.0.0.1")
+
+			if tt.expectedRetryAfter != 0 {
+				assertRetryAfter(t, err, tt.expectedRetryAfter)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoginLockoutService_RecordFailure(t *testing.T) {
+	ctx := context.Background()
+	window := 24 * time.Hour
+	var payload tasks.EmailPayload
+
+	tests := []struct {
+		name               string
+		ip                 string
+		disabled           bool
+		setupMock          func(*mocksSupport.MockAttemptStore, *mocks.MockUserRepository, *mocksSupport.MockTaskClient)
+		expectEmail        bool
+		expectedRetryAfter int
+	}{
+		{
+			name: "counts failures below the limit",
+			ip:   "127.0.0.1",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutEmailKey, window).Return(4, nil)
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutIPKey, window).Return(4, nil)
+			},
+		},
+		{
+			name: "locks the email and notifies the owner at the limit",
+			ip:   "127.0.0.1",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutEmailKey, window).Return(5, nil)
+				attemptStore.EXPECT().Lock(mock.Anything, lockoutEmailKey, time.Minute).Return(nil)
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutIPKey, window).Return(5, nil)
+				userRepo.EXPECT().GetByEmail(mock.Anything, "jane@example.com").
+					Return(&sqlcgen.User{Name: "Jane Doe", Email: "jane@example.com"}, nil)
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+						payload = p.(tasks.EmailPayload)
+					})
+			},
+			expectEmail:        true,
+			expectedRetryAfter: 60,
+		},
+		{
+			name: "does not notify unknown emails",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutEmailKey, window).Return(5, nil)
+				attemptStore.EXPECT().Lock(mock.Anything, lockoutEmailKey, time.Minute).Return(nil)
+				userRepo.EXPECT().GetByEmail(mock.Anything, "jane@example.com").Return(nil, pgx.ErrNoRows)
+			},
+			expectedRetryAfter: 60,
+		},
+		{
+			name: "doubles the lock for every further failure",
+			ip:   "127.0.0.1",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutEmailKey, window).Return(7, nil)
+				attemptStore.EXPECT().Lock(mock.Anything, lockoutEmailKey, 4*time.Minute).Return(nil)
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutIPKey, window).Return(7, nil)
+			},
+			expectedRetryAfter: 240,
+		},
+		{
+			name: "caps the lock at the maximum duration",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutEmailKey, window).Return(100, nil)
+				attemptStore.EXPECT().Lock(mock.Anything, lockoutEmailKey, 10*time.Minute).Return(nil)
+			},
+			expectedRetryAfter: 600,
+		},
+		{
+			name: "locks the ip at its own limit",
+			ip:   "127.0.0.1",
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutEmailKey, window).Return(1, nil)
+				attemptStore.EXPECT().Fail(mock.Anything, lockoutIPKey, window).Return(21, nil)
+				attemptStore.EXPECT().Lock(mock.Anything, lockoutIPKey, 2*time.Minute).Return(nil)
+			},
+			expectedRetryAfter: 120,
+		},
+		{
+			name:     "does nothing when disabled",
+			ip:       "127.0.0.1",
+			disabled: true,
+			setupMock: func(attemptStore *mocksSupport.MockAttemptStore, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAttemptStore := mocksSupport.NewMockAttemptStore(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(mockAttemptStore, mockUserRepo, mockTaskClient)
+
+			service := services.NewLoginLockoutService(newLockoutTestConfig(!tt.disabled), mockAttemptStore, mockUserRepo, mockTaskClient)
+			err := service.RecordFailure(ctx, "jane@example.com", tt.ip)
+
+			if tt.expectedRetryAfter != 0 {
+				assertRetryAfter(t, err, tt.expectedRetryAfter)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.expectEmail {
+				assert.Equal(t, "login-locked", payload.Template)
+				assert.Equal(t, 1, payload.Data["LockedMinutes"])
+			}
+		})
+	}
+}
+
+func TestLoginLockoutService_RecordSuccess(t *testing.T) {
+	mockAttemptStore := mocksSupport.NewMockAttemptStore(t)
+	mockAttemptStore.EXPECT().Reset(mock.Anything, lockoutEmailKey).Return(nil)
+
+	service := services.NewLoginLockoutService(newLockoutTestConfig(true), mockAttemptStore, mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t))
+	err := service.RecordSuccess(context.Background(), "jane@example.com")
+
+	require.NoError(t, err)
+}
+
+func TestLoginLockoutService_Unlock(t *testing.T) {
+	mockAttemptStore := mocksSupport.NewMockAttemptStore(t)
+	mockAttemptStore.EXPECT().Reset(mock.Anything, lockoutEmailKey).Return(nil)
+
+	service := services.NewLoginLockoutService(newLockoutTestConfig(true), mockAttemptStore, mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t))
+	err := service.Unlock(context.Background(), "Jane@example.com")
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,248 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMagicLinkTestConfig() *config.Config {
+	cfg := newTestConfig()
+	cfg.App.BaseURL = "https://app.example.com"
+	cfg.Auth.MagicLinkTokenTTL = 15 * time.Minute
+	return cfg
+}
+
+func TestMagicLinkService_Create(t *testing.T) {
+	ctx := context.Background()
+	user := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com"}
+
+	var storedHash string
+	var expiresAt time.Time
+	var payload tasks.EmailPayload
+
+	tests := []struct {
+		name        string
+		email       string
+		setupMock   func(*mocks.MockUserRepository, *mocks.MockMagicLinkRepository, *mocksSupport.MockTaskClient)
+		expectEmail bool
+		expectedErr error
+	}{
+		{
+			name:  "emails a link whose token matches the stored hash",
+			email: user.Email,
+			setupMock: func(userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, user.Email).Return(user, nil)
+				magicLinkRepo.EXPECT().Create(mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _ uuid.UUID, tokenHash string, exp time.Time) (*sqlcgen.MagicLink, error) {
+						storedHash = tokenHash
+						expiresAt = exp
+						return &sqlcgen.MagicLink{}, nil
+					})
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+						payload = p.(tasks.EmailPayload)
+					})
+			},
+			expectEmail: true,
+		},
+		{
+			name:  "silently succeeds for an unknown email",
+			email: "unknown@example.com",
+			setupMock: func(userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "unknown@example.com").Return(nil, pgx.ErrNoRows)
+			},
+		},
+		{
+			name:  "returns error when storing the link fails",
+			email: user.Email,
+			setupMock: func(userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, taskClient *mocksSupport.MockTaskClient) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, user.Email).Return(user, nil)
+				magicLinkRepo.EXPECT().Create(mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockMagicLinkRepo := mocks.NewMockMagicLinkRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(mockUserRepo, mockMagicLinkRepo, mockTaskClient)
+
+			service := services.NewMagicLinkService(newMagicLinkTestConfig(), mockUserRepo, mockMagicLinkRepo, mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockSessionService(t), db.NewTxManager(mockPool), mockTaskClient, newTestTokenHasher())
+			err = service.Create(ctx, tt.email)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expectEmail {
+				assert.Equal(t, user.Email, payload.To)
+				assert.Equal(t, "magic-link", payload.Template)
+				assert.Equal(t, 15, payload.Data["ExpiresInMinutes"])
+
+				link, err := url.Parse(payload.Data["LoginLink"].(string))
+				require.NoError(t, err)
+				assert.Equal(t, "/magic-link", link.Path)
+				assert.Equal(t, newTestTokenHasher().Hash(link.Query().Get("token")), storedHash)
+				assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Minute)
+			}
+		})
+	}
+}
+
+func TestMagicLinkService_Execute(t *testing.T) {
+	ctx := context.Background()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	tokens := &ifaces.SessionTokens{AccessToken: "access-token"}
+	challenge := &ifaces.TwoFactorChallenge{Token: "challenge-token"}
+	token := "magic-token"
+	userID := uuid.New()
+	linkID := uuid.New()
+	verifiedAt := time.Now()
+
+	validLink := func() *sqlcgen.MagicLink {
+		return &sqlcgen.MagicLink{ID: linkID, UserID: userID, ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	tests := []struct {
+		name            string
+		setupMock       func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockMagicLinkRepository, *mocksServices.MockTwoFactorService, *mocksServices.MockSessionService)
+		expectChallenge bool
+		expectedErr     error
+	}{
+		{
+			name: "creates a session and verifies the email",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				user := &sqlcgen.User{ID: userID}
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				magicLinkRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(validLink(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				magicLinkRepo.EXPECT().WithTx(mock.Anything).Return(magicLinkRepo)
+				magicLinkRepo.EXPECT().MarkUsed(mock.Anything, linkID).Return(nil)
+				magicLinkRepo.EXPECT().InvalidateAllForUser(mock.Anything, userID).Return(nil)
+				userRepo.EXPECT().MarkEmailVerified(mock.Anything, userID).Return(nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+				sessionService.EXPECT().StartSession(mock.Anything, user, client).Return(tokens, nil)
+			},
+		},
+		{
+			name: "returns a challenge when two-factor is enabled",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				magicLinkRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(validLink(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, EmailVerifiedAt: &verifiedAt}, nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				magicLinkRepo.EXPECT().WithTx(mock.Anything).Return(magicLinkRepo)
+				magicLinkRepo.EXPECT().MarkUsed(mock.Anything, linkID).Return(nil)
+				magicLinkRepo.EXPECT().InvalidateAllForUser(mock.Anything, userID).Return(nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(true, nil)
+				twoFactor.EXPECT().CreateChallenge(mock.Anything, userID).Return(challenge, nil)
+			},
+			expectChallenge: true,
+		},
+		{
+			name: "returns error when a concurrent redemption wins",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				magicLinkRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(validLink(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				magicLinkRepo.EXPECT().WithTx(mock.Anything).Return(magicLinkRepo)
+				magicLinkRepo.EXPECT().MarkUsed(mock.Anything, linkID).Return(pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrTokenAlreadyUsed,
+		},
+		{
+			name: "returns error for an unknown token",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				magicLinkRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidMagicLink,
+		},
+		{
+			name: "returns error for a used link",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				link := validLink()
+				link.UsedAt = &verifiedAt
+				magicLinkRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(link, nil)
+			},
+			expectedErr: errors.ErrTokenAlreadyUsed,
+		},
+		{
+			name: "returns error for an expired link",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, magicLinkRepo *mocks.MockMagicLinkRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				link := validLink()
+				link.ExpiresAt = time.Now().Add(-time.Minute)
+				magicLinkRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates(token)).Return(link, nil)
+			},
+			expectedErr: errors.ErrTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockMagicLinkRepo := mocks.NewMockMagicLinkRepository(t)
+			mockTwoFactor := mocksServices.NewMockTwoFactorService(t)
+			mockSessionService := mocksServices.NewMockSessionService(t)
+			tt.setupMock(mockPool, mockUserRepo, mockMagicLinkRepo, mockTwoFactor, mockSessionService)
+
+			service := services.NewMagicLinkService(newMagicLinkTestConfig(), mockUserRepo, mockMagicLinkRepo, mockTwoFactor, mockSessionService, db.NewTxManager(mockPool), mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			result, err := service.Execute(ctx, token, client)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID, result.User.ID)
+				assert.NotNil(t, result.User.EmailVerifiedAt)
+				if tt.expectChallenge {
+					assert.Equal(t, challenge, result.Challenge)
+					assert.Nil(t, result.Tokens)
+				} else {
+					assert.Equal(t, tokens, result.Tokens)
+					assert.Nil(t, result.Challenge)
+				}
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// oidcStateLength is the number of random bytes in the state and nonce
+	oidcStateLength = 32
+
+	// oidcHTTPTimeout bounds each request to a provider
+	oidcHTTPTimeout = 10 * time.Second
+)
+
+// oidcDefaultScopes are requested when a provider configures none.
+var oidcDefaultScopes = []string{oidc.ScopeOpenID, "email", "profile"}
+
+type OIDCService struct {
+	config           *config.Config
+	txManager        *db.TxManager
+	providers        map[string]*oidcProvider
+	userRepo         repositories.UserRepository
+	identityRepo     repositories.UserIdentityRepository
+	stateRepo        repositories.OIDCLoginStateRepository
+	twoFactorService services.TwoFactorService
+	sessionService   services.SessionService
+}
+
+func NewOIDCService(
+	cfg *config.Config,
+	txManager *db.TxManager,
+	userRepo repositories.UserRepository,
+	identityRepo repositories.UserIdentityRepository,
+	stateRepo repositories.OIDCLoginStateRepository,
+	twoFactorService services.TwoFactorService,
+	sessionService services.SessionService,
+) *OIDCService {
+	httpClient := &http.Client{Timeout: oidcHTTPTimeout}
+
+	providers := make(map[string]*oidcProvider, len(cfg.OIDC.Providers))
+	for _, provider := range cfg.OIDC.Providers {
+		providers[provider.Name] = &oidcProvider{config: provider, httpClient: httpClient}
+	}
+
+	return &OIDCService{
+		config:           cfg,
+		txManager:        txManager,
+		providers:        providers,
+		userRepo:         userRepo,
+		identityRepo:     identityRepo,
+		stateRepo:        stateRepo,
+		twoFactorService: twoFactorService,
+		sessionService:   sessionService,
+	}
+}
+
+func (s *OIDCService) BeginLogin(ctx context.Context, providerName string) (string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", errors.ErrOIDCProviderNotFound
+	}
+
+	state, err := GenerateSecureToken(oidcStateLength)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := GenerateSecureToken(oidcStateLength)
+	if err != nil {
+		return "", err
+	}
+	codeVerifier := oauth2.GenerateVerifier()
+
+	oauth2Config, _, err := provider.discover(ctx)
+	if err != nil {
+		return "", eris.Wrap(err, "failed to discover oidc provider")
+	}
+	authURL := oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier))
+
+	expiresAt := time.Now().UTC().Add(s.config.OIDC.StateTTL)
+	if _, err := s.stateRepo.Create(ctx, providerName, HashToken(state), codeVerifier, nonce, expiresAt); err != nil {
+		return "", eris.Wrap(err, "failed to store oidc login state")
+	}
+
+	return authURL, nil
+}
+
+func (s *OIDCService) FinishLogin(ctx context.Context, providerName, code, state string, client services.ClientInfo) (*services.LoginResult, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, errors.ErrOIDCProviderNotFound
+	}
+
+	loginState, err := s.stateRepo.Consume(ctx, HashToken(state), providerName)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrInvalidOIDCLogin
+		}
+		return nil, eris.Wrap(err, "failed to consume oidc login state")
+	}
+	if time.Now().After(loginState.ExpiresAt) {
+		return nil, errors.ErrInvalidOIDCLogin
+	}
+
+	claims, err := provider.exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, errors.ErrInvalidOIDCLogin
+	}
+
+	user, err := s.resolveUser(ctx, providerName, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorEnabled, err := s.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to check two factor status")
+	}
+
+	if twoFactorEnabled {
+		challenge, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to create two factor challenge")
+		}
+		return &services.LoginResult{User: user, Challenge: challenge}, nil
+	}
+
+	tokens, err := s.sessionService.StartSession(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.LoginResult{User: user, Tokens: tokens}, nil
+}
+
+func (s *OIDCService) ListIdentities(ctx context.Context, userID uuid.UUID) ([]sqlcgen.UserIdentity, error) {
+	identities, err := s.identityRepo.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list identities")
+	}
+	return identities, nil
+}
+
+func (s *OIDCService) Unlink(ctx context.Context, userID, identityID uuid.UUID) error {
+	return s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		userRepoTx := s.userRepo.WithTx(tx)
+		identityRepoTx := s.identityRepo.WithTx(tx)
+
+		user, err := userRepoTx.GetByID(ctx, userID)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return errors.ErrUserNotFound
+			}
+			return eris.Wrap(err, "failed to get user")
+		}
+
+		if err := identityRepoTx.DeleteForUser(ctx, identityID, userID); err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return errors.ErrIdentityNotFound
+			}
+			return eris.Wrap(err, "failed to delete identity")
+		}
+
+		if user.PasswordHash != "" {
+			return nil
+		}
+
+		remaining, err := identityRepoTx.CountForUser(ctx, userID)
+		if err != nil {
+			return eris.Wrap(err, "failed to count identities")
+		}
+		if remaining == 0 {
+			return errors.ErrLastSignInMethod
+		}
+		return nil
+	})
+}
+
+// resolveUser returns the user an identity signs in, linking the identity to
+// an existing account or creating one on first login.
+func (s *OIDCService) resolveUser(ctx context.Context, providerName string, claims *oidcClaims) (*sqlcgen.User, error) {
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, providerName, claims.Subject)
+	if err == nil {
+		if err := s.identityRepo.RecordLogin(ctx, identity.ID, claims.Email); err != nil {
+			return nil, eris.Wrap(err, "failed to record identity login")
+		}
+
+		user, err := s.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return nil, errors.ErrInvalidOIDCLogin
+			}
+			return nil, eris.Wrap(err, "failed to get user")
+		}
+		return user, nil
+	}
+	if !eris.Is(err, pgx.ErrNoRows) {
+		return nil, eris.Wrap(err, "failed to get identity")
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, errors.ErrOIDCEmailNotVerified
+	}
+
+	var user *sqlcgen.User
+	err = s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		userRepoTx := s.userRepo.WithTx(tx)
+
+		user, err = userRepoTx.GetByEmail(ctx, claims.Email)
+		switch {
+		case err == nil:
+			if user.EmailVerifiedAt == nil {
+				return errors.ErrOIDCEmailConflict
+			}
+		case eris.Is(err, pgx.ErrNoRows):
+			// Accounts created here have no password; users can set one with
+			// a password reset
+			user, err = userRepoTx.Create(ctx, identityDisplayName(claims), claims.Email, "")
+			if err != nil {
+				return eris.Wrap(err, "failed to create user")
+			}
+			if err := userRepoTx.MarkEmailVerified(ctx, user.ID); err != nil {
+				return eris.Wrap(err, "failed to mark email as verified")
+			}
+			now := time.Now().UTC()
+			user.EmailVerifiedAt = &now
+		default:
+			return eris.Wrap(err, "failed to get user by email")
+		}
+
+		if _, err := s.identityRepo.WithTx(tx).Create(ctx, user.ID, providerName, claims.Subject, claims.Email); err != nil {
+			return eris.Wrap(err, "failed to create identity")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// identityDisplayName picks a name for an account created from an identity,
+// falling back to the email's local part when the provider sent no name.
+func identityDisplayName(claims *oidcClaims) string {
+	if name := strings.TrimSpace(claims.Name); name != "" {
+		return name
+	}
+	local, _, _ := strings.Cut(claims.Email, "@")
+	return local
+}
+
+// oidcProvider is one configured OpenID provider. Discovery happens on first
+// use, so an unreachable provider does not prevent startup. Only ID token
+// claims are used; the userinfo endpoint is not called, so providers must
+// include the email claims in the ID token.
+type oidcProvider struct {
+	config     config.OIDCProviderConfig
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	oauth2Config *oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+}
+
+// oidcClaims is the subset of ID token claims used to link identities.
+type oidcClaims struct {
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified oidcBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// oidcBool accepts "true"/"false" strings, which some providers send for
+// email_verified.
+type oidcBool bool
+
+func (b *oidcBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", `"true"`:
+		*b = true
+	case "false", `"false"`, "null":
+		*b = false
+	default:
+		return eris.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// discover fetches the provider's metadata and returns the OAuth2 client
+// config and ID token verifier built from it. A failed discovery is retried
+// on the next call.
+func (p *oidcProvider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth2Config != nil {
+		return p.oauth2Config, p.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, p.httpClient), p.config.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = oidcDefaultScopes
+	}
+	if !slices.Contains(scopes, oidc.ScopeOpenID) {
+		scopes = append([]string{oidc.ScopeOpenID}, scopes...)
+	}
+
+	p.oauth2Config = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.config.ClientID})
+	return p.oauth2Config, p.verifier, nil
+}
+
+// exchange redeems an authorization code and returns the verified ID token
+// claims. nonce must match the one sent with the authorization request.
+func (p *oidcProvider) exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidcClaims, error) {
+	oauth2Config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, p.httpClient)
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, eris.Wrap(err, "token request failed")
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, eris.New("token response has no id_token")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, eris.Wrap(err, "invalid id token")
+	}
+	if idToken.Nonce != nonce {
+		return nil, eris.New("id token nonce mismatch")
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, eris.Wrap(err, "failed to decode id token claims")
+	}
+	return &claims, nil
+}
+
+var _ services.OIDCService = (*OIDCService)(nil)
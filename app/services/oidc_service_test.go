@@ -0,0 +1,486 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+	"go-reasonable-api/support/oidc/oidctest"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const (
+	testOIDCProvider     = "example"
+	testOIDCClientID     = "client-id"
+	testOIDCClientSecret = "client-secret"
+)
+
+var testOIDCUser = oidctest.User{
+	Subject:       "subject-1",
+	Email:         "jane@example.com",
+	EmailVerified: true,
+	Name:          "Jane Doe",
+}
+
+func newOIDCTestConfig(issuer string) *config.Config {
+	return &config.Config{
+		OIDC: config.OIDCConfig{
+			StateTTL: 10 * time.Minute,
+			Providers: []config.OIDCProviderConfig{{
+				Name:         testOIDCProvider,
+				Issuer:       issuer,
+				ClientID:     testOIDCClientID,
+				ClientSecret: testOIDCClientSecret,
+				RedirectURL:  "https://app.example.com/auth/callback",
+			}},
+		},
+	}
+}
+
+func newTestOIDCServer(t *testing.T) *oidctest.Server {
+	server, err := oidctest.NewServer(testOIDCClientID, testOIDCClientSecret)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// authorizeOIDC runs BeginLogin and signs in at server as user, returning the
+// code and state the provider redirects back with along with the login state
+// BeginLogin stored.
+func authorizeOIDC(t *testing.T, service *services.OIDCService, server *oidctest.Server, stateRepo *mocks.MockOIDCLoginStateRepository, user oidctest.User) (code, state string, stored *sqlcgen.OidcLoginState) {
+	stored = &sqlcgen.OidcLoginState{}
+	stateRepo.EXPECT().Create(mock.Anything, testOIDCProvider, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		RunAndReturn(func(_ context.Context, provider, stateHash, codeVerifier, nonce string, expiresAt time.Time) (*sqlcgen.OidcLoginState, error) {
+			*stored = sqlcgen.OidcLoginState{
+				Provider:     provider,
+				StateHash:    stateHash,
+				CodeVerifier: codeVerifier,
+				Nonce:        nonce,
+				ExpiresAt:    expiresAt,
+			}
+			return stored, nil
+		}).Once()
+
+	authURL, err := service.BeginLogin(context.Background(), testOIDCProvider)
+	require.NoError(t, err)
+
+	code, state, err = server.Authorize(authURL, user)
+	require.NoError(t, err)
+	return code, state, stored
+}
+
+func TestOIDCService_BeginLogin(t *testing.T) {
+	ctx := context.Background()
+	var stored sqlcgen.OidcLoginState
+
+	tests := []struct {
+		name        string
+		provider    string
+		setupMock   func(*mocks.MockOIDCLoginStateRepository)
+		expectedErr error
+	}{
+		{
+			name:     "returns the authorization url and stores the state hash",
+			provider: testOIDCProvider,
+			setupMock: func(stateRepo *mocks.MockOIDCLoginStateRepository) {
+				stateRepo.EXPECT().Create(mock.Anything, testOIDCProvider, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _, stateHash, codeVerifier, nonce string, _ time.Time) (*sqlcgen.OidcLoginState, error) {
+						stored = sqlcgen.OidcLoginState{StateHash: stateHash, CodeVerifier: codeVerifier, Nonce: nonce}
+						return &stored, nil
+					})
+			},
+		},
+		{
+			name:        "returns error for an unknown provider",
+			provider:    "unknown",
+			setupMock:   func(stateRepo *mocks.MockOIDCLoginStateRepository) {},
+			expectedErr: errors.ErrOIDCProviderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			server := newTestOIDCServer(t)
+			mockStateRepo := mocks.NewMockOIDCLoginStateRepository(t)
+			tt.setupMock(mockStateRepo)
+
+			service := services.NewOIDCService(newOIDCTestConfig(server.Issuer()), db.NewTxManager(mockPool), mocks.NewMockUserRepository(t), mocks.NewMockUserIdentityRepository(t), mockStateRepo, mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockSessionService(t))
+			authURL, err := service.BeginLogin(ctx, tt.provider)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, authURL)
+				return
+			}
+
+			require.NoError(t, err)
+			parsed, err := url.Parse(authURL)
+			require.NoError(t, err)
+			query := parsed.Query()
+			assert.Equal(t, server.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+			assert.Equal(t, services.HashToken(query.Get("state")), stored.StateHash)
+			assert.Equal(t, "openid email profile", query.Get("scope"))
+			assert.Equal(t, stored.Nonce, query.Get("nonce"))
+			assert.Equal(t, oauth2.S256ChallengeFromVerifier(stored.CodeVerifier), query.Get("code_challenge"))
+			assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		})
+	}
+}
+
+func TestOIDCService_FinishLogin(t *testing.T) {
+	ctx := context.Background()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	tokens := &ifaces.SessionTokens{AccessToken: "access-token"}
+	challenge := &ifaces.TwoFactorChallenge{Token: "challenge-token"}
+	userID := uuid.New()
+	identityID := uuid.New()
+	verifiedAt := time.Now()
+	verifiedUser := &sqlcgen.User{ID: userID, Email: testOIDCUser.Email, EmailVerifiedAt: &verifiedAt}
+	unnamedUser := oidctest.User{Subject: "subject-2", Email: "john@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name            string
+		user            oidctest.User
+		code            string
+		tamperState     func(*sqlcgen.OidcLoginState)
+		setupMock       func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockUserIdentityRepository, *mocksServices.MockTwoFactorService, *mocksServices.MockSessionService)
+		expectChallenge bool
+		expectedErr     error
+	}{
+		{
+			name: "signs in a linked identity",
+			user: testOIDCUser,
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, testOIDCUser.Subject).
+					Return(&sqlcgen.UserIdentity{ID: identityID, UserID: userID}, nil)
+				identityRepo.EXPECT().RecordLogin(mock.Anything, identityID, testOIDCUser.Email).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(verifiedUser, nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+				sessionService.EXPECT().StartSession(mock.Anything, verifiedUser, client).Return(tokens, nil)
+			},
+		},
+		{
+			name: "returns a challenge when two-factor is enabled",
+			user: testOIDCUser,
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, testOIDCUser.Subject).
+					Return(&sqlcgen.UserIdentity{ID: identityID, UserID: userID}, nil)
+				identityRepo.EXPECT().RecordLogin(mock.Anything, identityID, testOIDCUser.Email).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(verifiedUser, nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(true, nil)
+				twoFactor.EXPECT().CreateChallenge(mock.Anything, userID).Return(challenge, nil)
+			},
+			expectChallenge: true,
+		},
+		{
+			name: "links the identity to a verified account with the same email",
+			user: testOIDCUser,
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, testOIDCUser.Subject).Return(nil, pgx.ErrNoRows)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				identityRepo.EXPECT().WithTx(mock.Anything).Return(identityRepo)
+				userRepo.EXPECT().GetByEmail(mock.Anything, testOIDCUser.Email).Return(verifiedUser, nil)
+				identityRepo.EXPECT().Create(mock.Anything, userID, testOIDCProvider, testOIDCUser.Subject, testOIDCUser.Email).
+					Return(&sqlcgen.UserIdentity{}, nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+				sessionService.EXPECT().StartSession(mock.Anything, verifiedUser, client).Return(tokens, nil)
+			},
+		},
+		{
+			name: "creates a verified account without a password",
+			user: testOIDCUser,
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, testOIDCUser.Subject).Return(nil, pgx.ErrNoRows)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				identityRepo.EXPECT().WithTx(mock.Anything).Return(identityRepo)
+				userRepo.EXPECT().GetByEmail(mock.Anything, testOIDCUser.Email).Return(nil, pgx.ErrNoRows)
+				userRepo.EXPECT().Create(mock.Anything, testOIDCUser.Name, testOIDCUser.Email, "").
+					Return(&sqlcgen.User{ID: userID, Name: testOIDCUser.Name, Email: testOIDCUser.Email}, nil)
+				userRepo.EXPECT().MarkEmailVerified(mock.Anything, userID).Return(nil)
+				identityRepo.EXPECT().Create(mock.Anything, userID, testOIDCProvider, testOIDCUser.Subject, testOIDCUser.Email).
+					Return(&sqlcgen.UserIdentity{}, nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+				sessionService.EXPECT().StartSession(mock.Anything, mock.Anything, client).Return(tokens, nil)
+			},
+		},
+		{
+			name: "names new accounts after the email when the provider sends no name",
+			user: unnamedUser,
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, unnamedUser.Subject).Return(nil, pgx.ErrNoRows)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				identityRepo.EXPECT().WithTx(mock.Anything).Return(identityRepo)
+				userRepo.EXPECT().GetByEmail(mock.Anything, unnamedUser.Email).Return(nil, pgx.ErrNoRows)
+				userRepo.EXPECT().Create(mock.Anything, "john", unnamedUser.Email, "").Return(&sqlcgen.User{ID: userID}, nil)
+				userRepo.EXPECT().MarkEmailVerified(mock.Anything, userID).Return(nil)
+				identityRepo.EXPECT().Create(mock.Anything, userID, testOIDCProvider, unnamedUser.Subject, unnamedUser.Email).
+					Return(&sqlcgen.UserIdentity{}, nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+				sessionService.EXPECT().StartSession(mock.Anything, mock.Anything, client).Return(tokens, nil)
+			},
+		},
+		{
+			name: "refuses to link an account whose email is not verified",
+			user: testOIDCUser,
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, testOIDCUser.Subject).Return(nil, pgx.ErrNoRows)
+				userRepo.EXPECT().WithTx(mock.Anything).Return(userRepo)
+				userRepo.EXPECT().GetByEmail(mock.Anything, testOIDCUser.Email).Return(&sqlcgen.User{ID: userID}, nil)
+			},
+			expectedErr: errors.ErrOIDCEmailConflict,
+		},
+		{
+			name: "refuses a new identity without a verified email",
+			user: oidctest.User{Subject: "subject-3", Email: "jane@example.com"},
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+				identityRepo.EXPECT().GetByProviderSubject(mock.Anything, testOIDCProvider, "subject-3").Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrOIDCEmailNotVerified,
+		},
+		{
+			name: "returns error when the code is rejected",
+			user: testOIDCUser,
+			code: "wrong-code",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+			},
+			expectedErr: errors.ErrInvalidOIDCLogin,
+		},
+		{
+			name: "returns error when the code verifier does not match",
+			user: testOIDCUser,
+			tamperState: func(state *sqlcgen.OidcLoginState) {
+				state.CodeVerifier = oauth2.GenerateVerifier()
+			},
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+			},
+			expectedErr: errors.ErrInvalidOIDCLogin,
+		},
+		{
+			name: "returns error when the id token nonce does not match",
+			user: testOIDCUser,
+			tamperState: func(state *sqlcgen.OidcLoginState) {
+				state.Nonce = "other-nonce"
+			},
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+			},
+			expectedErr: errors.ErrInvalidOIDCLogin,
+		},
+		{
+			name: "returns error for an expired state",
+			user: testOIDCUser,
+			tamperState: func(state *sqlcgen.OidcLoginState) {
+				state.ExpiresAt = time.Now().Add(-time.Minute)
+			},
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository, twoFactor *mocksServices.MockTwoFactorService, sessionService *mocksServices.MockSessionService) {
+			},
+			expectedErr: errors.ErrInvalidOIDCLogin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			server := newTestOIDCServer(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockIdentityRepo := mocks.NewMockUserIdentityRepository(t)
+			mockStateRepo := mocks.NewMockOIDCLoginStateRepository(t)
+			mockTwoFactor := mocksServices.NewMockTwoFactorService(t)
+			mockSessionService := mocksServices.NewMockSessionService(t)
+
+			service := services.NewOIDCService(newOIDCTestConfig(server.Issuer()), db.NewTxManager(mockPool), mockUserRepo, mockIdentityRepo, mockStateRepo, mockTwoFactor, mockSessionService)
+			code, state, stored := authorizeOIDC(t, service, server, mockStateRepo, tt.user)
+			if tt.code != "" {
+				code = tt.code
+			}
+			if tt.tamperState != nil {
+				tt.tamperState(stored)
+			}
+			mockStateRepo.EXPECT().Consume(mock.Anything, services.HashToken(state), testOIDCProvider).Return(stored, nil)
+			tt.setupMock(mockPool, mockUserRepo, mockIdentityRepo, mockTwoFactor, mockSessionService)
+
+			result, err := service.FinishLogin(ctx, testOIDCProvider, code, state, client)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID, result.User.ID)
+				if tt.expectChallenge {
+					assert.Equal(t, challenge, result.Challenge)
+					assert.Nil(t, result.Tokens)
+				} else {
+					assert.Equal(t, tokens, result.Tokens)
+					assert.Nil(t, result.Challenge)
+					assert.NotNil(t, result.User.EmailVerifiedAt)
+				}
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOIDCService_FinishLogin_InvalidRequest(t *testing.T) {
+	ctx := context.Background()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+
+	tests := []struct {
+		name        string
+		provider    string
+		state       string
+		setupMock   func(*mocks.MockOIDCLoginStateRepository)
+		expectedErr error
+	}{
+		{
+			name:     "returns error for an unknown state",
+			provider: testOIDCProvider,
+			state:    "unknown",
+			setupMock: func(stateRepo *mocks.MockOIDCLoginStateRepository) {
+				stateRepo.EXPECT().Consume(mock.Anything, services.HashToken("unknown"), testOIDCProvider).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidOIDCLogin,
+		},
+		{
+			name:     "returns error when consuming the state fails",
+			provider: testOIDCProvider,
+			state:    "state",
+			setupMock: func(stateRepo *mocks.MockOIDCLoginStateRepository) {
+				stateRepo.EXPECT().Consume(mock.Anything, services.HashToken("state"), testOIDCProvider).Return(nil, assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name:        "returns error for an unknown provider",
+			provider:    "unknown",
+			state:       "state",
+			setupMock:   func(stateRepo *mocks.MockOIDCLoginStateRepository) {},
+			expectedErr: errors.ErrOIDCProviderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			server := newTestOIDCServer(t)
+			mockStateRepo := mocks.NewMockOIDCLoginStateRepository(t)
+			tt.setupMock(mockStateRepo)
+
+			service := services.NewOIDCService(newOIDCTestConfig(server.Issuer()), db.NewTxManager(mockPool), mocks.NewMockUserRepository(t), mocks.NewMockUserIdentityRepository(t), mockStateRepo, mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockSessionService(t))
+			result, err := service.FinishLogin(ctx, tt.provider, "code", tt.state, client)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestOIDCService_Unlink(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	identityID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockUserIdentityRepository)
+		expectedErr error
+	}{
+		{
+			name: "unlinks an identity from an account with a password",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, PasswordHash: "hash"}, nil)
+				identityRepo.EXPECT().DeleteForUser(mock.Anything, identityID, userID).Return(nil)
+			},
+		},
+		{
+			name: "unlinks one of several identities from an account without a password",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				identityRepo.EXPECT().DeleteForUser(mock.Anything, identityID, userID).Return(nil)
+				identityRepo.EXPECT().CountForUser(mock.Anything, userID).Return(1, nil)
+			},
+		},
+		{
+			name: "refuses to unlink the last identity of an account without a password",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				identityRepo.EXPECT().DeleteForUser(mock.Anything, identityID, userID).Return(nil)
+				identityRepo.EXPECT().CountForUser(mock.Anything, userID).Return(0, nil)
+			},
+			expectedErr: errors.ErrLastSignInMethod,
+		},
+		{
+			name: "returns error when the identity is not found",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, identityRepo *mocks.MockUserIdentityRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, PasswordHash: "hash"}, nil)
+				identityRepo.EXPECT().DeleteForUser(mock.Anything, identityID, userID).Return(pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrIdentityNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockIdentityRepo := mocks.NewMockUserIdentityRepository(t)
+			mockUserRepo.EXPECT().WithTx(mock.Anything).Return(mockUserRepo)
+			mockIdentityRepo.EXPECT().WithTx(mock.Anything).Return(mockIdentityRepo)
+			tt.setupMock(mockPool, mockUserRepo, mockIdentityRepo)
+
+			service := services.NewOIDCService(newOIDCTestConfig("https://issuer.example.com"), db.NewTxManager(mockPool), mockUserRepo, mockIdentityRepo, mocks.NewMockOIDCLoginStateRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockSessionService(t))
+			err = service.Unlink(ctx, userID, identityID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,654 @@
+package services_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrganizationTestConfig() *config.Config {
+	cfg := newTestConfig()
+	cfg.App.BaseURL = "https://app.example.com"
+	cfg.Organizations.InvitationTTL = 7 * 24 * time.Hour
+	return cfg
+}
+
+func TestOrgRoleAtLeast(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		minimum  string
+		expected bool
+	}{
+		{name: "owner is at least admin", role: ifaces.OrgRoleOwner, minimum: ifaces.OrgRoleAdmin, expected: true},
+		{name: "admin is at least admin", role: ifaces.OrgRoleAdmin, minimum: ifaces.OrgRoleAdmin, expected: true},
+		{name: "member is at least member", role: ifaces.OrgRoleMember, minimum: ifaces.OrgRoleMember, expected: true},
+		{name: "member is not admin", role: ifaces.OrgRoleMember, minimum: ifaces.OrgRoleAdmin},
+		{name: "admin is not owner", role: ifaces.OrgRoleAdmin, minimum: ifaces.OrgRoleOwner},
+		{name: "unknown role is not member", role: "guest", minimum: ifaces.OrgRoleMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ifaces.OrgRoleAtLeast(tt.role, tt.minimum))
+		})
+	}
+}
+
+func TestOrganizationService_Create(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	organization := &sqlcgen.Organization{ID: uuid.New(), Name: "Acme"}
+	deletionScheduledAt := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockOrganizationRepository, *mocks.MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "makes the creator the owner",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				orgRepo.EXPECT().Create(mock.Anything, "Acme").Return(organization, nil)
+				orgRepo.EXPECT().AddMember(mock.Anything, organization.ID, userID, ifaces.OrgRoleOwner).Return(true, nil)
+			},
+		},
+		{
+			name: "rolls back when the owner cannot be added",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				orgRepo.EXPECT().Create(mock.Anything, "Acme").Return(organization, nil)
+				orgRepo.EXPECT().AddMember(mock.Anything, organization.ID, userID, ifaces.OrgRoleOwner).Return(false, assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "returns error when the user's deletion is scheduled",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, DeletionScheduledAt: &deletionScheduledAt}, nil)
+			},
+			expectedErr: errors.ErrDeletionScheduled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			tt.setupMock(mockPool, mockOrgRepo, mockUserRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), db.NewTxManager(mockPool), mockOrgRepo, mocks.NewMockOrganizationInvitationRepository(t), mockUserRepo, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			created, err := service.Create(ctx, userID, "Acme")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, created)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, organization, created)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrganizationService_GetMemberRole(t *testing.T) {
+	ctx := context.Background()
+	orgID, userID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		setupMock    func(*mocks.MockOrganizationRepository)
+		expectedRole string
+		expectedErr  error
+	}{
+		{
+			name: "returns the role",
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(ifaces.OrgRoleAdmin, nil)
+			},
+			expectedRole: ifaces.OrgRoleAdmin,
+		},
+		{
+			name: "hides organizations from non-members",
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return("", eris.Wrap(pgx.ErrNoRows, "failed to get organization member role"))
+			},
+			expectedErr: errors.ErrOrganizationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			tt.setupMock(mockOrgRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), nil, mockOrgRepo, mocks.NewMockOrganizationInvitationRepository(t), mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			role, err := service.GetMemberRole(ctx, orgID, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedRole, role)
+			}
+		})
+	}
+}
+
+func TestOrganizationService_UpdateMemberRole(t *testing.T) {
+	ctx := context.Background()
+	orgID, userID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		role        string
+		setupMock   func(*mocks.MockOrganizationRepository)
+		expectedErr error
+	}{
+		{
+			name: "updates the role",
+			role: ifaces.OrgRoleAdmin,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(ifaces.OrgRoleMember, nil)
+				orgRepo.EXPECT().UpdateMemberRole(mock.Anything, orgID, userID, ifaces.OrgRoleAdmin).Return(nil)
+			},
+		},
+		{
+			name:        "rejects granting ownership",
+			role:        ifaces.OrgRoleOwner,
+			setupMock:   func(orgRepo *mocks.MockOrganizationRepository) {},
+			expectedErr: errors.ErrInvalidOrganizationRole,
+		},
+		{
+			name: "rejects changing the owner",
+			role: ifaces.OrgRoleMember,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(ifaces.OrgRoleOwner, nil)
+			},
+			expectedErr: errors.ErrOrganizationOwner,
+		},
+		{
+			name: "returns error when the user is not a member",
+			role: ifaces.OrgRoleAdmin,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return("", eris.Wrap(pgx.ErrNoRows, "failed to get organization member role"))
+			},
+			expectedErr: errors.ErrOrganizationMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			tt.setupMock(mockOrgRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), nil, mockOrgRepo, mocks.NewMockOrganizationInvitationRepository(t), mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			err := service.UpdateMemberRole(ctx, orgID, userID, tt.role)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrganizationService_RemoveMember(t *testing.T) {
+	ctx := context.Background()
+	orgID, actorID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		actorID     uuid.UUID
+		setupMock   func(*mocks.MockOrganizationRepository)
+		expectedErr error
+	}{
+		{
+			name:    "lets members leave",
+			actorID: userID,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(ifaces.OrgRoleMember, nil)
+				orgRepo.EXPECT().RemoveMember(mock.Anything, orgID, userID).Return(nil)
+			},
+		},
+		{
+			name:    "lets admins remove members",
+			actorID: actorID,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, actorID).Return(ifaces.OrgRoleAdmin, nil)
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(ifaces.OrgRoleMember, nil)
+				orgRepo.EXPECT().RemoveMember(mock.Anything, orgID, userID).Return(nil)
+			},
+		},
+		{
+			name:    "requires an admin to remove someone else",
+			actorID: actorID,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, actorID).Return(ifaces.OrgRoleMember, nil)
+			},
+			expectedErr: errors.ErrPermissionDenied,
+		},
+		{
+			name:    "rejects removing the owner",
+			actorID: actorID,
+			setupMock: func(orgRepo *mocks.MockOrganizationRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, actorID).Return(ifaces.OrgRoleAdmin, nil)
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(ifaces.OrgRoleOwner, nil)
+			},
+			expectedErr: errors.ErrOrganizationOwner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			tt.setupMock(mockOrgRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), nil, mockOrgRepo, mocks.NewMockOrganizationInvitationRepository(t), mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			err := service.RemoveMember(ctx, orgID, tt.actorID, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrganizationService_TransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	orgID, ownerID, newOwnerID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockOrganizationRepository, *mocks.MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "demotes the owner before promoting the new one",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, newOwnerID).Return(ifaces.OrgRoleMember, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, newOwnerID).Return(&sqlcgen.User{ID: newOwnerID}, nil)
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				demote := orgRepo.EXPECT().UpdateMemberRole(mock.Anything, orgID, ownerID, ifaces.OrgRoleAdmin).Return(nil).Call
+				orgRepo.EXPECT().UpdateMemberRole(mock.Anything, orgID, newOwnerID, ifaces.OrgRoleOwner).Return(nil).NotBefore(demote)
+			},
+		},
+		{
+			name: "returns error when the new owner is not a member",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, newOwnerID).Return("", eris.Wrap(pgx.ErrNoRows, "failed to get organization member role"))
+			},
+			expectedErr: errors.ErrOrganizationMemberNotFound,
+		},
+		{
+			name: "returns error when the new owner is scheduled for deletion",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				scheduledAt := time.Now().Add(24 * time.Hour)
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, newOwnerID).Return(ifaces.OrgRoleMember, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, newOwnerID).Return(&sqlcgen.User{ID: newOwnerID, DeletionScheduledAt: &scheduledAt}, nil)
+			},
+			expectedErr: errors.ErrOwnerDeletionScheduled,
+		},
+		{
+			name: "rolls back when promotion fails",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, userRepo *mocks.MockUserRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				orgRepo.EXPECT().GetMemberRole(mock.Anything, orgID, newOwnerID).Return(ifaces.OrgRoleMember, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, newOwnerID).Return(&sqlcgen.User{ID: newOwnerID}, nil)
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				orgRepo.EXPECT().UpdateMemberRole(mock.Anything, orgID, ownerID, ifaces.OrgRoleAdmin).Return(nil)
+				orgRepo.EXPECT().UpdateMemberRole(mock.Anything, orgID, newOwnerID, ifaces.OrgRoleOwner).Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			tt.setupMock(mockPool, mockOrgRepo, mockUserRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), db.NewTxManager(mockPool), mockOrgRepo, mocks.NewMockOrganizationInvitationRepository(t), mockUserRepo, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			err = service.TransferOwnership(ctx, orgID, ownerID, newOwnerID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrganizationService_Invite(t *testing.T) {
+	ctx := context.Background()
+	organization := &sqlcgen.Organization{ID: uuid.New(), Name: "Acme"}
+	inviter := &sqlcgen.User{ID: uuid.New(), Name: "Jane Doe", Email: "jane@example.com"}
+
+	var tokenHash string
+	var expiresAt time.Time
+	var payload tasks.EmailPayload
+
+	tests := []struct {
+		name        string
+		email       string
+		role        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockOrganizationRepository, *mocks.MockOrganizationInvitationRepository, *mocks.MockUserRepository, *mocksSupport.MockTaskClient)
+		expectedErr error
+	}{
+		{
+			name:  "emails an accept link matching the stored hash",
+			email: "new@example.com",
+			role:  ifaces.OrgRoleAdmin,
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				orgRepo.EXPECT().GetByID(mock.Anything, organization.ID).Return(organization, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, inviter.ID).Return(inviter, nil)
+				orgRepo.EXPECT().MemberEmailExists(mock.Anything, organization.ID, "new@example.com").Return(false, nil)
+				invitationRepo.EXPECT().WithTx(mock.Anything).Return(invitationRepo)
+				invitationRepo.EXPECT().DeletePendingForEmail(mock.Anything, organization.ID, "new@example.com").Return(nil)
+				invitationRepo.EXPECT().Create(mock.Anything, organization.ID, "new@example.com", ifaces.OrgRoleAdmin, mock.AnythingOfType("string"), inviter.ID, mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _ uuid.UUID, _, _, th string, _ uuid.UUID, exp time.Time) (*sqlcgen.OrganizationInvitation, error) {
+						tokenHash = th
+						expiresAt = exp
+						return &sqlcgen.OrganizationInvitation{ID: uuid.New()}, nil
+					})
+				taskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+						payload = p.(tasks.EmailPayload)
+					})
+			},
+		},
+		{
+			name:  "rejects inviting an owner",
+			email: "new@example.com",
+			role:  ifaces.OrgRoleOwner,
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+			},
+			expectedErr: errors.ErrInvalidOrganizationRole,
+		},
+		{
+			name:  "returns error when the email already belongs to a member",
+			email: "member@example.com",
+			role:  ifaces.OrgRoleMember,
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository, taskClient *mocksSupport.MockTaskClient) {
+				orgRepo.EXPECT().GetByID(mock.Anything, organization.ID).Return(organization, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, inviter.ID).Return(inviter, nil)
+				orgRepo.EXPECT().MemberEmailExists(mock.Anything, organization.ID, "member@example.com").Return(true, nil)
+			},
+			expectedErr: errors.ErrAlreadyOrganizationMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			mockInvitationRepo := mocks.NewMockOrganizationInvitationRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockTaskClient := mocksSupport.NewMockTaskClient(t)
+			tt.setupMock(mockPool, mockOrgRepo, mockInvitationRepo, mockUserRepo, mockTaskClient)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), db.NewTxManager(mockPool), mockOrgRepo, mockInvitationRepo, mockUserRepo, mockTaskClient, newTestTokenHasher())
+			invitation, err := service.Invite(ctx, organization.ID, inviter.ID, tt.email, tt.role)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, invitation)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, mockPool.ExpectationsWereMet())
+			assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), expiresAt, time.Minute)
+			assert.Equal(t, "new@example.com", payload.To)
+			assert.Equal(t, "organization-invitation", payload.Template)
+			assert.Equal(t, "Jane Doe", payload.Data["InviterName"])
+			assert.Equal(t, "Acme", payload.Data["OrganizationName"])
+			assert.Equal(t, 7, payload.Data["ExpiresInDays"])
+			link, err := url.Parse(payload.Data["AcceptLink"].(string))
+			require.NoError(t, err)
+			assert.Equal(t, "/accept-invitation", link.Path)
+			assert.Equal(t, tokenHash, newTestTokenHasher().Hash(link.Query().Get("token")))
+		})
+	}
+}
+
+func TestOrganizationService_AcceptInvitation(t *testing.T) {
+	ctx := context.Background()
+	token := "invitation-token"
+	userID := uuid.New()
+	invitationID := uuid.New()
+	organization := &sqlcgen.Organization{ID: uuid.New(), Name: "Acme"}
+	candidates := newTestTokenHasher().Candidates(token)
+	deletionScheduledAt := time.Now().Add(24 * time.Hour)
+
+	pending := func() *sqlcgen.OrganizationInvitation {
+		return &sqlcgen.OrganizationInvitation{
+			ID:             invitationID,
+			OrganizationID: organization.ID,
+			Email:          "new@example.com",
+			Role:           ifaces.OrgRoleMember,
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockOrganizationRepository, *mocks.MockOrganizationInvitationRepository, *mocks.MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "adds the user with the invited role",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(pending(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "New@Example.com"}, nil)
+				invitationRepo.EXPECT().WithTx(mock.Anything).Return(invitationRepo)
+				invitationRepo.EXPECT().MarkAccepted(mock.Anything, invitationID).Return(nil)
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				orgRepo.EXPECT().AddMember(mock.Anything, organization.ID, userID, ifaces.OrgRoleMember).Return(true, nil)
+			},
+		},
+		{
+			name: "returns error for unknown token",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(nil, eris.Wrap(pgx.ErrNoRows, "failed to get organization invitation by token hash"))
+			},
+			expectedErr: errors.ErrInvalidOrganizationInvitation,
+		},
+		{
+			name: "returns error for expired invitation",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				invitation := pending()
+				invitation.ExpiresAt = time.Now().Add(-time.Minute)
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(invitation, nil)
+			},
+			expectedErr: errors.ErrInvalidOrganizationInvitation,
+		},
+		{
+			name: "returns error for declined invitation",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				invitation := pending()
+				declinedAt := time.Now()
+				invitation.DeclinedAt = &declinedAt
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(invitation, nil)
+			},
+			expectedErr: errors.ErrInvalidOrganizationInvitation,
+		},
+		{
+			name: "returns error when the email does not match",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(pending(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "other@example.com"}, nil)
+			},
+			expectedErr: errors.ErrInvitationEmailMismatch,
+		},
+		{
+			name: "returns error for an admin invitation while the user's deletion is scheduled",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				invitation := pending()
+				invitation.Role = ifaces.OrgRoleAdmin
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(invitation, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "new@example.com", DeletionScheduledAt: &deletionScheduledAt}, nil)
+			},
+			expectedErr: errors.ErrDeletionScheduled,
+		},
+		{
+			name: "accepts a member invitation while the user's deletion is scheduled",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				pool.ExpectBegin()
+				pool.ExpectCommit()
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(pending(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "new@example.com", DeletionScheduledAt: &deletionScheduledAt}, nil)
+				invitationRepo.EXPECT().WithTx(mock.Anything).Return(invitationRepo)
+				invitationRepo.EXPECT().MarkAccepted(mock.Anything, invitationID).Return(nil)
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				orgRepo.EXPECT().AddMember(mock.Anything, organization.ID, userID, ifaces.OrgRoleMember).Return(true, nil)
+			},
+		},
+		{
+			name: "returns error when already a member",
+			setupMock: func(pool pgxmock.PgxPoolIface, orgRepo *mocks.MockOrganizationRepository, invitationRepo *mocks.MockOrganizationInvitationRepository, userRepo *mocks.MockUserRepository) {
+				pool.ExpectBegin()
+				pool.ExpectRollback()
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(pending(), nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "new@example.com"}, nil)
+				invitationRepo.EXPECT().WithTx(mock.Anything).Return(invitationRepo)
+				invitationRepo.EXPECT().MarkAccepted(mock.Anything, invitationID).Return(nil)
+				orgRepo.EXPECT().WithTx(mock.Anything).Return(orgRepo)
+				orgRepo.EXPECT().AddMember(mock.Anything, organization.ID, userID, ifaces.OrgRoleMember).Return(false, nil)
+			},
+			expectedErr: errors.ErrAlreadyOrganizationMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+			mockInvitationRepo := mocks.NewMockOrganizationInvitationRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			tt.setupMock(mockPool, mockOrgRepo, mockInvitationRepo, mockUserRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), db.NewTxManager(mockPool), mockOrgRepo, mockInvitationRepo, mockUserRepo, mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			err = service.AcceptInvitation(ctx, userID, token)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrganizationService_DeclineInvitation(t *testing.T) {
+	ctx := context.Background()
+	token := "invitation-token"
+	candidates := newTestTokenHasher().Candidates(token)
+	invitation := &sqlcgen.OrganizationInvitation{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockOrganizationInvitationRepository)
+		expectedErr error
+	}{
+		{
+			name: "declines the invitation",
+			setupMock: func(invitationRepo *mocks.MockOrganizationInvitationRepository) {
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(invitation, nil)
+				invitationRepo.EXPECT().MarkDeclined(mock.Anything, invitation.ID).Return(nil)
+			},
+		},
+		{
+			name: "returns error when answered concurrently",
+			setupMock: func(invitationRepo *mocks.MockOrganizationInvitationRepository) {
+				invitationRepo.EXPECT().GetByTokenHash(mock.Anything, candidates).Return(invitation, nil)
+				invitationRepo.EXPECT().MarkDeclined(mock.Anything, invitation.ID).Return(eris.Wrap(pgx.ErrNoRows, "no pending organization invitation"))
+			},
+			expectedErr: errors.ErrInvalidOrganizationInvitation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationRepo := mocks.NewMockOrganizationInvitationRepository(t)
+			tt.setupMock(mockInvitationRepo)
+
+			service := services.NewOrganizationService(newOrganizationTestConfig(), nil, mocks.NewMockOrganizationRepository(t), mockInvitationRepo, mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+			err := service.DeclineInvitation(ctx, token)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrganizationService_RevokeInvitation(t *testing.T) {
+	orgID, invitationID := uuid.New(), uuid.New()
+
+	mockInvitationRepo := mocks.NewMockOrganizationInvitationRepository(t)
+	mockInvitationRepo.EXPECT().DeletePending(mock.Anything, orgID, invitationID).Return(eris.Wrap(pgx.ErrNoRows, "no pending organization invitation"))
+
+	service := services.NewOrganizationService(newOrganizationTestConfig(), nil, mocks.NewMockOrganizationRepository(t), mockInvitationRepo, mocks.NewMockUserRepository(t), mocksSupport.NewMockTaskClient(t), newTestTokenHasher())
+	err := service.RevokeInvitation(context.Background(), orgID, invitationID)
+
+	assert.ErrorIs(t, err, errors.ErrInvitationNotFound)
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+const (
+	// DefaultPasskeyName is used when a passkey is registered without a name
+	DefaultPasskeyName = "Passkey"
+
+	// passkeyChallengeLength is the number of random bytes in a challenge
+	passkeyChallengeLength = 32
+
+	ceremonyRegistration = "registration"
+	ceremonyLogin        = "login"
+)
+
+type PasskeyService struct {
+	config         *config.Config
+	webAuthn       *webauthn.WebAuthn
+	userRepo       repositories.UserRepository
+	credentialRepo repositories.WebAuthnCredentialRepository
+	challengeRepo  repositories.WebAuthnChallengeRepository
+	sessionService services.SessionService
+}
+
+func NewPasskeyService(
+	cfg *config.Config,
+	webAuthn *webauthn.WebAuthn,
+	userRepo repositories.UserRepository,
+	credentialRepo repositories.WebAuthnCredentialRepository,
+	challengeRepo repositories.WebAuthnChallengeRepository,
+	sessionService services.SessionService,
+) *PasskeyService {
+	return &PasskeyService{
+		config:         cfg,
+		webAuthn:       webAuthn,
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		challengeRepo:  challengeRepo,
+		sessionService: sessionService,
+	}
+}
+
+func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (*services.PasskeyRegistrationOptions, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, eris.Wrap(err, "failed to get user")
+	}
+
+	existing, err := s.credentialRepo.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list passkeys")
+	}
+
+	challenge, err := s.createChallenge(ctx, &userID, ceremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop authenticators from registering a second passkey for the same account
+	exclude := make([]services.PasskeyDescriptor, 0, len(existing))
+	for _, credential := range existing {
+		exclude = append(exclude, services.PasskeyDescriptor{
+			ID:         credential.CredentialID,
+			Transports: credential.Transports,
+		})
+	}
+
+	return &services.PasskeyRegistrationOptions{
+		Challenge:          challenge,
+		RPID:               s.config.WebAuthn.RPID,
+		RPName:             s.config.WebAuthn.RPName,
+		User:               user,
+		Algorithms:         passkeyAlgorithms(),
+		ExcludeCredentials: exclude,
+		Timeout:            s.config.WebAuthn.ChallengeTTL,
+	}, nil
+}
+
+func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUID, registration services.PasskeyRegistration) (*sqlcgen.WebauthnCredential, error) {
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(registration.Credential)
+	if err != nil {
+		return nil, errors.ErrInvalidPasskeyResponse
+	}
+
+	challenge, err := s.consumeChallenge(ctx, parsed.Response.CollectedClientData.Challenge, ceremonyRegistration)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrPasskeyRegistrationFailed
+		}
+		return nil, err
+	}
+	if challenge.UserID == nil || *challenge.UserID != userID {
+		return nil, errors.ErrPasskeyRegistrationFailed
+	}
+
+	credential, err := s.webAuthn.CreateCredential(passkeyUser{id: userID[:]}, webauthn.SessionData{
+		Challenge:        parsed.Response.CollectedClientData.Challenge,
+		UserID:           userID[:],
+		UserVerification: protocol.VerificationRequired,
+		CredParams:       webauthn.CredentialParametersDefault(),
+	}, parsed)
+	if err != nil {
+		return nil, errors.ErrPasskeyRegistrationFailed
+	}
+
+	_, err = s.credentialRepo.GetByCredentialID(ctx, credential.ID)
+	if err == nil {
+		return nil, errors.ErrPasskeyAlreadyRegistered
+	}
+	if !eris.Is(err, pgx.ErrNoRows) {
+		return nil, eris.Wrap(err, "failed to check existing passkey")
+	}
+
+	name := registration.Name
+	if name == "" {
+		name = DefaultPasskeyName
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	stored, err := s.credentialRepo.Create(ctx, userID, credential.ID, credential.PublicKey, int64(credential.Authenticator.SignCount),
+		credential.Authenticator.AAGUID, credential.Flags.BackupEligible, transports, name)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to store passkey")
+	}
+
+	return stored, nil
+}
+
+func (s *PasskeyService) BeginLogin(ctx context.Context) (*services.PasskeyLoginOptions, error) {
+	challenge, err := s.createChallenge(ctx, nil, ceremonyLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.PasskeyLoginOptions{
+		Challenge: challenge,
+		RPID:      s.config.WebAuthn.RPID,
+		Timeout:   s.config.WebAuthn.ChallengeTTL,
+	}, nil
+}
+
+func (s *PasskeyService) FinishLogin(ctx context.Context, credential []byte, client services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		return nil, nil, errors.ErrInvalidPasskeyResponse
+	}
+
+	challenge := parsed.Response.CollectedClientData.Challenge
+	if _, err := s.consumeChallenge(ctx, challenge, ceremonyLogin); err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errors.ErrInvalidPasskey
+		}
+		return nil, nil, err
+	}
+
+	// The library checks the user handle against the owner returned here, so
+	// only the credential needs looking up
+	var stored *sqlcgen.WebauthnCredential
+	var lookupErr error
+	findCredential := func(rawID, _ []byte) (webauthn.User, error) {
+		stored, lookupErr = s.credentialRepo.GetByCredentialID(ctx, rawID)
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		return passkeyUser{
+			id: stored.UserID[:],
+			credentials: []webauthn.Credential{{
+				ID:        stored.CredentialID,
+				PublicKey: stored.PublicKey,
+				Flags:     webauthn.CredentialFlags{BackupEligible: stored.BackupEligible},
+				Authenticator: webauthn.Authenticator{
+					AAGUID:    stored.Aaguid,
+					SignCount: uint32(stored.SignCount),
+				},
+			}},
+		}, nil
+	}
+
+	_, verified, err := s.webAuthn.ValidatePasskeyLogin(findCredential, webauthn.SessionData{
+		Challenge:        challenge,
+		UserVerification: protocol.VerificationRequired,
+	}, parsed)
+	if lookupErr != nil && !eris.Is(lookupErr, pgx.ErrNoRows) {
+		return nil, nil, eris.Wrap(lookupErr, "failed to get passkey")
+	}
+	if err != nil {
+		return nil, nil, errors.ErrInvalidPasskey
+	}
+
+	// A counter that did not advance means the private key may have been
+	// copied to another authenticator
+	if verified.Authenticator.CloneWarning {
+		return nil, nil, errors.ErrInvalidPasskey
+	}
+
+	if err := s.credentialRepo.UpdateSignCount(ctx, stored.ID, int64(verified.Authenticator.SignCount)); err != nil {
+		return nil, nil, eris.Wrap(err, "failed to update passkey sign count")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errors.ErrInvalidPasskey
+		}
+		return nil, nil, eris.Wrap(err, "failed to get user")
+	}
+
+	tokens, err := s.sessionService.StartSession(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+func (s *PasskeyService) ListForUser(ctx context.Context, userID uuid.UUID) ([]sqlcgen.WebauthnCredential, error) {
+	credentials, err := s.credentialRepo.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list passkeys")
+	}
+	return credentials, nil
+}
+
+func (s *PasskeyService) Delete(ctx context.Context, userID, passkeyID uuid.UUID) error {
+	if err := s.credentialRepo.DeleteForUser(ctx, passkeyID, userID); err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return errors.ErrPasskeyNotFound
+		}
+		return eris.Wrap(err, "failed to delete passkey")
+	}
+	return nil
+}
+
+// createChallenge stores a new challenge for a ceremony and returns it
+// base64url encoded, as it will appear in client data.
+func (s *PasskeyService) createChallenge(ctx context.Context, userID *uuid.UUID, ceremony string) (string, error) {
+	raw := make([]byte, passkeyChallengeLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", eris.Wrap(err, "failed to generate challenge")
+	}
+	challenge := base64.RawURLEncoding.EncodeToString(raw)
+
+	expiresAt := time.Now().UTC().Add(s.config.WebAuthn.ChallengeTTL)
+	if _, err := s.challengeRepo.Create(ctx, userID, ceremony, HashToken(challenge), expiresAt); err != nil {
+		return "", eris.Wrap(err, "failed to store webauthn challenge")
+	}
+
+	return challenge, nil
+}
+
+// consumeChallenge deletes the challenge echoed in client data and returns
+// it. An unknown or expired challenge returns a wrapped pgx.ErrNoRows.
+func (s *PasskeyService) consumeChallenge(ctx context.Context, challenge, ceremony string) (*sqlcgen.WebauthnChallenge, error) {
+	stored, err := s.challengeRepo.Consume(ctx, HashToken(challenge), ceremony)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, eris.Wrap(err, "failed to consume webauthn challenge")
+	}
+
+	if time.Now().UTC().After(stored.ExpiresAt) {
+		return nil, eris.Wrap(pgx.ErrNoRows, "webauthn challenge expired")
+	}
+
+	return stored, nil
+}
+
+// passkeyAlgorithms returns the COSE algorithms accepted for new passkeys.
+func passkeyAlgorithms() []int64 {
+	params := webauthn.CredentialParametersDefault()
+	algorithms := make([]int64, 0, len(params))
+	for _, param := range params {
+		algorithms = append(algorithms, int64(param.Algorithm))
+	}
+	return algorithms
+}
+
+// passkeyUser adapts a passkey owner to webauthn.User. The WebAuthn user ID
+// is the raw user UUID.
+type passkeyUser struct {
+	id          []byte
+	credentials []webauthn.Credential
+}
+
+func (u passkeyUser) WebAuthnID() []byte {
+	return u.id
+}
+
+func (u passkeyUser) WebAuthnName() string {
+	return ""
+}
+
+func (u passkeyUser) WebAuthnDisplayName() string {
+	return ""
+}
+
+func (u passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+var _ services.PasskeyService = (*PasskeyService)(nil)
@@ -0,0 +1,450 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/webauthn/webauthntest"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPasskeyRPID   = "example.com"
+	testPasskeyOrigin = "https://example.com"
+)
+
+func newPasskeyTestConfig() *config.Config {
+	return &config.Config{
+		WebAuthn: config.WebAuthnConfig{
+			RPID:         testPasskeyRPID,
+			RPName:       "Test App",
+			Origins:      []string{testPasskeyOrigin},
+			ChallengeTTL: 5 * time.Minute,
+		},
+	}
+}
+
+func newTestWebAuthn(t *testing.T) *webauthn.WebAuthn {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          testPasskeyRPID,
+		RPDisplayName: "Test App",
+		RPOrigins:     []string{testPasskeyOrigin},
+	})
+	require.NoError(t, err)
+	return webAuthn
+}
+
+func newTestAuthenticator(t *testing.T) *webauthntest.Authenticator {
+	authenticator, err := webauthntest.NewAuthenticator(testPasskeyRPID, testPasskeyOrigin)
+	require.NoError(t, err)
+	return authenticator
+}
+
+// registeredPasskey returns the record a repository would hold for
+// authenticator's credential once registered to userID.
+func registeredPasskey(t *testing.T, authenticator *webauthntest.Authenticator, userID uuid.UUID) *sqlcgen.WebauthnCredential {
+	publicKey, err := authenticator.PublicKey()
+	require.NoError(t, err)
+
+	return &sqlcgen.WebauthnCredential{
+		ID:             uuid.New(),
+		UserID:         userID,
+		CredentialID:   authenticator.CredentialID,
+		PublicKey:      publicKey,
+		SignCount:      int64(authenticator.SignCount),
+		BackupEligible: authenticator.BackupEligible,
+		Name:           "Laptop",
+	}
+}
+
+func TestPasskeyService_BeginRegistration(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	var storedHash string
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockUserRepository, *mocks.MockWebAuthnCredentialRepository, *mocks.MockWebAuthnChallengeRepository)
+		expectedErr error
+	}{
+		{
+			name: "returns options and stores the challenge hash",
+			setupMock: func(userRepo *mocks.MockUserRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, challengeRepo *mocks.MockWebAuthnChallengeRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "test@example.com"}, nil)
+				credentialRepo.EXPECT().ListForUser(mock.Anything, userID).Return([]sqlcgen.WebauthnCredential{
+					{CredentialID: []byte("existing"), Transports: []string{"internal"}},
+				}, nil)
+				challengeRepo.EXPECT().Create(mock.Anything, &userID, "registration", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					RunAndReturn(func(_ context.Context, _ *uuid.UUID, _, challengeHash string, _ time.Time) (*sqlcgen.WebauthnChallenge, error) {
+						storedHash = challengeHash
+						return &sqlcgen.WebauthnChallenge{}, nil
+					})
+			},
+		},
+		{
+			name: "returns error when user not found",
+			setupMock: func(userRepo *mocks.MockUserRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, challengeRepo *mocks.MockWebAuthnChallengeRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockCredentialRepo := mocks.NewMockWebAuthnCredentialRepository(t)
+			mockChallengeRepo := mocks.NewMockWebAuthnChallengeRepository(t)
+			tt.setupMock(mockUserRepo, mockCredentialRepo, mockChallengeRepo)
+
+			service := services.NewPasskeyService(newPasskeyTestConfig(), newTestWebAuthn(t), mockUserRepo, mockCredentialRepo, mockChallengeRepo, mocksServices.NewMockSessionService(t))
+			options, err := service.BeginRegistration(ctx, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, options)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, services.HashToken(options.Challenge), storedHash)
+			assert.Equal(t, testPasskeyRPID, options.RPID)
+			assert.Equal(t, "Test App", options.RPName)
+			assert.Equal(t, userID, options.User.ID)
+			assert.Contains(t, options.Algorithms, int64(-7))
+			require.Len(t, options.ExcludeCredentials, 1)
+			assert.Equal(t, []byte("existing"), options.ExcludeCredentials[0].ID)
+		})
+	}
+}
+
+func TestPasskeyService_FinishRegistration(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	challenge := "cmVnaXN0cmF0aW9uLWNoYWxsZW5nZQ"
+
+	validChallenge := func(owner uuid.UUID) *sqlcgen.WebauthnChallenge {
+		return &sqlcgen.WebauthnChallenge{
+			UserID:    &owner,
+			Ceremony:  "registration",
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		passkeyName    string
+		origin         string
+		backupEligible bool
+		credential     []byte
+		setupMock      func(*mocks.MockWebAuthnChallengeRepository, *mocks.MockWebAuthnCredentialRepository, *webauthntest.Authenticator)
+		expectedErr    error
+	}{
+		{
+			name:        "stores the verified passkey",
+			passkeyName: "Laptop",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(validChallenge(userID), nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, authenticator.CredentialID).Return(nil, pgx.ErrNoRows)
+				credentialRepo.EXPECT().Create(mock.Anything, userID, authenticator.CredentialID, mock.AnythingOfType("[]uint8"), int64(0), mock.AnythingOfType("[]uint8"), false, []string{"internal"}, "Laptop").
+					Return(&sqlcgen.WebauthnCredential{ID: uuid.New(), Name: "Laptop"}, nil)
+			},
+		},
+		{
+			name: "uses default name",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(validChallenge(userID), nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, authenticator.CredentialID).Return(nil, pgx.ErrNoRows)
+				credentialRepo.EXPECT().Create(mock.Anything, userID, authenticator.CredentialID, mock.Anything, int64(0), mock.Anything, false, mock.Anything, services.DefaultPasskeyName).
+					Return(&sqlcgen.WebauthnCredential{ID: uuid.New(), Name: services.DefaultPasskeyName}, nil)
+			},
+		},
+		{
+			name:           "stores backup eligibility",
+			passkeyName:    "Phone",
+			backupEligible: true,
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(validChallenge(userID), nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, authenticator.CredentialID).Return(nil, pgx.ErrNoRows)
+				credentialRepo.EXPECT().Create(mock.Anything, userID, authenticator.CredentialID, mock.Anything, int64(0), mock.Anything, true, mock.Anything, "Phone").
+					Return(&sqlcgen.WebauthnCredential{ID: uuid.New(), Name: "Phone", BackupEligible: true}, nil)
+			},
+		},
+		{
+			name:       "returns error when credential is malformed",
+			credential: []byte(`{"id":"abc","type":"public-key"}`),
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+			},
+			expectedErr: errors.ErrInvalidPasskeyResponse,
+		},
+		{
+			name: "returns error when challenge is unknown",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrPasskeyRegistrationFailed,
+		},
+		{
+			name: "returns error when challenge belongs to another user",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(validChallenge(uuid.New()), nil)
+			},
+			expectedErr: errors.ErrPasskeyRegistrationFailed,
+		},
+		{
+			name: "returns error when challenge expired",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				expired := validChallenge(userID)
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(expired, nil)
+			},
+			expectedErr: errors.ErrPasskeyRegistrationFailed,
+		},
+		{
+			name:   "returns error when attestation is for another origin",
+			origin: "https://evil.example",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(validChallenge(userID), nil)
+			},
+			expectedErr: errors.ErrPasskeyRegistrationFailed,
+		},
+		{
+			name: "returns error when passkey already registered",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, authenticator *webauthntest.Authenticator) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "registration").Return(validChallenge(userID), nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, authenticator.CredentialID).Return(&sqlcgen.WebauthnCredential{}, nil)
+			},
+			expectedErr: errors.ErrPasskeyAlreadyRegistered,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := tt.origin
+			if origin == "" {
+				origin = testPasskeyOrigin
+			}
+			authenticator, err := webauthntest.NewAuthenticator(testPasskeyRPID, origin)
+			require.NoError(t, err)
+			authenticator.BackupEligible = tt.backupEligible
+
+			credential := tt.credential
+			if credential == nil {
+				credential, err = authenticator.Create(challenge)
+				require.NoError(t, err)
+			}
+
+			mockChallengeRepo := mocks.NewMockWebAuthnChallengeRepository(t)
+			mockCredentialRepo := mocks.NewMockWebAuthnCredentialRepository(t)
+			tt.setupMock(mockChallengeRepo, mockCredentialRepo, authenticator)
+
+			service := services.NewPasskeyService(newPasskeyTestConfig(), newTestWebAuthn(t), mocks.NewMockUserRepository(t), mockCredentialRepo, mockChallengeRepo, mocksServices.NewMockSessionService(t))
+			stored, err := service.FinishRegistration(ctx, userID, ifaces.PasskeyRegistration{Name: tt.passkeyName, Credential: credential})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, stored)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, stored.ID)
+			}
+		})
+	}
+}
+
+func TestPasskeyService_BeginLogin(t *testing.T) {
+	mockChallengeRepo := mocks.NewMockWebAuthnChallengeRepository(t)
+	mockChallengeRepo.EXPECT().Create(mock.Anything, (*uuid.UUID)(nil), "login", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(&sqlcgen.WebauthnChallenge{}, nil)
+
+	service := services.NewPasskeyService(newPasskeyTestConfig(), newTestWebAuthn(t), mocks.NewMockUserRepository(t), mocks.NewMockWebAuthnCredentialRepository(t), mockChallengeRepo, mocksServices.NewMockSessionService(t))
+	options, err := service.BeginLogin(context.Background())
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, options.Challenge)
+	assert.Equal(t, testPasskeyRPID, options.RPID)
+	assert.Equal(t, 5*time.Minute, options.Timeout)
+}
+
+func TestPasskeyService_FinishLogin(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	challenge := "bG9naW4tY2hhbGxlbmdl"
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	tokens := &ifaces.SessionTokens{AccessToken: "token"}
+	otherPasskey := registeredPasskey(t, newTestAuthenticator(t), userID)
+	validChallenge := &sqlcgen.WebauthnChallenge{Ceremony: "login", ExpiresAt: time.Now().Add(time.Minute)}
+
+	tests := []struct {
+		name        string
+		credential  []byte
+		setupMock   func(*mocks.MockWebAuthnChallengeRepository, *mocks.MockWebAuthnCredentialRepository, *mocks.MockUserRepository, *mocksServices.MockSessionService, *sqlcgen.WebauthnCredential)
+		expectedErr error
+	}{
+		{
+			name: "issues a session",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(stored, nil)
+				credentialRepo.EXPECT().UpdateSignCount(mock.Anything, stored.ID, int64(1)).Return(nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+				sessionService.EXPECT().StartSession(mock.Anything, &sqlcgen.User{ID: userID}, client).Return(tokens, nil)
+			},
+		},
+		{
+			name:       "returns error when credential is malformed",
+			credential: []byte(`not json`),
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+			},
+			expectedErr: errors.ErrInvalidPasskeyResponse,
+		},
+		{
+			name: "returns error when challenge is unknown",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidPasskey,
+		},
+		{
+			name: "returns error when passkey is unknown",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidPasskey,
+		},
+		{
+			name: "returns error when passkey lookup fails",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(nil, assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "returns error when user handle does not match",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				stored.UserID = uuid.New()
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(stored, nil)
+			},
+			expectedErr: errors.ErrInvalidPasskey,
+		},
+		{
+			name: "returns error when signature is from another key",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				stored.PublicKey = otherPasskey.PublicKey
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(stored, nil)
+			},
+			expectedErr: errors.ErrInvalidPasskey,
+		},
+		{
+			name: "returns error when sign count goes backwards",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				stored.SignCount = 10
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(stored, nil)
+			},
+			expectedErr: errors.ErrInvalidPasskey,
+		},
+		{
+			name: "returns error when backup eligibility changed",
+			setupMock: func(challengeRepo *mocks.MockWebAuthnChallengeRepository, credentialRepo *mocks.MockWebAuthnCredentialRepository, userRepo *mocks.MockUserRepository, sessionService *mocksServices.MockSessionService, stored *sqlcgen.WebauthnCredential) {
+				stored.BackupEligible = true
+				challengeRepo.EXPECT().Consume(mock.Anything, services.HashToken(challenge), "login").Return(validChallenge, nil)
+				credentialRepo.EXPECT().GetByCredentialID(mock.Anything, stored.CredentialID).Return(stored, nil)
+			},
+			expectedErr: errors.ErrInvalidPasskey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator := newTestAuthenticator(t)
+			stored := registeredPasskey(t, authenticator, userID)
+
+			credential := tt.credential
+			if credential == nil {
+				var err error
+				credential, err = authenticator.Get(challenge, userID[:])
+				require.NoError(t, err)
+			}
+
+			mockChallengeRepo := mocks.NewMockWebAuthnChallengeRepository(t)
+			mockCredentialRepo := mocks.NewMockWebAuthnCredentialRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockSessionService := mocksServices.NewMockSessionService(t)
+			tt.setupMock(mockChallengeRepo, mockCredentialRepo, mockUserRepo, mockSessionService, stored)
+
+			service := services.NewPasskeyService(newPasskeyTestConfig(), newTestWebAuthn(t), mockUserRepo, mockCredentialRepo, mockChallengeRepo, mockSessionService)
+			user, got, err := service.FinishLogin(ctx, credential, client)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, user)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID, user.ID)
+				assert.Equal(t, tokens, got)
+			}
+		})
+	}
+}
+
+func TestPasskeyService_Delete(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	passkeyID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockWebAuthnCredentialRepository)
+		expectedErr error
+	}{
+		{
+			name: "deletes the passkey",
+			setupMock: func(credentialRepo *mocks.MockWebAuthnCredentialRepository) {
+				credentialRepo.EXPECT().DeleteForUser(mock.Anything, passkeyID, userID).Return(nil)
+			},
+		},
+		{
+			name: "returns not found for another user's passkey",
+			setupMock: func(credentialRepo *mocks.MockWebAuthnCredentialRepository) {
+				credentialRepo.EXPECT().DeleteForUser(mock.Anything, passkeyID, userID).Return(pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrPasskeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCredentialRepo := mocks.NewMockWebAuthnCredentialRepository(t)
+			tt.setupMock(mockCredentialRepo)
+
+			service := services.NewPasskeyService(newPasskeyTestConfig(), newTestWebAuthn(t), mocks.NewMockUserRepository(t), mockCredentialRepo, mocks.NewMockWebAuthnChallengeRepository(t), mocksServices.NewMockSessionService(t))
+			err := service.Delete(ctx, userID, passkeyID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/errors"
+
+	"github.com/rotisserie/eris"
+)
+
+// minPersonalInfoLength is the shortest name part or email local part that
+// a password may not contain; shorter fragments match too many passwords.
+const minPersonalInfoLength = 3
+
+// strengthThresholds are the entropy estimates, in bits, a password must
+// reach for scores 1 to 4.
+var strengthThresholds = [4]float64{28, 36, 60, 128}
+
+type PasswordPolicyService struct {
+	config   *config.Config
+	breached support.BreachedPasswords
+}
+
+// NewPasswordPolicyService creates a PasswordPolicyService. breached may be
+// nil, which disables the breached password check.
+func NewPasswordPolicyService(cfg *config.Config, breached support.BreachedPasswords) *PasswordPolicyService {
+	return &PasswordPolicyService{
+		config:   cfg,
+		breached: breached,
+	}
+}
+
+func (s *PasswordPolicyService) Check(ctx context.Context, password, name, email string) error {
+	policy := s.config.Password
+	var violations []string
+
+	if len([]rune(password)) < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", policy.MinLength))
+	}
+	if len(password) > policy.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes", policy.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if policy.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if policy.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if policy.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if policy.RejectPersonalInfo && containsPersonalInfo(password, name, email) {
+		violations = append(violations, "must not contain your name or email")
+	}
+
+	if s.Strength(password) < policy.MinStrength {
+		violations = append(violations, "is too easy to guess")
+	}
+
+	if s.breached != nil {
+		found, err := s.breached.Contains(ctx, password)
+		if err != nil {
+			return eris.Wrap(err, "failed to check breached passwords")
+		}
+		if found {
+			violations = append(violations, "has appeared in a data breach")
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return errors.NewWithDetails("VALIDATION_ERROR", "validation failed", http.StatusBadRequest, map[string]any{
+		"password": violations,
+	})
+}
+
+func (s *PasswordPolicyService) Strength(password string) int {
+	bits := entropyBits(password)
+
+	score := 0
+	for _, threshold := range strengthThresholds {
+		if bits < threshold {
+			break
+		}
+		score++
+	}
+	return score
+}
+
+// entropyBits estimates the entropy of password as its effective length
+// times log2 of the size of the character pools it draws from. Characters
+// that repeat the previous one ("aaa") or continue a run ("abc", "321")
+// don't add to the effective length.
+func entropyBits(password string) float64 {
+	runes := []rune(password)
+	var lower, upper, digit, symbol, other bool
+	var effective int
+	var prevDelta rune
+
+	for i, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r <= unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+
+		if i > 0 {
+			delta := r - runes[i-1]
+			repeated := delta == 0
+			continuesRun := (delta == 1 || delta == -1) && delta == prevDelta
+			prevDelta = delta
+			if repeated || continuesRun {
+				continue
+			}
+		}
+		effective++
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	return float64(effective) * math.Log2(float64(pool))
+}
+
+// containsPersonalInfo reports whether password contains the user's email,
+// its local part, or any part of their name, ignoring case.
+func containsPersonalInfo(password, name, email string) bool {
+	lowered := strings.ToLower(password)
+
+	fragments := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if email != "" {
+		email = strings.ToLower(email)
+		local, _, _ := strings.Cut(email, "@")
+		fragments = append(fragments, email, local)
+	}
+
+	for _, fragment := range fragments {
+		if len([]rune(fragment)) >= minPersonalInfoLength && strings.Contains(lowered, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ services.PasswordPolicyService = (*PasswordPolicyService)(nil)
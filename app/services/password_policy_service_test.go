@@ -0,0 +1,158 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	mocksSupport "go-reasonable-api/app/mocks/support"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/support/config"
+	supporterrors "go-reasonable-api/support/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPasswordPolicyConfig() *config.Config {
+	return &config.Config{
+		Password: config.PasswordConfig{
+			MinLength:          8,
+			MaxLength:          72,
+			RejectPersonalInfo: true,
+			MinStrength:        2,
+		},
+	}
+}
+
+func TestPasswordPolicyService_Check(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		password   string
+		configure  func(*config.PasswordConfig)
+		violations []string
+	}{
+		{
+			name:     "accepts a strong password",
+			password: "correct horse battery staple",
+		},
+		{
+			name:       "rejects a short password",
+			password:   "Xk9#",
+			violations: []string{"must be at least 8 characters", "is too easy to guess"},
+		},
+		{
+			name:       "rejects a password longer than bcrypt accepts",
+			password:   "correct horse battery staple correct horse battery staple correct horse battery",
+			violations: []string{"must be at most 72 bytes"},
+		},
+		{
+			name:     "requires character classes when configured",
+			password: "correct horse battery staple",
+			configure: func(c *config.PasswordConfig) {
+				c.RequireUpper = true
+				c.RequireDigit = true
+				c.RequireSymbol = true
+			},
+			violations: []string{"must contain an uppercase letter", "must contain a digit"},
+		},
+		{
+			name:       "rejects a password containing the user's name",
+			password:   "Jane-Rocks-2024!",
+			violations: []string{"must not contain your name or email"},
+		},
+		{
+			name:       "rejects a password containing the email local part",
+			password:   "my jdoe99 password",
+			violations: []string{"must not contain your name or email"},
+		},
+		{
+			name:     "allows personal info when not rejected",
+			password: "Jane-Rocks-2024!",
+			configure: func(c *config.PasswordConfig) {
+				c.RejectPersonalInfo = false
+			},
+		},
+		{
+			name:       "rejects repeated and sequential characters",
+			password:   "aaaaaaaa12345678",
+			violations: []string{"is too easy to guess"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newPasswordPolicyConfig()
+			if tt.configure != nil {
+				tt.configure(&cfg.Password)
+			}
+
+			service := services.NewPasswordPolicyService(cfg, nil)
+			err := service.Check(ctx, tt.password, "Jane Doe", "jdoe99@example.com")
+
+			if tt.violations == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			appErr, ok := supporterrors.Is(err)
+			require.True(t, ok)
+			assert.Equal(t, "VALIDATION_ERROR", appErr.Code)
+			assert.Equal(t, 400, appErr.StatusCode)
+			assert.Equal(t, tt.violations, appErr.Details["password"])
+		})
+	}
+}
+
+func TestPasswordPolicyService_Check_Breached(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects a breached password", func(t *testing.T) {
+		breached := mocksSupport.NewMockBreachedPasswords(t)
+		breached.EXPECT().Contains(mock.Anything, "correct horse battery staple").Return(true, nil)
+
+		service := services.NewPasswordPolicyService(newPasswordPolicyConfig(), breached)
+		err := service.Check(ctx, "correct horse battery staple", "Jane Doe", "jane@example.com")
+
+		appErr, ok := supporterrors.Is(err)
+		require.True(t, ok)
+		assert.Equal(t, []string{"has appeared in a data breach"}, appErr.Details["password"])
+	})
+
+	t.Run("returns lookup errors", func(t *testing.T) {
+		breached := mocksSupport.NewMockBreachedPasswords(t)
+		breached.EXPECT().Contains(mock.Anything, mock.Anything).Return(false, assert.AnError)
+
+		service := services.NewPasswordPolicyService(newPasswordPolicyConfig(), breached)
+		err := service.Check(ctx, "correct horse battery staple", "Jane Doe", "jane@example.com")
+
+		require.ErrorIs(t, err, assert.AnError)
+		_, ok := supporterrors.Is(err)
+		assert.False(t, ok)
+	})
+}
+
+func TestPasswordPolicyService_Strength(t *testing.T) {
+	service := services.NewPasswordPolicyService(newPasswordPolicyConfig(), nil)
+
+	tests := []struct {
+		password string
+		score    int
+	}{
+		{"", 0},
+		{"aaaaaaaaaaaa", 0},
+		{"abcdefgh", 0},
+		{"password", 1},
+		{"password123", 2},
+		{"Tr0ub4dor&3", 3},
+		{"correct horse battery staple", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.score, service.Strength(tt.password))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+type PermissionService struct {
+	roleRepo repositories.RoleRepository
+	userRepo repositories.UserRepository
+}
+
+func NewPermissionService(roleRepo repositories.RoleRepository, userRepo repositories.UserRepository) *PermissionService {
+	return &PermissionService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *PermissionService) GetAccess(ctx context.Context, userID uuid.UUID) (*services.Access, error) {
+	roles, err := s.roleRepo.ListNamesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list roles")
+	}
+
+	permissions, err := s.roleRepo.ListPermissionNamesForUser(ctx, userID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to list permissions")
+	}
+
+	return &services.Access{Roles: roles, Permissions: permissions}, nil
+}
+
+func (s *PermissionService) GrantRole(ctx context.Context, email, role string) error {
+	user, roleRow, err := s.lookup(ctx, email, role)
+	if err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.AssignToUser(ctx, user.ID, roleRow.ID); err != nil {
+		return eris.Wrap(err, "failed to grant role")
+	}
+	return nil
+}
+
+func (s *PermissionService) RevokeRole(ctx context.Context, email, role string) error {
+	user, roleRow, err := s.lookup(ctx, email, role)
+	if err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.RemoveFromUser(ctx, user.ID, roleRow.ID); err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return errors.ErrRoleNotAssigned
+		}
+		return eris.Wrap(err, "failed to revoke role")
+	}
+	return nil
+}
+
+func (s *PermissionService) lookup(ctx context.Context, email, role string) (*sqlcgen.User, *sqlcgen.Role, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errors.ErrUserNotFound
+		}
+		return nil, nil, eris.Wrap(err, "failed to get user")
+	}
+
+	roleRow, err := s.roleRepo.GetByName(ctx, role)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errors.ErrRoleNotFound
+		}
+		return nil, nil, eris.Wrap(err, "failed to get role")
+	}
+
+	return user, roleRow, nil
+}
+
+var _ services.PermissionService = (*PermissionService)(nil)
@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"go-reasonable-api/app/errors"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionService_GetAccess(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	roleRepo := mocks.NewMockRoleRepository(t)
+	userRepo := mocks.NewMockUserRepository(t)
+	roleRepo.EXPECT().ListNamesForUser(mock.Anything, userID).Return([]string{"admin"}, nil)
+	roleRepo.EXPECT().ListPermissionNamesForUser(mock.Anything, userID).Return([]string{"users:read", "users:write"}, nil)
+
+	svc := services.NewPermissionService(roleRepo, userRepo)
+	access, err := svc.GetAccess(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, access.Roles)
+	assert.Equal(t, []string{"users:read", "users:write"}, access.Permissions)
+}
+
+func TestPermissionService_GrantRole(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	roleID := uuid.New()
+	notFound := eris.Wrap(pgx.ErrNoRows, "not found")
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockRoleRepository, *mocks.MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "assigns role",
+			setupMock: func(roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "admin@example.com").Return(&sqlcgen.User{ID: userID}, nil)
+				roleRepo.EXPECT().GetByName(mock.Anything, "admin").Return(&sqlcgen.Role{ID: roleID, Name: "admin"}, nil)
+				roleRepo.EXPECT().AssignToUser(mock.Anything, userID, roleID).Return(nil)
+			},
+		},
+		{
+			name: "returns error for unknown user",
+			setupMock: func(roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "admin@example.com").Return(nil, notFound)
+			},
+			expectedErr: errors.ErrUserNotFound,
+		},
+		{
+			name: "returns error for unknown role",
+			setupMock: func(roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "admin@example.com").Return(&sqlcgen.User{ID: userID}, nil)
+				roleRepo.EXPECT().GetByName(mock.Anything, "admin").Return(nil, notFound)
+			},
+			expectedErr: errors.ErrRoleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := mocks.NewMockRoleRepository(t)
+			userRepo := mocks.NewMockUserRepository(t)
+			tt.setupMock(roleRepo, userRepo)
+
+			svc := services.NewPermissionService(roleRepo, userRepo)
+			err := svc.GrantRole(ctx, "admin@example.com", "admin")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPermissionService_RevokeRole(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	roleID := uuid.New()
+
+	tests := []struct {
+		name        string
+		removeErr   error
+		expectedErr error
+	}{
+		{
+			name: "removes role",
+		},
+		{
+			name:        "returns error when role not held",
+			removeErr:   eris.Wrap(pgx.ErrNoRows, "user does not have role"),
+			expectedErr: errors.ErrRoleNotAssigned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := mocks.NewMockRoleRepository(t)
+			userRepo := mocks.NewMockUserRepository(t)
+			userRepo.EXPECT().GetByEmail(mock.Anything, "admin@example.com").Return(&sqlcgen.User{ID: userID}, nil)
+			roleRepo.EXPECT().GetByName(mock.Anything, "admin").Return(&sqlcgen.Role{ID: roleID, Name: "admin"}, nil)
+			roleRepo.EXPECT().RemoveFromUser(mock.Anything, userID, roleID).Return(tt.removeErr)
+
+			svc := services.NewPermissionService(roleRepo, userRepo)
+			err := svc.RevokeRole(ctx, "admin@example.com", "admin")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -7,123 +7,486 @@ import (
 	"go-reasonable-api/app/errors"
 	"go-reasonable-api/app/interfaces/repositories"
 	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/app/interfaces/support"
 	"go-reasonable-api/db/sqlcgen"
 	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rotisserie/eris"
-	"golang.org/x/crypto/bcrypt"
 )
 
 const (
 	// DefaultAuthTokenTTL is the default time-to-live for authentication tokens
 	// when not explicitly configured
 	DefaultAuthTokenTTL = 365 * 24 * time.Hour // 1 year
+
+	// AuthTokenTouchInterval is the minimum time between last_used_at updates,
+	// so that not every authenticated request writes to the database
+	AuthTokenTouchInterval = 5 * time.Minute
 )
 
 type SessionService struct {
-	config        *config.Config
-	userRepo      repositories.UserRepository
-	authTokenRepo repositories.AuthTokenRepository
+	config            *config.Config
+	txManager         *db.TxManager
+	userRepo          repositories.UserRepository
+	authTokenRepo     repositories.AuthTokenRepository
+	refreshTokenRepo  repositories.RefreshTokenRepository
+	twoFactorService  services.TwoFactorService
+	lockoutService    services.LoginLockoutService
+	loginAlertService services.LoginAlertService
+	dummyHash         func() string
+	hasher            support.PasswordHasher
+	tokenHasher       support.TokenHasher
+	tokenCache        support.TokenCache
 }
 
 func NewSessionService(
 	cfg *config.Config,
+	txManager *db.TxManager,
 	userRepo repositories.UserRepository,
 	authTokenRepo repositories.AuthTokenRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	twoFactorService services.TwoFactorService,
+	lockoutService services.LoginLockoutService,
+	loginAlertService services.LoginAlertService,
+	hasher support.PasswordHasher,
+	tokenHasher support.TokenHasher,
+	tokenCache support.TokenCache,
 ) *SessionService {
 	return &SessionService{
-		config:        cfg,
-		userRepo:      userRepo,
-		authTokenRepo: authTokenRepo,
+		config:            cfg,
+		txManager:         txManager,
+		userRepo:          userRepo,
+		authTokenRepo:     authTokenRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		twoFactorService:  twoFactorService,
+		lockoutService:    lockoutService,
+		loginAlertService: loginAlertService,
+		dummyHash:         newDummyPasswordHash(hasher),
+		hasher:            hasher,
+		tokenHasher:       tokenHasher,
+		tokenCache:        tokenCache,
 	}
 }
 
-func (s *SessionService) Create(ctx context.Context, email, password string) (*sqlcgen.User, string, error) {
+func (s *SessionService) Create(ctx context.Context, email, password string, client services.ClientInfo) (*services.LoginResult, error) {
+	if err := s.lockoutService.Check(ctx, email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		if eris.Is(err, pgx.ErrNoRows) {
-			return nil, "", errors.ErrInvalidCredentials
+			if s.config.Auth.EnumerationSafe {
+				_ = s.hasher.Verify(password, s.dummyHash())
+			}
+			return nil, s.loginFailed(ctx, email, client, errors.ErrInvalidCredentials)
+		}
+		return nil, eris.Wrap(err, "failed to get user by email")
+	}
+
+	if !s.hasher.Verify(password, user.PasswordHash) {
+		return nil, s.loginFailed(ctx, email, client, errors.ErrInvalidCredentials)
+	}
+
+	// Upgrade hashes left behind by an earlier algorithm or cost while the
+	// plaintext password is at hand
+	if s.hasher.NeedsRehash(user.PasswordHash) {
+		passwordHash, err := s.hasher.Hash(password)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to rehash password")
+		}
+		if err := s.userRepo.UpdatePassword(ctx, user.ID, passwordHash); err != nil {
+			return nil, eris.Wrap(err, "failed to update password hash")
 		}
-		return nil, "", eris.Wrap(err, "failed to get user by email")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return nil, "", errors.ErrInvalidCredentials
+	twoFactorEnabled, err := s.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to check two factor status")
 	}
 
-	// Cancel scheduled deletion if user logs in
-	if user.DeletionScheduledAt != nil {
-		if err := s.userRepo.CancelDeletion(ctx, user.ID); err != nil {
-			return nil, "", eris.Wrap(err, "failed to cancel deletion")
+	if twoFactorEnabled {
+		challenge, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, eris.Wrap(err, "failed to create two factor challenge")
 		}
-		user.DeletionScheduledAt = nil
+		return &services.LoginResult{User: user, Challenge: challenge}, nil
+	}
+
+	// With two-factor enabled, failures are only cleared once the second
+	// step succeeds, or a known password would reset the lockout
+	if err := s.lockoutService.RecordSuccess(ctx, email); err != nil {
+		return nil, eris.Wrap(err, "failed to reset failed logins")
+	}
+
+	tokens, err := s.StartSession(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// loginFailed records a failed login attempt and returns the error for it:
+// ErrLoginLocked when the attempt locks the email or IP address, failure
+// otherwise.
+func (s *SessionService) loginFailed(ctx context.Context, email string, client services.ClientInfo, failure error) error {
+	if err := s.lockoutService.RecordFailure(ctx, email, client.IPAddress); err != nil {
+		return err
+	}
+	return failure
+}
+
+func (s *SessionService) CompleteTwoFactor(ctx context.Context, challengeToken, code string, client services.ClientInfo) (*sqlcgen.User, *services.SessionTokens, error) {
+	userID, err := s.twoFactorService.ChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errors.ErrInvalidTwoFactorChallenge
+		}
+		return nil, nil, eris.Wrap(err, "failed to get user")
+	}
+
+	// Wrong codes count toward the same lockout as wrong passwords, so
+	// starting new challenges does not buy more guesses
+	if err := s.lockoutService.Check(ctx, user.Email, client.IPAddress); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := s.twoFactorService.VerifyChallenge(ctx, challengeToken, code); err != nil {
+		if eris.Is(err, errors.ErrInvalidTwoFactorCode) {
+			return nil, nil, s.loginFailed(ctx, user.Email, client, err)
+		}
+		return nil, nil, err
+	}
+
+	if err := s.lockoutService.RecordSuccess(ctx, user.Email); err != nil {
+		return nil, nil, eris.Wrap(err, "failed to reset failed logins")
 	}
 
-	token, err := s.generateToken(ctx, user.ID)
+	tokens, err := s.StartSession(ctx, user, client)
 	if err != nil {
-		return nil, "", eris.Wrap(err, "failed to generate token")
+		return nil, nil, err
 	}
 
-	return user, token, nil
+	return user, tokens, nil
 }
 
-func (s *SessionService) CreateForUser(ctx context.Context, userID uuid.UUID) (string, error) {
-	return s.generateToken(ctx, userID)
+func (s *SessionService) CreateForUser(ctx context.Context, userID uuid.UUID, client services.ClientInfo) (*services.SessionTokens, error) {
+	return s.issueTokens(ctx, userID, client)
+}
+
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string, client services.ClientInfo) (*services.SessionTokens, error) {
+	tokenHashes := s.tokenHasher.Candidates(refreshToken)
+
+	var tokens *services.SessionTokens
+	var userID uuid.UUID
+	reused := false
+
+	err := s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		txAuthTokenRepo := s.authTokenRepo.WithTx(tx)
+		txRefreshTokenRepo := s.refreshTokenRepo.WithTx(tx)
+
+		// Lock the row so concurrent refreshes with the same token serialize
+		current, err := txRefreshTokenRepo.GetByHashForUpdate(ctx, tokenHashes)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return errors.ErrInvalidRefreshToken
+			}
+			return eris.Wrap(err, "failed to get refresh token")
+		}
+
+		if current.RevokedAt != nil {
+			return errors.ErrTokenRevoked
+		}
+		userID = current.UserID
+
+		// Move a token found under a previous key to the current key, so a
+		// rotated token still matches for reuse detection once that key is
+		// retired
+		if current.TokenHash != tokenHashes[0] {
+			if err := txRefreshTokenRepo.UpdateHash(ctx, current.ID, tokenHashes[0]); err != nil {
+				return eris.Wrap(err, "failed to rehash refresh token")
+			}
+		}
+
+		// A rotated token presented again means it leaked: end the whole
+		// family and let the transaction commit the revocation
+		if current.RotatedAt != nil {
+			reused = true
+			if err := txRefreshTokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+				return eris.Wrap(err, "failed to revoke refresh token family")
+			}
+			return nil
+		}
+
+		if time.Now().UTC().After(current.ExpiresAt) {
+			return errors.ErrTokenExpired
+		}
+
+		if err := txRefreshTokenRepo.MarkRotated(ctx, current.ID); err != nil {
+			return eris.Wrap(err, "failed to rotate refresh token")
+		}
+
+		// The new access token replaces the previous one, so the family
+		// stays a single session
+		if err := txAuthTokenRepo.RevokeFamilyAccessTokens(ctx, current.FamilyID); err != nil {
+			return eris.Wrap(err, "failed to revoke previous access token")
+		}
+
+		tokens, err = s.issueTokenPair(ctx, txAuthTokenRepo, txRefreshTokenRepo, current.UserID, current.FamilyID, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Either way tokens of the family were revoked
+	if err := s.invalidateCache(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return nil, errors.ErrRefreshTokenReused
+	}
+
+	return tokens, nil
 }
 
 func (s *SessionService) Delete(ctx context.Context, token string) error {
-	tokenHash := HashToken(token)
-	if err := s.authTokenRepo.RevokeByHash(ctx, tokenHash); err != nil {
+	tokenHashes := s.tokenHasher.Candidates(token)
+
+	// The owner is needed to invalidate the cache; a token that doesn't
+	// exist has nothing to revoke
+	authToken, err := s.authTokenRepo.GetByHash(ctx, tokenHashes)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return eris.Wrap(err, "failed to get auth token")
+	}
+
+	if err := s.authTokenRepo.RevokeByHash(ctx, tokenHashes); err != nil {
 		return eris.Wrap(err, "failed to revoke token")
 	}
-	return nil
+
+	return s.invalidateCache(ctx, authToken.UserID)
 }
 
+// ValidateToken resolves token to its auth token. Tokens are read through
+// the token cache, which is invalidated whenever a user's tokens are
+// revoked, so cached tokens only go through the revocation and expiry
+// checks again. The generation from the cache lookup keeps a token read
+// before a revocation from being cached after it.
 func (s *SessionService) ValidateToken(ctx context.Context, token string) (*sqlcgen.AuthToken, error) {
-	tokenHash := HashToken(token)
+	tokenHashes := s.tokenHasher.Candidates(token)
 
-	authToken, err := s.authTokenRepo.GetByHash(ctx, tokenHash)
-	if err != nil {
-		if eris.Is(err, pgx.ErrNoRows) {
-			return nil, errors.ErrInvalidToken
+	authToken, generation, cached := s.tokenCache.Get(ctx, tokenHashes[0])
+	if !cached {
+		var err error
+		authToken, err = s.authTokenRepo.GetByHash(ctx, tokenHashes)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return nil, errors.ErrInvalidToken
+			}
+			return nil, eris.Wrap(err, "failed to get auth token")
 		}
-		return nil, eris.Wrap(err, "failed to get auth token")
 	}
 
 	if authToken.RevokedAt != nil {
 		return nil, errors.ErrTokenRevoked
 	}
 
-	if time.Now().UTC().After(authToken.ExpiresAt) {
+	now := time.Now().UTC()
+	if now.After(authToken.ExpiresAt) {
 		return nil, errors.ErrTokenExpired
 	}
 
+	lastUsedAt := authToken.CreatedAt
+	if authToken.LastUsedAt != nil {
+		lastUsedAt = *authToken.LastUsedAt
+	}
+
+	if idleTTL := s.config.Auth.AuthTokenIdleTTL; idleTTL > 0 && now.Sub(lastUsedAt) > idleTTL {
+		return nil, errors.ErrTokenExpired
+	}
+
+	// Move tokens found under a previous key, or stored before tokens were
+	// keyed, to the current key so that key can be retired
+	if authToken.TokenHash != tokenHashes[0] {
+		if err := s.authTokenRepo.UpdateHash(ctx, authToken.ID, tokenHashes[0]); err != nil {
+			return nil, eris.Wrap(err, "failed to rehash auth token")
+		}
+		authToken.TokenHash = tokenHashes[0]
+	}
+
+	touched := false
+	if authToken.LastUsedAt == nil || now.Sub(lastUsedAt) >= AuthTokenTouchInterval {
+		if err := s.authTokenRepo.Touch(ctx, authToken.ID); err != nil {
+			return nil, eris.Wrap(err, "failed to touch auth token")
+		}
+		authToken.LastUsedAt = &now
+		touched = true
+	}
+
+	if !cached || touched {
+		s.tokenCache.Set(ctx, authToken, generation)
+	}
+
 	return authToken, nil
 }
 
-func (s *SessionService) generateToken(ctx context.Context, userID uuid.UUID) (string, error) {
-	token, err := GenerateSecureToken(32)
+func (s *SessionService) ListForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]sqlcgen.AuthToken, int64, error) {
+	tokens, err := s.authTokenRepo.ListActiveForUser(ctx, userID, limit, offset)
 	if err != nil {
-		return "", eris.Wrap(err, "failed to generate secure token")
+		return nil, 0, eris.Wrap(err, "failed to list sessions")
 	}
 
-	tokenHash := HashToken(token)
+	total, err := s.authTokenRepo.CountActiveForUser(ctx, userID)
+	if err != nil {
+		return nil, 0, eris.Wrap(err, "failed to count sessions")
+	}
 
-	now := time.Now().UTC()
-	ttl := s.config.Auth.AuthTokenTTL
-	if ttl == 0 {
-		ttl = DefaultAuthTokenTTL
+	return tokens, total, nil
+}
+
+func (s *SessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.authTokenRepo.RevokeForUser(ctx, sessionID, userID); err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return errors.ErrSessionNotFound
+		}
+		return eris.Wrap(err, "failed to revoke session")
+	}
+	return s.invalidateCache(ctx, userID)
+}
+
+func (s *SessionService) RevokeOthers(ctx context.Context, userID, currentSessionID uuid.UUID) error {
+	if err := s.authTokenRepo.RevokeAllForUserExcept(ctx, userID, currentSessionID); err != nil {
+		return eris.Wrap(err, "failed to revoke other sessions")
+	}
+	return s.invalidateCache(ctx, userID)
+}
+
+// invalidateCache drops the cached tokens of a user whose tokens were just
+// revoked.
+func (s *SessionService) invalidateCache(ctx context.Context, userID uuid.UUID) error {
+	if err := s.tokenCache.Invalidate(ctx, userID); err != nil {
+		return eris.Wrap(err, "failed to invalidate token cache")
+	}
+	return nil
+}
+
+// StartSession completes a login: it tells the user if they signed in from
+// a new device and issues tokens. The device is recorded first so that a
+// failure doesn't leave behind a session the client never received.
+func (s *SessionService) StartSession(ctx context.Context, user *sqlcgen.User, client services.ClientInfo) (*services.SessionTokens, error) {
+	if err := s.loginAlertService.RecordLogin(ctx, user, client); err != nil {
+		return nil, err
 	}
-	expiresAt := now.Add(ttl)
 
-	_, err = s.authTokenRepo.Create(ctx, userID, tokenHash, expiresAt)
+	tokens, err := s.issueTokens(ctx, user.ID, client)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to generate token")
+	}
+
+	return tokens, nil
+}
+
+// issueTokens starts a new session family. With refresh tokens disabled it
+// issues a single long-lived access token, otherwise a short-lived access
+// token plus a refresh token.
+func (s *SessionService) issueTokens(ctx context.Context, userID uuid.UUID, client services.ClientInfo) (*services.SessionTokens, error) {
+	familyID := uuid.New()
+
+	if s.config.Auth.RefreshTokenTTL == 0 {
+		ttl := s.config.Auth.AuthTokenTTL
+		if ttl == 0 {
+			ttl = DefaultAuthTokenTTL
+		}
+
+		accessToken, expiresAt, err := s.createAuthToken(ctx, s.authTokenRepo, userID, familyID, ttl, client)
+		if err != nil {
+			return nil, err
+		}
+
+		return &services.SessionTokens{
+			AccessToken:          accessToken,
+			AccessTokenExpiresAt: expiresAt,
+		}, nil
+	}
+
+	var tokens *services.SessionTokens
+	err := s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		tokens, err = s.issueTokenPair(ctx, s.authTokenRepo.WithTx(tx), s.refreshTokenRepo.WithTx(tx), userID, familyID, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// issueTokenPair creates an access token and a refresh token in the given family.
+func (s *SessionService) issueTokenPair(
+	ctx context.Context,
+	authTokenRepo repositories.AuthTokenRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	userID, familyID uuid.UUID,
+	client services.ClientInfo,
+) (*services.SessionTokens, error) {
+	accessToken, accessExpiresAt, err := s.createAuthToken(ctx, authTokenRepo, userID, familyID, s.config.Auth.AccessTokenTTL, client)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := GenerateSecureToken(32)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to generate secure token")
+	}
+
+	refreshExpiresAt := time.Now().UTC().Add(s.config.Auth.RefreshTokenTTL)
+	if _, err := refreshTokenRepo.Create(ctx, userID, familyID, s.tokenHasher.Hash(refreshToken), refreshExpiresAt); err != nil {
+		return nil, eris.Wrap(err, "failed to create refresh token")
+	}
+
+	return &services.SessionTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: &refreshExpiresAt,
+	}, nil
+}
+
+func (s *SessionService) createAuthToken(
+	ctx context.Context,
+	authTokenRepo repositories.AuthTokenRepository,
+	userID, familyID uuid.UUID,
+	ttl time.Duration,
+	client services.ClientInfo,
+) (string, time.Time, error) {
+	token, err := GenerateSecureToken(32)
+	if err != nil {
+		return "", time.Time{}, eris.Wrap(err, "failed to generate secure token")
+	}
+
+	tokenHash := s.tokenHasher.Hash(token)
+	expiresAt := time.Now().UTC().Add(ttl)
+
+	_, err = authTokenRepo.Create(ctx, userID, familyID, tokenHash, expiresAt, client.UserAgent, client.IPAddress)
 	if err != nil {
-		return "", eris.Wrap(err, "failed to create auth token")
+		return "", time.Time{}, eris.Wrap(err, "failed to create auth token")
 	}
 
-	return token, nil
+	return token, expiresAt, nil
 }
 
 var _ services.SessionService = (*SessionService)(nil)
@@ -2,17 +2,25 @@ package services_test
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"go-reasonable-api/app/errors"
+	ifaces "go-reasonable-api/app/interfaces/services"
 	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
+	mocksSupport "go-reasonable-api/app/mocks/support"
 	"go-reasonable-api/app/services"
 	"go-reasonable-api/db/sqlcgen"
 	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+	"go-reasonable-api/support/passwordhash"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -35,85 +43,622 @@ func TestSessionService_Create(t *testing.T) {
 	userID := uuid.New()
 
 	tests := []struct {
-		name        string
-		email       string
-		password    string
-		setupMock   func(*mocks.MockUserRepository, *mocks.MockAuthTokenRepository)
-		expectUser  bool
-		expectToken bool
-		expectedErr error
+		name            string
+		email           string
+		password        string
+		setupMock       func(*mocks.MockUserRepository, *mocks.MockAuthTokenRepository, *mocksServices.MockTwoFactorService)
+		setupLockout    func(*mocksServices.MockLoginLockoutService)
+		expectToken     bool
+		expectChallenge bool
+		expectedErr     error
 	}{
 		{
 			name:     "creates session successfully",
 			email:    "test@example.com",
 			password: password,
-			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository) {
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
 				userRepo.EXPECT().GetByEmail(mock.Anything, "test@example.com").Return(&sqlcgen.User{
 					ID:           userID,
 					Email:        "test@example.com",
 					PasswordHash: string(passwordHash),
 				}, nil)
-				authRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+				authRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
 					Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
 			},
-			expectUser:  true,
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordSuccess(mock.Anything, "test@example.com").Return(nil)
+			},
 			expectToken: true,
 			expectedErr: nil,
 		},
+		{
+			name:     "returns challenge when two factor is enabled",
+			email:    "test@example.com",
+			password: password,
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "test@example.com").Return(&sqlcgen.User{
+					ID:           userID,
+					Email:        "test@example.com",
+					PasswordHash: string(passwordHash),
+				}, nil)
+				twoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(true, nil)
+				twoFactor.EXPECT().CreateChallenge(mock.Anything, userID).Return(&ifaces.TwoFactorChallenge{
+					Token:     "challenge-token",
+					ExpiresAt: time.Now().UTC().Add(5 * time.Minute),
+				}, nil)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+			},
+			expectChallenge: true,
+			expectedErr:     nil,
+		},
 		{
 			name:     "returns error when user not found",
 			email:    "notfound@example.com",
 			password: password,
-			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository) {
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
 				userRepo.EXPECT().GetByEmail(mock.Anything, "notfound@example.com").Return(nil, pgx.ErrNoRows)
 			},
-			expectUser:  false,
-			expectToken: false,
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "notfound@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordFailure(mock.Anything, "notfound@example.com", "127.0.0.1").Return(nil)
+			},
 			expectedErr: errors.ErrInvalidCredentials,
 		},
 		{
 			name:     "returns error when password is wrong",
 			email:    "test@example.com",
 			password: "wrongpassword",
-			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository) {
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
 				userRepo.EXPECT().GetByEmail(mock.Anything, "test@example.com").Return(&sqlcgen.User{
 					ID:           userID,
 					Email:        "test@example.com",
 					PasswordHash: string(passwordHash),
 				}, nil)
 			},
-			expectUser:  false,
-			expectToken: false,
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordFailure(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+			},
 			expectedErr: errors.ErrInvalidCredentials,
 		},
+		{
+			name:     "returns error when the failure triggers a lockout",
+			email:    "test@example.com",
+			password: "wrongpassword",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				userRepo.EXPECT().GetByEmail(mock.Anything, "test@example.com").Return(&sqlcgen.User{
+					ID:           userID,
+					Email:        "test@example.com",
+					PasswordHash: string(passwordHash),
+				}, nil)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordFailure(mock.Anything, "test@example.com", "127.0.0.1").
+					Return(errors.ErrLoginLocked.WithDetail("retry_after", 60))
+			},
+			expectedErr: errors.ErrLoginLocked,
+		},
+		{
+			name:     "returns error without checking the password when locked",
+			email:    "test@example.com",
+			password: password,
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").
+					Return(errors.ErrLoginLocked.WithDetail("retry_after", 60))
+			},
+			expectedErr: errors.ErrLoginLocked,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUserRepo := mocks.NewMockUserRepository(t)
 			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
-			tt.setupMock(mockUserRepo, mockAuthRepo)
+			mockTwoFactor := mocksServices.NewMockTwoFactorService(t)
+			mockLockout := mocksServices.NewMockLoginLockoutService(t)
+			mockLoginAlert := mocksServices.NewMockLoginAlertService(t)
+			tt.setupMock(mockUserRepo, mockAuthRepo, mockTwoFactor)
+			tt.setupLockout(mockLockout)
+			if tt.expectToken {
+				mockLoginAlert.EXPECT().RecordLogin(mock.Anything, mock.Anything, ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}).Return(nil)
+			}
 
-			service := services.NewSessionService(newSessionTestConfig(), mockUserRepo, mockAuthRepo)
-			user, token, err := service.Create(ctx, tt.email, tt.password)
+			service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mockTwoFactor, mockLockout, mockLoginAlert, newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			result, err := service.Create(ctx, tt.email, tt.password, ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, result.User)
+			if tt.expectToken {
+				require.NotNil(t, result.Tokens)
+				assert.NotEmpty(t, result.Tokens.AccessToken)
+				assert.Empty(t, result.Tokens.RefreshToken)
+			} else {
+				assert.Nil(t, result.Tokens)
+			}
+			if tt.expectChallenge {
+				require.NotNil(t, result.Challenge)
+				assert.Equal(t, "challenge-token", result.Challenge.Token)
+			} else {
+				assert.Nil(t, result.Challenge)
+			}
+		})
+	}
+}
+
+func TestSessionService_Create_EnumerationSafe(t *testing.T) {
+	ctx := context.Background()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	cost := 10
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("password123"), cost)
+	require.NoError(t, err)
+
+	cfg := newSessionTestConfig()
+	cfg.Auth.PasswordHashAlgorithm = passwordhash.Bcrypt
+	cfg.Auth.BcryptCost = cost
+	cfg.Auth.EnumerationSafe = true
+
+	mockUserRepo := mocks.NewMockUserRepository(t)
+	mockLockout := mocksServices.NewMockLoginLockoutService(t)
+	mockUserRepo.EXPECT().GetByEmail(mock.Anything, "known@example.com").
+		Return(&sqlcgen.User{ID: uuid.New(), Email: "known@example.com", PasswordHash: string(passwordHash)}, nil)
+	mockUserRepo.EXPECT().GetByEmail(mock.Anything, "unknown@example.com").Return(nil, pgx.ErrNoRows)
+	mockLockout.EXPECT().Check(mock.Anything, mock.Anything, "127.0.0.1").Return(nil)
+	mockLockout.EXPECT().RecordFailure(mock.Anything, mock.Anything, "127.0.0.1").Return(nil)
+
+	service := services.NewSessionService(cfg, nil, mockUserRepo, mocks.NewMockAuthTokenRepository(t), mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mockLockout, mocksServices.NewMockLoginAlertService(t), passwordhash.NewHasher(cfg), newTestTokenHasher(), newTestTokenCache())
+
+	// Warm up the lazily computed dummy hash
+	_, err = service.Create(ctx, "unknown@example.com", "wrongpassword", client)
+	require.ErrorIs(t, err, errors.ErrInvalidCredentials)
+
+	start := time.Now()
+	_, knownErr := service.Create(ctx, "known@example.com", "wrongpassword", client)
+	known := time.Since(start)
+
+	start = time.Now()
+	_, unknownErr := service.Create(ctx, "unknown@example.com", "wrongpassword", client)
+	unknown := time.Since(start)
+
+	assert.ErrorIs(t, knownErr, errors.ErrInvalidCredentials)
+	assert.ErrorIs(t, unknownErr, errors.ErrInvalidCredentials)
+	assert.Greater(t, unknown, known/2, "unknown emails should cost a password hash comparison")
+}
+
+func TestSessionService_Create_Rehash(t *testing.T) {
+	ctx := context.Background()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	userID := uuid.New()
+
+	cfg := newSessionTestConfig()
+	cfg.Auth.PasswordHashAlgorithm = passwordhash.Argon2id
+	cfg.Auth.Argon2Memory = 64
+	cfg.Auth.Argon2Iterations = 1
+	cfg.Auth.Argon2Parallelism = 1
+	hasher := passwordhash.NewHasher(cfg)
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	currentHash, err := hasher.Hash("password123")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		passwordHash string
+		expectRehash bool
+	}{
+		{name: "upgrades a bcrypt hash to argon2id", passwordHash: string(bcryptHash), expectRehash: true},
+		{name: "keeps a current argon2id hash", passwordHash: currentHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockTwoFactor := mocksServices.NewMockTwoFactorService(t)
+			mockLockout := mocksServices.NewMockLoginLockoutService(t)
+			mockLoginAlert := mocksServices.NewMockLoginAlertService(t)
+
+			mockLockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+			mockLockout.EXPECT().RecordSuccess(mock.Anything, "test@example.com").Return(nil)
+			mockLoginAlert.EXPECT().RecordLogin(mock.Anything, mock.Anything, client).Return(nil)
+			mockUserRepo.EXPECT().GetByEmail(mock.Anything, "test@example.com").
+				Return(&sqlcgen.User{ID: userID, Email: "test@example.com", PasswordHash: tt.passwordHash}, nil)
+			mockTwoFactor.EXPECT().IsEnabled(mock.Anything, userID).Return(false, nil)
+			mockAuthRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+				Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
+
+			var storedHash string
+			if tt.expectRehash {
+				mockUserRepo.EXPECT().UpdatePassword(mock.Anything, userID, mock.AnythingOfType("string")).
+					Run(func(_ context.Context, _ uuid.UUID, passwordHash string) { storedHash = passwordHash }).
+					Return(nil)
+			}
+
+			service := services.NewSessionService(cfg, nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mockTwoFactor, mockLockout, mockLoginAlert, hasher, newTestTokenHasher(), newTestTokenCache())
+			_, err := service.Create(ctx, "test@example.com", "password123", client)
+			require.NoError(t, err)
+
+			if tt.expectRehash {
+				assert.True(t, strings.HasPrefix(storedHash, "$argon2id$"))
+				assert.True(t, hasher.Verify("password123", storedHash))
+				assert.False(t, hasher.NeedsRehash(storedHash))
+			}
+		})
+	}
+}
+
+func TestSessionService_CompleteTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	user := func() *sqlcgen.User {
+		return &sqlcgen.User{ID: userID, Email: "test@example.com"}
+	}
+
+	tests := []struct {
+		name         string
+		setupMock    func(*mocks.MockUserRepository, *mocks.MockAuthTokenRepository, *mocksServices.MockTwoFactorService)
+		setupLockout func(*mocksServices.MockLoginLockoutService)
+		expectedErr  error
+	}{
+		{
+			name: "issues tokens",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				twoFactor.EXPECT().ChallengeUser(mock.Anything, "challenge-token").Return(userID, nil)
+				twoFactor.EXPECT().VerifyChallenge(mock.Anything, "challenge-token", "123456").Return(userID, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user(), nil)
+				authRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+					Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordSuccess(mock.Anything, "test@example.com").Return(nil)
+			},
+		},
+		{
+			name: "records a failed login when code is invalid",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				twoFactor.EXPECT().ChallengeUser(mock.Anything, "challenge-token").Return(userID, nil)
+				twoFactor.EXPECT().VerifyChallenge(mock.Anything, "challenge-token", "123456").Return(uuid.Nil, errors.ErrInvalidTwoFactorCode)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user(), nil)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordFailure(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "returns error when the failure triggers a lockout",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				twoFactor.EXPECT().ChallengeUser(mock.Anything, "challenge-token").Return(userID, nil)
+				twoFactor.EXPECT().VerifyChallenge(mock.Anything, "challenge-token", "123456").Return(uuid.Nil, errors.ErrInvalidTwoFactorCode)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user(), nil)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").Return(nil)
+				lockout.EXPECT().RecordFailure(mock.Anything, "test@example.com", "127.0.0.1").
+					Return(errors.ErrLoginLocked.WithDetail("retry_after", 60))
+			},
+			expectedErr: errors.ErrLoginLocked,
+		},
+		{
+			name: "returns error without checking the code when locked",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				twoFactor.EXPECT().ChallengeUser(mock.Anything, "challenge-token").Return(userID, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user(), nil)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {
+				lockout.EXPECT().Check(mock.Anything, "test@example.com", "127.0.0.1").
+					Return(errors.ErrLoginLocked.WithDetail("retry_after", 60))
+			},
+			expectedErr: errors.ErrLoginLocked,
+		},
+		{
+			name: "returns error when challenge is invalid",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				twoFactor.EXPECT().ChallengeUser(mock.Anything, "challenge-token").Return(uuid.Nil, errors.ErrInvalidTwoFactorChallenge)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {},
+			expectedErr:  errors.ErrInvalidTwoFactorChallenge,
+		},
+		{
+			name: "returns error when user no longer exists",
+			setupMock: func(userRepo *mocks.MockUserRepository, authRepo *mocks.MockAuthTokenRepository, twoFactor *mocksServices.MockTwoFactorService) {
+				twoFactor.EXPECT().ChallengeUser(mock.Anything, "challenge-token").Return(userID, nil)
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			setupLockout: func(lockout *mocksServices.MockLoginLockoutService) {},
+			expectedErr:  errors.ErrInvalidTwoFactorChallenge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockTwoFactor := mocksServices.NewMockTwoFactorService(t)
+			mockLockout := mocksServices.NewMockLoginLockoutService(t)
+			mockLoginAlert := mocksServices.NewMockLoginAlertService(t)
+			tt.setupMock(mockUserRepo, mockAuthRepo, mockTwoFactor)
+			tt.setupLockout(mockLockout)
+			if tt.expectedErr == nil {
+				mockLoginAlert.EXPECT().RecordLogin(mock.Anything, mock.Anything, client).Return(nil)
+			}
+
+			service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mockTwoFactor, mockLockout, mockLoginAlert, newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			user, tokens, err := service.CompleteTwoFactor(ctx, "challenge-token", "123456", client)
 
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 				assert.Nil(t, user)
-				assert.Empty(t, token)
+				assert.Nil(t, tokens)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, user)
-				assert.NotEmpty(t, token)
+				assert.Equal(t, userID, user.ID)
+				require.NotNil(t, tokens)
+				assert.NotEmpty(t, tokens.AccessToken)
 			}
 		})
 	}
 }
 
+func TestSessionService_StartSession(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockAuthTokenRepository, *mocksServices.MockLoginAlertService)
+		expectedErr error
+	}{
+		{
+			name: "records the device and issues tokens",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, loginAlert *mocksServices.MockLoginAlertService) {
+				loginAlert.EXPECT().RecordLogin(mock.Anything, mock.Anything, client).Return(nil)
+				authRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+					Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name: "issues no tokens when the device cannot be recorded",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, loginAlert *mocksServices.MockLoginAlertService) {
+				loginAlert.EXPECT().RecordLogin(mock.Anything, mock.Anything, client).Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockLoginAlert := mocksServices.NewMockLoginAlertService(t)
+			tt.setupMock(mockAuthRepo, mockLoginAlert)
+
+			service := services.NewSessionService(newSessionTestConfig(), nil, mocks.NewMockUserRepository(t), mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mockLoginAlert, newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			deletionScheduledAt := time.Now().UTC().Add(time.Hour)
+			user := &sqlcgen.User{ID: userID, DeletionScheduledAt: &deletionScheduledAt}
+			tokens, err := service.StartSession(ctx, user, client)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotNil(t, user.DeletionScheduledAt, "signing in leaves a scheduled deletion in place")
+			}
+		})
+	}
+}
+
+func newRefreshTestConfig() *config.Config {
+	cfg := newSessionTestConfig()
+	cfg.Auth.AccessTokenTTL = 15 * time.Minute
+	cfg.Auth.RefreshTokenTTL = 30 * 24 * time.Hour
+	return cfg
+}
+
+func TestSessionService_CreateForUser_WithRefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockPool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	mockPool.ExpectBegin()
+	mockPool.ExpectCommit()
+
+	mockUserRepo := mocks.NewMockUserRepository(t)
+	mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+	mockRefreshRepo := mocks.NewMockRefreshTokenRepository(t)
+
+	var familyID uuid.UUID
+	mockAuthRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthRepo)
+	mockRefreshRepo.EXPECT().WithTx(mock.Anything).Return(mockRefreshRepo)
+	mockAuthRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+		RunAndReturn(func(_ context.Context, _, fID uuid.UUID, _ string, _ time.Time, _, _ string) (*sqlcgen.AuthToken, error) {
+			familyID = fID
+			return &sqlcgen.AuthToken{ID: uuid.New()}, nil
+		})
+	mockRefreshRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		RunAndReturn(func(_ context.Context, _, fID uuid.UUID, _ string, _ time.Time) (*sqlcgen.RefreshToken, error) {
+			assert.Equal(t, familyID, fID)
+			return &sqlcgen.RefreshToken{ID: uuid.New()}, nil
+		})
+
+	service := services.NewSessionService(newRefreshTestConfig(), db.NewTxManager(mockPool), mockUserRepo, mockAuthRepo, mockRefreshRepo, mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+	tokens, err := service.CreateForUser(ctx, userID, ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.NotEqual(t, tokens.AccessToken, tokens.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), tokens.AccessTokenExpiresAt, time.Minute)
+	require.NotNil(t, tokens.RefreshTokenExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), *tokens.RefreshTokenExpiresAt, time.Minute)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSessionService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	familyID := uuid.New()
+	refreshID := uuid.New()
+	client := ifaces.ClientInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+	tokenHashes := newTestTokenHasher().Candidates("refresh-token")
+
+	tests := []struct {
+		name        string
+		commit      bool
+		setupMock   func(*mocks.MockAuthTokenRepository, *mocks.MockRefreshTokenRepository)
+		expectedErr error
+	}{
+		{
+			name:   "rotates refresh token within the family",
+			commit: true,
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				refreshRepo.EXPECT().GetByHashForUpdate(mock.Anything, tokenHashes).Return(&sqlcgen.RefreshToken{
+					ID:        refreshID,
+					UserID:    userID,
+					FamilyID:  familyID,
+					TokenHash: tokenHashes[0],
+					ExpiresAt: time.Now().UTC().Add(time.Hour),
+				}, nil)
+				refreshRepo.EXPECT().MarkRotated(mock.Anything, refreshID).Return(nil)
+				authRepo.EXPECT().RevokeFamilyAccessTokens(mock.Anything, familyID).Return(nil)
+				authRepo.EXPECT().Create(mock.Anything, userID, familyID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+					Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
+				refreshRepo.EXPECT().Create(mock.Anything, userID, familyID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(&sqlcgen.RefreshToken{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name: "returns error when refresh token not found",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				refreshRepo.EXPECT().GetByHashForUpdate(mock.Anything, tokenHashes).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "returns error when refresh token is revoked",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				revokedAt := time.Now().UTC()
+				refreshRepo.EXPECT().GetByHashForUpdate(mock.Anything, tokenHashes).Return(&sqlcgen.RefreshToken{
+					ID:        refreshID,
+					FamilyID:  familyID,
+					ExpiresAt: time.Now().UTC().Add(time.Hour),
+					RevokedAt: &revokedAt,
+				}, nil)
+			},
+			expectedErr: errors.ErrTokenRevoked,
+		},
+		{
+			name: "returns error when refresh token is expired",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				refreshRepo.EXPECT().GetByHashForUpdate(mock.Anything, tokenHashes).Return(&sqlcgen.RefreshToken{
+					ID:        refreshID,
+					FamilyID:  familyID,
+					TokenHash: tokenHashes[0],
+					ExpiresAt: time.Now().UTC().Add(-time.Hour),
+				}, nil)
+			},
+			expectedErr: errors.ErrTokenExpired,
+		},
+		{
+			name:   "rehashes a refresh token stored under the unkeyed hash",
+			commit: true,
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				refreshRepo.EXPECT().GetByHashForUpdate(mock.Anything, tokenHashes).Return(&sqlcgen.RefreshToken{
+					ID:        refreshID,
+					UserID:    userID,
+					FamilyID:  familyID,
+					TokenHash: services.HashToken("refresh-token"),
+					ExpiresAt: time.Now().UTC().Add(time.Hour),
+				}, nil)
+				refreshRepo.EXPECT().UpdateHash(mock.Anything, refreshID, tokenHashes[0]).Return(nil)
+				refreshRepo.EXPECT().MarkRotated(mock.Anything, refreshID).Return(nil)
+				authRepo.EXPECT().RevokeFamilyAccessTokens(mock.Anything, familyID).Return(nil)
+				authRepo.EXPECT().Create(mock.Anything, userID, familyID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), "test-agent", "127.0.0.1").
+					Return(&sqlcgen.AuthToken{ID: uuid.New()}, nil)
+				refreshRepo.EXPECT().Create(mock.Anything, userID, familyID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(&sqlcgen.RefreshToken{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name:   "revokes the family and commits when a rotated token is reused",
+			commit: true,
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				rotatedAt := time.Now().UTC().Add(-time.Minute)
+				refreshRepo.EXPECT().GetByHashForUpdate(mock.Anything, tokenHashes).Return(&sqlcgen.RefreshToken{
+					ID:        refreshID,
+					FamilyID:  familyID,
+					TokenHash: tokenHashes[0],
+					ExpiresAt: time.Now().UTC().Add(time.Hour),
+					RotatedAt: &rotatedAt,
+				}, nil)
+				refreshRepo.EXPECT().RevokeFamily(mock.Anything, familyID).Return(nil)
+			},
+			expectedErr: errors.ErrRefreshTokenReused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockPool.ExpectBegin()
+			if tt.commit {
+				mockPool.ExpectCommit()
+			} else {
+				mockPool.ExpectRollback()
+			}
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockRefreshRepo := mocks.NewMockRefreshTokenRepository(t)
+			mockAuthRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthRepo)
+			mockRefreshRepo.EXPECT().WithTx(mock.Anything).Return(mockRefreshRepo)
+			tt.setupMock(mockAuthRepo, mockRefreshRepo)
+
+			service := services.NewSessionService(newRefreshTestConfig(), db.NewTxManager(mockPool), mockUserRepo, mockAuthRepo, mockRefreshRepo, mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			tokens, err := service.Refresh(ctx, "refresh-token", client)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestSessionService_ValidateToken(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
 	tokenID := uuid.New()
+	tokenHash := newTestTokenHasher().Hash("valid-token")
 
 	tests := []struct {
 		name        string
@@ -125,12 +670,14 @@ func TestSessionService_ValidateToken(t *testing.T) {
 			name:  "validates token successfully",
 			token: "valid-token",
 			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
-				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("string")).Return(&sqlcgen.AuthToken{
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
 					ID:        tokenID,
 					UserID:    userID,
+					TokenHash: tokenHash,
 					ExpiresAt: time.Now().UTC().Add(time.Hour),
 					RevokedAt: nil,
 				}, nil)
+				authRepo.EXPECT().Touch(mock.Anything, tokenID).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -138,7 +685,7 @@ func TestSessionService_ValidateToken(t *testing.T) {
 			name:  "returns error when token not found",
 			token: "invalid-token",
 			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
-				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("string")).Return(nil, pgx.ErrNoRows)
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(nil, pgx.ErrNoRows)
 			},
 			expectedErr: errors.ErrInvalidToken,
 		},
@@ -147,7 +694,7 @@ func TestSessionService_ValidateToken(t *testing.T) {
 			token: "revoked-token",
 			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
 				revokedAt := time.Now().UTC()
-				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("string")).Return(&sqlcgen.AuthToken{
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
 					ID:        tokenID,
 					UserID:    userID,
 					ExpiresAt: time.Now().UTC().Add(time.Hour),
@@ -160,7 +707,7 @@ func TestSessionService_ValidateToken(t *testing.T) {
 			name:  "returns error when token is expired",
 			token: "expired-token",
 			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
-				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("string")).Return(&sqlcgen.AuthToken{
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
 					ID:        tokenID,
 					UserID:    userID,
 					ExpiresAt: time.Now().UTC().Add(-time.Hour),
@@ -169,6 +716,68 @@ func TestSessionService_ValidateToken(t *testing.T) {
 			},
 			expectedErr: errors.ErrTokenExpired,
 		},
+		{
+			name:  "skips touch when token was used recently",
+			token: "valid-token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
+				lastUsedAt := time.Now().UTC().Add(-time.Minute)
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
+					ID:         tokenID,
+					UserID:     userID,
+					TokenHash:  tokenHash,
+					ExpiresAt:  time.Now().UTC().Add(time.Hour),
+					LastUsedAt: &lastUsedAt,
+				}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "touches token when last use is older than the touch interval",
+			token: "valid-token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
+				lastUsedAt := time.Now().UTC().Add(-services.AuthTokenTouchInterval - time.Minute)
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
+					ID:         tokenID,
+					UserID:     userID,
+					TokenHash:  tokenHash,
+					ExpiresAt:  time.Now().UTC().Add(time.Hour),
+					LastUsedAt: &lastUsedAt,
+				}, nil)
+				authRepo.EXPECT().Touch(mock.Anything, tokenID).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "returns error when touch fails",
+			token: "valid-token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
+					ID:        tokenID,
+					UserID:    userID,
+					TokenHash: tokenHash,
+					ExpiresAt: time.Now().UTC().Add(time.Hour),
+				}, nil)
+				authRepo.EXPECT().Touch(mock.Anything, tokenID).Return(pgx.ErrTxClosed)
+			},
+			expectedErr: pgx.ErrTxClosed,
+		},
+		{
+			name:  "rehashes token stored before tokens were keyed",
+			token: "valid-token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.MatchedBy(func(hashes []string) bool {
+					return hashes[0] == tokenHash && slices.Contains(hashes, services.HashToken("valid-token"))
+				})).Return(&sqlcgen.AuthToken{
+					ID:        tokenID,
+					UserID:    userID,
+					TokenHash: services.HashToken("valid-token"),
+					ExpiresAt: time.Now().UTC().Add(time.Hour),
+				}, nil)
+				authRepo.EXPECT().UpdateHash(mock.Anything, tokenID, tokenHash).Return(nil)
+				authRepo.EXPECT().Touch(mock.Anything, tokenID).Return(nil)
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,7 +786,7 @@ func TestSessionService_ValidateToken(t *testing.T) {
 			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
 			tt.setupMock(mockAuthRepo)
 
-			service := services.NewSessionService(newSessionTestConfig(), mockUserRepo, mockAuthRepo)
+			service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
 			authToken, err := service.ValidateToken(ctx, tt.token)
 
 			if tt.expectedErr != nil {
@@ -191,28 +800,284 @@ func TestSessionService_ValidateToken(t *testing.T) {
 	}
 }
 
+func TestSessionService_ValidateToken_IdleTTL(t *testing.T) {
+	ctx := context.Background()
+	tokenID := uuid.New()
+	now := time.Now().UTC()
+	recentUse := now.Add(-time.Hour)
+	staleUse := now.Add(-48 * time.Hour)
+
+	cfg := newSessionTestConfig()
+	cfg.Auth.AuthTokenIdleTTL = 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		createdAt   time.Time
+		lastUsedAt  *time.Time
+		expectTouch bool
+		expectedErr error
+	}{
+		{
+			name:        "accepts token used within the idle TTL",
+			createdAt:   now.Add(-72 * time.Hour),
+			lastUsedAt:  &recentUse,
+			expectTouch: true,
+		},
+		{
+			name:        "rejects token idle longer than the idle TTL",
+			createdAt:   now.Add(-72 * time.Hour),
+			lastUsedAt:  &staleUse,
+			expectedErr: errors.ErrTokenExpired,
+		},
+		{
+			name:        "rejects never used token created before the idle TTL",
+			createdAt:   now.Add(-48 * time.Hour),
+			expectedErr: errors.ErrTokenExpired,
+		},
+		{
+			name:        "accepts never used token created within the idle TTL",
+			createdAt:   now.Add(-time.Hour),
+			expectTouch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockAuthRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{
+				ID:         tokenID,
+				TokenHash:  newTestTokenHasher().Hash("token"),
+				ExpiresAt:  now.Add(time.Hour),
+				CreatedAt:  tt.createdAt,
+				LastUsedAt: tt.lastUsedAt,
+			}, nil)
+			if tt.expectTouch {
+				mockAuthRepo.EXPECT().Touch(mock.Anything, tokenID).Return(nil)
+			}
+
+			service := services.NewSessionService(cfg, nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			authToken, err := service.ValidateToken(ctx, "token")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, authToken)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, authToken)
+			}
+		})
+	}
+}
+
+func TestSessionService_ValidateToken_Cache(t *testing.T) {
+	ctx := context.Background()
+	tokenID := uuid.New()
+	userID := uuid.New()
+	tokenHash := newTestTokenHasher().Hash("token")
+
+	newToken := func(lastUsedAt time.Time) *sqlcgen.AuthToken {
+		return &sqlcgen.AuthToken{
+			ID:         tokenID,
+			UserID:     userID,
+			TokenHash:  tokenHash,
+			ExpiresAt:  time.Now().UTC().Add(time.Hour),
+			LastUsedAt: &lastUsedAt,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockAuthTokenRepository, *mocksSupport.MockTokenCache)
+		expectedErr error
+	}{
+		{
+			name: "serves cached token without a database lookup",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				tokenCache.EXPECT().Get(mock.Anything, tokenHash).Return(newToken(time.Now().UTC().Add(-time.Minute)), int64(3), true)
+			},
+		},
+		{
+			name: "caches token read from the database",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				tokenCache.EXPECT().Get(mock.Anything, tokenHash).Return(nil, int64(3), false)
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(newToken(time.Now().UTC().Add(-time.Minute)), nil)
+				tokenCache.EXPECT().Set(mock.Anything, mock.MatchedBy(func(token *sqlcgen.AuthToken) bool {
+					return token.ID == tokenID
+				}), int64(3)).Return()
+			},
+		},
+		{
+			name: "recaches cached token after touching it",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				tokenCache.EXPECT().Get(mock.Anything, tokenHash).Return(newToken(time.Now().UTC().Add(-services.AuthTokenTouchInterval-time.Minute)), int64(3), true)
+				authRepo.EXPECT().Touch(mock.Anything, tokenID).Return(nil)
+				tokenCache.EXPECT().Set(mock.Anything, mock.MatchedBy(func(token *sqlcgen.AuthToken) bool {
+					return time.Since(*token.LastUsedAt) < time.Minute
+				}), int64(3)).Return()
+			},
+		},
+		{
+			name: "rejects expired cached token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				token := newToken(time.Now().UTC())
+				token.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+				tokenCache.EXPECT().Get(mock.Anything, tokenHash).Return(token, int64(3), true)
+			},
+			expectedErr: errors.ErrTokenExpired,
+		},
+		{
+			name: "does not cache revoked token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				token := newToken(time.Now().UTC())
+				revokedAt := time.Now().UTC()
+				token.RevokedAt = &revokedAt
+				tokenCache.EXPECT().Get(mock.Anything, tokenHash).Return(nil, int64(3), false)
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(token, nil)
+			},
+			expectedErr: errors.ErrTokenRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockTokenCache := mocksSupport.NewMockTokenCache(t)
+			tt.setupMock(mockAuthRepo, mockTokenCache)
+
+			service := services.NewSessionService(newSessionTestConfig(), nil, mocks.NewMockUserRepository(t), mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), mockTokenCache)
+			authToken, err := service.ValidateToken(ctx, "token")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, authToken)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tokenID, authToken.ID)
+			}
+		})
+	}
+}
+
 func TestSessionService_Delete(t *testing.T) {
 	ctx := context.Background()
+	userID := uuid.New()
 
 	tests := []struct {
 		name        string
 		token       string
-		setupMock   func(*mocks.MockAuthTokenRepository)
+		setupMock   func(*mocks.MockAuthTokenRepository, *mocksSupport.MockTokenCache)
 		expectedErr error
 	}{
 		{
-			name:  "deletes session successfully",
+			name:  "deletes session and invalidates cache",
 			token: "valid-token",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
-				authRepo.EXPECT().RevokeByHash(mock.Anything, mock.AnythingOfType("string")).Return(nil)
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{UserID: userID}, nil)
+				authRepo.EXPECT().RevokeByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(nil)
+				tokenCache.EXPECT().Invalidate(mock.Anything, userID).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "does nothing for unknown token",
+			token: "unknown-token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(nil, pgx.ErrNoRows)
 			},
 			expectedErr: nil,
 		},
 		{
 			name:  "returns error when revoke fails",
 			token: "valid-token",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository, tokenCache *mocksSupport.MockTokenCache) {
+				authRepo.EXPECT().GetByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.AuthToken{UserID: userID}, nil)
+				authRepo.EXPECT().RevokeByHash(mock.Anything, mock.AnythingOfType("[]string")).Return(pgx.ErrTxClosed)
+			},
+			expectedErr: pgx.ErrTxClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+			mockTokenCache := mocksSupport.NewMockTokenCache(t)
+			tt.setupMock(mockAuthRepo, mockTokenCache)
+
+			service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), mockTokenCache)
+			err := service.Delete(ctx, tt.token)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSessionService_ListForUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("returns sessions and total", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository(t)
+		mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+
+		mockAuthRepo.EXPECT().ListActiveForUser(mock.Anything, userID, int32(20), int32(0)).
+			Return([]sqlcgen.AuthToken{{ID: uuid.New(), UserID: userID}, {ID: uuid.New(), UserID: userID}}, nil)
+		mockAuthRepo.EXPECT().CountActiveForUser(mock.Anything, userID).Return(int64(2), nil)
+
+		service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+		tokens, total, err := service.ListForUser(ctx, userID, 20, 0)
+
+		require.NoError(t, err)
+		assert.Len(t, tokens, 2)
+		assert.Equal(t, int64(2), total)
+	})
+
+	t.Run("returns error when list fails", func(t *testing.T) {
+		mockUserRepo := mocks.NewMockUserRepository(t)
+		mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+
+		mockAuthRepo.EXPECT().ListActiveForUser(mock.Anything, userID, int32(20), int32(0)).Return(nil, pgx.ErrTxClosed)
+
+		service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+		_, _, err := service.ListForUser(ctx, userID, 20, 0)
+
+		assert.ErrorIs(t, err, pgx.ErrTxClosed)
+	})
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockAuthTokenRepository)
+		expectedErr error
+	}{
+		{
+			name: "revokes session successfully",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
+				authRepo.EXPECT().RevokeForUser(mock.Anything, sessionID, userID).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "returns ErrSessionNotFound when session does not belong to user",
 			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
-				authRepo.EXPECT().RevokeByHash(mock.Anything, mock.AnythingOfType("string")).Return(pgx.ErrTxClosed)
+				authRepo.EXPECT().RevokeForUser(mock.Anything, sessionID, userID).Return(pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrSessionNotFound,
+		},
+		{
+			name: "returns error when revoke fails",
+			setupMock: func(authRepo *mocks.MockAuthTokenRepository) {
+				authRepo.EXPECT().RevokeForUser(mock.Anything, sessionID, userID).Return(pgx.ErrTxClosed)
 			},
 			expectedErr: pgx.ErrTxClosed,
 		},
@@ -224,8 +1089,8 @@ func TestSessionService_Delete(t *testing.T) {
 			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
 			tt.setupMock(mockAuthRepo)
 
-			service := services.NewSessionService(newSessionTestConfig(), mockUserRepo, mockAuthRepo)
-			err := service.Delete(ctx, tt.token)
+			service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+			err := service.Revoke(ctx, userID, sessionID)
 
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
@@ -235,3 +1100,18 @@ func TestSessionService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionService_RevokeOthers(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	currentID := uuid.New()
+
+	mockUserRepo := mocks.NewMockUserRepository(t)
+	mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
+	mockAuthRepo.EXPECT().RevokeAllForUserExcept(mock.Anything, userID, currentID).Return(nil)
+
+	service := services.NewSessionService(newSessionTestConfig(), nil, mockUserRepo, mockAuthRepo, mocks.NewMockRefreshTokenRepository(t), mocksServices.NewMockTwoFactorService(t), mocksServices.NewMockLoginLockoutService(t), mocksServices.NewMockLoginAlertService(t), newTestHasher(), newTestTokenHasher(), newTestTokenCache())
+	err := service.RevokeOthers(ctx, userID, currentID)
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 test key from RFC 6238, Appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 vectors, truncated to 6 digits
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+		{unix: 20000000000, expected: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			code, err := services.TOTPCode(rfc6238Secret, services.TOTPStep(time.Unix(tt.unix, 0)))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestTOTPCode_InvalidSecret(t *testing.T) {
+	_, err := services.TOTPCode("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := services.TOTPStep(now)
+
+	codeAt := func(s int64) string {
+		code, err := services.TOTPCode(rfc6238Secret, s)
+		require.NoError(t, err)
+		return code
+	}
+
+	tests := []struct {
+		name         string
+		code         string
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{name: "current step", code: codeAt(step), expectedStep: step, expectedOK: true},
+		{name: "previous step within skew", code: codeAt(step - 1), expectedStep: step - 1, expectedOK: true},
+		{name: "next step within skew", code: codeAt(step + 1), expectedStep: step + 1, expectedOK: true},
+		{name: "outside skew", code: codeAt(step - 2), expectedOK: false},
+		{name: "wrong length", code: "12345", expectedOK: false},
+		{name: "wrong code", code: "000000", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, ok := services.ValidateTOTP(rfc6238Secret, tt.code, now)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if tt.expectedOK {
+				assert.Equal(t, tt.expectedStep, matched)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := services.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+	assert.Len(t, key, 20)
+
+	other, err := services.GenerateTOTPSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestTOTPKeyURI(t *testing.T) {
+	uri := services.TOTPKeyURI("Acme", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/Acme:user@example.com", parsed.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "Acme", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rotisserie/eris"
+)
+
+const (
+	// MaxTwoFactorAttempts is the number of wrong codes a login challenge
+	// accepts before it is rejected outright
+	MaxTwoFactorAttempts = 5
+
+	// RecoveryCodeCount is the number of recovery codes issued per enrollment
+	RecoveryCodeCount = 10
+)
+
+type TwoFactorService struct {
+	config           *config.Config
+	txManager        *db.TxManager
+	userRepo         repositories.UserRepository
+	totpRepo         repositories.TOTPCredentialRepository
+	recoveryCodeRepo repositories.RecoveryCodeRepository
+	challengeRepo    repositories.TwoFactorChallengeRepository
+	hasher           support.PasswordHasher
+	tokenHasher      support.TokenHasher
+}
+
+func NewTwoFactorService(
+	cfg *config.Config,
+	txManager *db.TxManager,
+	userRepo repositories.UserRepository,
+	totpRepo repositories.TOTPCredentialRepository,
+	recoveryCodeRepo repositories.RecoveryCodeRepository,
+	challengeRepo repositories.TwoFactorChallengeRepository,
+	hasher support.PasswordHasher,
+	tokenHasher support.TokenHasher,
+) *TwoFactorService {
+	return &TwoFactorService{
+		config:           cfg,
+		txManager:        txManager,
+		userRepo:         userRepo,
+		totpRepo:         totpRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		challengeRepo:    challengeRepo,
+		hasher:           hasher,
+		tokenHasher:      tokenHasher,
+	}
+}
+
+func (s *TwoFactorService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*services.TOTPEnrollment, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, eris.Wrap(err, "failed to get user")
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.totpRepo.UpsertPending(ctx, userID, secret)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to store totp secret")
+	}
+	if !stored {
+		return nil, errors.ErrTwoFactorAlreadyEnabled
+	}
+
+	return &services.TOTPEnrollment{
+		Secret: secret,
+		URI:    TOTPKeyURI(s.config.Auth.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *TwoFactorService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	var recoveryCodes []string
+
+	err := s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		txTOTPRepo := s.totpRepo.WithTx(tx)
+		txRecoveryCodeRepo := s.recoveryCodeRepo.WithTx(tx)
+
+		credential, err := txTOTPRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return errors.ErrTwoFactorEnrollmentNotFound
+			}
+			return eris.Wrap(err, "failed to get totp credential")
+		}
+
+		if credential.ConfirmedAt != nil {
+			return errors.ErrTwoFactorAlreadyEnabled
+		}
+
+		step, ok := ValidateTOTP(credential.Secret, normalizeTwoFactorCode(code), time.Now())
+		if !ok {
+			return errors.ErrInvalidTwoFactorCode
+		}
+
+		confirmed, err := txTOTPRepo.Confirm(ctx, userID, step)
+		if err != nil {
+			return eris.Wrap(err, "failed to confirm totp credential")
+		}
+		if !confirmed {
+			return errors.ErrTwoFactorAlreadyEnabled
+		}
+
+		if err := txRecoveryCodeRepo.DeleteAllForUser(ctx, userID); err != nil {
+			return eris.Wrap(err, "failed to delete recovery codes")
+		}
+
+		recoveryCodes = make([]string, 0, RecoveryCodeCount)
+		for range RecoveryCodeCount {
+			code, err := generateRecoveryCode()
+			if err != nil {
+				return err
+			}
+			if err := txRecoveryCodeRepo.Create(ctx, userID, s.tokenHasher.Hash(normalizeTwoFactorCode(code))); err != nil {
+				return eris.Wrap(err, "failed to create recovery code")
+			}
+			recoveryCodes = append(recoveryCodes, code)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, password string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return errors.ErrUserNotFound
+		}
+		return eris.Wrap(err, "failed to get user")
+	}
+
+	if !s.hasher.Verify(password, user.PasswordHash) {
+		return errors.ErrInvalidPassword
+	}
+
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return errors.ErrTwoFactorNotEnabled
+	}
+
+	return s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		if err := s.totpRepo.WithTx(tx).Delete(ctx, userID); err != nil {
+			return eris.Wrap(err, "failed to delete totp credential")
+		}
+		if err := s.recoveryCodeRepo.WithTx(tx).DeleteAllForUser(ctx, userID); err != nil {
+			return eris.Wrap(err, "failed to delete recovery codes")
+		}
+		return nil
+	})
+}
+
+func (s *TwoFactorService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	credential, err := s.totpRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, eris.Wrap(err, "failed to get totp credential")
+	}
+
+	return credential.ConfirmedAt != nil, nil
+}
+
+func (s *TwoFactorService) CreateChallenge(ctx context.Context, userID uuid.UUID) (*services.TwoFactorChallenge, error) {
+	token, err := GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().UTC().Add(s.config.Auth.TwoFactorChallengeTTL)
+	if _, err := s.challengeRepo.Create(ctx, userID, s.tokenHasher.Hash(token), expiresAt); err != nil {
+		return nil, eris.Wrap(err, "failed to create two factor challenge")
+	}
+
+	return &services.TwoFactorChallenge{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *TwoFactorService) ChallengeUser(ctx context.Context, token string) (uuid.UUID, error) {
+	challenge, err := s.challengeRepo.GetByTokenHash(ctx, s.tokenHasher.Candidates(token))
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, errors.ErrInvalidTwoFactorChallenge
+		}
+		return uuid.Nil, eris.Wrap(err, "failed to get two factor challenge")
+	}
+
+	if !challengeOpen(challenge) {
+		return uuid.Nil, errors.ErrInvalidTwoFactorChallenge
+	}
+
+	return challenge.UserID, nil
+}
+
+func (s *TwoFactorService) VerifyChallenge(ctx context.Context, token, code string) (uuid.UUID, error) {
+	tokenHashes := s.tokenHasher.Candidates(token)
+
+	var userID uuid.UUID
+	failed := false
+
+	err := s.txManager.RunInTx(ctx, func(tx pgx.Tx) error {
+		txChallengeRepo := s.challengeRepo.WithTx(tx)
+
+		// Lock the row so concurrent attempts against one challenge serialize
+		challenge, err := txChallengeRepo.GetByTokenHashForUpdate(ctx, tokenHashes)
+		if err != nil {
+			if eris.Is(err, pgx.ErrNoRows) {
+				return errors.ErrInvalidTwoFactorChallenge
+			}
+			return eris.Wrap(err, "failed to get two factor challenge")
+		}
+
+		if !challengeOpen(challenge) {
+			return errors.ErrInvalidTwoFactorChallenge
+		}
+
+		valid, err := s.verifyCode(ctx, tx, challenge.UserID, code)
+		if err != nil {
+			return err
+		}
+
+		// Record the failure and let the transaction commit it
+		if !valid {
+			failed = true
+			if err := txChallengeRepo.IncrementAttempts(ctx, challenge.ID); err != nil {
+				return eris.Wrap(err, "failed to record two factor attempt")
+			}
+			return nil
+		}
+
+		if err := txChallengeRepo.MarkUsed(ctx, challenge.ID); err != nil {
+			return eris.Wrap(err, "failed to mark two factor challenge as used")
+		}
+
+		userID = challenge.UserID
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if failed {
+		return uuid.Nil, errors.ErrInvalidTwoFactorCode
+	}
+
+	return userID, nil
+}
+
+// verifyCode checks a TOTP code, or a recovery code when the input is not a
+// TOTP code, and consumes it.
+func (s *TwoFactorService) verifyCode(ctx context.Context, tx pgx.Tx, userID uuid.UUID, code string) (bool, error) {
+	txTOTPRepo := s.totpRepo.WithTx(tx)
+
+	credential, err := txTOTPRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if eris.Is(err, pgx.ErrNoRows) {
+			return false, errors.ErrInvalidTwoFactorChallenge
+		}
+		return false, eris.Wrap(err, "failed to get totp credential")
+	}
+
+	// Two-factor was disabled after the challenge was issued
+	if credential.ConfirmedAt == nil {
+		return false, errors.ErrInvalidTwoFactorChallenge
+	}
+
+	code = normalizeTwoFactorCode(code)
+
+	if len(code) == TOTPDigits {
+		step, ok := ValidateTOTP(credential.Secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+
+		// Reject a code whose time step was already used
+		updated, err := txTOTPRepo.UpdateLastUsedStep(ctx, userID, step)
+		if err != nil {
+			return false, eris.Wrap(err, "failed to update totp last used step")
+		}
+		return updated, nil
+	}
+
+	// Recovery codes are single-use, so one found under a previous key is
+	// used up here and never needs rehashing
+	used, err := s.recoveryCodeRepo.WithTx(tx).Use(ctx, userID, s.tokenHasher.Candidates(code))
+	if err != nil {
+		return false, eris.Wrap(err, "failed to use recovery code")
+	}
+	return used, nil
+}
+
+// challengeOpen reports whether a challenge still accepts codes: it is
+// unused, unexpired and has attempts left.
+func challengeOpen(challenge *sqlcgen.TwoFactorChallenge) bool {
+	return challenge.UsedAt == nil &&
+		!time.Now().UTC().After(challenge.ExpiresAt) &&
+		challenge.Attempts < MaxTwoFactorAttempts
+}
+
+// generateRecoveryCode returns a random code formatted as two groups of five
+// hex characters, e.g. "3f9a1-c07be".
+func generateRecoveryCode() (string, error) {
+	code, err := GenerateSecureToken(5)
+	if err != nil {
+		return "", err
+	}
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeTwoFactorCode strips separators and whitespace users commonly
+// type, so "3f9a1-c07be", "3F9A1C07BE" and "123 456" are all accepted.
+func normalizeTwoFactorCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+var _ services.TwoFactorService = (*TwoFactorService)(nil)
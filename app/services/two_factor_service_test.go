@@ -0,0 +1,479 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	mocks "go-reasonable-api/app/mocks/repositories"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/db"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func newTwoFactorTestConfig() *config.Config {
+	return &config.Config{
+		Auth: config.AuthConfig{
+			BcryptCost:            bcrypt.MinCost,
+			TOTPIssuer:            "Test App",
+			TwoFactorChallengeTTL: 5 * time.Minute,
+		},
+	}
+}
+
+func currentTOTPCode(t *testing.T) string {
+	code, err := services.TOTPCode(testTOTPSecret, services.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestTwoFactorService_BeginEnrollment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockUserRepository, *mocks.MockTOTPCredentialRepository)
+		expectedErr error
+	}{
+		{
+			name: "returns secret and otpauth uri",
+			setupMock: func(userRepo *mocks.MockUserRepository, totpRepo *mocks.MockTOTPCredentialRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "test@example.com"}, nil)
+				totpRepo.EXPECT().UpsertPending(mock.Anything, userID, mock.AnythingOfType("string")).Return(true, nil)
+			},
+		},
+		{
+			name: "returns error when already enabled",
+			setupMock: func(userRepo *mocks.MockUserRepository, totpRepo *mocks.MockTOTPCredentialRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "test@example.com"}, nil)
+				totpRepo.EXPECT().UpsertPending(mock.Anything, userID, mock.AnythingOfType("string")).Return(false, nil)
+			},
+			expectedErr: errors.ErrTwoFactorAlreadyEnabled,
+		},
+		{
+			name: "returns error when user not found",
+			setupMock: func(userRepo *mocks.MockUserRepository, totpRepo *mocks.MockTOTPCredentialRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockTOTPRepo := mocks.NewMockTOTPCredentialRepository(t)
+			tt.setupMock(mockUserRepo, mockTOTPRepo)
+
+			service := services.NewTwoFactorService(newTwoFactorTestConfig(), nil, mockUserRepo, mockTOTPRepo, mocks.NewMockRecoveryCodeRepository(t), mocks.NewMockTwoFactorChallengeRepository(t), newTestHasher(), newTestTokenHasher())
+			enrollment, err := service.BeginEnrollment(ctx, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, enrollment)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, enrollment.Secret)
+			assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/"))
+			assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+			assert.Contains(t, enrollment.URI, "test@example.com")
+		})
+	}
+}
+
+func TestTwoFactorService_ConfirmEnrollment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		code        func(t *testing.T) string
+		setupMock   func(*mocks.MockTOTPCredentialRepository, *mocks.MockRecoveryCodeRepository)
+		expectedErr error
+	}{
+		{
+			name: "confirms and issues recovery codes",
+			code: currentTOTPCode,
+			setupMock: func(totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(&sqlcgen.TotpCredential{UserID: userID, Secret: testTOTPSecret}, nil)
+				totpRepo.EXPECT().Confirm(mock.Anything, userID, mock.AnythingOfType("int64")).Return(true, nil)
+				recoveryRepo.EXPECT().DeleteAllForUser(mock.Anything, userID).Return(nil)
+				recoveryRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("string")).Return(nil).Times(services.RecoveryCodeCount)
+			},
+		},
+		{
+			name: "returns error when code is wrong",
+			code: func(*testing.T) string { return "000000" },
+			setupMock: func(totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(&sqlcgen.TotpCredential{UserID: userID, Secret: testTOTPSecret}, nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "returns error when no enrollment is pending",
+			code: currentTOTPCode,
+			setupMock: func(totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrTwoFactorEnrollmentNotFound,
+		},
+		{
+			name: "returns error when already confirmed",
+			code: currentTOTPCode,
+			setupMock: func(totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				confirmedAt := time.Now().UTC()
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(&sqlcgen.TotpCredential{UserID: userID, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil)
+			},
+			expectedErr: errors.ErrTwoFactorAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockPool.ExpectBegin()
+			if tt.expectedErr == nil {
+				mockPool.ExpectCommit()
+			} else {
+				mockPool.ExpectRollback()
+			}
+
+			mockTOTPRepo := mocks.NewMockTOTPCredentialRepository(t)
+			mockRecoveryRepo := mocks.NewMockRecoveryCodeRepository(t)
+			mockTOTPRepo.EXPECT().WithTx(mock.Anything).Return(mockTOTPRepo)
+			mockRecoveryRepo.EXPECT().WithTx(mock.Anything).Return(mockRecoveryRepo)
+			tt.setupMock(mockTOTPRepo, mockRecoveryRepo)
+
+			service := services.NewTwoFactorService(newTwoFactorTestConfig(), db.NewTxManager(mockPool), mocks.NewMockUserRepository(t), mockTOTPRepo, mockRecoveryRepo, mocks.NewMockTwoFactorChallengeRepository(t), newTestHasher(), newTestTokenHasher())
+			codes, err := service.ConfirmEnrollment(ctx, userID, tt.code(t))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, codes)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, codes, services.RecoveryCodeCount)
+				assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, codes[0])
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &sqlcgen.User{ID: userID, PasswordHash: string(passwordHash)}
+	confirmedAt := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		password    string
+		setupMock   func(pgxmock.PgxPoolIface, *mocks.MockUserRepository, *mocks.MockTOTPCredentialRepository, *mocks.MockRecoveryCodeRepository)
+		expectedErr error
+	}{
+		{
+			name:     "removes credential and recovery codes",
+			password: "password123",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(&sqlcgen.TotpCredential{ConfirmedAt: &confirmedAt}, nil)
+				pool.ExpectBegin()
+				totpRepo.EXPECT().WithTx(mock.Anything).Return(totpRepo)
+				recoveryRepo.EXPECT().WithTx(mock.Anything).Return(recoveryRepo)
+				totpRepo.EXPECT().Delete(mock.Anything, userID).Return(nil)
+				recoveryRepo.EXPECT().DeleteAllForUser(mock.Anything, userID).Return(nil)
+				pool.ExpectCommit()
+			},
+		},
+		{
+			name:     "returns error when password is wrong",
+			password: "wrongpassword",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+			},
+			expectedErr: errors.ErrInvalidPassword,
+		},
+		{
+			name:     "returns error when not enabled",
+			password: "password123",
+			setupMock: func(pool pgxmock.PgxPoolIface, userRepo *mocks.MockUserRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrTwoFactorNotEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockTOTPRepo := mocks.NewMockTOTPCredentialRepository(t)
+			mockRecoveryRepo := mocks.NewMockRecoveryCodeRepository(t)
+			tt.setupMock(mockPool, mockUserRepo, mockTOTPRepo, mockRecoveryRepo)
+
+			service := services.NewTwoFactorService(newTwoFactorTestConfig(), db.NewTxManager(mockPool), mockUserRepo, mockTOTPRepo, mockRecoveryRepo, mocks.NewMockTwoFactorChallengeRepository(t), newTestHasher(), newTestTokenHasher())
+			err = service.Disable(ctx, userID, tt.password)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTwoFactorService_CreateChallenge(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockChallengeRepo := mocks.NewMockTwoFactorChallengeRepository(t)
+	mockChallengeRepo.EXPECT().Create(mock.Anything, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(&sqlcgen.TwoFactorChallenge{ID: uuid.New()}, nil)
+
+	service := services.NewTwoFactorService(newTwoFactorTestConfig(), nil, mocks.NewMockUserRepository(t), mocks.NewMockTOTPCredentialRepository(t), mocks.NewMockRecoveryCodeRepository(t), mockChallengeRepo, newTestHasher(), newTestTokenHasher())
+	challenge, err := service.CreateChallenge(ctx, userID)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, challenge.Token)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), challenge.ExpiresAt, time.Minute)
+}
+
+func TestTwoFactorService_ChallengeUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	usedAt := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockTwoFactorChallengeRepository)
+		expectedErr error
+	}{
+		{
+			name: "returns the user of an open challenge",
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository) {
+				challengeRepo.EXPECT().GetByTokenHash(mock.Anything, newTestTokenHasher().Candidates("challenge-token")).Return(&sqlcgen.TwoFactorChallenge{
+					UserID:    userID,
+					ExpiresAt: time.Now().UTC().Add(5 * time.Minute),
+				}, nil)
+			},
+		},
+		{
+			name: "returns error when challenge not found",
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository) {
+				challengeRepo.EXPECT().GetByTokenHash(mock.Anything, mock.AnythingOfType("[]string")).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+		{
+			name: "returns error when challenge was already used",
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository) {
+				challengeRepo.EXPECT().GetByTokenHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.TwoFactorChallenge{
+					UserID:    userID,
+					ExpiresAt: time.Now().UTC().Add(5 * time.Minute),
+					UsedAt:    &usedAt,
+				}, nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+		{
+			name: "returns error when attempts are exhausted",
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository) {
+				challengeRepo.EXPECT().GetByTokenHash(mock.Anything, mock.AnythingOfType("[]string")).Return(&sqlcgen.TwoFactorChallenge{
+					UserID:    userID,
+					ExpiresAt: time.Now().UTC().Add(5 * time.Minute),
+					Attempts:  services.MaxTwoFactorAttempts,
+				}, nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChallengeRepo := mocks.NewMockTwoFactorChallengeRepository(t)
+			tt.setupMock(mockChallengeRepo)
+
+			service := services.NewTwoFactorService(newTwoFactorTestConfig(), nil, mocks.NewMockUserRepository(t), mocks.NewMockTOTPCredentialRepository(t), mocks.NewMockRecoveryCodeRepository(t), mockChallengeRepo, newTestHasher(), newTestTokenHasher())
+			got, err := service.ChallengeUser(ctx, "challenge-token")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Equal(t, uuid.Nil, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID, got)
+			}
+		})
+	}
+}
+
+func TestTwoFactorService_VerifyChallenge(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	challengeID := uuid.New()
+	confirmedAt := time.Now().UTC()
+	credential := &sqlcgen.TotpCredential{UserID: userID, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}
+
+	activeChallenge := func() *sqlcgen.TwoFactorChallenge {
+		return &sqlcgen.TwoFactorChallenge{
+			ID:        challengeID,
+			UserID:    userID,
+			ExpiresAt: time.Now().UTC().Add(5 * time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		code        func(t *testing.T) string
+		commit      bool
+		setupMock   func(*mocks.MockTwoFactorChallengeRepository, *mocks.MockTOTPCredentialRepository, *mocks.MockRecoveryCodeRepository)
+		expectedErr error
+	}{
+		{
+			name:   "accepts a totp code",
+			code:   currentTOTPCode,
+			commit: true,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, newTestTokenHasher().Candidates("challenge-token")).Return(activeChallenge(), nil)
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(credential, nil)
+				totpRepo.EXPECT().UpdateLastUsedStep(mock.Anything, userID, mock.AnythingOfType("int64")).Return(true, nil)
+				challengeRepo.EXPECT().MarkUsed(mock.Anything, challengeID).Return(nil)
+			},
+		},
+		{
+			name:   "accepts a recovery code",
+			code:   func(*testing.T) string { return "ABCDE-12345" },
+			commit: true,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(activeChallenge(), nil)
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(credential, nil)
+				recoveryRepo.EXPECT().Use(mock.Anything, userID, newTestTokenHasher().Candidates("abcde12345")).Return(true, nil)
+				challengeRepo.EXPECT().MarkUsed(mock.Anything, challengeID).Return(nil)
+			},
+		},
+		{
+			name:   "records a failed attempt when code is wrong",
+			code:   func(*testing.T) string { return "000000" },
+			commit: true,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(activeChallenge(), nil)
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(credential, nil)
+				challengeRepo.EXPECT().IncrementAttempts(mock.Anything, challengeID).Return(nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:   "rejects a replayed totp code",
+			code:   currentTOTPCode,
+			commit: true,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(activeChallenge(), nil)
+				totpRepo.EXPECT().GetByUserID(mock.Anything, userID).Return(credential, nil)
+				totpRepo.EXPECT().UpdateLastUsedStep(mock.Anything, userID, mock.AnythingOfType("int64")).Return(false, nil)
+				challengeRepo.EXPECT().IncrementAttempts(mock.Anything, challengeID).Return(nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "returns error when challenge not found",
+			code: currentTOTPCode,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+		{
+			name: "returns error when challenge is expired",
+			code: currentTOTPCode,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challenge := activeChallenge()
+				challenge.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(challenge, nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+		{
+			name: "returns error when attempts are exhausted",
+			code: currentTOTPCode,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				challenge := activeChallenge()
+				challenge.Attempts = services.MaxTwoFactorAttempts
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(challenge, nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+		{
+			name: "returns error when challenge was already used",
+			code: currentTOTPCode,
+			setupMock: func(challengeRepo *mocks.MockTwoFactorChallengeRepository, totpRepo *mocks.MockTOTPCredentialRepository, recoveryRepo *mocks.MockRecoveryCodeRepository) {
+				usedAt := time.Now().UTC()
+				challenge := activeChallenge()
+				challenge.UsedAt = &usedAt
+				challengeRepo.EXPECT().GetByTokenHashForUpdate(mock.Anything, mock.AnythingOfType("[]string")).Return(challenge, nil)
+			},
+			expectedErr: errors.ErrInvalidTwoFactorChallenge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPool, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mockPool.Close()
+
+			mockPool.ExpectBegin()
+			if tt.commit {
+				mockPool.ExpectCommit()
+			} else {
+				mockPool.ExpectRollback()
+			}
+
+			mockChallengeRepo := mocks.NewMockTwoFactorChallengeRepository(t)
+			mockTOTPRepo := mocks.NewMockTOTPCredentialRepository(t)
+			mockRecoveryRepo := mocks.NewMockRecoveryCodeRepository(t)
+			mockChallengeRepo.EXPECT().WithTx(mock.Anything).Return(mockChallengeRepo)
+			mockTOTPRepo.EXPECT().WithTx(mock.Anything).Return(mockTOTPRepo).Maybe()
+			mockRecoveryRepo.EXPECT().WithTx(mock.Anything).Return(mockRecoveryRepo).Maybe()
+			tt.setupMock(mockChallengeRepo, mockTOTPRepo, mockRecoveryRepo)
+
+			service := services.NewTwoFactorService(newTwoFactorTestConfig(), db.NewTxManager(mockPool), mocks.NewMockUserRepository(t), mockTOTPRepo, mockRecoveryRepo, mockChallengeRepo, newTestHasher(), newTestTokenHasher())
+			verifiedUserID, err := service.VerifyChallenge(ctx, "challenge-token", tt.code(t))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Equal(t, uuid.Nil, verifiedUserID)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID, verifiedUserID)
+			}
+			require.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
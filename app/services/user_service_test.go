@@ -2,18 +2,29 @@ package services_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
 	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	ifaces "go-reasonable-api/app/interfaces/services"
 	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
 	mocksSupport "go-reasonable-api/app/mocks/support"
 	"go-reasonable-api/app/services"
+	"go-reasonable-api/app/tasks"
 	"go-reasonable-api/db/sqlcgen"
 	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/db"
+	supporterrors "go-reasonable-api/support/errors"
+	"go-reasonable-api/support/linksign"
+	"go-reasonable-api/support/passwordhash"
+	"go-reasonable-api/support/tokencache"
+	"go-reasonable-api/support/tokenhash"
 
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +47,32 @@ func newTestConfig() *config.Config {
 	}
 }
 
+// newTestHasher returns a bcrypt hasher at the cost of the hashes tests
+// store for users, so logins never trigger a rehash.
+func newTestHasher() *passwordhash.Hasher {
+	return passwordhash.NewHasher(&config.Config{
+		Auth: config.AuthConfig{PasswordHashAlgorithm: passwordhash.Bcrypt, BcryptCost: bcrypt.MinCost},
+	})
+}
+
+// newTestTokenHasher returns a token keyring with a fixed secret.
+func newTestTokenHasher() *tokenhash.Keyring {
+	return tokenhash.NewKeyring(&config.Config{Auth: config.AuthConfig{Secret: "test-secret"}})
+}
+
+// newTestTokenCache returns a disabled token cache, so every lookup reaches
+// the repository.
+func newTestTokenCache() *tokencache.RedisCache {
+	return tokencache.NewRedisCache(nil, &config.Config{})
+}
+
+// allowAllPasswords returns a password policy that accepts every password.
+func allowAllPasswords(t *testing.T) *mocksServices.MockPasswordPolicyService {
+	policy := mocksServices.NewMockPasswordPolicyService(t)
+	policy.EXPECT().Check(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return policy
+}
+
 func TestUserService_Create(t *testing.T) {
 	ctx := context.Background()
 
@@ -91,7 +128,7 @@ func TestUserService_Create(t *testing.T) {
 			mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
 			tt.setupMock(mockRepo)
 
-			service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil)
+			service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil, nil, nil, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
 			user, err := service.Create(ctx, tt.userName, tt.email, tt.password)
 
 			if tt.expectedErr != nil {
@@ -151,7 +188,7 @@ func TestUserService_GetByID(t *testing.T) {
 			mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
 			tt.setupMock(mockRepo)
 
-			service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil)
+			service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil, nil, nil, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
 			user, err := service.GetByID(ctx, tt.userID)
 
 			if tt.expectedErr != nil {
@@ -210,7 +247,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 			mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
 			tt.setupMock(mockRepo)
 
-			service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil)
+			service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil, nil, nil, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
 			user, err := service.GetByEmail(ctx, tt.email)
 
 			if tt.expectedErr != nil {
@@ -225,6 +262,204 @@ func TestUserService_GetByEmail(t *testing.T) {
 	}
 }
 
+func TestUserService_Create_RejectsPolicyViolations(t *testing.T) {
+	violation := supporterrors.NewWithDetails("VALIDATION_ERROR", "validation failed", http.StatusBadRequest, map[string]any{
+		"password": []string{"is too easy to guess"},
+	})
+	policy := mocksServices.NewMockPasswordPolicyService(t)
+	policy.EXPECT().Check(mock.Anything, "aaaaaaaa", "Test User", "test@example.com").Return(violation)
+
+	// The repository is never reached
+	service := services.NewUserService(newTestConfig(), nil, mocks.NewMockUserRepository(t), mocks.NewMockAuthTokenRepository(t), nil, nil, nil, nil, policy, newTestHasher(), newTestTokenCache(), nil)
+	user, err := service.Create(context.Background(), "Test User", "test@example.com", "aaaaaaaa")
+
+	assert.ErrorIs(t, err, violation)
+	assert.Nil(t, user)
+}
+
+func TestUserService_Create_EnumerationSafe(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig()
+	cfg.Auth.EnumerationSafe = true
+	cfg.App.BaseURL = "https://app.example.com"
+
+	t.Run("sends a verification email to a new account", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockVerification := mocksServices.NewMockEmailVerificationService(t)
+		userID := uuid.New()
+
+		mockRepo.EXPECT().GetByEmail(mock.Anything, "new@example.com").Return(nil, pgx.ErrNoRows)
+		mockRepo.EXPECT().Create(mock.Anything, "Test User", "new@example.com", mock.AnythingOfType("string")).
+			Return(&sqlcgen.User{ID: userID, Name: "Test User", Email: "new@example.com"}, nil)
+		mockVerification.EXPECT().Send(mock.Anything, userID).Return(nil)
+
+		service := services.NewUserService(cfg, nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, mocksSupport.NewMockTaskClient(t), mockVerification, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		user, err := service.Create(ctx, "Test User", "new@example.com", "password123")
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+	})
+
+	t.Run("emails the owner of a taken address", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+		mockRepo.EXPECT().GetByEmail(mock.Anything, "existing@example.com").
+			Return(&sqlcgen.User{ID: uuid.New(), Name: "Owner", Email: "existing@example.com"}, nil)
+
+		var payload tasks.EmailPayload
+		mockTaskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(_ context.Context, _ string, p any, _ ...asynq.Option) {
+				payload = p.(tasks.EmailPayload)
+			})
+
+		service := services.NewUserService(cfg, nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, mockTaskClient, mocksServices.NewMockEmailVerificationService(t), allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		user, err := service.Create(ctx, "Someone Else", "existing@example.com", "password123")
+
+		assert.ErrorIs(t, err, errors.ErrEmailAlreadyExists)
+		assert.Nil(t, user)
+		assert.Equal(t, "existing@example.com", payload.To)
+		assert.Equal(t, "account-exists", payload.Template)
+		assert.Equal(t, "Owner", payload.Data["Name"])
+		assert.Equal(t, "https://app.example.com/forgot-password", payload.Data["ResetLink"])
+	})
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	name := "New Name"
+
+	t.Run("updates name", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockRepo.EXPECT().UpdateProfile(mock.Anything, userID, repositories.UserProfileUpdate{Name: &name}).
+			Return(&sqlcgen.User{ID: userID, Name: name}, nil)
+
+		service := services.NewUserService(newTestConfig(), nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, mocksSupport.NewMockTaskClient(t), nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		user, err := service.UpdateProfile(ctx, userID, ifaces.ProfileUpdate{Name: &name})
+
+		require.NoError(t, err)
+		assert.Equal(t, name, user.Name)
+	})
+
+	t.Run("returns stored user for empty update", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Name: "Old Name"}, nil)
+
+		service := services.NewUserService(newTestConfig(), nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, mocksSupport.NewMockTaskClient(t), nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		user, err := service.UpdateProfile(ctx, userID, ifaces.ProfileUpdate{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Old Name", user.Name)
+	})
+
+	t.Run("returns error when user not found", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockRepo.EXPECT().UpdateProfile(mock.Anything, userID, repositories.UserProfileUpdate{Name: &name}).
+			Return(nil, pgx.ErrNoRows)
+
+		service := services.NewUserService(newTestConfig(), nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, mocksSupport.NewMockTaskClient(t), nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		_, err := service.UpdateProfile(ctx, userID, ifaces.ProfileUpdate{Name: &name})
+
+		assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	})
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &sqlcgen.User{ID: userID, Name: "Test User", Email: "test@example.com", PasswordHash: string(hash)}
+
+	t.Run("returns error when user not found", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+
+		service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil, nil, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		err := service.ChangePassword(ctx, userID, sessionID, "oldpassword", "newpassword")
+
+		assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	})
+
+	t.Run("returns error when current password is wrong", func(t *testing.T) {
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+
+		service := services.NewUserService(newTestConfig(), nil, mockRepo, mockAuthTokenRepo, nil, nil, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		err := service.ChangePassword(ctx, userID, sessionID, "wrongpassword", "newpassword")
+
+		assert.ErrorIs(t, err, errors.ErrInvalidPassword)
+	})
+
+	t.Run("returns error and sends no email when revocation fails", func(t *testing.T) {
+		mockPool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mockPool.Close()
+
+		mockPool.ExpectBegin()
+		mockPool.ExpectRollback()
+
+		txManager := db.NewTxManager(mockPool)
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+		mockRepo.EXPECT().WithTx(mock.Anything).Return(mockRepo)
+		mockAuthTokenRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthTokenRepo)
+		mockRepo.EXPECT().UpdatePassword(mock.Anything, userID, mock.AnythingOfType("string")).Return(nil)
+		mockAuthTokenRepo.EXPECT().RevokeAllForUserExcept(mock.Anything, userID, sessionID).Return(assert.AnError)
+
+		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, nil, nil, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		err = service.ChangePassword(ctx, userID, sessionID, "oldpassword", "newpassword")
+
+		require.Error(t, err)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("changes password and revokes other sessions", func(t *testing.T) {
+		mockPool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mockPool.Close()
+
+		mockPool.ExpectBegin()
+		mockPool.ExpectCommit()
+
+		txManager := db.NewTxManager(mockPool)
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+		var storedHash string
+		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+		mockRepo.EXPECT().WithTx(mock.Anything).Return(mockRepo)
+		mockAuthTokenRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthTokenRepo)
+		mockRepo.EXPECT().UpdatePassword(mock.Anything, userID, mock.AnythingOfType("string")).
+			Run(func(_ context.Context, _ uuid.UUID, passwordHash string) { storedHash = passwordHash }).
+			Return(nil)
+		mockAuthTokenRepo.EXPECT().RevokeAllForUserExcept(mock.Anything, userID, sessionID).Return(nil)
+		mockTaskClient.EXPECT().EnqueueCtx(mock.Anything, tasks.TypeEmail, mock.MatchedBy(func(p tasks.EmailPayload) bool {
+			return p.To == "test@example.com" && p.Template == "password-changed"
+		}), mock.Anything, mock.Anything, mock.Anything)
+
+		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, nil, nil, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		err = service.ChangePassword(ctx, userID, sessionID, "oldpassword", "newpassword")
+
+		require.NoError(t, err)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(storedHash), []byte("newpassword")))
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+}
+
 func TestUserService_ScheduleDeletion(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
@@ -240,13 +475,15 @@ func TestUserService_ScheduleDeletion(t *testing.T) {
 		txManager := db.NewTxManager(mockPool)
 		mockRepo := mocks.NewMockUserRepository(t)
 		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
 		mockTaskClient := mocksSupport.NewMockTaskClient(t)
 
 		mockRepo.EXPECT().WithTx(mock.Anything).Return(mockRepo)
 		mockAuthTokenRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthTokenRepo)
+		mockAPIKeyRepo.EXPECT().WithTx(mock.Anything).Return(mockAPIKeyRepo)
 		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
 
-		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockTaskClient)
+		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockAPIKeyRepo, nil, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
 		err = service.ScheduleDeletion(ctx, userID)
 
 		assert.ErrorIs(t, err, errors.ErrUserNotFound)
@@ -263,23 +500,54 @@ func TestUserService_ScheduleDeletion(t *testing.T) {
 		txManager := db.NewTxManager(mockPool)
 		mockRepo := mocks.NewMockUserRepository(t)
 		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
 		mockTaskClient := mocksSupport.NewMockTaskClient(t)
 
 		scheduledAt := time.Now().Add(24 * time.Hour)
 		mockRepo.EXPECT().WithTx(mock.Anything).Return(mockRepo)
 		mockAuthTokenRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthTokenRepo)
+		mockAPIKeyRepo.EXPECT().WithTx(mock.Anything).Return(mockAPIKeyRepo)
 		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{
 			ID:                  userID,
 			Email:               "test@example.com",
 			DeletionScheduledAt: &scheduledAt,
 		}, nil)
 
-		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockTaskClient)
+		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockAPIKeyRepo, nil, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
 		err = service.ScheduleDeletion(ctx, userID)
 
 		assert.ErrorIs(t, err, errors.ErrDeletionAlreadyScheduled)
 	})
 
+	t.Run("returns error when user owns an organization", func(t *testing.T) {
+		mockPool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mockPool.Close()
+
+		mockPool.ExpectBegin()
+		mockPool.ExpectRollback()
+
+		txManager := db.NewTxManager(mockPool)
+		mockRepo := mocks.NewMockUserRepository(t)
+		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+		mockOrgRepo := mocks.NewMockOrganizationRepository(t)
+		mockTaskClient := mocksSupport.NewMockTaskClient(t)
+
+		mockRepo.EXPECT().WithTx(mock.Anything).Return(mockRepo)
+		mockAuthTokenRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthTokenRepo)
+		mockAPIKeyRepo.EXPECT().WithTx(mock.Anything).Return(mockAPIKeyRepo)
+		mockOrgRepo.EXPECT().WithTx(mock.Anything).Return(mockOrgRepo)
+		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, Email: "test@example.com"}, nil)
+		mockOrgRepo.EXPECT().OwnsAny(mock.Anything, userID).Return(true, nil)
+
+		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockAPIKeyRepo, mockOrgRepo, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+		err = service.ScheduleDeletion(ctx, userID)
+
+		assert.ErrorIs(t, err, errors.ErrOwnsOrganization)
+		assert.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
 	t.Run("schedules deletion successfully", func(t *testing.T) {
 		mockPool, err := pgxmock.NewPool()
 		require.NoError(t, err)
@@ -291,23 +559,159 @@ func TestUserService_ScheduleDeletion(t *testing.T) {
 		txManager := db.NewTxManager(mockPool)
 		mockRepo := mocks.NewMockUserRepository(t)
 		mockAuthTokenRepo := mocks.NewMockAuthTokenRepository(t)
+		mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+		mockOrgRepo := mocks.NewMockOrganizationRepository(t)
 		mockTaskClient := mocksSupport.NewMockTaskClient(t)
 
 		mockRepo.EXPECT().WithTx(mock.Anything).Return(mockRepo)
 		mockAuthTokenRepo.EXPECT().WithTx(mock.Anything).Return(mockAuthTokenRepo)
+		mockAPIKeyRepo.EXPECT().WithTx(mock.Anything).Return(mockAPIKeyRepo)
+		mockOrgRepo.EXPECT().WithTx(mock.Anything).Return(mockOrgRepo)
 		mockRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{
 			ID:                  userID,
 			Name:                "Test User",
 			Email:               "test@example.com",
 			DeletionScheduledAt: nil,
 		}, nil)
+		mockOrgRepo.EXPECT().OwnsAny(mock.Anything, userID).Return(false, nil)
 		mockRepo.EXPECT().ScheduleDeletion(mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(nil)
 		mockAuthTokenRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+		mockAPIKeyRepo.EXPECT().RevokeAllForUser(mock.Anything, userID).Return(nil)
+		mockTokenCache := mocksSupport.NewMockTokenCache(t)
+		mockTokenCache.EXPECT().Invalidate(mock.Anything, userID).Return(nil)
 		mockTaskClient.EXPECT().EnqueueCtx(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
-		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockTaskClient)
+		service := services.NewUserService(newTestConfig(), txManager, mockRepo, mockAuthTokenRepo, mockAPIKeyRepo, mockOrgRepo, mockTaskClient, nil, allowAllPasswords(t), newTestHasher(), mockTokenCache, linksign.NewSigner(newTestConfig()))
 		err = service.ScheduleDeletion(ctx, userID)
 
 		require.NoError(t, err)
 	})
 }
+
+func TestUserService_CancelDeletion(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	scheduledAt := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "cancels scheduled deletion",
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, DeletionScheduledAt: &scheduledAt}, nil)
+				userRepo.EXPECT().CancelDeletion(mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name: "returns error when deletion not scheduled",
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+			},
+			expectedErr: errors.ErrDeletionNotScheduled,
+		},
+		{
+			name: "returns error when user not found",
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockUserRepository(t)
+			tt.setupMock(mockRepo)
+
+			service := services.NewUserService(newTestConfig(), nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, nil, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), nil)
+			err := service.CancelDeletion(ctx, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserService_CancelDeletionWithLink(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	cfg := newTestConfig()
+	signer := linksign.NewSigner(cfg)
+	scheduledAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	signature := signer.Sign("account-deletion-cancel", userID.String(), scheduledAt)
+	rescheduledAt := scheduledAt.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		scheduledAt time.Time
+		signature   string
+		setupMock   func(*mocks.MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name:        "cancels the deletion the link was sent for",
+			scheduledAt: scheduledAt,
+			signature:   signature,
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, DeletionScheduledAt: &scheduledAt}, nil)
+				userRepo.EXPECT().CancelDeletion(mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name:        "rejects a tampered deletion time",
+			scheduledAt: rescheduledAt,
+			signature:   signature,
+			setupMock:   func(userRepo *mocks.MockUserRepository) {},
+			expectedErr: errors.ErrInvalidDeletionLink,
+		},
+		{
+			name:        "rejects a link for a deletion that was rescheduled",
+			scheduledAt: scheduledAt,
+			signature:   signature,
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID, DeletionScheduledAt: &rescheduledAt}, nil)
+			},
+			expectedErr: errors.ErrInvalidDeletionLink,
+		},
+		{
+			name:        "rejects a link once the deletion was cancelled",
+			scheduledAt: scheduledAt,
+			signature:   signature,
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(&sqlcgen.User{ID: userID}, nil)
+			},
+			expectedErr: errors.ErrInvalidDeletionLink,
+		},
+		{
+			name:        "rejects a link for a deleted user",
+			scheduledAt: scheduledAt,
+			signature:   signature,
+			setupMock: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().GetByID(mock.Anything, userID).Return(nil, pgx.ErrNoRows)
+			},
+			expectedErr: errors.ErrInvalidDeletionLink,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockUserRepository(t)
+			tt.setupMock(mockRepo)
+
+			service := services.NewUserService(cfg, nil, mockRepo, mocks.NewMockAuthTokenRepository(t), nil, nil, nil, nil, allowAllPasswords(t), newTestHasher(), newTestTokenCache(), signer)
+			err := service.CancelDeletionWithLink(ctx, userID, tt.scheduledAt, tt.signature)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
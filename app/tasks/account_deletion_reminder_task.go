@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"context"
+
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/logger"
+	"go-reasonable-api/support/taskqueue"
+
+	"github.com/hibiken/asynq"
+	"github.com/rotisserie/eris"
+	"github.com/rs/zerolog"
+)
+
+const TypeAccountDeletionReminder = "account_deletion:remind"
+
+// AccountDeletionReminderTask periodically emails users whose scheduled
+// account deletion is near a link to cancel it
+type AccountDeletionReminderTask struct {
+	logger                 *zerolog.Logger
+	accountDeletionService services.AccountDeletionService
+}
+
+func NewAccountDeletionReminderTask(logger *zerolog.Logger, accountDeletionService services.AccountDeletionService) *AccountDeletionReminderTask {
+	return &AccountDeletionReminderTask{
+		logger:                 logger,
+		accountDeletionService: accountDeletionService,
+	}
+}
+
+func (t *AccountDeletionReminderTask) Handle(ctx context.Context, task *asynq.Task) error {
+	meta, err := taskqueue.UnwrapPayload(task.Payload(), &struct{}{})
+	if err != nil {
+		// For periodic tasks, payload might be empty
+		meta = taskqueue.TaskMetadata{}
+	}
+
+	ctx = meta.LoggerContext(ctx, t.logger)
+	log := logger.Ctx(ctx)
+
+	log.Info().Str("task", TypeAccountDeletionReminder).Msg("sending account deletion reminders")
+
+	sent, err := t.accountDeletionService.SendReminders(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to send account deletion reminders")
+		return eris.Wrap(err, "failed to send account deletion reminders")
+	}
+
+	log.Info().
+		Int64("reminders_sent", sent).
+		Msg("account deletion reminders sent")
+
+	return nil
+}
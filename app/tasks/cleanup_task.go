@@ -2,8 +2,11 @@ package tasks
 
 import (
 	"context"
+	"time"
 
 	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/logger"
 	"go-reasonable-api/support/taskqueue"
 
@@ -16,26 +19,53 @@ const TypeMaintenance = "maintenance:cleanup"
 
 // CleanupTask handles periodic cleanup of expired tokens and scheduled account deletions
 type CleanupTask struct {
-	logger                *zerolog.Logger
-	authTokenRepo         repositories.AuthTokenRepository
-	passwordResetRepo     repositories.PasswordResetRepository
-	emailVerificationRepo repositories.EmailVerificationRepository
-	userRepo              repositories.UserRepository
+	logger                 *zerolog.Logger
+	config                 *config.Config
+	authTokenRepo          repositories.AuthTokenRepository
+	refreshTokenRepo       repositories.RefreshTokenRepository
+	twoFactorChallengeRepo repositories.TwoFactorChallengeRepository
+	webAuthnChallengeRepo  repositories.WebAuthnChallengeRepository
+	oidcLoginStateRepo     repositories.OIDCLoginStateRepository
+	magicLinkRepo          repositories.MagicLinkRepository
+	passwordResetRepo      repositories.PasswordResetRepository
+	emailVerificationRepo  repositories.EmailVerificationRepository
+	emailChangeRepo        repositories.EmailChangeRepository
+	invitationRepo         repositories.OrganizationInvitationRepository
+	dataExportRepo         repositories.DataExportRepository
+	accountDeletionService services.AccountDeletionService
 }
 
 func NewCleanupTask(
 	logger *zerolog.Logger,
+	cfg *config.Config,
 	authTokenRepo repositories.AuthTokenRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	twoFactorChallengeRepo repositories.TwoFactorChallengeRepository,
+	webAuthnChallengeRepo repositories.WebAuthnChallengeRepository,
+	oidcLoginStateRepo repositories.OIDCLoginStateRepository,
+	magicLinkRepo repositories.MagicLinkRepository,
 	passwordResetRepo repositories.PasswordResetRepository,
 	emailVerificationRepo repositories.EmailVerificationRepository,
-	userRepo repositories.UserRepository,
+	emailChangeRepo repositories.EmailChangeRepository,
+	invitationRepo repositories.OrganizationInvitationRepository,
+	dataExportRepo repositories.DataExportRepository,
+	accountDeletionService services.AccountDeletionService,
 ) *CleanupTask {
 	return &CleanupTask{
-		logger:                logger,
-		authTokenRepo:         authTokenRepo,
-		passwordResetRepo:     passwordResetRepo,
-		emailVerificationRepo: emailVerificationRepo,
-		userRepo:              userRepo,
+		logger:                 logger,
+		config:                 cfg,
+		authTokenRepo:          authTokenRepo,
+		refreshTokenRepo:       refreshTokenRepo,
+		twoFactorChallengeRepo: twoFactorChallengeRepo,
+		webAuthnChallengeRepo:  webAuthnChallengeRepo,
+		oidcLoginStateRepo:     oidcLoginStateRepo,
+		magicLinkRepo:          magicLinkRepo,
+		passwordResetRepo:      passwordResetRepo,
+		emailVerificationRepo:  emailVerificationRepo,
+		emailChangeRepo:        emailChangeRepo,
+		invitationRepo:         invitationRepo,
+		dataExportRepo:         dataExportRepo,
+		accountDeletionService: accountDeletionService,
 	}
 }
 
@@ -58,6 +88,51 @@ func (t *CleanupTask) Handle(ctx context.Context, task *asynq.Task) error {
 		return eris.Wrap(err, "failed to cleanup auth tokens")
 	}
 
+	// Cleanup auth tokens unused for longer than the idle TTL
+	var idleDeleted int64
+	if idleTTL := t.config.Auth.AuthTokenIdleTTL; idleTTL > 0 {
+		idleDeleted, err = t.authTokenRepo.DeleteIdle(ctx, time.Now().UTC().Add(-idleTTL))
+		if err != nil {
+			log.Error().Err(err).Msg("failed to cleanup idle auth tokens")
+			return eris.Wrap(err, "failed to cleanup idle auth tokens")
+		}
+	}
+
+	// Cleanup refresh tokens
+	refreshDeleted, err := t.refreshTokenRepo.DeleteExpiredOrRevoked(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup refresh tokens")
+		return eris.Wrap(err, "failed to cleanup refresh tokens")
+	}
+
+	// Cleanup two-factor login challenges
+	challengesDeleted, err := t.twoFactorChallengeRepo.DeleteExpiredOrUsed(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup two factor challenges")
+		return eris.Wrap(err, "failed to cleanup two factor challenges")
+	}
+
+	// Cleanup passkey ceremony challenges
+	webAuthnDeleted, err := t.webAuthnChallengeRepo.DeleteExpired(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup webauthn challenges")
+		return eris.Wrap(err, "failed to cleanup webauthn challenges")
+	}
+
+	// Cleanup abandoned OIDC logins
+	oidcDeleted, err := t.oidcLoginStateRepo.DeleteExpired(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup oidc login states")
+		return eris.Wrap(err, "failed to cleanup oidc login states")
+	}
+
+	// Cleanup magic link tokens
+	magicLinksDeleted, err := t.magicLinkRepo.DeleteExpiredOrUsed(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup magic link tokens")
+		return eris.Wrap(err, "failed to cleanup magic link tokens")
+	}
+
 	// Cleanup password reset tokens
 	passwordDeleted, err := t.passwordResetRepo.DeleteExpiredOrUsed(ctx)
 	if err != nil {
@@ -72,8 +147,29 @@ func (t *CleanupTask) Handle(ctx context.Context, task *asynq.Task) error {
 		return eris.Wrap(err, "failed to cleanup email verification tokens")
 	}
 
-	// Delete users with scheduled deletion date in the past
-	usersDeleted, err := t.userRepo.DeleteScheduledUsers(ctx)
+	// Cleanup email changes whose revert window has passed
+	emailChangesDeleted, err := t.emailChangeRepo.DeleteExpired(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup email changes")
+		return eris.Wrap(err, "failed to cleanup email changes")
+	}
+
+	// Cleanup organization invitations
+	invitationsDeleted, err := t.invitationRepo.DeleteExpiredOrUsed(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup organization invitations")
+		return eris.Wrap(err, "failed to cleanup organization invitations")
+	}
+
+	// Cleanup data exports whose download link has expired
+	dataExportsDeleted, err := t.dataExportRepo.DeleteExpired(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cleanup data exports")
+		return eris.Wrap(err, "failed to cleanup data exports")
+	}
+
+	// Delete users with scheduled deletion date in the past and tell them
+	usersDeleted, err := t.accountDeletionService.DeleteScheduled(ctx)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to delete scheduled users")
 		return eris.Wrap(err, "failed to delete scheduled users")
@@ -81,8 +177,17 @@ func (t *CleanupTask) Handle(ctx context.Context, task *asynq.Task) error {
 
 	log.Info().
 		Int64("auth_tokens_deleted", authDeleted).
+		Int64("idle_auth_tokens_deleted", idleDeleted).
+		Int64("refresh_tokens_deleted", refreshDeleted).
+		Int64("two_factor_challenges_deleted", challengesDeleted).
+		Int64("webauthn_challenges_deleted", webAuthnDeleted).
+		Int64("oidc_login_states_deleted", oidcDeleted).
+		Int64("magic_links_deleted", magicLinksDeleted).
 		Int64("password_resets_deleted", passwordDeleted).
 		Int64("email_verifications_deleted", emailDeleted).
+		Int64("email_changes_deleted", emailChangesDeleted).
+		Int64("organization_invitations_deleted", invitationsDeleted).
+		Int64("data_exports_deleted", dataExportsDeleted).
 		Int64("users_deleted", usersDeleted).
 		Msg("cleanup completed")
 
@@ -3,9 +3,12 @@ package tasks_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	mocks "go-reasonable-api/app/mocks/repositories"
+	mocksServices "go-reasonable-api/app/mocks/services"
 	"go-reasonable-api/app/tasks"
+	"go-reasonable-api/support/config"
 
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5"
@@ -19,65 +22,261 @@ func newTestLogger() *zerolog.Logger {
 	return &logger
 }
 
+type cleanupMocks struct {
+	authRepo      *mocks.MockAuthTokenRepository
+	refreshRepo   *mocks.MockRefreshTokenRepository
+	challengeRepo *mocks.MockTwoFactorChallengeRepository
+	webAuthnRepo  *mocks.MockWebAuthnChallengeRepository
+	oidcRepo      *mocks.MockOIDCLoginStateRepository
+	magicRepo     *mocks.MockMagicLinkRepository
+	pwRepo        *mocks.MockPasswordResetRepository
+	emailRepo     *mocks.MockEmailVerificationRepository
+	changeRepo    *mocks.MockEmailChangeRepository
+	inviteRepo    *mocks.MockOrganizationInvitationRepository
+	exportRepo    *mocks.MockDataExportRepository
+	deletionSvc   *mocksServices.MockAccountDeletionService
+}
+
+func newCleanupMocks(t *testing.T) *cleanupMocks {
+	return &cleanupMocks{
+		authRepo:      mocks.NewMockAuthTokenRepository(t),
+		refreshRepo:   mocks.NewMockRefreshTokenRepository(t),
+		challengeRepo: mocks.NewMockTwoFactorChallengeRepository(t),
+		webAuthnRepo:  mocks.NewMockWebAuthnChallengeRepository(t),
+		oidcRepo:      mocks.NewMockOIDCLoginStateRepository(t),
+		magicRepo:     mocks.NewMockMagicLinkRepository(t),
+		pwRepo:        mocks.NewMockPasswordResetRepository(t),
+		emailRepo:     mocks.NewMockEmailVerificationRepository(t),
+		changeRepo:    mocks.NewMockEmailChangeRepository(t),
+		inviteRepo:    mocks.NewMockOrganizationInvitationRepository(t),
+		exportRepo:    mocks.NewMockDataExportRepository(t),
+		deletionSvc:   mocksServices.NewMockAccountDeletionService(t),
+	}
+}
+
 func TestCleanupTask_Handle(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
 		name        string
-		setupMock   func(*mocks.MockAuthTokenRepository, *mocks.MockPasswordResetRepository, *mocks.MockEmailVerificationRepository, *mocks.MockUserRepository)
+		idleTTL     time.Duration
+		setupMock   func(*cleanupMocks)
 		expectedErr bool
 	}{
 		{
 			name: "cleans up all tokens and users successfully",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository, pwRepo *mocks.MockPasswordResetRepository, emailRepo *mocks.MockEmailVerificationRepository, userRepo *mocks.MockUserRepository) {
-				authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
-				pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
-				emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
-				userRepo.EXPECT().DeleteScheduledUsers(mock.Anything).Return(int64(1), nil)
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.inviteRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(1), nil)
+				m.exportRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.deletionSvc.EXPECT().DeleteScheduled(mock.Anything).Return(int64(1), nil)
 			},
 			expectedErr: false,
 		},
 		{
 			name: "returns error when auth token cleanup fails",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository, pwRepo *mocks.MockPasswordResetRepository, emailRepo *mocks.MockEmailVerificationRepository, userRepo *mocks.MockUserRepository) {
-				authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name:    "purges idle auth tokens when idle TTL is configured",
+			idleTTL: 24 * time.Hour,
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.authRepo.EXPECT().DeleteIdle(mock.Anything, mock.MatchedBy(func(idleBefore time.Time) bool {
+					return time.Since(idleBefore) >= 24*time.Hour
+				})).Return(int64(4), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.inviteRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(1), nil)
+				m.exportRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.deletionSvc.EXPECT().DeleteScheduled(mock.Anything).Return(int64(1), nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name:    "returns error when idle auth token cleanup fails",
+			idleTTL: 24 * time.Hour,
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.authRepo.EXPECT().DeleteIdle(mock.Anything, mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when refresh token cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when two factor challenge cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when webauthn challenge cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when oidc login state cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when magic link cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
 			},
 			expectedErr: true,
 		},
 		{
 			name: "returns error when password reset cleanup fails",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository, pwRepo *mocks.MockPasswordResetRepository, emailRepo *mocks.MockEmailVerificationRepository, userRepo *mocks.MockUserRepository) {
-				authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
-				pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
 			},
 			expectedErr: true,
 		},
 		{
 			name: "returns error when email verification cleanup fails",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository, pwRepo *mocks.MockPasswordResetRepository, emailRepo *mocks.MockEmailVerificationRepository, userRepo *mocks.MockUserRepository) {
-				authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
-				pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
-				emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when email change cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when organization invitation cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.inviteRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "returns error when data export cleanup fails",
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.inviteRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(1), nil)
+				m.exportRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
 			},
 			expectedErr: true,
 		},
 		{
 			name: "returns error when user deletion fails",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository, pwRepo *mocks.MockPasswordResetRepository, emailRepo *mocks.MockEmailVerificationRepository, userRepo *mocks.MockUserRepository) {
-				authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
-				pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
-				emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
-				userRepo.EXPECT().DeleteScheduledUsers(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(5), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(6), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(6), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(6), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(4), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(3), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(2), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.inviteRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(1), nil)
+				m.exportRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(1), nil)
+				m.deletionSvc.EXPECT().DeleteScheduled(mock.Anything).Return(int64(0), pgx.ErrTxClosed)
 			},
 			expectedErr: true,
 		},
 		{
 			name: "handles zero deleted tokens and users",
-			setupMock: func(authRepo *mocks.MockAuthTokenRepository, pwRepo *mocks.MockPasswordResetRepository, emailRepo *mocks.MockEmailVerificationRepository, userRepo *mocks.MockUserRepository) {
-				authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), nil)
-				pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
-				emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
-				userRepo.EXPECT().DeleteScheduledUsers(mock.Anything).Return(int64(0), nil)
+			setupMock: func(m *cleanupMocks) {
+				m.authRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), nil)
+				m.refreshRepo.EXPECT().DeleteExpiredOrRevoked(mock.Anything).Return(int64(0), nil)
+				m.challengeRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.webAuthnRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), nil)
+				m.oidcRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), nil)
+				m.magicRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.pwRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.emailRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.changeRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), nil)
+				m.inviteRepo.EXPECT().DeleteExpiredOrUsed(mock.Anything).Return(int64(0), nil)
+				m.exportRepo.EXPECT().DeleteExpired(mock.Anything).Return(int64(0), nil)
+				m.deletionSvc.EXPECT().DeleteScheduled(mock.Anything).Return(int64(0), nil)
 			},
 			expectedErr: false,
 		},
@@ -85,13 +284,11 @@ func TestCleanupTask_Handle(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockAuthRepo := mocks.NewMockAuthTokenRepository(t)
-			mockPwRepo := mocks.NewMockPasswordResetRepository(t)
-			mockEmailRepo := mocks.NewMockEmailVerificationRepository(t)
-			mockUserRepo := mocks.NewMockUserRepository(t)
-			tt.setupMock(mockAuthRepo, mockPwRepo, mockEmailRepo, mockUserRepo)
+			m := newCleanupMocks(t)
+			tt.setupMock(m)
 
-			task := tasks.NewCleanupTask(newTestLogger(), mockAuthRepo, mockPwRepo, mockEmailRepo, mockUserRepo)
+			cfg := &config.Config{Auth: config.AuthConfig{AuthTokenIdleTTL: tt.idleTTL}}
+			task := tasks.NewCleanupTask(newTestLogger(), cfg, m.authRepo, m.refreshRepo, m.challengeRepo, m.webAuthnRepo, m.oidcRepo, m.magicRepo, m.pwRepo, m.emailRepo, m.changeRepo, m.inviteRepo, m.exportRepo, m.deletionSvc)
 
 			// Create an empty asynq task (periodic tasks have empty payload)
 			asynqTask := asynq.NewTask(tasks.TypeMaintenance, nil)
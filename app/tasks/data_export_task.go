@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/logger"
+	"go-reasonable-api/support/taskqueue"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/rotisserie/eris"
+	"github.com/rs/zerolog"
+)
+
+const TypeDataExport = "data_export:build"
+
+// DataExportTaskOptions returns the asynq options for data export tasks.
+func DataExportTaskOptions() []asynq.Option {
+	return []asynq.Option{
+		asynq.MaxRetry(3),
+		asynq.Timeout(10 * time.Minute),
+	}
+}
+
+// DataExportPayload identifies the requested export to build
+type DataExportPayload struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// DataExportTask builds requested data exports and emails their download links
+type DataExportTask struct {
+	logger            *zerolog.Logger
+	dataExportService services.DataExportService
+}
+
+func NewDataExportTask(logger *zerolog.Logger, dataExportService services.DataExportService) *DataExportTask {
+	return &DataExportTask{
+		logger:            logger,
+		dataExportService: dataExportService,
+	}
+}
+
+func (t *DataExportTask) Handle(ctx context.Context, task *asynq.Task) error {
+	var payload DataExportPayload
+	meta, err := taskqueue.UnwrapPayload(task.Payload(), &payload)
+	if err != nil {
+		return eris.Wrap(err, "failed to unmarshal payload")
+	}
+
+	ctx = meta.LoggerContext(ctx, t.logger)
+	log := logger.Ctx(ctx)
+
+	log.Info().
+		Str("task", TypeDataExport).
+		Str("export_id", payload.ExportID.String()).
+		Msg("building data export")
+
+	if err := t.dataExportService.Build(ctx, payload.ExportID); err != nil {
+		log.Error().Err(err).
+			Str("export_id", payload.ExportID.String()).
+			Msg("failed to build data export")
+		return eris.Wrap(err, "failed to build data export")
+	}
+
+	log.Info().
+		Str("export_id", payload.ExportID.String()).
+		Msg("data export built successfully")
+
+	return nil
+}
@@ -10,7 +10,12 @@
 //
 //   - TypeEmail ("email:send"): Generic email sending with template rendering
 //   - TypeMaintenance ("maintenance:cleanup"): Periodic cleanup of expired
-//     tokens and finalisation of scheduled account deletions
+//     tokens and finalisation of scheduled account deletions, which emails
+//     each deleted user
+//   - TypeDataExport ("data_export:build"): Builds a user's data export and
+//     emails the download link
+//   - TypeAccountDeletionReminder ("account_deletion:remind"): Periodic
+//     reminders of account deletions that are about to happen
 //
 // # Lifecycle
 //
@@ -8,14 +8,18 @@ import (
 // Registry centralizes all task handler and scheduled task registration.
 // Add new tasks here to register them with the worker.
 type Registry struct {
-	emailTask   *EmailTask
-	cleanupTask *CleanupTask
+	emailTask      *EmailTask
+	cleanupTask    *CleanupTask
+	dataExportTask *DataExportTask
+	reminderTask   *AccountDeletionReminderTask
 }
 
-func NewRegistry(emailTask *EmailTask, cleanupTask *CleanupTask) *Registry {
+func NewRegistry(emailTask *EmailTask, cleanupTask *CleanupTask, dataExportTask *DataExportTask, reminderTask *AccountDeletionReminderTask) *Registry {
 	return &Registry{
-		emailTask:   emailTask,
-		cleanupTask: cleanupTask,
+		emailTask:      emailTask,
+		cleanupTask:    cleanupTask,
+		dataExportTask: dataExportTask,
+		reminderTask:   reminderTask,
 	}
 }
 
@@ -28,6 +32,8 @@ func NewRegistry(emailTask *EmailTask, cleanupTask *CleanupTask) *Registry {
 func (r *Registry) RegisterHandlers(mux *asynq.ServeMux) {
 	mux.HandleFunc(TypeEmail, r.emailTask.Handle)
 	mux.HandleFunc(TypeMaintenance, r.cleanupTask.Handle)
+	mux.HandleFunc(TypeDataExport, r.dataExportTask.Handle)
+	mux.HandleFunc(TypeAccountDeletionReminder, r.reminderTask.Handle)
 }
 
 // RegisterScheduledTasks registers all periodic tasks with the scheduler.
@@ -38,5 +44,10 @@ func (r *Registry) RegisterScheduledTasks(scheduler *asynq.Scheduler) error {
 		return eris.Wrap(err, "failed to register cleanup task")
 	}
 
+	// Remind users of upcoming account deletions every hour
+	if _, err := scheduler.Register("@every 1h", asynq.NewTask(TypeAccountDeletionReminder, nil)); err != nil {
+		return eris.Wrap(err, "failed to register account deletion reminder task")
+	}
+
 	return nil
 }
@@ -0,0 +1,108 @@
+package users
+
+import (
+	"context"
+
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/logger"
+	"go-reasonable-api/support/wire"
+
+	"github.com/rotisserie/eris"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "User administration commands",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return eris.Wrap(err, "failed to load config")
+			}
+			logger.Init(cfg)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newUnlockCommand())
+	cmd.AddCommand(newGrantRoleCommand())
+	cmd.AddCommand(newRevokeRoleCommand())
+
+	return cmd
+}
+
+func newUnlockCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock [email]",
+		Short: "Clear the sign-in lockout for an account",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUnlock,
+	}
+}
+
+func runUnlock(cmd *cobra.Command, args []string) error {
+	lockoutService, cleanup, err := wire.InitializeLoginLockoutService()
+	if err != nil {
+		return eris.Wrap(err, "failed to initialize lockout service")
+	}
+	defer cleanup()
+
+	email := args[0]
+	if err := lockoutService.Unlock(context.Background(), email); err != nil {
+		return eris.Wrap(err, "failed to unlock account")
+	}
+
+	logger.Info().Str("email", email).Msg("account unlocked")
+	return nil
+}
+
+func newGrantRoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-role [email] [role]",
+		Short: "Grant a role to a user, e.g. to bootstrap the first admin",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runGrantRole,
+	}
+}
+
+func runGrantRole(cmd *cobra.Command, args []string) error {
+	permissionService, cleanup, err := wire.InitializePermissionService()
+	if err != nil {
+		return eris.Wrap(err, "failed to initialize permission service")
+	}
+	defer cleanup()
+
+	email, role := args[0], args[1]
+	if err := permissionService.GrantRole(context.Background(), email, role); err != nil {
+		return eris.Wrap(err, "failed to grant role")
+	}
+
+	logger.Info().Str("email", email).Str("role", role).Msg("role granted")
+	return nil
+}
+
+func newRevokeRoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-role [email] [role]",
+		Short: "Revoke a role from a user",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runRevokeRole,
+	}
+}
+
+func runRevokeRole(cmd *cobra.Command, args []string) error {
+	permissionService, cleanup, err := wire.InitializePermissionService()
+	if err != nil {
+		return eris.Wrap(err, "failed to initialize permission service")
+	}
+	defer cleanup()
+
+	email, role := args[0], args[1]
+	if err := permissionService.RevokeRole(context.Background(), email, role); err != nil {
+		return eris.Wrap(err, "failed to revoke role")
+	}
+
+	logger.Info().Str("email", email).Str("role", role).Msg("role revoked")
+	return nil
+}
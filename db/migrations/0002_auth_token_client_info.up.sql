@@ -0,0 +1,9 @@
+-- =============================================================================
+-- AUTH TOKENS: CLIENT INFO
+-- =============================================================================
+-- Record which client created each token and when it was last used so users
+-- can review and revoke their active sessions.
+ALTER TABLE auth_tokens
+    ADD COLUMN user_agent TEXT NOT NULL DEFAULT '',
+    ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    ADD COLUMN last_used_at TIMESTAMPTZ;
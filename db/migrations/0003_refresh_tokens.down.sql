@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS refresh_tokens;
+
+DROP INDEX IF EXISTS idx_auth_tokens_family_id;
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS family_id;
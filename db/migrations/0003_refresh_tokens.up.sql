@@ -0,0 +1,44 @@
+-- =============================================================================
+-- AUTH TOKENS: SESSION FAMILY
+-- =============================================================================
+-- Every auth token belongs to a family. A family is one login: refreshing
+-- issues new tokens in the same family, and revoking any token in it revokes
+-- the whole family. Existing tokens become single-token families.
+ALTER TABLE auth_tokens ADD COLUMN family_id UUID;
+UPDATE auth_tokens SET family_id = id;
+ALTER TABLE auth_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX idx_auth_tokens_family_id ON auth_tokens(family_id);
+
+-- =============================================================================
+-- REFRESH TOKENS TABLE
+-- =============================================================================
+-- Refresh tokens are single use. Refreshing marks the presented token as
+-- rotated and issues a new one in the same family; presenting a rotated
+-- token again revokes the family.
+CREATE TABLE refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_refresh_tokens_token_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+
+-- Index for family revocation: WHERE family_id = ? AND revoked_at IS NULL
+CREATE INDEX idx_refresh_tokens_family_active
+    ON refresh_tokens(family_id)
+    WHERE revoked_at IS NULL;
+
+-- Partial index for cleanup of expired tokens
+CREATE INDEX idx_refresh_tokens_expired
+    ON refresh_tokens(expires_at)
+    WHERE revoked_at IS NULL;
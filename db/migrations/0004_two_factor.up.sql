@@ -0,0 +1,56 @@
+-- =============================================================================
+-- TOTP CREDENTIALS TABLE
+-- =============================================================================
+-- One TOTP secret per user. The credential is pending until confirmed_at is
+-- set by verifying a first code. last_used_step stores the time step of the
+-- last accepted code so a code cannot be replayed.
+CREATE TABLE totp_credentials (
+    user_id UUID PRIMARY KEY,
+    secret VARCHAR(255) NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_totp_credentials_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- =============================================================================
+-- RECOVERY CODES TABLE
+-- =============================================================================
+-- Single-use codes issued when TOTP is enabled. Stored as SHA-256 hashes.
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_recovery_codes_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_recovery_codes_user_code_hash UNIQUE (user_id, code_hash)
+);
+
+-- =============================================================================
+-- TWO FACTOR CHALLENGES TABLE
+-- =============================================================================
+-- Short-lived tokens returned by a password login when 2FA is enabled.
+-- attempts counts failed codes so a challenge cannot be brute-forced.
+CREATE TABLE two_factor_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_two_factor_challenges_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_two_factor_challenges_token_hash UNIQUE (token_hash)
+);
+
+-- Partial index for cleanup of expired challenges
+CREATE INDEX idx_two_factor_challenges_expired
+    ON two_factor_challenges(expires_at)
+    WHERE used_at IS NULL;
@@ -0,0 +1,52 @@
+-- =============================================================================
+-- WEBAUTHN CREDENTIALS TABLE
+-- =============================================================================
+-- Passkeys registered by users. credential_id is the authenticator-assigned
+-- id and public_key the COSE_Key it returned at registration. sign_count is
+-- the last signature counter seen, used to detect cloned authenticators.
+-- backup_eligible records whether the passkey can be synced between devices,
+-- which must not change between ceremonies.
+CREATE TABLE webauthn_credentials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    credential_id BYTEA NOT NULL,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    aaguid BYTEA NOT NULL,
+    backup_eligible BOOLEAN NOT NULL DEFAULT false,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    name VARCHAR(255) NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_webauthn_credentials_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_webauthn_credentials_credential_id UNIQUE (credential_id)
+);
+
+CREATE INDEX idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);
+
+-- =============================================================================
+-- WEBAUTHN CHALLENGES TABLE
+-- =============================================================================
+-- Challenges issued for registration and login ceremonies, stored as SHA-256
+-- hashes and deleted when consumed. Registration challenges belong to the
+-- user adding a passkey; login challenges have no user, since the passkey
+-- itself identifies the account.
+CREATE TABLE webauthn_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID,
+    ceremony VARCHAR(32) NOT NULL,
+    challenge_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_webauthn_challenges_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_webauthn_challenges_challenge_hash UNIQUE (challenge_hash),
+    CONSTRAINT chk_webauthn_challenges_ceremony
+        CHECK (ceremony IN ('registration', 'login'))
+);
+
+-- Index for cleanup of expired challenges
+CREATE INDEX idx_webauthn_challenges_expires_at ON webauthn_challenges(expires_at);
@@ -0,0 +1,42 @@
+-- =============================================================================
+-- USER IDENTITIES TABLE
+-- =============================================================================
+-- External OpenID Connect identities linked to users. provider is the name
+-- of a configured provider and subject its stable user identifier ("sub");
+-- email is the address the provider last reported, kept for display only.
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- =============================================================================
+-- OIDC LOGIN STATES TABLE
+-- =============================================================================
+-- Pending authorization requests. The state parameter is stored as a SHA-256
+-- hash and the row deleted when the provider redirects back; the PKCE code
+-- verifier and nonce are only useful together with the authorization code.
+CREATE TABLE oidc_login_states (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider VARCHAR(64) NOT NULL,
+    state_hash VARCHAR(255) NOT NULL,
+    code_verifier VARCHAR(255) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_oidc_login_states_state_hash UNIQUE (state_hash)
+);
+
+-- Index for cleanup of expired states
+CREATE INDEX idx_oidc_login_states_expires_at ON oidc_login_states(expires_at);
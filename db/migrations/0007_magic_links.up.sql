@@ -0,0 +1,25 @@
+-- =============================================================================
+-- MAGIC LINKS TABLE
+-- =============================================================================
+-- One-time passwordless login links. Same lifecycle as password resets: the
+-- token is stored as a SHA-256 hash and used_at is set when it is redeemed.
+CREATE TABLE magic_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_magic_links_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_magic_links_token_hash UNIQUE (token_hash)
+);
+
+-- Index for user's magic link requests
+CREATE INDEX idx_magic_links_user_id ON magic_links(user_id);
+
+-- Partial index for cleanup of expired/unused tokens
+CREATE INDEX idx_magic_links_expired
+    ON magic_links(expires_at)
+    WHERE used_at IS NULL;
@@ -0,0 +1,32 @@
+-- =============================================================================
+-- EMAIL CHANGES TABLE
+-- =============================================================================
+-- Pending and completed email address changes. The confirmation token is
+-- mailed to the new address, the revert token to the old one. Both are
+-- stored as SHA-256 hashes. A change is applied when confirmed_at is set and
+-- undone when reverted_at is set; the revert link outlives the confirmation
+-- link so the previous owner can recover a hijacked account.
+CREATE TABLE email_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    old_email VARCHAR(255) NOT NULL,
+    new_email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    revert_token_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revert_expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    reverted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_email_changes_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_email_changes_token_hash UNIQUE (token_hash),
+    CONSTRAINT uq_email_changes_revert_token_hash UNIQUE (revert_token_hash)
+);
+
+-- Index for user's email change requests
+CREATE INDEX idx_email_changes_user_id ON email_changes(user_id);
+
+-- Index for cleanup once the revert window has passed
+CREATE INDEX idx_email_changes_revert_expires_at ON email_changes(revert_expires_at);
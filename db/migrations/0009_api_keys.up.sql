@@ -0,0 +1,25 @@
+-- =============================================================================
+-- API KEYS TABLE
+-- =============================================================================
+-- Personal API keys for machine clients. Only the SHA-256 hash of a key is
+-- stored; key_prefix keeps its first characters so users can tell keys
+-- apart. scopes limit which endpoints a key may call. A key without
+-- expires_at is valid until revoked.
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(32) NOT NULL,
+    token_hash VARCHAR(255) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_api_keys_token_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
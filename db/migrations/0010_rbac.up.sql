@@ -0,0 +1,65 @@
+-- =============================================================================
+-- ROLES AND PERMISSIONS
+-- =============================================================================
+-- Role-based access control. Permissions are named capabilities checked by
+-- route middleware; roles group permissions and are assigned to users. A
+-- user's effective permissions are the union over all of their roles.
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(64) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_roles_name UNIQUE (name)
+);
+
+CREATE TABLE permissions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(128) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_permissions_name UNIQUE (name)
+);
+
+CREATE TABLE role_permissions (
+    role_id UUID NOT NULL,
+    permission_id UUID NOT NULL,
+
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id)
+        REFERENCES roles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id)
+        REFERENCES permissions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL,
+    role_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id)
+        REFERENCES roles(id) ON DELETE CASCADE
+);
+
+-- Index for finding the users that hold a role
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+-- =============================================================================
+-- SEED DATA
+-- =============================================================================
+-- The admin role holds every permission defined here. Assign it with
+-- `users grant-role <email> admin`.
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full administrative access');
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'View any user account'),
+    ('users:write', 'Modify any user account');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin';
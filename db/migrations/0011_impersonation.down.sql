@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE name = 'users:impersonate';
+
+DROP TABLE IF EXISTS audit_logs;
+
+ALTER TABLE auth_tokens DROP CONSTRAINT IF EXISTS fk_auth_tokens_impersonator;
+ALTER TABLE auth_tokens DROP COLUMN IF EXISTS impersonator_id;
@@ -0,0 +1,44 @@
+-- =============================================================================
+-- AUTH TOKENS: IMPERSONATION
+-- =============================================================================
+-- Sessions minted by an administrator to act as another user record who
+-- started them. Deleting the administrator ends their impersonations.
+ALTER TABLE auth_tokens ADD COLUMN impersonator_id UUID;
+ALTER TABLE auth_tokens ADD CONSTRAINT fk_auth_tokens_impersonator
+    FOREIGN KEY (impersonator_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- =============================================================================
+-- AUDIT LOGS TABLE
+-- =============================================================================
+-- Append-only record of privileged actions. actor_id is who acted and
+-- subject_id whose account was acted on; both survive the users' deletion
+-- as NULL so the trail is kept.
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID,
+    subject_id UUID,
+    action VARCHAR(64) NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_audit_logs_actor FOREIGN KEY (actor_id)
+        REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_audit_logs_subject FOREIGN KEY (subject_id)
+        REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX idx_audit_logs_subject_id ON audit_logs(subject_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- =============================================================================
+-- SEED DATA
+-- =============================================================================
+INSERT INTO permissions (name, description) VALUES
+    ('users:impersonate', 'Sign in as any user');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin' AND p.name = 'users:impersonate';
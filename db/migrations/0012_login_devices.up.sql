@@ -0,0 +1,16 @@
+-- =============================================================================
+-- LOGIN DEVICES TABLE
+-- =============================================================================
+-- Devices each user has signed in from, so a sign-in from a new one can be
+-- reported to them. A device is identified by a SHA-256 fingerprint of the
+-- user agent and IP address it signed in with.
+CREATE TABLE login_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_login_devices_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_login_devices_user_fingerprint UNIQUE (user_id, fingerprint)
+);
@@ -0,0 +1,59 @@
+-- =============================================================================
+-- ORGANIZATIONS TABLE
+-- =============================================================================
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- =============================================================================
+-- ORGANIZATION MEMBERS TABLE
+-- =============================================================================
+-- A user's role in an organization. Every organization has exactly one
+-- owner; ownership moves by demoting the owner before promoting the new one.
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (organization_id, user_id),
+    CONSTRAINT fk_organization_members_organization FOREIGN KEY (organization_id)
+        REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_organization_members_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_organization_members_role CHECK (role IN ('owner', 'admin', 'member'))
+);
+
+CREATE UNIQUE INDEX uq_organization_members_owner ON organization_members(organization_id) WHERE role = 'owner';
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+-- =============================================================================
+-- ORGANIZATION INVITATIONS TABLE
+-- =============================================================================
+-- Emailed invitations to join an organization. Only the token hash is
+-- stored. Invitations can't grant ownership.
+CREATE TABLE organization_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    invited_by UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    declined_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_organization_invitations_organization FOREIGN KEY (organization_id)
+        REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_organization_invitations_invited_by FOREIGN KEY (invited_by)
+        REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT uq_organization_invitations_token_hash UNIQUE (token_hash),
+    CONSTRAINT chk_organization_invitations_role CHECK (role IN ('admin', 'member'))
+);
+
+CREATE INDEX idx_organization_invitations_organization_id ON organization_invitations(organization_id);
+CREATE INDEX idx_organization_invitations_expires_at ON organization_invitations(expires_at);
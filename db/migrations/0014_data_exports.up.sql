@@ -0,0 +1,22 @@
+-- =============================================================================
+-- DATA EXPORTS TABLE
+-- =============================================================================
+-- Archives of everything stored about a user, built by the worker on request
+-- and kept until a signed download link expires. archive and completed_at
+-- stay NULL until the archive is built.
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    format VARCHAR(8) NOT NULL,
+    archive BYTEA,
+    expires_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_data_exports_user FOREIGN KEY (user_id)
+        REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_data_exports_format CHECK (format IN ('json', 'zip'))
+);
+
+CREATE INDEX idx_data_exports_user_id_created_at ON data_exports(user_id, created_at);
+CREATE INDEX idx_data_exports_expires_at ON data_exports(expires_at);
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_reminder_sent_at;
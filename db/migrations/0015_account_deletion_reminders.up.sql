@@ -0,0 +1,6 @@
+-- =============================================================================
+-- USERS: ACCOUNT DELETION REMINDERS
+-- =============================================================================
+-- Records when a user was reminded of their scheduled deletion, so the
+-- reminder goes out once. Scheduling or cancelling a deletion clears it.
+ALTER TABLE users ADD COLUMN deletion_reminder_sent_at TIMESTAMPTZ;
@@ -0,0 +1,30 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, user_id, name, key_prefix, token_hash, scopes, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAPIKeyByHash :one
+SELECT * FROM api_keys WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
+
+-- name: ListActiveAPIKeysForUser :many
+SELECT * FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC, id;
+
+-- name: RenameAPIKeyForUser :one
+UPDATE api_keys SET name = $1
+WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = $1 WHERE id = $2;
+
+-- name: UpdateAPIKeyHash :exec
+UPDATE api_keys SET token_hash = $1 WHERE id = $2;
+
+-- name: RevokeAPIKeyForUser :execrows
+UPDATE api_keys SET revoked_at = $1
+WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL;
+
+-- name: RevokeAllAPIKeysForUser :exec
+UPDATE api_keys SET revoked_at = $1
+WHERE user_id = $2 AND revoked_at IS NULL;
@@ -0,0 +1,8 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (id, actor_id, subject_id, action, metadata, ip_address, user_agent, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListAuditLogsForUser :many
+SELECT * FROM audit_logs
+WHERE actor_id = sqlc.arg(user_id)::uuid OR subject_id = sqlc.arg(user_id)::uuid
+ORDER BY created_at, id;
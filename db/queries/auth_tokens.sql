@@ -1,18 +1,91 @@
 -- name: CreateAuthToken :exec
-INSERT INTO auth_tokens (id, user_id, token_hash, expires_at, created_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO auth_tokens (id, user_id, family_id, token_hash, expires_at, user_agent, ip_address, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: CreateImpersonationAuthToken :exec
+INSERT INTO auth_tokens (id, user_id, family_id, token_hash, expires_at, user_agent, ip_address, created_at, impersonator_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 
 -- name: GetAuthTokenByHash :one
-SELECT * FROM auth_tokens WHERE token_hash = $1;
+SELECT * FROM auth_tokens WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
+
+-- name: ListActiveAuthTokensForUser :many
+SELECT * FROM auth_tokens
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+ORDER BY COALESCE(last_used_at, created_at) DESC, id
+LIMIT $3 OFFSET $4;
+
+-- name: ListAuthTokensForUser :many
+SELECT * FROM auth_tokens WHERE user_id = $1 ORDER BY created_at, id;
+
+-- name: CountActiveAuthTokensForUser :one
+SELECT COUNT(*) FROM auth_tokens
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2;
+
+-- name: TouchAuthToken :exec
+UPDATE auth_tokens SET last_used_at = $1 WHERE id = $2;
+
+-- name: UpdateAuthTokenHash :exec
+UPDATE auth_tokens SET token_hash = $1 WHERE id = $2;
 
 -- name: RevokeAuthToken :exec
-UPDATE auth_tokens SET revoked_at = $1 WHERE id = $2;
+WITH target AS (
+    SELECT family_id FROM auth_tokens WHERE auth_tokens.id = $2
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.family_id IN (SELECT family_id FROM target) AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.family_id IN (SELECT family_id FROM target) AND auth_tokens.revoked_at IS NULL;
+
+-- name: RevokeAuthTokenForUser :execrows
+WITH target AS (
+    SELECT family_id FROM auth_tokens
+    WHERE auth_tokens.id = $2 AND auth_tokens.user_id = $3 AND auth_tokens.revoked_at IS NULL
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.family_id IN (SELECT family_id FROM target) AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.family_id IN (SELECT family_id FROM target) AND auth_tokens.revoked_at IS NULL;
 
 -- name: RevokeAuthTokenByHash :exec
-UPDATE auth_tokens SET revoked_at = $1 WHERE token_hash = $2;
+WITH target AS (
+    SELECT family_id FROM auth_tokens WHERE auth_tokens.token_hash = ANY(sqlc.arg(token_hashes)::text[])
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = sqlc.arg(revoked_at)
+    WHERE refresh_tokens.family_id IN (SELECT family_id FROM target) AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = sqlc.arg(revoked_at)
+WHERE auth_tokens.family_id IN (SELECT family_id FROM target) AND auth_tokens.revoked_at IS NULL;
+
+-- name: RevokeFamilyAuthTokens :exec
+UPDATE auth_tokens SET revoked_at = $1
+WHERE family_id = $2 AND revoked_at IS NULL;
 
 -- name: RevokeAllAuthTokensForUser :exec
-UPDATE auth_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL;
+WITH revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.user_id = $2 AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.user_id = $2 AND auth_tokens.revoked_at IS NULL;
+
+-- name: RevokeOtherAuthTokensForUser :exec
+WITH kept AS (
+    SELECT family_id FROM auth_tokens WHERE auth_tokens.id = $3
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.user_id = $2 AND refresh_tokens.revoked_at IS NULL
+      AND refresh_tokens.family_id NOT IN (SELECT family_id FROM kept)
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.user_id = $2 AND auth_tokens.revoked_at IS NULL
+  AND auth_tokens.family_id NOT IN (SELECT family_id FROM kept);
 
 -- name: DeleteExpiredOrRevokedAuthTokens :execrows
 DELETE FROM auth_tokens WHERE expires_at < $1 OR revoked_at IS NOT NULL;
+
+-- name: DeleteIdleAuthTokens :execrows
+DELETE FROM auth_tokens
+WHERE created_at < @idle_before AND (last_used_at IS NULL OR last_used_at < @idle_before);
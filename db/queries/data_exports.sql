@@ -0,0 +1,15 @@
+-- name: CreateDataExport :exec
+INSERT INTO data_exports (id, user_id, format, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetDataExportByID :one
+SELECT * FROM data_exports WHERE id = $1;
+
+-- name: CompleteDataExport :execrows
+UPDATE data_exports SET archive = $1, expires_at = $2, completed_at = $3 WHERE id = $4 AND completed_at IS NULL;
+
+-- name: CountDataExportsForUserSince :one
+SELECT COUNT(*) FROM data_exports WHERE user_id = $1 AND created_at > $2;
+
+-- name: DeleteExpiredDataExports :execrows
+DELETE FROM data_exports WHERE expires_at < $1;
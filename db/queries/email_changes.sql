@@ -0,0 +1,24 @@
+-- name: CreateEmailChange :exec
+INSERT INTO email_changes (id, user_id, old_email, new_email, token_hash, revert_token_hash, expires_at, revert_expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetEmailChangeByTokenHash :one
+SELECT * FROM email_changes WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
+
+-- name: GetEmailChangeByRevertTokenHash :one
+SELECT * FROM email_changes WHERE revert_token_hash = ANY(sqlc.arg(revert_token_hashes)::text[]);
+
+-- name: ListEmailChangesForUser :many
+SELECT * FROM email_changes WHERE user_id = $1 ORDER BY created_at, id;
+
+-- name: MarkEmailChangeConfirmed :execrows
+UPDATE email_changes SET confirmed_at = $1 WHERE id = $2 AND confirmed_at IS NULL AND reverted_at IS NULL;
+
+-- name: MarkEmailChangeReverted :execrows
+UPDATE email_changes SET reverted_at = $1 WHERE id = $2 AND reverted_at IS NULL;
+
+-- name: DeletePendingEmailChangesForUser :exec
+DELETE FROM email_changes WHERE user_id = $1 AND confirmed_at IS NULL AND reverted_at IS NULL;
+
+-- name: DeleteExpiredEmailChanges :execrows
+DELETE FROM email_changes WHERE revert_expires_at < $1;
@@ -3,7 +3,7 @@ INSERT INTO email_verifications (id, user_id, token_hash, expires_at, created_at
 VALUES ($1, $2, $3, $4, $5);
 
 -- name: GetEmailVerificationByTokenHash :one
-SELECT * FROM email_verifications WHERE token_hash = $1;
+SELECT * FROM email_verifications WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
 
 -- name: MarkEmailVerificationUsed :exec
 UPDATE email_verifications SET used_at = $1 WHERE id = $2;
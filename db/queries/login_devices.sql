@@ -0,0 +1,14 @@
+-- name: CreateLoginDevice :execrows
+INSERT INTO login_devices (id, user_id, fingerprint, created_at)
+SELECT sqlc.arg(id)::uuid, sqlc.arg(user_id)::uuid, sqlc.arg(fingerprint)::text, sqlc.arg(created_at)::timestamptz
+WHERE NOT EXISTS (
+    SELECT 1 FROM login_devices
+    WHERE user_id = sqlc.arg(user_id)::uuid AND fingerprint = ANY(sqlc.arg(fingerprints)::text[])
+)
+ON CONFLICT (user_id, fingerprint) DO NOTHING;
+
+-- name: LoginDeviceExistsForUser :one
+SELECT EXISTS(SELECT 1 FROM login_devices WHERE user_id = $1);
+
+-- name: ListLoginDevicesForUser :many
+SELECT * FROM login_devices WHERE user_id = $1 ORDER BY created_at DESC;
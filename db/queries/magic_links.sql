@@ -0,0 +1,15 @@
+-- name: CreateMagicLink :exec
+INSERT INTO magic_links (id, user_id, token_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetMagicLinkByTokenHash :one
+SELECT * FROM magic_links WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
+
+-- name: MarkMagicLinkUsed :execrows
+UPDATE magic_links SET used_at = $1 WHERE id = $2 AND used_at IS NULL;
+
+-- name: InvalidateAllMagicLinksForUser :exec
+UPDATE magic_links SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL;
+
+-- name: DeleteExpiredOrUsedMagicLinks :execrows
+DELETE FROM magic_links WHERE expires_at < $1 OR used_at IS NOT NULL;
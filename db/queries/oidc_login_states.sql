@@ -0,0 +1,9 @@
+-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (id, provider, state_hash, code_verifier, nonce, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ConsumeOIDCLoginState :one
+DELETE FROM oidc_login_states WHERE state_hash = $1 AND provider = $2 RETURNING *;
+
+-- name: DeleteExpiredOIDCLoginStates :execrows
+DELETE FROM oidc_login_states WHERE expires_at < $1;
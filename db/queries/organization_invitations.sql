@@ -0,0 +1,31 @@
+-- name: CreateOrganizationInvitation :exec
+INSERT INTO organization_invitations (id, organization_id, email, role, token_hash, invited_by, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetOrganizationInvitationByTokenHash :one
+SELECT * FROM organization_invitations WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
+
+-- name: ListPendingOrganizationInvitations :many
+SELECT * FROM organization_invitations
+WHERE organization_id = $1 AND accepted_at IS NULL AND declined_at IS NULL AND expires_at > $2
+ORDER BY created_at DESC, id;
+
+-- name: MarkOrganizationInvitationAccepted :execrows
+UPDATE organization_invitations SET accepted_at = $1
+WHERE id = $2 AND accepted_at IS NULL AND declined_at IS NULL;
+
+-- name: MarkOrganizationInvitationDeclined :execrows
+UPDATE organization_invitations SET declined_at = $1
+WHERE id = $2 AND accepted_at IS NULL AND declined_at IS NULL;
+
+-- name: DeletePendingOrganizationInvitation :execrows
+DELETE FROM organization_invitations
+WHERE id = $1 AND organization_id = $2 AND accepted_at IS NULL AND declined_at IS NULL;
+
+-- name: DeletePendingOrganizationInvitationsForEmail :exec
+DELETE FROM organization_invitations
+WHERE organization_id = $1 AND email = $2 AND accepted_at IS NULL AND declined_at IS NULL;
+
+-- name: DeleteExpiredOrUsedOrganizationInvitations :execrows
+DELETE FROM organization_invitations
+WHERE expires_at < $1 OR accepted_at IS NOT NULL OR declined_at IS NOT NULL;
@@ -0,0 +1,51 @@
+-- name: CreateOrganization :exec
+INSERT INTO organizations (id, name, created_at, updated_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations WHERE id = $1;
+
+-- name: RenameOrganization :one
+UPDATE organizations SET name = $1, updated_at = $2 WHERE id = $3
+RETURNING *;
+
+-- name: DeleteOrganization :execrows
+DELETE FROM organizations WHERE id = $1;
+
+-- name: ListOrganizationsForUser :many
+SELECT o.id, o.name, o.created_at, o.updated_at, m.role FROM organizations o
+JOIN organization_members m ON m.organization_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.name, o.id;
+
+-- name: AddOrganizationMember :execrows
+INSERT INTO organization_members (organization_id, user_id, role, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (organization_id, user_id) DO NOTHING;
+
+-- name: GetOrganizationMemberRole :one
+SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT m.user_id, u.name, u.email, m.role, m.created_at FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1
+ORDER BY m.created_at, m.user_id;
+
+-- name: OrganizationMemberEmailExists :one
+SELECT EXISTS(
+    SELECT 1 FROM organization_members m
+    JOIN users u ON u.id = m.user_id
+    WHERE m.organization_id = $1 AND u.email = $2
+);
+
+-- name: UserOwnsOrganization :one
+SELECT EXISTS(
+    SELECT 1 FROM organization_members WHERE user_id = $1 AND role = 'owner'
+);
+
+-- name: UpdateOrganizationMemberRole :execrows
+UPDATE organization_members SET role = $1 WHERE organization_id = $2 AND user_id = $3;
+
+-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2;
@@ -3,7 +3,7 @@ INSERT INTO password_resets (id, user_id, token_hash, expires_at, created_at)
 VALUES ($1, $2, $3, $4, $5);
 
 -- name: GetPasswordResetByTokenHash :one
-SELECT * FROM password_resets WHERE token_hash = $1;
+SELECT * FROM password_resets WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
 
 -- name: MarkPasswordResetUsed :exec
 UPDATE password_resets SET used_at = $1 WHERE id = $2;
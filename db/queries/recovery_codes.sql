@@ -0,0 +1,10 @@
+-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes (id, user_id, code_hash, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes SET used_at = $1
+WHERE user_id = $2 AND code_hash = ANY(sqlc.arg(code_hashes)::text[]) AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodesForUser :exec
+DELETE FROM recovery_codes WHERE user_id = $1;
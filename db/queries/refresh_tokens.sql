@@ -0,0 +1,23 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetRefreshTokenByHashForUpdate :one
+SELECT * FROM refresh_tokens WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]) FOR UPDATE;
+
+-- name: MarkRefreshTokenRotated :exec
+UPDATE refresh_tokens SET rotated_at = $1 WHERE id = $2;
+
+-- name: UpdateRefreshTokenHash :exec
+UPDATE refresh_tokens SET token_hash = $1 WHERE id = $2;
+
+-- name: RevokeRefreshTokenFamily :exec
+WITH revoked_auth_tokens AS (
+    UPDATE auth_tokens SET revoked_at = $1
+    WHERE auth_tokens.family_id = $2 AND auth_tokens.revoked_at IS NULL
+)
+UPDATE refresh_tokens SET revoked_at = $1
+WHERE refresh_tokens.family_id = $2 AND refresh_tokens.revoked_at IS NULL;
+
+-- name: DeleteExpiredOrRevokedRefreshTokens :execrows
+DELETE FROM refresh_tokens WHERE expires_at < $1 OR revoked_at IS NOT NULL;
@@ -0,0 +1,29 @@
+-- name: GetRoleByName :one
+SELECT * FROM roles WHERE name = $1;
+
+-- name: ListRoleNamesForUser :many
+SELECT r.name FROM roles r
+JOIN user_roles ur ON ur.role_id = r.id
+WHERE ur.user_id = $1
+ORDER BY r.name;
+
+-- name: ListRoleAssignmentsForUser :many
+SELECT r.name, ur.created_at FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.user_id = $1
+ORDER BY ur.created_at, r.name;
+
+-- name: ListPermissionNamesForUser :many
+SELECT DISTINCT p.name FROM permissions p
+JOIN role_permissions rp ON rp.permission_id = p.id
+JOIN user_roles ur ON ur.role_id = rp.role_id
+WHERE ur.user_id = $1
+ORDER BY p.name;
+
+-- name: AssignRoleToUser :exec
+INSERT INTO user_roles (user_id, role_id, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, role_id) DO NOTHING;
+
+-- name: RemoveRoleFromUser :execrows
+DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2;
@@ -0,0 +1,20 @@
+-- name: UpsertPendingTOTPCredential :execrows
+INSERT INTO totp_credentials (user_id, secret, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = EXCLUDED.created_at
+WHERE totp_credentials.confirmed_at IS NULL;
+
+-- name: GetTOTPCredentialByUserID :one
+SELECT * FROM totp_credentials WHERE user_id = $1;
+
+-- name: ConfirmTOTPCredential :execrows
+UPDATE totp_credentials SET confirmed_at = $1, last_used_step = $2
+WHERE user_id = $3 AND confirmed_at IS NULL;
+
+-- name: UpdateTOTPCredentialLastUsedStep :execrows
+UPDATE totp_credentials SET last_used_step = $1
+WHERE user_id = $2 AND last_used_step < $1;
+
+-- name: DeleteTOTPCredential :exec
+DELETE FROM totp_credentials WHERE user_id = $1;
@@ -0,0 +1,18 @@
+-- name: CreateTwoFactorChallenge :exec
+INSERT INTO two_factor_challenges (id, user_id, token_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetTwoFactorChallengeByTokenHash :one
+SELECT * FROM two_factor_challenges WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]);
+
+-- name: GetTwoFactorChallengeByTokenHashForUpdate :one
+SELECT * FROM two_factor_challenges WHERE token_hash = ANY(sqlc.arg(token_hashes)::text[]) FOR UPDATE;
+
+-- name: IncrementTwoFactorChallengeAttempts :exec
+UPDATE two_factor_challenges SET attempts = attempts + 1 WHERE id = $1;
+
+-- name: MarkTwoFactorChallengeUsed :exec
+UPDATE two_factor_challenges SET used_at = $1 WHERE id = $2;
+
+-- name: DeleteExpiredOrUsedTwoFactorChallenges :execrows
+DELETE FROM two_factor_challenges WHERE expires_at < $1 OR used_at IS NOT NULL;
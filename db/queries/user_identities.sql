@@ -0,0 +1,18 @@
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (id, user_id, provider, subject, email, last_login_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetUserIdentityByProviderSubject :one
+SELECT * FROM user_identities WHERE provider = $1 AND subject = $2;
+
+-- name: ListUserIdentitiesForUser :many
+SELECT * FROM user_identities WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: CountUserIdentitiesForUser :one
+SELECT COUNT(*) FROM user_identities WHERE user_id = $1;
+
+-- name: UpdateUserIdentityLogin :exec
+UPDATE user_identities SET email = $1, last_login_at = $2 WHERE id = $3;
+
+-- name: DeleteUserIdentityForUser :execrows
+DELETE FROM user_identities WHERE id = $1 AND user_id = $2;
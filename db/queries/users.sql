@@ -19,10 +19,49 @@ UPDATE users SET email_verified_at = $1, updated_at = $2 WHERE id = $3;
 SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);
 
 -- name: ScheduleUserDeletion :exec
-UPDATE users SET deletion_scheduled_at = $1, updated_at = $2 WHERE id = $3;
+UPDATE users SET deletion_scheduled_at = $1, deletion_reminder_sent_at = NULL, updated_at = $2 WHERE id = $3;
 
 -- name: CancelUserDeletion :exec
-UPDATE users SET deletion_scheduled_at = NULL, updated_at = $1 WHERE id = $2;
+UPDATE users SET deletion_scheduled_at = NULL, deletion_reminder_sent_at = NULL, updated_at = $1 WHERE id = $2;
 
--- name: DeleteScheduledUsers :execrows
-DELETE FROM users WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1;
+-- name: ClaimUserDeletionReminders :many
+UPDATE users SET deletion_reminder_sent_at = sqlc.arg('sent_at')
+WHERE deletion_scheduled_at > sqlc.arg('sent_at')
+  AND deletion_scheduled_at <= sqlc.arg('due_before')
+  AND deletion_reminder_sent_at IS NULL
+RETURNING *;
+
+-- name: DeleteScheduledUsers :many
+DELETE FROM users WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+RETURNING *;
+
+-- name: UpdateUserEmail :exec
+UPDATE users SET email = $1, email_verified_at = $2, updated_at = $3 WHERE id = $4;
+
+-- name: UpdateUserProfile :one
+UPDATE users
+SET name = COALESCE(sqlc.narg('name'), name), updated_at = sqlc.arg('updated_at')
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: SearchUsers :many
+SELECT * FROM users
+WHERE (sqlc.narg('search')::text IS NULL
+        OR email ILIKE '%' || sqlc.narg('search') || '%'
+        OR name ILIKE '%' || sqlc.narg('search') || '%')
+  AND (sqlc.narg('verified')::boolean IS NULL
+        OR (email_verified_at IS NOT NULL) = sqlc.narg('verified'))
+  AND (sqlc.narg('deletion_scheduled')::boolean IS NULL
+        OR (deletion_scheduled_at IS NOT NULL) = sqlc.narg('deletion_scheduled'))
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountSearchUsers :one
+SELECT COUNT(*) FROM users
+WHERE (sqlc.narg('search')::text IS NULL
+        OR email ILIKE '%' || sqlc.narg('search') || '%'
+        OR name ILIKE '%' || sqlc.narg('search') || '%')
+  AND (sqlc.narg('verified')::boolean IS NULL
+        OR (email_verified_at IS NOT NULL) = sqlc.narg('verified'))
+  AND (sqlc.narg('deletion_scheduled')::boolean IS NULL
+        OR (deletion_scheduled_at IS NOT NULL) = sqlc.narg('deletion_scheduled'));
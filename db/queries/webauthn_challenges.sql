@@ -0,0 +1,9 @@
+-- name: CreateWebAuthnChallenge :exec
+INSERT INTO webauthn_challenges (id, user_id, ceremony, challenge_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ConsumeWebAuthnChallenge :one
+DELETE FROM webauthn_challenges WHERE challenge_hash = $1 AND ceremony = $2 RETURNING *;
+
+-- name: DeleteExpiredWebAuthnChallenges :execrows
+DELETE FROM webauthn_challenges WHERE expires_at < $1;
@@ -0,0 +1,15 @@
+-- name: CreateWebAuthnCredential :exec
+INSERT INTO webauthn_credentials (id, user_id, credential_id, public_key, sign_count, aaguid, backup_eligible, transports, name, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetWebAuthnCredentialByCredentialID :one
+SELECT * FROM webauthn_credentials WHERE credential_id = $1;
+
+-- name: ListWebAuthnCredentialsForUser :many
+SELECT * FROM webauthn_credentials WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: UpdateWebAuthnCredentialSignCount :exec
+UPDATE webauthn_credentials SET sign_count = $1, last_used_at = $2 WHERE id = $3;
+
+-- name: DeleteWebAuthnCredentialForUser :execrows
+DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2;
@@ -12,16 +12,36 @@ import (
 	"github.com/google/uuid"
 )
 
+const countActiveAuthTokensForUser = `-- name: CountActiveAuthTokensForUser :one
+SELECT COUNT(*) FROM auth_tokens
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+`
+
+type CountActiveAuthTokensForUserParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CountActiveAuthTokensForUser(ctx context.Context, arg CountActiveAuthTokensForUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveAuthTokensForUser, arg.UserID, arg.ExpiresAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAuthToken = `-- name: CreateAuthToken :exec
-INSERT INTO auth_tokens (id, user_id, token_hash, expires_at, created_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO auth_tokens (id, user_id, family_id, token_hash, expires_at, user_agent, ip_address, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateAuthTokenParams struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
+	FamilyID  uuid.UUID `json:"family_id"`
 	TokenHash string    `json:"token_hash"`
 	ExpiresAt time.Time `json:"expires_at"`
+	UserAgent string    `json:"user_agent"`
+	IpAddress string    `json:"ip_address"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -29,13 +49,48 @@ func (q *Queries) CreateAuthToken(ctx context.Context, arg CreateAuthTokenParams
 	_, err := q.db.Exec(ctx, createAuthToken,
 		arg.ID,
 		arg.UserID,
+		arg.FamilyID,
 		arg.TokenHash,
 		arg.ExpiresAt,
+		arg.UserAgent,
+		arg.IpAddress,
 		arg.CreatedAt,
 	)
 	return err
 }
 
+const createImpersonationAuthToken = `-- name: CreateImpersonationAuthToken :exec
+INSERT INTO auth_tokens (id, user_id, family_id, token_hash, expires_at, user_agent, ip_address, created_at, impersonator_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+type CreateImpersonationAuthTokenParams struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	FamilyID       uuid.UUID  `json:"family_id"`
+	TokenHash      string     `json:"token_hash"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	UserAgent      string     `json:"user_agent"`
+	IpAddress      string     `json:"ip_address"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id"`
+}
+
+func (q *Queries) CreateImpersonationAuthToken(ctx context.Context, arg CreateImpersonationAuthTokenParams) error {
+	_, err := q.db.Exec(ctx, createImpersonationAuthToken,
+		arg.ID,
+		arg.UserID,
+		arg.FamilyID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.CreatedAt,
+		arg.ImpersonatorID,
+	)
+	return err
+}
+
 const deleteExpiredOrRevokedAuthTokens = `-- name: DeleteExpiredOrRevokedAuthTokens :execrows
 DELETE FROM auth_tokens WHERE expires_at < $1 OR revoked_at IS NOT NULL
 `
@@ -48,12 +103,25 @@ func (q *Queries) DeleteExpiredOrRevokedAuthTokens(ctx context.Context, expiresA
 	return result.RowsAffected(), nil
 }
 
+const deleteIdleAuthTokens = `-- name: DeleteIdleAuthTokens :execrows
+DELETE FROM auth_tokens
+WHERE created_at < $1 AND (last_used_at IS NULL OR last_used_at < $1)
+`
+
+func (q *Queries) DeleteIdleAuthTokens(ctx context.Context, idleBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteIdleAuthTokens, idleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAuthTokenByHash = `-- name: GetAuthTokenByHash :one
-SELECT id, user_id, token_hash, expires_at, revoked_at, created_at FROM auth_tokens WHERE token_hash = $1
+SELECT id, user_id, token_hash, expires_at, revoked_at, created_at, user_agent, ip_address, last_used_at, family_id, impersonator_id FROM auth_tokens WHERE token_hash = ANY($1::text[])
 `
 
-func (q *Queries) GetAuthTokenByHash(ctx context.Context, tokenHash string) (AuthToken, error) {
-	row := q.db.QueryRow(ctx, getAuthTokenByHash, tokenHash)
+func (q *Queries) GetAuthTokenByHash(ctx context.Context, tokenHashes []string) (AuthToken, error) {
+	row := q.db.QueryRow(ctx, getAuthTokenByHash, tokenHashes)
 	var i AuthToken
 	err := row.Scan(
 		&i.ID,
@@ -62,12 +130,109 @@ func (q *Queries) GetAuthTokenByHash(ctx context.Context, tokenHash string) (Aut
 		&i.ExpiresAt,
 		&i.RevokedAt,
 		&i.CreatedAt,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.ImpersonatorID,
 	)
 	return i, err
 }
 
+const listActiveAuthTokensForUser = `-- name: ListActiveAuthTokensForUser :many
+SELECT id, user_id, token_hash, expires_at, revoked_at, created_at, user_agent, ip_address, last_used_at, family_id, impersonator_id FROM auth_tokens
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+ORDER BY COALESCE(last_used_at, created_at) DESC, id
+LIMIT $3 OFFSET $4
+`
+
+type ListActiveAuthTokensForUserParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
+
+func (q *Queries) ListActiveAuthTokensForUser(ctx context.Context, arg ListActiveAuthTokensForUserParams) ([]AuthToken, error) {
+	rows, err := q.db.Query(ctx, listActiveAuthTokensForUser,
+		arg.UserID,
+		arg.ExpiresAt,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuthToken{}
+	for rows.Next() {
+		var i AuthToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TokenHash,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.LastUsedAt,
+			&i.FamilyID,
+			&i.ImpersonatorID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthTokensForUser = `-- name: ListAuthTokensForUser :many
+SELECT id, user_id, token_hash, expires_at, revoked_at, created_at, user_agent, ip_address, last_used_at, family_id, impersonator_id FROM auth_tokens WHERE user_id = $1 ORDER BY created_at, id
+`
+
+func (q *Queries) ListAuthTokensForUser(ctx context.Context, userID uuid.UUID) ([]AuthToken, error) {
+	rows, err := q.db.Query(ctx, listAuthTokensForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuthToken{}
+	for rows.Next() {
+		var i AuthToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TokenHash,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.LastUsedAt,
+			&i.FamilyID,
+			&i.ImpersonatorID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const revokeAllAuthTokensForUser = `-- name: RevokeAllAuthTokensForUser :exec
-UPDATE auth_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL
+WITH revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.user_id = $2 AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.user_id = $2 AND auth_tokens.revoked_at IS NULL
 `
 
 type RevokeAllAuthTokensForUserParams struct {
@@ -81,7 +246,14 @@ func (q *Queries) RevokeAllAuthTokensForUser(ctx context.Context, arg RevokeAllA
 }
 
 const revokeAuthToken = `-- name: RevokeAuthToken :exec
-UPDATE auth_tokens SET revoked_at = $1 WHERE id = $2
+WITH target AS (
+    SELECT family_id FROM auth_tokens WHERE auth_tokens.id = $2
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.family_id IN (SELECT family_id FROM target) AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.family_id IN (SELECT family_id FROM target) AND auth_tokens.revoked_at IS NULL
 `
 
 type RevokeAuthTokenParams struct {
@@ -95,15 +267,115 @@ func (q *Queries) RevokeAuthToken(ctx context.Context, arg RevokeAuthTokenParams
 }
 
 const revokeAuthTokenByHash = `-- name: RevokeAuthTokenByHash :exec
-UPDATE auth_tokens SET revoked_at = $1 WHERE token_hash = $2
+WITH target AS (
+    SELECT family_id FROM auth_tokens WHERE auth_tokens.token_hash = ANY($1::text[])
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $2
+    WHERE refresh_tokens.family_id IN (SELECT family_id FROM target) AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $2
+WHERE auth_tokens.family_id IN (SELECT family_id FROM target) AND auth_tokens.revoked_at IS NULL
 `
 
 type RevokeAuthTokenByHashParams struct {
-	RevokedAt *time.Time `json:"revoked_at"`
-	TokenHash string     `json:"token_hash"`
+	TokenHashes []string   `json:"token_hashes"`
+	RevokedAt   *time.Time `json:"revoked_at"`
 }
 
 func (q *Queries) RevokeAuthTokenByHash(ctx context.Context, arg RevokeAuthTokenByHashParams) error {
-	_, err := q.db.Exec(ctx, revokeAuthTokenByHash, arg.RevokedAt, arg.TokenHash)
+	_, err := q.db.Exec(ctx, revokeAuthTokenByHash, arg.TokenHashes, arg.RevokedAt)
+	return err
+}
+
+const revokeAuthTokenForUser = `-- name: RevokeAuthTokenForUser :execrows
+WITH target AS (
+    SELECT family_id FROM auth_tokens
+    WHERE auth_tokens.id = $2 AND auth_tokens.user_id = $3 AND auth_tokens.revoked_at IS NULL
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.family_id IN (SELECT family_id FROM target) AND refresh_tokens.revoked_at IS NULL
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.family_id IN (SELECT family_id FROM target) AND auth_tokens.revoked_at IS NULL
+`
+
+type RevokeAuthTokenForUserParams struct {
+	RevokedAt *time.Time `json:"revoked_at"`
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+}
+
+func (q *Queries) RevokeAuthTokenForUser(ctx context.Context, arg RevokeAuthTokenForUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeAuthTokenForUser, arg.RevokedAt, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeFamilyAuthTokens = `-- name: RevokeFamilyAuthTokens :exec
+UPDATE auth_tokens SET revoked_at = $1
+WHERE family_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeFamilyAuthTokensParams struct {
+	RevokedAt *time.Time `json:"revoked_at"`
+	FamilyID  uuid.UUID  `json:"family_id"`
+}
+
+func (q *Queries) RevokeFamilyAuthTokens(ctx context.Context, arg RevokeFamilyAuthTokensParams) error {
+	_, err := q.db.Exec(ctx, revokeFamilyAuthTokens, arg.RevokedAt, arg.FamilyID)
+	return err
+}
+
+const revokeOtherAuthTokensForUser = `-- name: RevokeOtherAuthTokensForUser :exec
+WITH kept AS (
+    SELECT family_id FROM auth_tokens WHERE auth_tokens.id = $3
+), revoked_refresh_tokens AS (
+    UPDATE refresh_tokens SET revoked_at = $1
+    WHERE refresh_tokens.user_id = $2 AND refresh_tokens.revoked_at IS NULL
+      AND refresh_tokens.family_id NOT IN (SELECT family_id FROM kept)
+)
+UPDATE auth_tokens SET revoked_at = $1
+WHERE auth_tokens.user_id = $2 AND auth_tokens.revoked_at IS NULL
+  AND auth_tokens.family_id NOT IN (SELECT family_id FROM kept)
+`
+
+type RevokeOtherAuthTokensForUserParams struct {
+	RevokedAt *time.Time `json:"revoked_at"`
+	UserID    uuid.UUID  `json:"user_id"`
+	ID        uuid.UUID  `json:"id"`
+}
+
+func (q *Queries) RevokeOtherAuthTokensForUser(ctx context.Context, arg RevokeOtherAuthTokensForUserParams) error {
+	_, err := q.db.Exec(ctx, revokeOtherAuthTokensForUser, arg.RevokedAt, arg.UserID, arg.ID)
+	return err
+}
+
+const touchAuthToken = `-- name: TouchAuthToken :exec
+UPDATE auth_tokens SET last_used_at = $1 WHERE id = $2
+`
+
+type TouchAuthTokenParams struct {
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ID         uuid.UUID  `json:"id"`
+}
+
+func (q *Queries) TouchAuthToken(ctx context.Context, arg TouchAuthTokenParams) error {
+	_, err := q.db.Exec(ctx, touchAuthToken, arg.LastUsedAt, arg.ID)
+	return err
+}
+
+const updateAuthTokenHash = `-- name: UpdateAuthTokenHash :exec
+UPDATE auth_tokens SET token_hash = $1 WHERE id = $2
+`
+
+type UpdateAuthTokenHashParams struct {
+	TokenHash string    `json:"token_hash"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateAuthTokenHash(ctx context.Context, arg UpdateAuthTokenHashParams) error {
+	_, err := q.db.Exec(ctx, updateAuthTokenHash, arg.TokenHash, arg.ID)
 	return err
 }
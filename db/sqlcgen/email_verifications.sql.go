@@ -49,11 +49,11 @@ func (q *Queries) DeleteExpiredOrUsedEmailVerifications(ctx context.Context, exp
 }
 
 const getEmailVerificationByTokenHash = `-- name: GetEmailVerificationByTokenHash :one
-SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM email_verifications WHERE token_hash = $1
+SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM email_verifications WHERE token_hash = ANY($1::text[])
 `
 
-func (q *Queries) GetEmailVerificationByTokenHash(ctx context.Context, tokenHash string) (EmailVerification, error) {
-	row := q.db.QueryRow(ctx, getEmailVerificationByTokenHash, tokenHash)
+func (q *Queries) GetEmailVerificationByTokenHash(ctx context.Context, tokenHashes []string) (EmailVerification, error) {
+	row := q.db.QueryRow(ctx, getEmailVerificationByTokenHash, tokenHashes)
 	var i EmailVerification
 	err := row.Scan(
 		&i.ID,
@@ -10,16 +10,85 @@ import (
 	"github.com/google/uuid"
 )
 
+type ApiKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	TokenHash  string     `json:"token_hash"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID        uuid.UUID  `json:"id"`
+	ActorID   *uuid.UUID `json:"actor_id"`
+	SubjectID *uuid.UUID `json:"subject_id"`
+	Action    string     `json:"action"`
+	Metadata  []byte     `json:"metadata"`
+	IpAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
 type AuthToken struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	TokenHash      string     `json:"token_hash"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UserAgent      string     `json:"user_agent"`
+	IpAddress      string     `json:"ip_address"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	FamilyID       uuid.UUID  `json:"family_id"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id"`
+}
+
+type DataExport struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Format      string     `json:"format"`
+	Archive     []byte     `json:"archive"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type EmailChange struct {
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	OldEmail        string     `json:"old_email"`
+	NewEmail        string     `json:"new_email"`
+	TokenHash       string     `json:"token_hash"`
+	RevertTokenHash string     `json:"revert_token_hash"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	RevertExpiresAt time.Time  `json:"revert_expires_at"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	RevertedAt      *time.Time `json:"reverted_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type EmailVerification struct {
 	ID        uuid.UUID  `json:"id"`
 	UserID    uuid.UUID  `json:"user_id"`
 	TokenHash string     `json:"token_hash"`
 	ExpiresAt time.Time  `json:"expires_at"`
-	RevokedAt *time.Time `json:"revoked_at"`
+	UsedAt    *time.Time `json:"used_at"`
 	CreatedAt time.Time  `json:"created_at"`
 }
 
-type EmailVerification struct {
+type LoginDevice struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type MagicLink struct {
 	ID        uuid.UUID  `json:"id"`
 	UserID    uuid.UUID  `json:"user_id"`
 	TokenHash string     `json:"token_hash"`
@@ -28,6 +97,43 @@ type EmailVerification struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+type OidcLoginState struct {
+	ID           uuid.UUID `json:"id"`
+	Provider     string    `json:"provider"`
+	StateHash    string    `json:"state_hash"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationInvitation struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	TokenHash      string     `json:"token_hash"`
+	InvitedBy      *uuid.UUID `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	DeclinedAt     *time.Time `json:"declined_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 type PasswordReset struct {
 	ID        uuid.UUID  `json:"id"`
 	UserID    uuid.UUID  `json:"user_id"`
@@ -37,13 +143,109 @@ type PasswordReset struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+type Permission struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	CodeHash  string     `json:"code_hash"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	FamilyID  uuid.UUID  `json:"family_id"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RotatedAt *time.Time `json:"rotated_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type Role struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RolePermission struct {
+	RoleID       uuid.UUID `json:"role_id"`
+	PermissionID uuid.UUID `json:"permission_id"`
+}
+
+type TotpCredential struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	Secret       string     `json:"secret"`
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	LastUsedStep int64      `json:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type TwoFactorChallenge struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	Attempts  int32      `json:"attempts"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
 type User struct {
-	ID                  uuid.UUID  `json:"id"`
-	Name                string     `json:"name"`
-	Email               string     `json:"email"`
-	PasswordHash        string     `json:"password_hash"`
-	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
-	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
-	CreatedAt           time.Time  `json:"created_at"`
-	UpdatedAt           time.Time  `json:"updated_at"`
+	ID                     uuid.UUID  `json:"id"`
+	Name                   string     `json:"name"`
+	Email                  string     `json:"email"`
+	PasswordHash           string     `json:"password_hash"`
+	EmailVerifiedAt        *time.Time `json:"email_verified_at"`
+	DeletionScheduledAt    *time.Time `json:"deletion_scheduled_at"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+	DeletionReminderSentAt *time.Time `json:"deletion_reminder_sent_at"`
+}
+
+type UserIdentity struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Provider    string    `json:"provider"`
+	Subject     string    `json:"subject"`
+	Email       string    `json:"email"`
+	LastLoginAt time.Time `json:"last_login_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type UserRole struct {
+	UserID    uuid.UUID `json:"user_id"`
+	RoleID    uuid.UUID `json:"role_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebauthnChallenge struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        *uuid.UUID `json:"user_id"`
+	Ceremony      string     `json:"ceremony"`
+	ChallengeHash string     `json:"challenge_hash"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type WebauthnCredential struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	CredentialID   []byte     `json:"credential_id"`
+	PublicKey      []byte     `json:"public_key"`
+	SignCount      int64      `json:"sign_count"`
+	Aaguid         []byte     `json:"aaguid"`
+	BackupEligible bool       `json:"backup_eligible"`
+	Transports     []string   `json:"transports"`
+	Name           string     `json:"name"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
@@ -49,11 +49,11 @@ func (q *Queries) DeleteExpiredOrUsedPasswordResets(ctx context.Context, expires
 }
 
 const getPasswordResetByTokenHash = `-- name: GetPasswordResetByTokenHash :one
-SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_resets WHERE token_hash = $1
+SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_resets WHERE token_hash = ANY($1::text[])
 `
 
-func (q *Queries) GetPasswordResetByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error) {
-	row := q.db.QueryRow(ctx, getPasswordResetByTokenHash, tokenHash)
+func (q *Queries) GetPasswordResetByTokenHash(ctx context.Context, tokenHashes []string) (PasswordReset, error) {
+	row := q.db.QueryRow(ctx, getPasswordResetByTokenHash, tokenHashes)
 	var i PasswordReset
 	err := row.Scan(
 		&i.ID,
@@ -12,31 +12,140 @@ import (
 )
 
 type Querier interface {
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (int64, error)
+	AssignRoleToUser(ctx context.Context, arg AssignRoleToUserParams) error
 	CancelUserDeletion(ctx context.Context, arg CancelUserDeletionParams) error
+	ClaimUserDeletionReminders(ctx context.Context, arg ClaimUserDeletionRemindersParams) ([]User, error)
+	CompleteDataExport(ctx context.Context, arg CompleteDataExportParams) (int64, error)
+	ConfirmTOTPCredential(ctx context.Context, arg ConfirmTOTPCredentialParams) (int64, error)
+	ConsumeOIDCLoginState(ctx context.Context, arg ConsumeOIDCLoginStateParams) (OidcLoginState, error)
+	ConsumeWebAuthnChallenge(ctx context.Context, arg ConsumeWebAuthnChallengeParams) (WebauthnChallenge, error)
+	CountActiveAuthTokensForUser(ctx context.Context, arg CountActiveAuthTokensForUserParams) (int64, error)
+	CountDataExportsForUserSince(ctx context.Context, arg CountDataExportsForUserSinceParams) (int64, error)
+	CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error)
+	CountUserIdentitiesForUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
 	CreateAuthToken(ctx context.Context, arg CreateAuthTokenParams) error
+	CreateDataExport(ctx context.Context, arg CreateDataExportParams) error
+	CreateEmailChange(ctx context.Context, arg CreateEmailChangeParams) error
 	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) error
+	CreateImpersonationAuthToken(ctx context.Context, arg CreateImpersonationAuthTokenParams) error
+	CreateLoginDevice(ctx context.Context, arg CreateLoginDeviceParams) (int64, error)
+	CreateMagicLink(ctx context.Context, arg CreateMagicLinkParams) error
+	CreateOIDCLoginState(ctx context.Context, arg CreateOIDCLoginStateParams) error
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) error
+	CreateOrganizationInvitation(ctx context.Context, arg CreateOrganizationInvitationParams) error
 	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) error
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateTwoFactorChallenge(ctx context.Context, arg CreateTwoFactorChallengeParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error
+	CreateWebAuthnChallenge(ctx context.Context, arg CreateWebAuthnChallengeParams) error
+	CreateWebAuthnCredential(ctx context.Context, arg CreateWebAuthnCredentialParams) error
+	DeleteExpiredDataExports(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredEmailChanges(ctx context.Context, revertExpiresAt time.Time) (int64, error)
+	DeleteExpiredOIDCLoginStates(ctx context.Context, expiresAt time.Time) (int64, error)
 	DeleteExpiredOrRevokedAuthTokens(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredOrRevokedRefreshTokens(ctx context.Context, expiresAt time.Time) (int64, error)
 	DeleteExpiredOrUsedEmailVerifications(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredOrUsedMagicLinks(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredOrUsedOrganizationInvitations(ctx context.Context, expiresAt time.Time) (int64, error)
 	DeleteExpiredOrUsedPasswordResets(ctx context.Context, expiresAt time.Time) (int64, error)
-	DeleteScheduledUsers(ctx context.Context, deletionScheduledAt *time.Time) (int64, error)
+	DeleteExpiredOrUsedTwoFactorChallenges(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredWebAuthnChallenges(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteIdleAuthTokens(ctx context.Context, idleBefore time.Time) (int64, error)
+	DeleteOrganization(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) (int64, error)
+	DeletePendingEmailChangesForUser(ctx context.Context, userID uuid.UUID) error
+	DeletePendingOrganizationInvitation(ctx context.Context, arg DeletePendingOrganizationInvitationParams) (int64, error)
+	DeletePendingOrganizationInvitationsForEmail(ctx context.Context, arg DeletePendingOrganizationInvitationsForEmailParams) error
+	DeleteRecoveryCodesForUser(ctx context.Context, userID uuid.UUID) error
+	DeleteScheduledUsers(ctx context.Context, deletionScheduledAt *time.Time) ([]User, error)
+	DeleteTOTPCredential(ctx context.Context, userID uuid.UUID) error
+	DeleteUserIdentityForUser(ctx context.Context, arg DeleteUserIdentityForUserParams) (int64, error)
+	DeleteWebAuthnCredentialForUser(ctx context.Context, arg DeleteWebAuthnCredentialForUserParams) (int64, error)
 	EmailExists(ctx context.Context, email string) (bool, error)
-	GetAuthTokenByHash(ctx context.Context, tokenHash string) (AuthToken, error)
-	GetEmailVerificationByTokenHash(ctx context.Context, tokenHash string) (EmailVerification, error)
-	GetPasswordResetByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error)
+	GetAPIKeyByHash(ctx context.Context, tokenHashes []string) (ApiKey, error)
+	GetAuthTokenByHash(ctx context.Context, tokenHashes []string) (AuthToken, error)
+	GetDataExportByID(ctx context.Context, id uuid.UUID) (DataExport, error)
+	GetEmailChangeByRevertTokenHash(ctx context.Context, revertTokenHashes []string) (EmailChange, error)
+	GetEmailChangeByTokenHash(ctx context.Context, tokenHashes []string) (EmailChange, error)
+	GetEmailVerificationByTokenHash(ctx context.Context, tokenHashes []string) (EmailVerification, error)
+	GetMagicLinkByTokenHash(ctx context.Context, tokenHashes []string) (MagicLink, error)
+	GetOrganizationByID(ctx context.Context, id uuid.UUID) (Organization, error)
+	GetOrganizationInvitationByTokenHash(ctx context.Context, tokenHashes []string) (OrganizationInvitation, error)
+	GetOrganizationMemberRole(ctx context.Context, arg GetOrganizationMemberRoleParams) (string, error)
+	GetPasswordResetByTokenHash(ctx context.Context, tokenHashes []string) (PasswordReset, error)
+	GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHashes []string) (RefreshToken, error)
+	GetRoleByName(ctx context.Context, name string) (Role, error)
+	GetTOTPCredentialByUserID(ctx context.Context, userID uuid.UUID) (TotpCredential, error)
+	GetTwoFactorChallengeByTokenHash(ctx context.Context, tokenHashes []string) (TwoFactorChallenge, error)
+	GetTwoFactorChallengeByTokenHashForUpdate(ctx context.Context, tokenHashes []string) (TwoFactorChallenge, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
+	GetUserIdentityByProviderSubject(ctx context.Context, arg GetUserIdentityByProviderSubjectParams) (UserIdentity, error)
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (WebauthnCredential, error)
+	IncrementTwoFactorChallengeAttempts(ctx context.Context, id uuid.UUID) error
 	InvalidateAllEmailVerificationsForUser(ctx context.Context, arg InvalidateAllEmailVerificationsForUserParams) error
+	InvalidateAllMagicLinksForUser(ctx context.Context, arg InvalidateAllMagicLinksForUserParams) error
 	InvalidateAllPasswordResetsForUser(ctx context.Context, arg InvalidateAllPasswordResetsForUserParams) error
+	ListActiveAPIKeysForUser(ctx context.Context, userID uuid.UUID) ([]ApiKey, error)
+	ListActiveAuthTokensForUser(ctx context.Context, arg ListActiveAuthTokensForUserParams) ([]AuthToken, error)
+	ListAuditLogsForUser(ctx context.Context, userID uuid.UUID) ([]AuditLog, error)
+	ListAuthTokensForUser(ctx context.Context, userID uuid.UUID) ([]AuthToken, error)
+	ListEmailChangesForUser(ctx context.Context, userID uuid.UUID) ([]EmailChange, error)
+	ListLoginDevicesForUser(ctx context.Context, userID uuid.UUID) ([]LoginDevice, error)
+	ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationMembersRow, error)
+	ListOrganizationsForUser(ctx context.Context, userID uuid.UUID) ([]ListOrganizationsForUserRow, error)
+	ListPendingOrganizationInvitations(ctx context.Context, arg ListPendingOrganizationInvitationsParams) ([]OrganizationInvitation, error)
+	ListPermissionNamesForUser(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListRoleAssignmentsForUser(ctx context.Context, userID uuid.UUID) ([]ListRoleAssignmentsForUserRow, error)
+	ListRoleNamesForUser(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListUserIdentitiesForUser(ctx context.Context, userID uuid.UUID) ([]UserIdentity, error)
+	ListWebAuthnCredentialsForUser(ctx context.Context, userID uuid.UUID) ([]WebauthnCredential, error)
+	LoginDeviceExistsForUser(ctx context.Context, userID uuid.UUID) (bool, error)
+	MarkEmailChangeConfirmed(ctx context.Context, arg MarkEmailChangeConfirmedParams) (int64, error)
+	MarkEmailChangeReverted(ctx context.Context, arg MarkEmailChangeRevertedParams) (int64, error)
 	MarkEmailVerificationUsed(ctx context.Context, arg MarkEmailVerificationUsedParams) error
+	MarkMagicLinkUsed(ctx context.Context, arg MarkMagicLinkUsedParams) (int64, error)
+	MarkOrganizationInvitationAccepted(ctx context.Context, arg MarkOrganizationInvitationAcceptedParams) (int64, error)
+	MarkOrganizationInvitationDeclined(ctx context.Context, arg MarkOrganizationInvitationDeclinedParams) (int64, error)
 	MarkPasswordResetUsed(ctx context.Context, arg MarkPasswordResetUsedParams) error
+	MarkRefreshTokenRotated(ctx context.Context, arg MarkRefreshTokenRotatedParams) error
+	MarkTwoFactorChallengeUsed(ctx context.Context, arg MarkTwoFactorChallengeUsedParams) error
 	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error
+	OrganizationMemberEmailExists(ctx context.Context, arg OrganizationMemberEmailExistsParams) (bool, error)
+	RemoveRoleFromUser(ctx context.Context, arg RemoveRoleFromUserParams) (int64, error)
+	RenameAPIKeyForUser(ctx context.Context, arg RenameAPIKeyForUserParams) (ApiKey, error)
+	RenameOrganization(ctx context.Context, arg RenameOrganizationParams) (Organization, error)
+	RevokeAPIKeyForUser(ctx context.Context, arg RevokeAPIKeyForUserParams) (int64, error)
+	RevokeAllAPIKeysForUser(ctx context.Context, arg RevokeAllAPIKeysForUserParams) error
 	RevokeAllAuthTokensForUser(ctx context.Context, arg RevokeAllAuthTokensForUserParams) error
 	RevokeAuthToken(ctx context.Context, arg RevokeAuthTokenParams) error
 	RevokeAuthTokenByHash(ctx context.Context, arg RevokeAuthTokenByHashParams) error
+	RevokeAuthTokenForUser(ctx context.Context, arg RevokeAuthTokenForUserParams) (int64, error)
+	RevokeFamilyAuthTokens(ctx context.Context, arg RevokeFamilyAuthTokensParams) error
+	RevokeOtherAuthTokensForUser(ctx context.Context, arg RevokeOtherAuthTokensForUserParams) error
+	RevokeRefreshTokenFamily(ctx context.Context, arg RevokeRefreshTokenFamilyParams) error
 	ScheduleUserDeletion(ctx context.Context, arg ScheduleUserDeletionParams) error
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	TouchAPIKey(ctx context.Context, arg TouchAPIKeyParams) error
+	TouchAuthToken(ctx context.Context, arg TouchAuthTokenParams) error
+	UpdateAPIKeyHash(ctx context.Context, arg UpdateAPIKeyHashParams) error
+	UpdateAuthTokenHash(ctx context.Context, arg UpdateAuthTokenHashParams) error
+	UpdateOrganizationMemberRole(ctx context.Context, arg UpdateOrganizationMemberRoleParams) (int64, error)
+	UpdateRefreshTokenHash(ctx context.Context, arg UpdateRefreshTokenHashParams) error
+	UpdateTOTPCredentialLastUsedStep(ctx context.Context, arg UpdateTOTPCredentialLastUsedStepParams) (int64, error)
+	UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) error
+	UpdateUserIdentityLogin(ctx context.Context, arg UpdateUserIdentityLoginParams) error
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
+	UpdateWebAuthnCredentialSignCount(ctx context.Context, arg UpdateWebAuthnCredentialSignCountParams) error
+	UpsertPendingTOTPCredential(ctx context.Context, arg UpsertPendingTOTPCredentialParams) (int64, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UserOwnsOrganization(ctx context.Context, userID uuid.UUID) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -13,7 +13,7 @@ import (
 )
 
 const cancelUserDeletion = `-- name: CancelUserDeletion :exec
-UPDATE users SET deletion_scheduled_at = NULL, updated_at = $1 WHERE id = $2
+UPDATE users SET deletion_scheduled_at = NULL, deletion_reminder_sent_at = NULL, updated_at = $1 WHERE id = $2
 `
 
 type CancelUserDeletionParams struct {
@@ -26,10 +26,77 @@ func (q *Queries) CancelUserDeletion(ctx context.Context, arg CancelUserDeletion
 	return err
 }
 
+const claimUserDeletionReminders = `-- name: ClaimUserDeletionReminders :many
+UPDATE users SET deletion_reminder_sent_at = $1
+WHERE deletion_scheduled_at > $1
+  AND deletion_scheduled_at <= $2
+  AND deletion_reminder_sent_at IS NULL
+RETURNING id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at
+`
+
+type ClaimUserDeletionRemindersParams struct {
+	SentAt    *time.Time `json:"sent_at"`
+	DueBefore *time.Time `json:"due_before"`
+}
+
+func (q *Queries) ClaimUserDeletionReminders(ctx context.Context, arg ClaimUserDeletionRemindersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, claimUserDeletionReminders, arg.SentAt, arg.DueBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.PasswordHash,
+			&i.EmailVerifiedAt,
+			&i.DeletionScheduledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletionReminderSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countSearchUsers = `-- name: CountSearchUsers :one
+SELECT COUNT(*) FROM users
+WHERE ($1::text IS NULL
+        OR email ILIKE '%' || $1 || '%'
+        OR name ILIKE '%' || $1 || '%')
+  AND ($2::boolean IS NULL
+        OR (email_verified_at IS NOT NULL) = $2)
+  AND ($3::boolean IS NULL
+        OR (deletion_scheduled_at IS NOT NULL) = $3)
+`
+
+type CountSearchUsersParams struct {
+	Search            *string `json:"search"`
+	Verified          *bool   `json:"verified"`
+	DeletionScheduled *bool   `json:"deletion_scheduled"`
+}
+
+func (q *Queries) CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSearchUsers, arg.Search, arg.Verified, arg.DeletionScheduled)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, name, email, password_hash, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at
+RETURNING id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at
 `
 
 type CreateUserParams struct {
@@ -60,20 +127,44 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletionReminderSentAt,
 	)
 	return i, err
 }
 
-const deleteScheduledUsers = `-- name: DeleteScheduledUsers :execrows
+const deleteScheduledUsers = `-- name: DeleteScheduledUsers :many
 DELETE FROM users WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+RETURNING id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at
 `
 
-func (q *Queries) DeleteScheduledUsers(ctx context.Context, deletionScheduledAt *time.Time) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteScheduledUsers, deletionScheduledAt)
+func (q *Queries) DeleteScheduledUsers(ctx context.Context, deletionScheduledAt *time.Time) ([]User, error) {
+	rows, err := q.db.Query(ctx, deleteScheduledUsers, deletionScheduledAt)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return result.RowsAffected(), nil
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.PasswordHash,
+			&i.EmailVerifiedAt,
+			&i.DeletionScheduledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletionReminderSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const emailExists = `-- name: EmailExists :one
@@ -88,7 +179,7 @@ func (q *Queries) EmailExists(ctx context.Context, email string) (bool, error) {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE email = $1
+SELECT id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -103,12 +194,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletionReminderSentAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at FROM users WHERE id = $1
+SELECT id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
@@ -123,6 +215,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletionReminderSentAt,
 	)
 	return i, err
 }
@@ -142,8 +235,65 @@ func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVe
 	return err
 }
 
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at FROM users
+WHERE ($1::text IS NULL
+        OR email ILIKE '%' || $1 || '%'
+        OR name ILIKE '%' || $1 || '%')
+  AND ($2::boolean IS NULL
+        OR (email_verified_at IS NOT NULL) = $2)
+  AND ($3::boolean IS NULL
+        OR (deletion_scheduled_at IS NOT NULL) = $3)
+ORDER BY created_at DESC, id
+LIMIT $4 OFFSET $5
+`
+
+type SearchUsersParams struct {
+	Search            *string `json:"search"`
+	Verified          *bool   `json:"verified"`
+	DeletionScheduled *bool   `json:"deletion_scheduled"`
+	Limit             int32   `json:"limit"`
+	Offset            int32   `json:"offset"`
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsers,
+		arg.Search,
+		arg.Verified,
+		arg.DeletionScheduled,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.PasswordHash,
+			&i.EmailVerifiedAt,
+			&i.DeletionScheduledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletionReminderSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const scheduleUserDeletion = `-- name: ScheduleUserDeletion :exec
-UPDATE users SET deletion_scheduled_at = $1, updated_at = $2 WHERE id = $3
+UPDATE users SET deletion_scheduled_at = $1, deletion_reminder_sent_at = NULL, updated_at = $2 WHERE id = $3
 `
 
 type ScheduleUserDeletionParams struct {
@@ -157,6 +307,27 @@ func (q *Queries) ScheduleUserDeletion(ctx context.Context, arg ScheduleUserDele
 	return err
 }
 
+const updateUserEmail = `-- name: UpdateUserEmail :exec
+UPDATE users SET email = $1, email_verified_at = $2, updated_at = $3 WHERE id = $4
+`
+
+type UpdateUserEmailParams struct {
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) error {
+	_, err := q.db.Exec(ctx, updateUserEmail,
+		arg.Email,
+		arg.EmailVerifiedAt,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const updateUserPassword = `-- name: UpdateUserPassword :exec
 UPDATE users SET password_hash = $1, updated_at = $2 WHERE id = $3
 `
@@ -171,3 +342,33 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 	_, err := q.db.Exec(ctx, updateUserPassword, arg.PasswordHash, arg.UpdatedAt, arg.ID)
 	return err
 }
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+UPDATE users
+SET name = COALESCE($1, name), updated_at = $2
+WHERE id = $3
+RETURNING id, name, email, password_hash, email_verified_at, deletion_scheduled_at, created_at, updated_at, deletion_reminder_sent_at
+`
+
+type UpdateUserProfileParams struct {
+	Name      *string   `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserProfile, arg.Name, arg.UpdatedAt, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.PasswordHash,
+		&i.EmailVerifiedAt,
+		&i.DeletionScheduledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletionReminderSentAt,
+	)
+	return i, err
+}
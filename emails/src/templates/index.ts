@@ -1,3 +1,14 @@
+export { AccountDeleted } from "./AccountDeleted";
+export { AccountDeletionReminder } from "./AccountDeletionReminder";
+export { AccountExists } from "./AccountExists";
+export { DataExportReady } from "./DataExportReady";
+export { EmailChangeConfirmation } from "./EmailChangeConfirmation";
+export { EmailChangeRequested } from "./EmailChangeRequested";
 export { EmailVerification } from "./EmailVerification";
+export { LoginLocked } from "./LoginLocked";
+export { MagicLink } from "./MagicLink";
+export { NewSignIn } from "./NewSignIn";
+export { OrganizationInvitation } from "./OrganizationInvitation";
+export { PasswordChanged } from "./PasswordChanged";
 export { PasswordReset } from "./PasswordReset";
 export { Welcome } from "./Welcome";
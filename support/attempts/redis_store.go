@@ -0,0 +1,73 @@
+// Package attempts tracks failed attempts in Redis so limits hold across
+// every API replica.
+package attempts
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+const keyPrefix = "attempts:"
+
+// RedisStore implements support.AttemptStore.
+//
+// Each key maps to two Redis keys: a failure counter whose expiry is pushed
+// back by every failure, and a lock that expires on its own.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a RedisStore using client.
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func failuresKey(key string) string {
+	return keyPrefix + "failures:" + key
+}
+
+func lockKey(key string) string {
+	return keyPrefix + "lock:" + key
+}
+
+func (s *RedisStore) Fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, failuresKey(key))
+		pipe.PExpire(ctx, failuresKey(key), window)
+		return nil
+	})
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to record failed attempt")
+	}
+	return incr.Val(), nil
+}
+
+func (s *RedisStore) Lock(ctx context.Context, key string, d time.Duration) error {
+	if err := s.client.Set(ctx, lockKey(key), 1, d).Err(); err != nil {
+		return eris.Wrap(err, "failed to lock key")
+	}
+	return nil
+}
+
+func (s *RedisStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, lockKey(key)).Result()
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to get lock ttl")
+	}
+	// PTTL reports a missing key as -2 and a key without expiry as -1
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, failuresKey(key), lockKey(key)).Err(); err != nil {
+		return eris.Wrap(err, "failed to reset attempts")
+	}
+	return nil
+}
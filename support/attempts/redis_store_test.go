@@ -0,0 +1,87 @@
+package attempts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupTest(t *testing.T) *RedisStore {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	container, err := testcontainers.Run(ctx, "redis:7-alpine",
+		testcontainers.WithExposedPorts("6379/tcp"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("6379/tcp")),
+	)
+	testcontainers.CleanupContainer(t, container)
+	require.NoError(t, err)
+
+	addr, err := container.PortEndpoint(ctx, "6379/tcp", "")
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedisStore(client)
+}
+
+func TestRedisStore(t *testing.T) {
+	store := setupTest(t)
+	ctx := context.Background()
+
+	t.Run("Fail_Counts", func(t *testing.T) {
+		for i := int64(1); i <= 3; i++ {
+			count, err := store.Fail(ctx, "count", time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, i, count)
+		}
+	})
+
+	t.Run("Fail_ForgetsAfterWindow", func(t *testing.T) {
+		_, err := store.Fail(ctx, "window", 50*time.Millisecond)
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		count, err := store.Fail(ctx, "window", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		require.NoError(t, store.Lock(ctx, "lock", time.Minute))
+
+		lockedFor, err := store.LockedFor(ctx, "lock")
+		require.NoError(t, err)
+		assert.InDelta(t, time.Minute, lockedFor, float64(time.Second))
+	})
+
+	t.Run("LockedFor_NotLocked", func(t *testing.T) {
+		lockedFor, err := store.LockedFor(ctx, "unlocked")
+		require.NoError(t, err)
+		assert.Zero(t, lockedFor)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		_, err := store.Fail(ctx, "reset", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, store.Lock(ctx, "reset", time.Minute))
+
+		require.NoError(t, store.Reset(ctx, "reset"))
+
+		lockedFor, err := store.LockedFor(ctx, "reset")
+		require.NoError(t, err)
+		assert.Zero(t, lockedFor)
+
+		count, err := store.Fail(ctx, "reset", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
@@ -0,0 +1,66 @@
+// Package breached checks passwords against a local copy of a breached
+// password list.
+package breached
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// prefixLength is the number of hex characters of the SHA-1 hash that
+// select a range file.
+const prefixLength = 5
+
+// RangeDir implements support.BreachedPasswords over a directory of range
+// files in the Have I Been Pwned k-anonymity format: one file per hash
+// prefix, named "<PREFIX>.txt", holding "<SUFFIX>:<COUNT>" lines with the
+// remaining 35 hex characters of each breached hash.
+//
+// Only the single range file for a password's prefix is read per lookup. A
+// missing range file means no breached password has that prefix.
+type RangeDir struct {
+	dir string
+}
+
+// NewRangeDir creates a RangeDir reading range files from dir.
+func NewRangeDir(dir string) *RangeDir {
+	return &RangeDir{dir: dir}
+}
+
+func (r *RangeDir) Contains(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	f, err := os.Open(filepath.Join(r.dir, prefix+".txt"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, eris.Wrap(err, "failed to open breached password range")
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		candidate, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(candidate, suffix) {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, eris.Wrap(err, "failed to read breached password range")
+	}
+
+	return false, nil
+}
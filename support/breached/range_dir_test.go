@@ -0,0 +1,48 @@
+package breached
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeDir(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	range5BAA6 := "003D68EB55068C33ACE09247EE4C639306B:3\r\n" +
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(range5BAA6), 0o600))
+	// SHA-1("Password") starts with 8BE3C; its suffix is not listed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "8BE3C.txt"), []byte("003D68EB55068C33ACE09247EE4C639306B:3\n"), 0o600))
+
+	checker := NewRangeDir(dir)
+
+	t.Run("Contains_Breached", func(t *testing.T) {
+		found, err := checker.Contains(ctx, "password")
+		require.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("Contains_NotListed", func(t *testing.T) {
+		found, err := checker.Contains(ctx, "Password")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Contains_MissingRangeFile", func(t *testing.T) {
+		found, err := checker.Contains(ctx, "correct horse battery staple")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Contains_UnreadableDir", func(t *testing.T) {
+		_, err := NewRangeDir(filepath.Join(dir, "5BAA6.txt")).Contains(ctx, "password")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the PostgreSQL SQLSTATE for unique_violation.
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err, or any error it wraps, is a
+// PostgreSQL unique violation on the named constraint. Services use it to
+// turn races that a prior existence check cannot rule out into domain errors.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == uniqueViolation && pgErr.ConstraintName == constraint
+}
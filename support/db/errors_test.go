@@ -0,0 +1,33 @@
+package db_test
+
+import (
+	"testing"
+
+	"go-reasonable-api/support/db"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	violation := &pgconn.PgError{Code: "23505", ConstraintName: "uq_users_email"}
+
+	t.Run("matches wrapped violation on constraint", func(t *testing.T) {
+		err := eris.Wrap(violation, "failed to update user email")
+		assert.True(t, db.IsUniqueViolation(err, "uq_users_email"))
+	})
+
+	t.Run("ignores other constraints", func(t *testing.T) {
+		assert.False(t, db.IsUniqueViolation(violation, "uq_auth_tokens_token_hash"))
+	})
+
+	t.Run("ignores other error codes", func(t *testing.T) {
+		err := &pgconn.PgError{Code: "23503", ConstraintName: "uq_users_email"}
+		assert.False(t, db.IsUniqueViolation(err, "uq_users_email"))
+	})
+
+	t.Run("ignores non-postgres errors", func(t *testing.T) {
+		assert.False(t, db.IsUniqueViolation(eris.New("boom"), "uq_users_email"))
+	})
+}
@@ -120,6 +120,10 @@ func Unauthorized(code, message string) *AppError {
 	return NewWithStatus(code, message, http.StatusUnauthorized)
 }
 
+func Forbidden(code, message string) *AppError {
+	return NewWithStatus(code, message, http.StatusForbidden)
+}
+
 func NotFound(code, message string) *AppError {
 	return NewWithStatus(code, message, http.StatusNotFound)
 }
@@ -136,6 +140,10 @@ func BadRequest(code, message string) *AppError {
 	return NewWithStatus(code, message, http.StatusBadRequest)
 }
 
+func TooManyRequests(code, message string) *AppError {
+	return NewWithStatus(code, message, http.StatusTooManyRequests)
+}
+
 func InternalError(code, message string) *AppError {
 	return NewWithStatus(code, message, http.StatusInternalServerError)
 }
@@ -1,7 +1,11 @@
 package bind
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"reflect"
 	"strings"
 
 	"go-reasonable-api/support/errors"
@@ -36,3 +40,82 @@ func RequiredParam(c *echo.Context, name string) (string, error) {
 	}
 	return value, nil
 }
+
+// MIMEApplicationMergePatchJSON is the media type of a JSON merge patch (RFC 7396).
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// NullableMembers is implemented by merge-patch requests whose members can be
+// removed by sending null. It returns the JSON names of those members.
+type NullableMembers interface {
+	NullableMembers() []string
+}
+
+// MergePatch binds a JSON merge patch to the given struct and validates it.
+// The request body must be a JSON object sent as application/merge-patch+json
+// or application/json. Members absent from the patch leave their pointer
+// fields nil. A null member is a validation error unless req lists it in
+// NullableMembers, since null means "remove" and most fields cannot be removed.
+func MergePatch(c *echo.Context, req any) error {
+	mediatype, _, _ := strings.Cut(c.Request().Header.Get(echo.HeaderContentType), ";")
+	switch strings.TrimSpace(mediatype) {
+	case MIMEApplicationMergePatchJSON, echo.MIMEApplicationJSON:
+	default:
+		return errors.NewWithStatus("UNSUPPORTED_MEDIA_TYPE", "content type must be "+MIMEApplicationMergePatchJSON, http.StatusUnsupportedMediaType)
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read request body")
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return errors.BadRequest("INVALID_MERGE_PATCH", "request body must be a JSON object")
+	}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		return errors.BadRequest("INVALID_MERGE_PATCH", "request body does not match the expected fields")
+	}
+
+	if details := nullMemberErrors(req, members); len(details) > 0 {
+		return errors.NewWithDetails("VALIDATION_ERROR", "validation failed", http.StatusBadRequest, details)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return eris.Wrap(err, "failed to validate request")
+	}
+
+	return nil
+}
+
+// nullMemberErrors reports the null members of a patch that req does not
+// allow to be removed, keyed by struct field name like validator errors.
+func nullMemberErrors(req any, members map[string]json.RawMessage) map[string]any {
+	nullable := map[string]bool{}
+	if n, ok := req.(NullableMembers); ok {
+		for _, name := range n.NullableMembers() {
+			nullable[name] = true
+		}
+	}
+
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	details := make(map[string]any)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" || nullable[name] {
+			continue
+		}
+		if raw, ok := members[name]; ok && string(raw) == "null" {
+			details[field.Name] = []string{"cannot be null"}
+		}
+	}
+	return details
+}
@@ -22,4 +22,10 @@
 // The reqctx subpackage provides typed accessors for request-scoped values
 // (user ID, request ID, auth token). Middleware populates these; handlers
 // and services read them via context.Context.
+//
+// # Session Cookies
+//
+// The sessioncookie subpackage sets, reads and clears the session and CSRF
+// cookies used when session_cookie.enabled is set, and checks the CSRF
+// header on cookie-authenticated requests.
 package http
@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"strconv"
 
 	"go-reasonable-api/support/errors"
 	"go-reasonable-api/support/http/reqctx"
@@ -19,7 +20,8 @@ type ErrorResponse struct {
 }
 
 // ErrorHandler is Echo's custom error handler. It transforms errors into
-// consistent JSON responses and reports 5xx errors to Sentry.
+// consistent JSON responses and reports 5xx errors to Sentry. A
+// "retry_after" detail (in seconds) is also sent as the Retry-After header.
 func ErrorHandler(c *echo.Context, err error) {
 	if c.Response().(*echo.Response).Committed {
 		return
@@ -36,6 +38,9 @@ func ErrorHandler(c *echo.Context, err error) {
 			Message: ae.Message,
 			Details: ae.Details,
 		}
+		if retryAfter, ok := ae.Details["retry_after"].(int); ok {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
 	} else if eris.As(err, &he) {
 		statusCode = he.Code
 		response = ErrorResponse{
@@ -5,7 +5,9 @@ import (
 
 	"go-reasonable-api/app/errors"
 	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/config"
 	"go-reasonable-api/support/http/reqctx"
+	"go-reasonable-api/support/http/sessioncookie"
 	"go-reasonable-api/support/logger"
 
 	"github.com/google/uuid"
@@ -13,12 +15,33 @@ import (
 	"github.com/rotisserie/eris"
 )
 
-func AuthMiddleware(sessionService services.SessionService) echo.MiddlewareFunc {
+// AuthMiddleware requires a bearer token: either a session token or a
+// personal API key, told apart by services.APIKeyPrefix. Requests made
+// with an API key carry its scopes in the request context; see
+// RequireScope and RequireSession.
+//
+// Without an Authorization header, the session cookie is accepted when
+// cookie sessions are enabled, and unsafe requests must then pass the
+// CSRF check; see sessioncookie.
+func AuthMiddleware(sessionService services.SessionService, apiKeyService services.APIKeyService, cookie config.SessionCookieConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
-				return errors.ErrMissingAuthHeader
+				token, ok := sessioncookie.Token(c, cookie)
+				if !ok {
+					return errors.ErrMissingAuthHeader
+				}
+
+				if !sessioncookie.ValidCSRF(c, cookie) {
+					return errors.ErrInvalidCSRFToken
+				}
+
+				if err := authenticateSession(c, sessionService, token); err != nil {
+					return err
+				}
+
+				return next(c)
 			}
 
 			parts := strings.Split(authHeader, " ")
@@ -26,24 +49,23 @@ func AuthMiddleware(sessionService services.SessionService) echo.MiddlewareFunc
 				return errors.ErrInvalidAuthFormat
 			}
 
-			token := parts[1]
-			authToken, err := sessionService.ValidateToken(c.Request().Context(), token)
-			if err != nil {
-				return eris.Wrap(err, "failed to validate token")
+			if err := authenticate(c, sessionService, apiKeyService, parts[1]); err != nil {
+				return err
 			}
 
-			setAuthContext(c, authToken.UserID, token)
-
 			return next(c)
 		}
 	}
 }
 
-func OptionalAuthMiddleware(sessionService services.SessionService) echo.MiddlewareFunc {
+func OptionalAuthMiddleware(sessionService services.SessionService, apiKeyService services.APIKeyService, cookie config.SessionCookieConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
+				if token, ok := sessioncookie.Token(c, cookie); ok && sessioncookie.ValidCSRF(c, cookie) {
+					_ = authenticateSession(c, sessionService, token)
+				}
 				return next(c)
 			}
 
@@ -52,28 +74,63 @@ func OptionalAuthMiddleware(sessionService services.SessionService) echo.Middlew
 				return next(c)
 			}
 
-			token := parts[1]
-			authToken, err := sessionService.ValidateToken(c.Request().Context(), token)
-			if err != nil {
-				return next(c)
-			}
-
-			setAuthContext(c, authToken.UserID, token)
+			_ = authenticate(c, sessionService, apiKeyService, parts[1])
 
 			return next(c)
 		}
 	}
 }
 
-// setAuthContext sets the authenticated user's ID and token in the request context.
-// It also enriches the logger with the user_id for request tracing.
-func setAuthContext(c *echo.Context, userID uuid.UUID, token string) {
-	reqctx.SetUserID(c, userID)
+// authenticate validates token as an API key or a session token and sets
+// the request context for it.
+func authenticate(c *echo.Context, sessionService services.SessionService, apiKeyService services.APIKeyService, token string) error {
+	ctx := c.Request().Context()
+
+	if strings.HasPrefix(token, services.APIKeyPrefix) {
+		apiKey, err := apiKeyService.Validate(ctx, token)
+		if err != nil {
+			return eris.Wrap(err, "failed to validate api key")
+		}
+
+		reqctx.SetAPIKeyID(c, apiKey.ID)
+		reqctx.SetScopes(c, apiKey.Scopes)
+		setAuthContext(c, apiKey.UserID)
+		return nil
+	}
+
+	return authenticateSession(c, sessionService, token)
+}
+
+// authenticateSession validates token as a session token and sets the
+// request context for it.
+func authenticateSession(c *echo.Context, sessionService services.SessionService, token string) error {
+	authToken, err := sessionService.ValidateToken(c.Request().Context(), token)
+	if err != nil {
+		return eris.Wrap(err, "failed to validate token")
+	}
+
+	reqctx.SetSessionID(c, authToken.ID)
 	reqctx.SetToken(c, token)
+	if authToken.ImpersonatorID != nil {
+		reqctx.SetImpersonatorID(c, *authToken.ImpersonatorID)
+	}
+	setAuthContext(c, authToken.UserID)
+	return nil
+}
+
+// setAuthContext sets the authenticated user's ID in the request context. It
+// also enriches the logger with the user_id, and the impersonator_id for
+// impersonation sessions, for request tracing.
+func setAuthContext(c *echo.Context, userID uuid.UUID) {
+	reqctx.SetUserID(c, userID)
 
 	userIDStr := userID.String()
 	if reqLogger := reqctx.Logger(c); reqLogger != nil {
-		enrichedLogger := reqLogger.With().Str("user_id", userIDStr).Logger()
+		logCtx := reqLogger.With().Str("user_id", userIDStr)
+		if impersonatorID, ok := reqctx.GetImpersonatorID(c); ok {
+			logCtx = logCtx.Str("impersonator_id", impersonatorID.String())
+		}
+		enrichedLogger := logCtx.Logger()
 		reqctx.SetLogger(c, &enrichedLogger)
 
 		ctx := c.Request().Context()
@@ -82,3 +139,46 @@ func setAuthContext(c *echo.Context, userID uuid.UUID, token string) {
 		c.SetRequest(c.Request().WithContext(ctx))
 	}
 }
+
+// RequireScope lets requests authenticated with an API key through only
+// when the key was granted scope. Session-authenticated requests always
+// pass. Use it after AuthMiddleware.
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if !reqctx.HasScope(c, scope) {
+				return errors.ErrInsufficientScope.WithDetail("scope", scope)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireSession rejects requests authenticated with an API key, for
+// endpoints that manage the account or its credentials. Use it after
+// AuthMiddleware.
+func RequireSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if _, ok := reqctx.GetAPIKeyID(c); ok {
+				return errors.ErrSessionRequired
+			}
+			return next(c)
+		}
+	}
+}
+
+// RejectImpersonation blocks impersonation sessions from endpoints that
+// change credentials, delete the account or manage sessions, so an
+// administrator acting as a user cannot lock them out. Use it after
+// AuthMiddleware.
+func RejectImpersonation() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if _, ok := reqctx.GetImpersonatorID(c); ok {
+				return errors.ErrImpersonationForbidden
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/repositories"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/app/services"
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/http/middlewares"
+	"go-reasonable-api/support/tokencache"
+	"go-reasonable-api/support/tokenhash"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestAuthMiddleware_SessionCookie(t *testing.T) {
+	cookie := config.SessionCookieConfig{
+		Enabled:        true,
+		Name:           "session",
+		SameSite:       "lax",
+		CSRFCookieName: "csrf_token",
+		CSRFHeaderName: "X-CSRF-Token",
+	}
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		method        string
+		cookie        config.SessionCookieConfig
+		setupRequest  func(*http.Request)
+		validates     bool
+		expectedError error
+	}{
+		{
+			name:   "accepts session cookie on safe methods without csrf token",
+			method: http.MethodGet,
+			cookie: cookie,
+			setupRequest: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "session", Value: "token"})
+			},
+			validates: true,
+		},
+		{
+			name:   "accepts session cookie with matching csrf token",
+			method: http.MethodPost,
+			cookie: cookie,
+			setupRequest: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "session", Value: "token"})
+				req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "csrf"})
+				req.Header.Set("X-CSRF-Token", "csrf")
+			},
+			validates: true,
+		},
+		{
+			name:   "rejects unsafe method without csrf token",
+			method: http.MethodDelete,
+			cookie: cookie,
+			setupRequest: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "session", Value: "token"})
+				req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "csrf"})
+			},
+			expectedError: errors.ErrInvalidCSRFToken,
+		},
+		{
+			name:   "rejects mismatched csrf token",
+			method: http.MethodPost,
+			cookie: cookie,
+			setupRequest: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "session", Value: "token"})
+				req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "csrf"})
+				req.Header.Set("X-CSRF-Token", "other")
+			},
+			expectedError: errors.ErrInvalidCSRFToken,
+		},
+		{
+			name:   "does not require csrf token with authorization header",
+			method: http.MethodPost,
+			cookie: cookie,
+			setupRequest: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "session", Value: "other"})
+				req.Header.Set("Authorization", "Bearer token")
+			},
+			validates: true,
+		},
+		{
+			name:   "ignores session cookie when disabled",
+			method: http.MethodGet,
+			cookie: config.SessionCookieConfig{Name: "session"},
+			setupRequest: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "session", Value: "token"})
+			},
+			expectedError: errors.ErrMissingAuthHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionSvc := mocks.NewMockSessionService(t)
+			if tt.validates {
+				mockSessionSvc.EXPECT().ValidateToken(mock.Anything, "token").
+					Return(&sqlcgen.AuthToken{ID: uuid.New(), UserID: userID}, nil)
+			}
+
+			called := false
+			handler := middlewares.AuthMiddleware(mockSessionSvc, nil, tt.cookie)(func(c *echo.Context) error {
+				called = true
+				return c.NoContent(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(tt.method, "/users/me", nil)
+			tt.setupRequest(req)
+			err := handler(echo.New().NewContext(req, httptest.NewRecorder()))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.False(t, called)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, called)
+			}
+		})
+	}
+}
+
+// dbRoundTrip stands in for a GetByHash query against Postgres on the same
+// network, so the benchmarks measure what the cache saves rather than
+// Postgres itself.
+const dbRoundTrip = 500 * time.Microsecond
+
+// authTokenRepo serves a single token, waiting dbRoundTrip on every lookup.
+type authTokenRepo struct {
+	repositories.AuthTokenRepository
+	token *sqlcgen.AuthToken
+}
+
+func (r *authTokenRepo) GetByHash(_ context.Context, tokenHashes []string) (*sqlcgen.AuthToken, error) {
+	time.Sleep(dbRoundTrip)
+	for _, tokenHash := range tokenHashes {
+		if tokenHash == r.token.TokenHash {
+			token := *r.token
+			return &token, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (r *authTokenRepo) Touch(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func setupRedis(b *testing.B) *redis.Client {
+	ctx := context.Background()
+
+	container, err := testcontainers.Run(ctx, "redis:7-alpine",
+		testcontainers.WithExposedPorts("6379/tcp"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("6379/tcp")),
+	)
+	testcontainers.CleanupContainer(b, container)
+	if err != nil {
+		b.Skipf("redis unavailable: %v", err)
+	}
+
+	addr, err := container.PortEndpoint(ctx, "6379/tcp", "")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	b.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func benchmarkAuthMiddleware(b *testing.B, client *redis.Client, cacheCfg config.TokenCacheConfig) {
+	cfg := &config.Config{
+		Auth:       config.AuthConfig{Secret: "benchmark-secret"},
+		TokenCache: cacheCfg,
+	}
+	tokenHasher := tokenhash.NewKeyring(cfg)
+
+	now := time.Now().UTC()
+	repo := &authTokenRepo{token: &sqlcgen.AuthToken{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		TokenHash:  tokenHasher.Hash("token"),
+		ExpiresAt:  now.Add(time.Hour),
+		CreatedAt:  now,
+		LastUsedAt: &now,
+	}}
+
+	sessionService := services.NewSessionService(cfg, nil, nil, repo, nil, nil, nil, nil, nil, tokenHasher, tokencache.NewRedisCache(client, cfg))
+	handler := middlewares.AuthMiddleware(sessionService, nil, cfg.SessionCookie)(func(c *echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	e := echo.New()
+	for b.Loop() {
+		req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		if err := handler(e.NewContext(req, httptest.NewRecorder())); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAuthMiddleware(b *testing.B) {
+	b.Run("Uncached", func(b *testing.B) {
+		benchmarkAuthMiddleware(b, nil, config.TokenCacheConfig{})
+	})
+
+	b.Run("Redis", func(b *testing.B) {
+		benchmarkAuthMiddleware(b, setupRedis(b), config.TokenCacheConfig{
+			Enabled: true,
+			TTL:     time.Minute,
+		})
+	})
+
+	b.Run("RedisWithLocalLRU", func(b *testing.B) {
+		benchmarkAuthMiddleware(b, setupRedis(b), config.TokenCacheConfig{
+			Enabled:   true,
+			TTL:       time.Minute,
+			LocalTTL:  time.Minute,
+			LocalSize: 1000,
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/http/bind"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// RequireOrgRole resolves the :org_id path parameter and lets a request
+// through only when the authenticated user holds at least role in that
+// organization. Non-members get ErrOrganizationNotFound. Handlers read the
+// organization and role back with reqctx.GetOrganizationID and
+// reqctx.GetOrganizationRole. Use it after AuthMiddleware.
+func RequireOrgRole(organizationService services.OrganizationService, role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			userID, ok := reqctx.GetUserID(c)
+			if !ok {
+				return errors.ErrInvalidToken
+			}
+
+			param, err := bind.RequiredParam(c, "org_id")
+			if err != nil {
+				return err
+			}
+
+			organizationID, err := uuid.Parse(param)
+			if err != nil {
+				return errors.ErrInvalidOrganizationID
+			}
+
+			held, err := organizationService.GetMemberRole(c.Request().Context(), organizationID, userID)
+			if err != nil {
+				return eris.Wrap(err, "failed to get organization role")
+			}
+
+			if !services.OrgRoleAtLeast(held, role) {
+				return errors.ErrPermissionDenied.WithDetail("role", role)
+			}
+
+			reqctx.SetOrganization(c, organizationID, held)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	mocks "go-reasonable-api/app/mocks/services"
+	"go-reasonable-api/support/http/middlewares"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireOrgRole(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	tests := []struct {
+		name          string
+		param         string
+		required      string
+		setupMock     func(*mocks.MockOrganizationService)
+		expectedError error
+	}{
+		{
+			name:          "rejects invalid organization id",
+			param:         "not-a-uuid",
+			required:      services.OrgRoleMember,
+			setupMock:     func(orgSvc *mocks.MockOrganizationService) {},
+			expectedError: errors.ErrInvalidOrganizationID,
+		},
+		{
+			name:     "rejects non-members",
+			param:    orgID.String(),
+			required: services.OrgRoleMember,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return("", errors.ErrOrganizationNotFound)
+			},
+			expectedError: errors.ErrOrganizationNotFound,
+		},
+		{
+			name:     "rejects members below the required role",
+			param:    orgID.String(),
+			required: services.OrgRoleAdmin,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(services.OrgRoleMember, nil)
+			},
+			expectedError: errors.ErrPermissionDenied,
+		},
+		{
+			name:     "allows higher roles",
+			param:    orgID.String(),
+			required: services.OrgRoleAdmin,
+			setupMock: func(orgSvc *mocks.MockOrganizationService) {
+				orgSvc.EXPECT().GetMemberRole(mock.Anything, orgID, userID).Return(services.OrgRoleOwner, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrgSvc := mocks.NewMockOrganizationService(t)
+			tt.setupMock(mockOrgSvc)
+
+			var gotOrgID uuid.UUID
+			var gotRole string
+			handler := middlewares.RequireOrgRole(mockOrgSvc, tt.required)(func(c *echo.Context) error {
+				gotOrgID, _ = reqctx.GetOrganizationID(c)
+				gotRole, _ = reqctx.GetOrganizationRole(c)
+				return c.NoContent(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/organizations/"+tt.param, nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			c.SetPathValues(echo.PathValues{{Name: "org_id", Value: tt.param}})
+			reqctx.SetUserID(c, userID)
+
+			err := handler(c)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, uuid.Nil, gotOrgID)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, orgID, gotOrgID)
+				assert.Equal(t, services.OrgRoleOwner, gotRole)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"slices"
+
+	"go-reasonable-api/app/errors"
+	"go-reasonable-api/app/interfaces/services"
+	"go-reasonable-api/support/http/reqctx"
+
+	"github.com/labstack/echo/v5"
+	"github.com/rotisserie/eris"
+)
+
+// RequirePermission lets a request through only when the authenticated
+// user holds every one of permissions through their roles. Use it after
+// AuthMiddleware.
+func RequirePermission(permissionService services.PermissionService, permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if err := loadAccess(c, permissionService); err != nil {
+				return err
+			}
+
+			granted, _ := reqctx.GetPermissions(c)
+			for _, permission := range permissions {
+				if !slices.Contains(granted, permission) {
+					return errors.ErrPermissionDenied.WithDetail("permission", permission)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireRole lets a request through only when the authenticated user
+// holds at least one of roles. Prefer RequirePermission so that roles can
+// be reshaped without touching routes. Use it after AuthMiddleware.
+func RequireRole(permissionService services.PermissionService, roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if err := loadAccess(c, permissionService); err != nil {
+				return err
+			}
+
+			held, _ := reqctx.GetRoles(c)
+			for _, role := range roles {
+				if slices.Contains(held, role) {
+					return next(c)
+				}
+			}
+			return errors.ErrPermissionDenied.WithDetail("roles", roles)
+		}
+	}
+}
+
+// loadAccess loads the authenticated user's roles and permissions into the
+// request context unless an earlier middleware already did.
+func loadAccess(c *echo.Context, permissionService services.PermissionService) error {
+	if _, ok := reqctx.GetPermissions(c); ok {
+		return nil
+	}
+
+	userID, ok := reqctx.GetUserID(c)
+	if !ok {
+		return errors.ErrInvalidToken
+	}
+
+	access, err := permissionService.GetAccess(c.Request().Context(), userID)
+	if err != nil {
+		return eris.Wrap(err, "failed to load permissions")
+	}
+
+	reqctx.SetAccess(c, access.Roles, access.Permissions)
+	return nil
+}
@@ -1,16 +1,26 @@
 package reqctx
 
 import (
+	"slices"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v5"
 	"github.com/rs/zerolog"
 )
 
 const (
-	contextKeyUserID    = "user_id"
-	contextKeyToken     = "token"
-	contextKeyRequestID = "request_id"
-	contextKeyLogger    = "logger"
+	contextKeyUserID       = "user_id"
+	contextKeyToken        = "token"
+	contextKeySessionID    = "session_id"
+	contextKeyAPIKeyID     = "api_key_id"
+	contextKeyImpersonator = "impersonator_id"
+	contextKeyScopes       = "scopes"
+	contextKeyRoles        = "roles"
+	contextKeyPermissions  = "permissions"
+	contextKeyOrgID        = "organization_id"
+	contextKeyOrgRole      = "organization_role"
+	contextKeyRequestID    = "request_id"
+	contextKeyLogger       = "logger"
 )
 
 func SetUserID(c *echo.Context, userID uuid.UUID) {
@@ -31,6 +41,98 @@ func GetToken(c *echo.Context) (string, bool) {
 	return token, ok
 }
 
+func SetSessionID(c *echo.Context, sessionID uuid.UUID) {
+	c.Set(contextKeySessionID, sessionID)
+}
+
+// GetSessionID returns the ID of the auth token used to authenticate the request.
+func GetSessionID(c *echo.Context) (uuid.UUID, bool) {
+	sessionID, ok := c.Get(contextKeySessionID).(uuid.UUID)
+	return sessionID, ok
+}
+
+func SetAPIKeyID(c *echo.Context, apiKeyID uuid.UUID) {
+	c.Set(contextKeyAPIKeyID, apiKeyID)
+}
+
+// GetAPIKeyID returns the ID of the API key used to authenticate the request.
+// It is not set for requests authenticated with a session token.
+func GetAPIKeyID(c *echo.Context) (uuid.UUID, bool) {
+	apiKeyID, ok := c.Get(contextKeyAPIKeyID).(uuid.UUID)
+	return apiKeyID, ok
+}
+
+func SetImpersonatorID(c *echo.Context, impersonatorID uuid.UUID) {
+	c.Set(contextKeyImpersonator, impersonatorID)
+}
+
+// GetImpersonatorID returns the ID of the administrator acting as the
+// authenticated user. ok is false unless the session is an impersonation.
+func GetImpersonatorID(c *echo.Context) (uuid.UUID, bool) {
+	impersonatorID, ok := c.Get(contextKeyImpersonator).(uuid.UUID)
+	return impersonatorID, ok
+}
+
+func SetScopes(c *echo.Context, scopes []string) {
+	c.Set(contextKeyScopes, scopes)
+}
+
+// GetScopes returns the scopes of the API key used to authenticate the
+// request. ok is false for requests authenticated with a session token,
+// which are not limited by scopes.
+func GetScopes(c *echo.Context) ([]string, bool) {
+	scopes, ok := c.Get(contextKeyScopes).([]string)
+	return scopes, ok
+}
+
+// HasScope reports whether the request may act with scope: always for
+// session tokens, and for API keys only when the key was granted scope.
+func HasScope(c *echo.Context, scope string) bool {
+	scopes, ok := GetScopes(c)
+	return !ok || slices.Contains(scopes, scope)
+}
+
+// SetAccess stores the authenticated user's roles and permissions for the
+// rest of the request.
+func SetAccess(c *echo.Context, roles, permissions []string) {
+	c.Set(contextKeyRoles, roles)
+	c.Set(contextKeyPermissions, permissions)
+}
+
+// GetRoles returns the authenticated user's roles. ok is false until they
+// have been loaded with SetAccess.
+func GetRoles(c *echo.Context) ([]string, bool) {
+	roles, ok := c.Get(contextKeyRoles).([]string)
+	return roles, ok
+}
+
+// GetPermissions returns the authenticated user's permissions. ok is false
+// until they have been loaded with SetAccess.
+func GetPermissions(c *echo.Context) ([]string, bool) {
+	permissions, ok := c.Get(contextKeyPermissions).([]string)
+	return permissions, ok
+}
+
+// SetOrganization stores the organization named in the request path and the
+// authenticated user's role in it.
+func SetOrganization(c *echo.Context, organizationID uuid.UUID, role string) {
+	c.Set(contextKeyOrgID, organizationID)
+	c.Set(contextKeyOrgRole, role)
+}
+
+// GetOrganizationID returns the organization resolved by RequireOrgRole.
+func GetOrganizationID(c *echo.Context) (uuid.UUID, bool) {
+	organizationID, ok := c.Get(contextKeyOrgID).(uuid.UUID)
+	return organizationID, ok
+}
+
+// GetOrganizationRole returns the authenticated user's role in the
+// organization resolved by RequireOrgRole.
+func GetOrganizationRole(c *echo.Context) (string, bool) {
+	role, ok := c.Get(contextKeyOrgRole).(string)
+	return role, ok
+}
+
 func SetRequestID(c *echo.Context, requestID string) {
 	c.Set(contextKeyRequestID, requestID)
 }
@@ -18,10 +18,23 @@ type Router struct {
 	logger                   *zerolog.Logger
 	userHandler              *handlers.UserHandler
 	sessionHandler           *handlers.SessionHandler
+	twoFactorHandler         *handlers.TwoFactorHandler
+	passkeyHandler           *handlers.PasskeyHandler
+	oidcHandler              *handlers.OIDCHandler
+	magicLinkHandler         *handlers.MagicLinkHandler
 	passwordResetHandler     *handlers.PasswordResetHandler
 	emailVerificationHandler *handlers.EmailVerificationHandler
+	emailChangeHandler       *handlers.EmailChangeHandler
+	apiKeyHandler            *handlers.APIKeyHandler
+	adminHandler             *handlers.AdminHandler
+	impersonationHandler     *handlers.ImpersonationHandler
+	organizationHandler      *handlers.OrganizationHandler
+	dataExportHandler        *handlers.DataExportHandler
 	healthHandler            *handlers.HealthHandler
 	sessionService           services.SessionService
+	apiKeyService            services.APIKeyService
+	permissionService        services.PermissionService
+	organizationService      services.OrganizationService
 }
 
 func NewRouter(
@@ -29,10 +42,23 @@ func NewRouter(
 	logger *zerolog.Logger,
 	userHandler *handlers.UserHandler,
 	sessionHandler *handlers.SessionHandler,
+	twoFactorHandler *handlers.TwoFactorHandler,
+	passkeyHandler *handlers.PasskeyHandler,
+	oidcHandler *handlers.OIDCHandler,
+	magicLinkHandler *handlers.MagicLinkHandler,
 	passwordResetHandler *handlers.PasswordResetHandler,
 	emailVerificationHandler *handlers.EmailVerificationHandler,
+	emailChangeHandler *handlers.EmailChangeHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	adminHandler *handlers.AdminHandler,
+	impersonationHandler *handlers.ImpersonationHandler,
+	organizationHandler *handlers.OrganizationHandler,
+	dataExportHandler *handlers.DataExportHandler,
 	healthHandler *handlers.HealthHandler,
 	sessionService services.SessionService,
+	apiKeyService services.APIKeyService,
+	permissionService services.PermissionService,
+	organizationService services.OrganizationService,
 ) *Router {
 	return &Router{
 		echo:                     echo.New(),
@@ -40,10 +66,23 @@ func NewRouter(
 		logger:                   logger,
 		userHandler:              userHandler,
 		sessionHandler:           sessionHandler,
+		twoFactorHandler:         twoFactorHandler,
+		passkeyHandler:           passkeyHandler,
+		oidcHandler:              oidcHandler,
+		magicLinkHandler:         magicLinkHandler,
 		passwordResetHandler:     passwordResetHandler,
 		emailVerificationHandler: emailVerificationHandler,
+		emailChangeHandler:       emailChangeHandler,
+		apiKeyHandler:            apiKeyHandler,
+		adminHandler:             adminHandler,
+		impersonationHandler:     impersonationHandler,
+		organizationHandler:      organizationHandler,
+		dataExportHandler:        dataExportHandler,
 		healthHandler:            healthHandler,
 		sessionService:           sessionService,
+		apiKeyService:            apiKeyService,
+		permissionService:        permissionService,
+		organizationService:      organizationService,
 	}
 }
 
@@ -59,11 +98,25 @@ func (r *Router) Setup() *echo.Echo {
 	r.setupMiddlewares()
 	routes.SetupRoutes(
 		r.echo,
+		r.config,
 		r.sessionService,
+		r.apiKeyService,
+		r.permissionService,
+		r.organizationService,
 		r.userHandler,
 		r.sessionHandler,
+		r.twoFactorHandler,
+		r.passkeyHandler,
+		r.oidcHandler,
+		r.magicLinkHandler,
 		r.passwordResetHandler,
 		r.emailVerificationHandler,
+		r.emailChangeHandler,
+		r.apiKeyHandler,
+		r.adminHandler,
+		r.impersonationHandler,
+		r.organizationHandler,
+		r.dataExportHandler,
 		r.healthHandler,
 	)
 	return r.echo
@@ -0,0 +1,156 @@
+// Package sessioncookie carries session tokens in cookies for browser
+// clients, so the frontend never has to keep the token in storage that
+// scripts can read.
+//
+// Cookie sessions are protected from cross-site request forgery with a
+// double-submit token: next to the HttpOnly session cookie the server sets
+// a CSRF cookie the frontend can read, and unsafe requests authenticated by
+// the session cookie must repeat its value in the CSRF header. Other sites
+// can make the browser send both cookies but cannot read them to fill in
+// the header.
+//
+// The refresh token gets its own HttpOnly cookie, sent only to RefreshPath,
+// so ordinary requests never carry it.
+//
+// Cookies are opt-in per request: a client asks for them by sending the
+// request header named in the config, so mobile and other bearer clients
+// keep receiving tokens in response bodies when cookie sessions are enabled.
+package sessioncookie
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-reasonable-api/support/config"
+
+	"github.com/labstack/echo/v5"
+)
+
+// RefreshPath is the only path the refresh cookie is sent to.
+const RefreshPath = "/sessions/refresh"
+
+// Requested reports whether the client asked for its session in cookies
+// rather than in the response body.
+func Requested(c *echo.Context, cfg config.SessionCookieConfig) bool {
+	if !cfg.Enabled {
+		return false
+	}
+
+	requested, err := strconv.ParseBool(c.Request().Header.Get(cfg.RequestHeaderName))
+	return err == nil && requested
+}
+
+// Set stores token in the session cookie until expiresAt, along with a new
+// CSRF token. It does nothing unless cookie sessions are enabled.
+func Set(c *echo.Context, cfg config.SessionCookieConfig, token string, expiresAt time.Time) {
+	if !cfg.Enabled {
+		return
+	}
+
+	c.SetCookie(newCookie(cfg, cfg.Name, token, expiresAt, true))
+	c.SetCookie(newCookie(cfg, cfg.CSRFCookieName, rand.Text(), expiresAt, false))
+}
+
+// SetRefresh stores token in the refresh cookie until expiresAt. It does
+// nothing unless cookie sessions are enabled and there is a refresh token.
+func SetRefresh(c *echo.Context, cfg config.SessionCookieConfig, token string, expiresAt *time.Time) {
+	if !cfg.Enabled || token == "" || expiresAt == nil {
+		return
+	}
+
+	c.SetCookie(newRefreshCookie(cfg, token, *expiresAt))
+}
+
+// Clear expires the session, refresh and CSRF cookies. It does nothing
+// unless cookie sessions are enabled.
+func Clear(c *echo.Context, cfg config.SessionCookieConfig) {
+	if !cfg.Enabled {
+		return
+	}
+
+	for _, cookie := range []*http.Cookie{
+		newCookie(cfg, cfg.Name, "", time.Unix(0, 0), true),
+		newRefreshCookie(cfg, "", time.Unix(0, 0)),
+		newCookie(cfg, cfg.CSRFCookieName, "", time.Unix(0, 0), false),
+	} {
+		cookie.MaxAge = -1
+		c.SetCookie(cookie)
+	}
+}
+
+// Token returns the session token sent in the session cookie.
+func Token(c *echo.Context, cfg config.SessionCookieConfig) (string, bool) {
+	if !cfg.Enabled {
+		return "", false
+	}
+
+	cookie, err := c.Cookie(cfg.Name)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return cookie.Value, true
+}
+
+// RefreshToken returns the refresh token sent in the refresh cookie.
+func RefreshToken(c *echo.Context, cfg config.SessionCookieConfig) (string, bool) {
+	if !cfg.Enabled {
+		return "", false
+	}
+
+	cookie, err := c.Cookie(cfg.RefreshCookieName)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return cookie.Value, true
+}
+
+// ValidCSRF reports whether a request authenticated by the session cookie
+// may proceed: safe methods always may, unsafe ones only when the CSRF
+// header matches the CSRF cookie.
+func ValidCSRF(c *echo.Context, cfg config.SessionCookieConfig) bool {
+	switch c.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+
+	cookie, err := c.Cookie(cfg.CSRFCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	header := c.Request().Header.Get(cfg.CSRFHeaderName)
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) == 1
+}
+
+func newCookie(cfg config.SessionCookieConfig, name, value string, expiresAt time.Time, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   cfg.Domain,
+		Expires:  expiresAt,
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: sameSite(cfg.SameSite),
+	}
+}
+
+func newRefreshCookie(cfg config.SessionCookieConfig, value string, expiresAt time.Time) *http.Cookie {
+	cookie := newCookie(cfg, cfg.RefreshCookieName, value, expiresAt, true)
+	cookie.Path = RefreshPath
+	return cookie
+}
+
+func sameSite(policy string) http.SameSite {
+	switch policy {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
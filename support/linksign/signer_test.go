@@ -0,0 +1,58 @@
+package linksign
+
+import (
+	"testing"
+	"time"
+
+	"go-reasonable-api/support/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSigner(secret string, previous ...string) *Signer {
+	return NewSigner(&config.Config{
+		Auth: config.AuthConfig{Secret: secret, PreviousSecrets: previous},
+	})
+}
+
+func TestSigner(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("Verify_AcceptsOwnSignature", func(t *testing.T) {
+		signer := newSigner("secret-a")
+		signature := signer.Sign("download", "subject", expiresAt)
+
+		assert.True(t, signer.Verify("download", "subject", expiresAt, signature))
+	})
+
+	t.Run("Verify_RejectsChangedArguments", func(t *testing.T) {
+		signer := newSigner("secret-a")
+		signature := signer.Sign("download", "subject", expiresAt)
+
+		assert.False(t, signer.Verify("cancel", "subject", expiresAt, signature))
+		assert.False(t, signer.Verify("download", "other", expiresAt, signature))
+		assert.False(t, signer.Verify("download", "subject", expiresAt.Add(time.Hour), signature))
+		assert.False(t, signer.Verify("download", "subject", expiresAt, signature+"x"))
+		assert.False(t, newSigner("secret-b").Verify("download", "subject", expiresAt, signature))
+	})
+
+	t.Run("Verify_RejectsExpired", func(t *testing.T) {
+		signer := newSigner("secret-a")
+		signature := signer.Sign("download", "subject", expiresAt)
+		signer.now = func() time.Time { return expiresAt }
+
+		assert.False(t, signer.Verify("download", "subject", expiresAt, signature))
+	})
+
+	t.Run("Verify_AcceptsPreviousSecrets", func(t *testing.T) {
+		signature := newSigner("secret-a").Sign("download", "subject", expiresAt)
+
+		assert.True(t, newSigner("secret-b", "secret-a").Verify("download", "subject", expiresAt, signature))
+	})
+
+	t.Run("Sign_UsesCurrentSecret", func(t *testing.T) {
+		rotated := newSigner("secret-b", "secret-a")
+
+		assert.Equal(t, newSigner("secret-b").Sign("download", "subject", expiresAt), rotated.Sign("download", "subject", expiresAt))
+	})
+}
@@ -0,0 +1,215 @@
+// Package oidctest provides an in-process OpenID provider, so the
+// authorization code flow can be tested without a real identity provider.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+const keyID = "test-key"
+
+// User is the identity the provider asserts when authorizing a request.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type grant struct {
+	user          User
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Server is an OpenID provider that signs RS256 ID tokens and requires PKCE.
+// Call Close when done.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+// NewServer starts a provider that accepts one registered client.
+func NewServer(clientID, clientSecret string) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		grants:       make(map[string]grant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("POST /token", s.handleToken)
+	mux.HandleFunc("GET /jwks", s.handleJWKS)
+	s.Server = httptest.NewServer(mux)
+
+	return s, nil
+}
+
+// Issuer returns the issuer identifier to configure on the relying party.
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// Authorize plays the browser and the provider's consent screen: it takes the
+// authorization URL built by the relying party, signs in as user, and returns
+// the code and state the provider would redirect back with.
+func (s *Server) Authorize(authURL string, user User) (code, state string, err error) {
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := parsed.Query()
+
+	switch {
+	case parsed.Path != "/authorize":
+		return "", "", errors.New("unexpected authorization endpoint")
+	case query.Get("response_type") != "code":
+		return "", "", errors.New("unsupported response_type")
+	case query.Get("client_id") != s.ClientID:
+		return "", "", errors.New("unknown client_id")
+	case query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "":
+		return "", "", errors.New("missing PKCE challenge")
+	}
+
+	code = rand.Text()
+
+	s.mu.Lock()
+	s.grants[code] = grant{
+		user:          user,
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	return code, query.Get("state"), nil
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{
+			Key:       &s.key.PublicKey,
+			KeyID:     keyID,
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}},
+	})
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientID != s.ClientID || clientSecret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	s.mu.Lock()
+	g, ok := s.grants[code]
+	delete(s.grants, code)
+	s.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	switch {
+	case r.PostFormValue("grant_type") != "authorization_code", !ok,
+		g.clientID != clientID,
+		g.redirectURI != r.PostFormValue("redirect_uri"),
+		g.codeChallenge != base64.RawURLEncoding.EncodeToString(verifier[:]):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := s.signIDToken(g)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (s *Server) signIDToken(g grant) (string, error) {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: s.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims, err := json.Marshal(map[string]any{
+		"iss":            s.URL,
+		"sub":            g.user.Subject,
+		"aud":            g.clientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          g.nonce,
+		"email":          g.user.Email,
+		"email_verified": g.user.EmailVerified,
+		"name":           g.user.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := signer.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+	return signed.CompactSerialize()
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,142 @@
+// Package passwordhash hashes passwords with argon2id or bcrypt and verifies
+// hashes made by either.
+package passwordhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"go-reasonable-api/support/config"
+
+	"github.com/rotisserie/eris"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithms accepted by auth.password_hash_algorithm.
+const (
+	Argon2id = "argon2id"
+	Bcrypt   = "bcrypt"
+)
+
+const (
+	argon2idPrefix   = "$argon2id$"
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// Hasher implements support.PasswordHasher.
+//
+// New hashes use the configured algorithm. Argon2id hashes are stored in
+// the PHC string format ($argon2id$v=19$m=...,t=...,p=...$salt$key) and
+// bcrypt hashes in their usual modular crypt format, so the algorithm and
+// parameters of any stored hash can be read back from it.
+type Hasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     argon2Params
+}
+
+// NewHasher creates a Hasher from the auth configuration.
+func NewHasher(cfg *config.Config) *Hasher {
+	return &Hasher{
+		algorithm:  cfg.Auth.PasswordHashAlgorithm,
+		bcryptCost: cfg.Auth.BcryptCost,
+		argon2: argon2Params{
+			memory:      cfg.Auth.Argon2Memory,
+			iterations:  cfg.Auth.Argon2Iterations,
+			parallelism: cfg.Auth.Argon2Parallelism,
+		},
+	}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.algorithm == Bcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+		if err != nil {
+			return "", eris.Wrap(err, "failed to hash password with bcrypt")
+		}
+		return string(hash), nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", eris.Wrap(err, "failed to generate salt")
+	}
+	key := argon2.IDKey([]byte(password), salt, h.argon2.iterations, h.argon2.memory, h.argon2.parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, h.argon2.memory, h.argon2.iterations, h.argon2.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Hasher) Verify(password, hash string) bool {
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, candidate) == 1
+}
+
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || h.algorithm != Bcrypt || cost != h.bcryptCost
+	}
+
+	params, salt, key, err := decodeArgon2id(hash)
+	return err != nil || h.algorithm != Argon2id || params != h.argon2 ||
+		len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+}
+
+// decodeArgon2id parses a PHC-formatted argon2id hash into its parameters,
+// salt and derived key.
+func decodeArgon2id(hash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, eris.New("malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, eris.Wrap(err, "malformed argon2id version")
+	}
+	if version != argon2.Version {
+		return params, nil, nil, eris.Errorf("unsupported argon2id version %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, eris.Wrap(err, "malformed argon2id parameters")
+	}
+	if params.iterations == 0 || params.parallelism == 0 {
+		return params, nil, nil, eris.New("invalid argon2id parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, eris.Wrap(err, "malformed argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, eris.New("malformed argon2id key")
+	}
+
+	return params, salt, key, nil
+}
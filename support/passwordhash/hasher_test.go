@@ -0,0 +1,96 @@
+package passwordhash
+
+import (
+	"strings"
+	"testing"
+
+	"go-reasonable-api/support/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newHasher(algorithm string, bcryptCost int, memory uint32) *Hasher {
+	return NewHasher(&config.Config{
+		Auth: config.AuthConfig{
+			PasswordHashAlgorithm: algorithm,
+			BcryptCost:            bcryptCost,
+			Argon2Memory:          memory,
+			Argon2Iterations:      1,
+			Argon2Parallelism:     1,
+		},
+	})
+}
+
+func TestHasher(t *testing.T) {
+	argon := newHasher(Argon2id, bcrypt.MinCost, 64)
+
+	t.Run("Argon2id_RoundTrip", func(t *testing.T) {
+		hash, err := argon.Hash("password123")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+		assert.True(t, argon.Verify("password123", hash))
+		assert.False(t, argon.Verify("password124", hash))
+		assert.False(t, argon.NeedsRehash(hash))
+	})
+
+	t.Run("Argon2id_SaltsEachHash", func(t *testing.T) {
+		first, err := argon.Hash("password123")
+		require.NoError(t, err)
+		second, err := argon.Hash("password123")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Bcrypt_RoundTrip", func(t *testing.T) {
+		hasher := newHasher(Bcrypt, bcrypt.MinCost, 64)
+		hash, err := hasher.Hash("password123")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(hash, "$2a$"))
+		assert.True(t, hasher.Verify("password123", hash))
+		assert.False(t, hasher.Verify("password124", hash))
+		assert.False(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("Verify_AcceptsOtherAlgorithmsAndParameters", func(t *testing.T) {
+		bcryptHash, err := newHasher(Bcrypt, bcrypt.MinCost, 64).Hash("password123")
+		require.NoError(t, err)
+		argonHash, err := newHasher(Argon2id, bcrypt.MinCost, 128).Hash("password123")
+		require.NoError(t, err)
+
+		assert.True(t, argon.Verify("password123", bcryptHash))
+		assert.True(t, argon.Verify("password123", argonHash))
+		assert.True(t, newHasher(Bcrypt, bcrypt.MinCost, 64).Verify("password123", argonHash))
+	})
+
+	t.Run("NeedsRehash_OutdatedHashes", func(t *testing.T) {
+		bcryptHash, err := newHasher(Bcrypt, bcrypt.MinCost, 64).Hash("password123")
+		require.NoError(t, err)
+		argonHash, err := newHasher(Argon2id, bcrypt.MinCost, 128).Hash("password123")
+		require.NoError(t, err)
+
+		assert.True(t, argon.NeedsRehash(bcryptHash), "bcrypt hash under argon2id")
+		assert.True(t, argon.NeedsRehash(argonHash), "argon2id hash with other memory")
+		assert.True(t, newHasher(Bcrypt, bcrypt.MinCost+1, 64).NeedsRehash(bcryptHash), "bcrypt hash with lower cost")
+		assert.True(t, newHasher(Bcrypt, bcrypt.MinCost, 64).NeedsRehash(argonHash), "argon2id hash under bcrypt")
+	})
+
+	t.Run("MalformedHashes", func(t *testing.T) {
+		for _, hash := range []string{
+			"",
+			"not-a-hash",
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+			"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+			"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$",
+		} {
+			assert.False(t, argon.Verify("password123", hash), hash)
+			assert.True(t, argon.NeedsRehash(hash), hash)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+// Package tokencache caches validated auth tokens in an in-memory LRU backed
+// by Redis, so authenticated requests can skip the database.
+package tokencache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+const keyPrefix = "tokencache:"
+
+// generationKey holds a counter that Invalidate increments. Each user's
+// generation is the value it had at their last invalidation.
+const generationKey = keyPrefix + "generation"
+
+// setScript caches a token unless its user was invalidated after the
+// generation passed in ARGV[1] was read, and returns whether it did.
+var setScript = redis.NewScript(`
+local invalidated = tonumber(redis.call("GET", KEYS[3]) or "0")
+if invalidated > tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[4])
+redis.call("SADD", KEYS[2], ARGV[3])
+redis.call("PEXPIRE", KEYS[2], ARGV[4])
+return 1
+`)
+
+// entry is a cached token together with the generation read before it was
+// loaded, so a cache hit can be cached again after being touched.
+type entry struct {
+	Token      sqlcgen.AuthToken `json:"token"`
+	Generation int64             `json:"generation"`
+}
+
+// RedisCache implements support.TokenCache.
+//
+// Lookups try the process-local LRU first, then Redis, which every replica
+// shares. Each cached token is a JSON value under its hash, and each user
+// has a set of the hashes cached for them so Invalidate can find them.
+// Invalidate records the user's generation before clearing Redis and this
+// process's LRU, so a Set for a token loaded before the invalidation is
+// dropped rather than caching a revoked token again. Other processes drop
+// their copy when token_cache.local_ttl passes.
+type RedisCache struct {
+	client redis.UniversalClient
+	local  *expirable.LRU[string, entry]
+	ttl    time.Duration
+}
+
+// NewRedisCache creates a RedisCache using client, configured by
+// token_cache. With the cache disabled every lookup misses.
+func NewRedisCache(client redis.UniversalClient, cfg *config.Config) *RedisCache {
+	c := &RedisCache{client: client}
+	if !cfg.TokenCache.Enabled {
+		return c
+	}
+
+	c.ttl = cfg.TokenCache.TTL
+	if cfg.TokenCache.LocalSize > 0 && cfg.TokenCache.LocalTTL > 0 {
+		c.local = expirable.NewLRU[string, entry](cfg.TokenCache.LocalSize, nil, cfg.TokenCache.LocalTTL)
+	}
+	return c
+}
+
+func tokenKey(tokenHash string) string {
+	return keyPrefix + "token:" + tokenHash
+}
+
+func userKey(userID uuid.UUID) string {
+	return keyPrefix + "user:" + userID.String()
+}
+
+func userGenerationKey(userID uuid.UUID) string {
+	return keyPrefix + "user-generation:" + userID.String()
+}
+
+func (c *RedisCache) Get(ctx context.Context, tokenHash string) (*sqlcgen.AuthToken, int64, bool) {
+	if c.ttl <= 0 {
+		return nil, 0, false
+	}
+
+	if c.local != nil {
+		if cached, ok := c.local.Get(tokenHash); ok {
+			return &cached.Token, cached.Generation, true
+		}
+	}
+
+	// Read the generation along with the token, so a miss can be cached
+	// with the generation from before the database lookup
+	var tokenCmd *redis.StringCmd
+	var generationCmd *redis.StringCmd
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		tokenCmd = pipe.Get(ctx, tokenKey(tokenHash))
+		generationCmd = pipe.Get(ctx, generationKey)
+		return nil
+	})
+	if err != nil && !eris.Is(err, redis.Nil) {
+		return nil, 0, false
+	}
+
+	generation, err := generationCmd.Int64()
+	if err != nil && !eris.Is(err, redis.Nil) {
+		return nil, 0, false
+	}
+
+	data, err := tokenCmd.Bytes()
+	if err != nil {
+		return nil, generation, false
+	}
+
+	var cached entry
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Token.ID == uuid.Nil {
+		return nil, generation, false
+	}
+
+	if c.local != nil {
+		c.local.Add(tokenHash, cached)
+	}
+	return &cached.Token, cached.Generation, true
+}
+
+func (c *RedisCache) Set(ctx context.Context, token *sqlcgen.AuthToken, generation int64) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	cached := entry{Token: *token, Generation: generation}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+
+	stored, err := setScript.Run(ctx, c.client,
+		[]string{tokenKey(token.TokenHash), userKey(token.UserID), userGenerationKey(token.UserID)},
+		generation, data, token.TokenHash, c.ttl.Milliseconds(),
+	).Int()
+	if err != nil || stored == 0 {
+		return
+	}
+
+	if c.local != nil {
+		c.local.Add(token.TokenHash, cached)
+	}
+}
+
+func (c *RedisCache) Invalidate(ctx context.Context, userID uuid.UUID) error {
+	if c.ttl <= 0 {
+		return nil
+	}
+
+	if c.local != nil {
+		for _, tokenHash := range c.local.Keys() {
+			if cached, ok := c.local.Peek(tokenHash); ok && cached.Token.UserID == userID {
+				c.local.Remove(tokenHash)
+			}
+		}
+	}
+
+	// Bump the generation first: a Set that runs after this sees it and is
+	// dropped, and one that ran before is in the user's set deleted below
+	generation, err := c.client.Incr(ctx, generationKey).Result()
+	if err != nil {
+		return eris.Wrap(err, "failed to bump token cache generation")
+	}
+	if err := c.client.Set(ctx, userGenerationKey(userID), generation, c.ttl).Err(); err != nil {
+		return eris.Wrap(err, "failed to record token cache generation")
+	}
+
+	tokenHashes, err := c.client.SMembers(ctx, userKey(userID)).Result()
+	if err != nil {
+		return eris.Wrap(err, "failed to list cached tokens")
+	}
+
+	keys := make([]string, 0, len(tokenHashes)+1)
+	for _, tokenHash := range tokenHashes {
+		keys = append(keys, tokenKey(tokenHash))
+	}
+	keys = append(keys, userKey(userID))
+
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		return eris.Wrap(err, "failed to invalidate cached tokens")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package tokencache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-reasonable-api/db/sqlcgen"
+	"go-reasonable-api/support/config"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupTest(t *testing.T) *redis.Client {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	container, err := testcontainers.Run(ctx, "redis:7-alpine",
+		testcontainers.WithExposedPorts("6379/tcp"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("6379/tcp")),
+	)
+	testcontainers.CleanupContainer(t, container)
+	require.NoError(t, err)
+
+	addr, err := container.PortEndpoint(ctx, "6379/tcp", "")
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func newTestConfig(localSize int) *config.Config {
+	return &config.Config{
+		TokenCache: config.TokenCacheConfig{
+			Enabled:   true,
+			TTL:       time.Minute,
+			LocalTTL:  time.Minute,
+			LocalSize: localSize,
+		},
+	}
+}
+
+func newToken(userID uuid.UUID, tokenHash string) *sqlcgen.AuthToken {
+	return &sqlcgen.AuthToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().UTC().Add(time.Hour).Truncate(time.Microsecond),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	client := setupTest(t)
+	ctx := context.Background()
+
+	t.Run("Get_Miss", func(t *testing.T) {
+		cache := NewRedisCache(client, newTestConfig(10))
+
+		_, _, ok := cache.Get(ctx, "missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("Set_Get", func(t *testing.T) {
+		cache := NewRedisCache(client, newTestConfig(10))
+		token := newToken(uuid.New(), "set-get")
+
+		cache.Set(ctx, token, 0)
+
+		cached, _, ok := cache.Get(ctx, token.TokenHash)
+		require.True(t, ok)
+		assert.Equal(t, token.ID, cached.ID)
+		assert.True(t, token.ExpiresAt.Equal(cached.ExpiresAt))
+	})
+
+	t.Run("Get_SharedAcrossInstances", func(t *testing.T) {
+		token := newToken(uuid.New(), "shared")
+		NewRedisCache(client, newTestConfig(10)).Set(ctx, token, 0)
+
+		cached, _, ok := NewRedisCache(client, newTestConfig(10)).Get(ctx, token.TokenHash)
+		require.True(t, ok)
+		assert.Equal(t, token.ID, cached.ID)
+	})
+
+	t.Run("Get_LocalTier", func(t *testing.T) {
+		cache := NewRedisCache(client, newTestConfig(10))
+		token := newToken(uuid.New(), "local")
+		cache.Set(ctx, token, 0)
+
+		require.NoError(t, client.Del(ctx, tokenKey(token.TokenHash)).Err())
+
+		_, _, ok := cache.Get(ctx, token.TokenHash)
+		assert.True(t, ok)
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		userID := uuid.New()
+		other := newToken(uuid.New(), "invalidate-other")
+		cache := NewRedisCache(client, newTestConfig(10))
+		cache.Set(ctx, newToken(userID, "invalidate-1"), 0)
+		cache.Set(ctx, newToken(userID, "invalidate-2"), 0)
+		cache.Set(ctx, other, 0)
+
+		require.NoError(t, cache.Invalidate(ctx, userID))
+
+		_, _, ok := cache.Get(ctx, "invalidate-1")
+		assert.False(t, ok)
+		_, _, ok = cache.Get(ctx, "invalidate-2")
+		assert.False(t, ok)
+		_, _, ok = cache.Get(ctx, other.TokenHash)
+		assert.True(t, ok)
+	})
+
+	t.Run("Set_AfterInvalidate", func(t *testing.T) {
+		cache := NewRedisCache(client, newTestConfig(10))
+		token := newToken(uuid.New(), "set-after-invalidate")
+
+		// A lookup that misses, loads the token and only caches it after
+		// the user's tokens were invalidated must not cache it
+		_, generation, ok := cache.Get(ctx, token.TokenHash)
+		require.False(t, ok)
+		require.NoError(t, cache.Invalidate(ctx, token.UserID))
+		cache.Set(ctx, token, generation)
+
+		_, _, ok = cache.Get(ctx, token.TokenHash)
+		assert.False(t, ok)
+
+		_, generation, _ = cache.Get(ctx, token.TokenHash)
+		cache.Set(ctx, token, generation)
+
+		_, _, ok = cache.Get(ctx, token.TokenHash)
+		assert.True(t, ok)
+	})
+
+	t.Run("Invalidate_OtherInstance", func(t *testing.T) {
+		userID := uuid.New()
+		token := newToken(userID, "invalidate-remote")
+		NewRedisCache(client, newTestConfig(10)).Set(ctx, token, 0)
+
+		// Without a local tier the other instance's invalidation is
+		// seen immediately
+		cache := NewRedisCache(client, newTestConfig(0))
+		require.NoError(t, NewRedisCache(client, newTestConfig(10)).Invalidate(ctx, userID))
+
+		_, _, ok := cache.Get(ctx, token.TokenHash)
+		assert.False(t, ok)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cache := NewRedisCache(client, &config.Config{})
+		token := newToken(uuid.New(), "disabled")
+
+		cache.Set(ctx, token, 0)
+
+		_, _, ok := cache.Get(ctx, token.TokenHash)
+		assert.False(t, ok)
+		assert.NoError(t, cache.Invalidate(ctx, token.UserID))
+	})
+}
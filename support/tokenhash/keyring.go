@@ -0,0 +1,60 @@
+// Package tokenhash hashes opaque tokens with HMAC-SHA256 under a keyring
+// that supports key rotation.
+package tokenhash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go-reasonable-api/support/config"
+)
+
+// Keyring implements support.TokenHasher.
+//
+// New hashes use the current key, auth.secret. Keys listed in
+// auth.previous_secrets only serve lookups of tokens hashed before a
+// rotation. The plain SHA-256 hash written before tokens were keyed is
+// always the last candidate, so sessions created then stay valid until
+// they are rehashed or expire.
+type Keyring struct {
+	current  []byte
+	previous [][]byte
+}
+
+// NewKeyring creates a Keyring from the auth configuration.
+func NewKeyring(cfg *config.Config) *Keyring {
+	previous := make([][]byte, 0, len(cfg.Auth.PreviousSecrets))
+	for _, secret := range cfg.Auth.PreviousSecrets {
+		previous = append(previous, []byte(secret))
+	}
+	return &Keyring{
+		current:  []byte(cfg.Auth.Secret),
+		previous: previous,
+	}
+}
+
+func (k *Keyring) Hash(token string) string {
+	return keyedHash(k.current, token)
+}
+
+func (k *Keyring) Candidates(token string) []string {
+	hashes := make([]string, 0, len(k.previous)+2)
+	hashes = append(hashes, keyedHash(k.current, token))
+	for _, key := range k.previous {
+		hashes = append(hashes, keyedHash(key, token))
+	}
+	return append(hashes, legacyHash(token))
+}
+
+func keyedHash(key []byte, token string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// legacyHash is the unkeyed hash tokens were stored under before Keyring.
+func legacyHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,46 @@
+package tokenhash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"go-reasonable-api/support/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeyring(secret string, previous ...string) *Keyring {
+	return NewKeyring(&config.Config{
+		Auth: config.AuthConfig{Secret: secret, PreviousSecrets: previous},
+	})
+}
+
+func TestKeyring(t *testing.T) {
+	t.Run("Hash_IsKeyed", func(t *testing.T) {
+		hash := newKeyring("secret-a").Hash("token")
+
+		assert.Len(t, hash, 64)
+		assert.Equal(t, hash, newKeyring("secret-a").Hash("token"))
+		assert.NotEqual(t, hash, newKeyring("secret-b").Hash("token"))
+		assert.NotEqual(t, hash, newKeyring("secret-a").Hash("other-token"))
+	})
+
+	t.Run("Candidates_CurrentKeyFirst", func(t *testing.T) {
+		keyring := newKeyring("secret-b", "secret-a")
+		candidates := keyring.Candidates("token")
+
+		require.Len(t, candidates, 3)
+		assert.Equal(t, keyring.Hash("token"), candidates[0])
+		assert.Equal(t, newKeyring("secret-a").Hash("token"), candidates[1])
+	})
+
+	t.Run("Candidates_EndWithUnkeyedHash", func(t *testing.T) {
+		legacy := sha256.Sum256([]byte("token"))
+		candidates := newKeyring("secret-a").Candidates("token")
+
+		require.Len(t, candidates, 2)
+		assert.Equal(t, hex.EncodeToString(legacy[:]), candidates[1])
+	})
+}
@@ -0,0 +1,80 @@
+// Package useragent names the browser and operating system behind a
+// User-Agent header, for showing users where they signed in from.
+//
+// It recognises the common desktop and mobile browsers by the product
+// tokens they send; it is not meant for feature detection.
+package useragent
+
+import "strings"
+
+// Agent is the browser and operating system named by a User-Agent header.
+// Either is empty when it could not be recognised.
+type Agent struct {
+	Browser string
+	OS      string
+}
+
+// String describes the agent as "Browser on OS", falling back to whichever
+// part is known, or "Unknown device".
+func (a Agent) String() string {
+	switch {
+	case a.Browser != "" && a.OS != "":
+		return a.Browser + " on " + a.OS
+	case a.Browser != "":
+		return a.Browser
+	case a.OS != "":
+		return a.OS
+	default:
+		return "Unknown device"
+	}
+}
+
+// token pairs a substring of the User-Agent header with the name it implies.
+type token struct {
+	match string
+	name  string
+}
+
+// Browsers built on Chrome or Safari also send those products' tokens, so
+// the more specific ones must be checked first.
+var browsers = []token{
+	{"Edg/", "Edge"},
+	{"EdgA/", "Edge"},
+	{"EdgiOS/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+// iOS and Android agents also mention Mac OS X and Linux respectively.
+var systems = []token{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"CrOS", "ChromeOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"Macintosh", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// Parse recognises the browser and operating system in a User-Agent header.
+func Parse(header string) Agent {
+	return Agent{
+		Browser: find(header, browsers),
+		OS:      find(header, systems),
+	}
+}
+
+func find(header string, tokens []token) string {
+	for _, t := range tokens {
+		if strings.Contains(header, t.match) {
+			return t.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package useragent_test
+
+import (
+	"testing"
+
+	"go-reasonable-api/support/useragent"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{
+			name:     "chrome on windows",
+			header:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36",
+			expected: "Chrome on Windows",
+		},
+		{
+			name:     "edge on windows",
+			header:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36 Edg/129.0.0.0",
+			expected: "Edge on Windows",
+		},
+		{
+			name:     "safari on macos",
+			header:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_6) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.6 Safari/605.1.15",
+			expected: "Safari on macOS",
+		},
+		{
+			name:     "firefox on linux",
+			header:   "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			expected: "Firefox on Linux",
+		},
+		{
+			name:     "safari on iphone",
+			header:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.6 Mobile/15E148 Safari/604.1",
+			expected: "Safari on iOS",
+		},
+		{
+			name:     "chrome on iphone",
+			header:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/129.0.6668.69 Mobile/15E148 Safari/604.1",
+			expected: "Chrome on iOS",
+		},
+		{
+			name:     "samsung internet on android",
+			header:   "Mozilla/5.0 (Linux; Android 14; SM-S921B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/26.0 Chrome/122.0.0.0 Mobile Safari/537.36",
+			expected: "Samsung Internet on Android",
+		},
+		{
+			name:     "operating system only",
+			header:   "MyApp/1.0 (Windows NT 10.0)",
+			expected: "Windows",
+		},
+		{
+			name:     "unrecognised",
+			header:   "curl/8.9.1",
+			expected: "Unknown device",
+		},
+		{
+			name:     "empty",
+			header:   "",
+			expected: "Unknown device",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, useragent.Parse(tt.header).String())
+		})
+	}
+}
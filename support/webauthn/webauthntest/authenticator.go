@@ -0,0 +1,170 @@
+// Package webauthntest provides a software authenticator that produces
+// WebAuthn registration and authentication responses, so ceremonies can be
+// tested without a browser or hardware key.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// Authenticator is a software ES256 authenticator with a single credential.
+// Set BackupEligible to act like a synced passkey.
+type Authenticator struct {
+	RPID           string
+	Origin         string
+	CredentialID   []byte
+	SignCount      uint32
+	BackupEligible bool
+
+	key *ecdsa.PrivateKey
+}
+
+// NewAuthenticator creates an authenticator with a fresh credential for rpID,
+// answering ceremonies as if run from origin.
+func NewAuthenticator(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{
+		RPID:         rpID,
+		Origin:       origin,
+		CredentialID: credentialID,
+		key:          key,
+	}, nil
+}
+
+// PublicKey returns the credential's COSE_Key, as a relying party stores it
+// after registration.
+func (a *Authenticator) PublicKey() ([]byte, error) {
+	publicKey, err := a.key.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: publicKey[1:33],
+		YCoord: publicKey[33:65],
+	})
+}
+
+// Create answers a registration ceremony for challenge (base64url, as sent
+// in the options) with "none" attestation. It returns the credential in the
+// JSON encoding browsers produce with PublicKeyCredential.toJSON().
+func (a *Authenticator) Create(challenge string) ([]byte, error) {
+	clientDataJSON, err := a.clientData(protocol.CreateCeremony, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := a.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	authData := a.authenticatorData(byte(protocol.FlagAttestedCredentialData))
+	authData = append(authData, make([]byte, 16)...) // aaguid
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.CredentialID)))
+	authData = append(authData, a.CredentialID...)
+	authData = append(authData, publicKey...)
+
+	attestationObject, err := webauthncbor.Marshal(protocol.AttestationObject{
+		RawAuthData: authData,
+		Format:      "none",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(protocol.CredentialCreationResponse{
+		PublicKeyCredential: a.publicKeyCredential(),
+		AttestationResponse: protocol.AuthenticatorAttestationResponse{
+			AuthenticatorResponse: protocol.AuthenticatorResponse{ClientDataJSON: clientDataJSON},
+			Transports:            []string{"internal"},
+			AttestationObject:     attestationObject,
+		},
+	})
+}
+
+// Get answers an authentication ceremony for challenge, bumping the
+// signature counter. userHandle is the user ID given at registration. It
+// returns the credential in the same JSON encoding as Create.
+func (a *Authenticator) Get(challenge string, userHandle []byte) ([]byte, error) {
+	clientDataJSON, err := a.clientData(protocol.AssertCeremony, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	a.SignCount++
+	authenticatorData := a.authenticatorData(0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authenticatorData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(protocol.CredentialAssertionResponse{
+		PublicKeyCredential: a.publicKeyCredential(),
+		AssertionResponse: protocol.AuthenticatorAssertionResponse{
+			AuthenticatorResponse: protocol.AuthenticatorResponse{ClientDataJSON: clientDataJSON},
+			AuthenticatorData:     authenticatorData,
+			Signature:             signature,
+			UserHandle:            userHandle,
+		},
+	})
+}
+
+func (a *Authenticator) publicKeyCredential() protocol.PublicKeyCredential {
+	return protocol.PublicKeyCredential{
+		Credential: protocol.Credential{
+			ID:   base64.RawURLEncoding.EncodeToString(a.CredentialID),
+			Type: string(protocol.PublicKeyCredentialType),
+		},
+		RawID: a.CredentialID,
+	}
+}
+
+func (a *Authenticator) clientData(ceremony protocol.CeremonyType, challenge string) ([]byte, error) {
+	return json.Marshal(protocol.CollectedClientData{
+		Type:      ceremony,
+		Challenge: challenge,
+		Origin:    a.Origin,
+	})
+}
+
+// authenticatorData builds rpIdHash, flags (UP and UV, BE when set, plus
+// extra) and the signature counter.
+func (a *Authenticator) authenticatorData(extraFlags byte) []byte {
+	flags := byte(protocol.FlagUserPresent | protocol.FlagUserVerified)
+	if a.BackupEligible {
+		flags |= byte(protocol.FlagBackupEligible)
+	}
+
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags|extraFlags)
+	return binary.BigEndian.AppendUint32(data, a.SignCount)
+}
@@ -0,0 +1,16 @@
+package providers
+
+import (
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/breached"
+	"go-reasonable-api/support/config"
+)
+
+// ProvideBreachedPasswords returns the breached password list configured by
+// password.breached_list_dir, or nil when the check is disabled.
+func ProvideBreachedPasswords(cfg *config.Config) support.BreachedPasswords {
+	if cfg.Password.BreachedListDir == "" {
+		return nil
+	}
+	return breached.NewRangeDir(cfg.Password.BreachedListDir)
+}
@@ -0,0 +1,41 @@
+package providers
+
+import (
+	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/support"
+	svcImpl "go-reasonable-api/app/services"
+	"go-reasonable-api/support/config"
+)
+
+// ProvideDataExportService returns the data export service with every
+// module's exporter registered. Register an exporter here when adding a
+// table that stores user data, so that exports include it.
+func ProvideDataExportService(
+	cfg *config.Config,
+	exportRepo repositories.DataExportRepository,
+	userRepo repositories.UserRepository,
+	authTokenRepo repositories.AuthTokenRepository,
+	orgRepo repositories.OrganizationRepository,
+	identityRepo repositories.UserIdentityRepository,
+	credentialRepo repositories.WebAuthnCredentialRepository,
+	apiKeyRepo repositories.APIKeyRepository,
+	loginDeviceRepo repositories.LoginDeviceRepository,
+	auditLogRepo repositories.AuditLogRepository,
+	emailChangeRepo repositories.EmailChangeRepository,
+	totpRepo repositories.TOTPCredentialRepository,
+	roleRepo repositories.RoleRepository,
+	taskClient support.TaskClient,
+	linkSigner support.LinkSigner,
+) *svcImpl.DataExportService {
+	service := svcImpl.NewDataExportService(cfg, exportRepo, userRepo, authTokenRepo, taskClient, linkSigner)
+	service.RegisterExporter("organizations", svcImpl.OrganizationsExporter(orgRepo))
+	service.RegisterExporter("identities", svcImpl.IdentitiesExporter(identityRepo))
+	service.RegisterExporter("passkeys", svcImpl.PasskeysExporter(credentialRepo))
+	service.RegisterExporter("api_keys", svcImpl.APIKeysExporter(apiKeyRepo))
+	service.RegisterExporter("login_devices", svcImpl.LoginDevicesExporter(loginDeviceRepo))
+	service.RegisterExporter("audit_logs", svcImpl.AuditLogsExporter(auditLogRepo))
+	service.RegisterExporter("email_changes", svcImpl.EmailChangesExporter(emailChangeRepo))
+	service.RegisterExporter("two_factor", svcImpl.TwoFactorExporter(totpRepo))
+	service.RegisterExporter("roles", svcImpl.RolesExporter(roleRepo))
+	return service
+}
@@ -0,0 +1,13 @@
+package providers
+
+import (
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/linksign"
+)
+
+// ProvideLinkSigner returns the link signer keyed by auth.secret and
+// auth.previous_secrets.
+func ProvideLinkSigner(cfg *config.Config) support.LinkSigner {
+	return linksign.NewSigner(cfg)
+}
@@ -0,0 +1,13 @@
+package providers
+
+import (
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/passwordhash"
+)
+
+// ProvidePasswordHasher returns the password hasher configured by
+// auth.password_hash_algorithm.
+func ProvidePasswordHasher(cfg *config.Config) support.PasswordHasher {
+	return passwordhash.NewHasher(cfg)
+}
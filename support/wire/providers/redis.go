@@ -0,0 +1,28 @@
+package providers
+
+import (
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/attempts"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/tokencache"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func ProvideRedisClient(cfg *config.Config) (*redis.Client, func(), error) {
+	client := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr})
+
+	cleanup := func() {
+		_ = client.Close()
+	}
+
+	return client, cleanup, nil
+}
+
+func ProvideAttemptStore(client *redis.Client) support.AttemptStore {
+	return attempts.NewRedisStore(client)
+}
+
+func ProvideTokenCache(client *redis.Client, cfg *config.Config) support.TokenCache {
+	return tokencache.NewRedisCache(client, cfg)
+}
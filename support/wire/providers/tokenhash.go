@@ -0,0 +1,13 @@
+package providers
+
+import (
+	"go-reasonable-api/app/interfaces/support"
+	"go-reasonable-api/support/config"
+	"go-reasonable-api/support/tokenhash"
+)
+
+// ProvideTokenHasher returns the token hashing keyring configured by
+// auth.secret and auth.previous_secrets.
+func ProvideTokenHasher(cfg *config.Config) support.TokenHasher {
+	return tokenhash.NewKeyring(cfg)
+}
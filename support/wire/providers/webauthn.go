@@ -0,0 +1,22 @@
+package providers
+
+import (
+	"go-reasonable-api/support/config"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/rotisserie/eris"
+)
+
+// ProvideWebAuthn returns the WebAuthn relying party configured by the
+// webauthn section.
+func ProvideWebAuthn(cfg *config.Config) (*webauthn.WebAuthn, error) {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthn.RPID,
+		RPDisplayName: cfg.WebAuthn.RPName,
+		RPOrigins:     cfg.WebAuthn.Origins,
+	})
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to configure webauthn")
+	}
+	return webAuthn, nil
+}
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"go-reasonable-api/app/interfaces/repositories"
+	"go-reasonable-api/app/interfaces/services"
 	"go-reasonable-api/app/interfaces/support"
 	"go-reasonable-api/app/tasks"
 	"go-reasonable-api/support/config"
@@ -22,16 +23,33 @@ func ProvideEmailTask(logger *zerolog.Logger, emailSender support.EmailSender) (
 
 func ProvideCleanupTask(
 	logger *zerolog.Logger,
+	cfg *config.Config,
 	authTokenRepo repositories.AuthTokenRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	twoFactorChallengeRepo repositories.TwoFactorChallengeRepository,
+	webAuthnChallengeRepo repositories.WebAuthnChallengeRepository,
+	oidcLoginStateRepo repositories.OIDCLoginStateRepository,
+	magicLinkRepo repositories.MagicLinkRepository,
 	passwordResetRepo repositories.PasswordResetRepository,
 	emailVerificationRepo repositories.EmailVerificationRepository,
-	userRepo repositories.UserRepository,
+	emailChangeRepo repositories.EmailChangeRepository,
+	invitationRepo repositories.OrganizationInvitationRepository,
+	dataExportRepo repositories.DataExportRepository,
+	accountDeletionService services.AccountDeletionService,
 ) *tasks.CleanupTask {
-	return tasks.NewCleanupTask(logger, authTokenRepo, passwordResetRepo, emailVerificationRepo, userRepo)
+	return tasks.NewCleanupTask(logger, cfg, authTokenRepo, refreshTokenRepo, twoFactorChallengeRepo, webAuthnChallengeRepo, oidcLoginStateRepo, magicLinkRepo, passwordResetRepo, emailVerificationRepo, emailChangeRepo, invitationRepo, dataExportRepo, accountDeletionService)
 }
 
-func ProvideTaskRegistry(emailTask *tasks.EmailTask, cleanupTask *tasks.CleanupTask) *tasks.Registry {
-	return tasks.NewRegistry(emailTask, cleanupTask)
+func ProvideDataExportTask(logger *zerolog.Logger, dataExportService services.DataExportService) *tasks.DataExportTask {
+	return tasks.NewDataExportTask(logger, dataExportService)
+}
+
+func ProvideAccountDeletionReminderTask(logger *zerolog.Logger, accountDeletionService services.AccountDeletionService) *tasks.AccountDeletionReminderTask {
+	return tasks.NewAccountDeletionReminderTask(logger, accountDeletionService)
+}
+
+func ProvideTaskRegistry(emailTask *tasks.EmailTask, cleanupTask *tasks.CleanupTask, dataExportTask *tasks.DataExportTask, reminderTask *tasks.AccountDeletionReminderTask) *tasks.Registry {
+	return tasks.NewRegistry(emailTask, cleanupTask, dataExportTask, reminderTask)
 }
 
 func ProvideServeMux(registry *tasks.Registry) *asynq.ServeMux {
@@ -16,6 +16,7 @@
 //   - HandlerProviderSet: all HTTP handlers
 //   - APIProviderSet: combines above for the API server
 //   - WorkerProviderSet: combines for the background worker
+//   - CLIProviderSet: minimal set for administrative CLI commands
 //
 // # Adding New Dependencies
 //
@@ -55,30 +56,102 @@ var RepositoryProviderSet = wire.NewSet(
 	wire.Bind(new(repositories.UserRepository), new(*repoImpl.UserRepository)),
 	repoImpl.NewAuthTokenRepository,
 	wire.Bind(new(repositories.AuthTokenRepository), new(*repoImpl.AuthTokenRepository)),
+	repoImpl.NewRefreshTokenRepository,
+	wire.Bind(new(repositories.RefreshTokenRepository), new(*repoImpl.RefreshTokenRepository)),
+	repoImpl.NewTOTPCredentialRepository,
+	wire.Bind(new(repositories.TOTPCredentialRepository), new(*repoImpl.TOTPCredentialRepository)),
+	repoImpl.NewRecoveryCodeRepository,
+	wire.Bind(new(repositories.RecoveryCodeRepository), new(*repoImpl.RecoveryCodeRepository)),
+	repoImpl.NewTwoFactorChallengeRepository,
+	wire.Bind(new(repositories.TwoFactorChallengeRepository), new(*repoImpl.TwoFactorChallengeRepository)),
+	repoImpl.NewWebAuthnCredentialRepository,
+	wire.Bind(new(repositories.WebAuthnCredentialRepository), new(*repoImpl.WebAuthnCredentialRepository)),
+	repoImpl.NewWebAuthnChallengeRepository,
+	wire.Bind(new(repositories.WebAuthnChallengeRepository), new(*repoImpl.WebAuthnChallengeRepository)),
+	repoImpl.NewUserIdentityRepository,
+	wire.Bind(new(repositories.UserIdentityRepository), new(*repoImpl.UserIdentityRepository)),
+	repoImpl.NewOIDCLoginStateRepository,
+	wire.Bind(new(repositories.OIDCLoginStateRepository), new(*repoImpl.OIDCLoginStateRepository)),
+	repoImpl.NewMagicLinkRepository,
+	wire.Bind(new(repositories.MagicLinkRepository), new(*repoImpl.MagicLinkRepository)),
 	repoImpl.NewPasswordResetRepository,
 	wire.Bind(new(repositories.PasswordResetRepository), new(*repoImpl.PasswordResetRepository)),
 	repoImpl.NewEmailVerificationRepository,
 	wire.Bind(new(repositories.EmailVerificationRepository), new(*repoImpl.EmailVerificationRepository)),
+	repoImpl.NewEmailChangeRepository,
+	wire.Bind(new(repositories.EmailChangeRepository), new(*repoImpl.EmailChangeRepository)),
+	repoImpl.NewAPIKeyRepository,
+	wire.Bind(new(repositories.APIKeyRepository), new(*repoImpl.APIKeyRepository)),
+	repoImpl.NewRoleRepository,
+	wire.Bind(new(repositories.RoleRepository), new(*repoImpl.RoleRepository)),
+	repoImpl.NewAuditLogRepository,
+	wire.Bind(new(repositories.AuditLogRepository), new(*repoImpl.AuditLogRepository)),
+	repoImpl.NewLoginDeviceRepository,
+	wire.Bind(new(repositories.LoginDeviceRepository), new(*repoImpl.LoginDeviceRepository)),
+	repoImpl.NewOrganizationRepository,
+	wire.Bind(new(repositories.OrganizationRepository), new(*repoImpl.OrganizationRepository)),
+	repoImpl.NewOrganizationInvitationRepository,
+	wire.Bind(new(repositories.OrganizationInvitationRepository), new(*repoImpl.OrganizationInvitationRepository)),
+	repoImpl.NewDataExportRepository,
+	wire.Bind(new(repositories.DataExportRepository), new(*repoImpl.DataExportRepository)),
 )
 
 // ServiceProviderSet contains all service providers
 var ServiceProviderSet = wire.NewSet(
 	svcImpl.NewUserService,
 	wire.Bind(new(services.UserService), new(*svcImpl.UserService)),
+	svcImpl.NewTwoFactorService,
+	wire.Bind(new(services.TwoFactorService), new(*svcImpl.TwoFactorService)),
 	svcImpl.NewSessionService,
 	wire.Bind(new(services.SessionService), new(*svcImpl.SessionService)),
+	svcImpl.NewPasskeyService,
+	wire.Bind(new(services.PasskeyService), new(*svcImpl.PasskeyService)),
+	svcImpl.NewOIDCService,
+	wire.Bind(new(services.OIDCService), new(*svcImpl.OIDCService)),
+	svcImpl.NewMagicLinkService,
+	wire.Bind(new(services.MagicLinkService), new(*svcImpl.MagicLinkService)),
 	svcImpl.NewPasswordResetService,
 	wire.Bind(new(services.PasswordResetService), new(*svcImpl.PasswordResetService)),
 	svcImpl.NewEmailVerificationService,
 	wire.Bind(new(services.EmailVerificationService), new(*svcImpl.EmailVerificationService)),
+	svcImpl.NewEmailChangeService,
+	wire.Bind(new(services.EmailChangeService), new(*svcImpl.EmailChangeService)),
+	svcImpl.NewLoginLockoutService,
+	wire.Bind(new(services.LoginLockoutService), new(*svcImpl.LoginLockoutService)),
+	svcImpl.NewLoginAlertService,
+	wire.Bind(new(services.LoginAlertService), new(*svcImpl.LoginAlertService)),
+	svcImpl.NewPasswordPolicyService,
+	wire.Bind(new(services.PasswordPolicyService), new(*svcImpl.PasswordPolicyService)),
+	svcImpl.NewAPIKeyService,
+	wire.Bind(new(services.APIKeyService), new(*svcImpl.APIKeyService)),
+	svcImpl.NewPermissionService,
+	wire.Bind(new(services.PermissionService), new(*svcImpl.PermissionService)),
+	svcImpl.NewAdminService,
+	wire.Bind(new(services.AdminService), new(*svcImpl.AdminService)),
+	svcImpl.NewImpersonationService,
+	wire.Bind(new(services.ImpersonationService), new(*svcImpl.ImpersonationService)),
+	svcImpl.NewOrganizationService,
+	wire.Bind(new(services.OrganizationService), new(*svcImpl.OrganizationService)),
+	providers.ProvideDataExportService,
+	wire.Bind(new(services.DataExportService), new(*svcImpl.DataExportService)),
 )
 
 // HandlerProviderSet contains all handler providers
 var HandlerProviderSet = wire.NewSet(
 	handlers.NewUserHandler,
 	handlers.NewSessionHandler,
+	handlers.NewTwoFactorHandler,
+	handlers.NewPasskeyHandler,
+	handlers.NewOIDCHandler,
+	handlers.NewMagicLinkHandler,
 	handlers.NewPasswordResetHandler,
 	handlers.NewEmailVerificationHandler,
+	handlers.NewEmailChangeHandler,
+	handlers.NewAPIKeyHandler,
+	handlers.NewAdminHandler,
+	handlers.NewImpersonationHandler,
+	handlers.NewOrganizationHandler,
+	handlers.NewDataExportHandler,
 	handlers.NewHealthHandler,
 )
 
@@ -91,6 +164,14 @@ var APIProviderSet = wire.NewSet(
 	providers.ProvideAsynqClient,
 	wire.Bind(new(handlers.RedisPinger), new(*asynq.Client)),
 	providers.ProvideTaskClient,
+	providers.ProvideRedisClient,
+	providers.ProvideAttemptStore,
+	providers.ProvideBreachedPasswords,
+	providers.ProvidePasswordHasher,
+	providers.ProvideTokenHasher,
+	providers.ProvideTokenCache,
+	providers.ProvideLinkSigner,
+	providers.ProvideWebAuthn,
 	RepositoryProviderSet,
 	ServiceProviderSet,
 	HandlerProviderSet,
@@ -101,16 +182,43 @@ var APIProviderSet = wire.NewSet(
 var WorkerProviderSet = wire.NewSet(
 	BaseProviderSet,
 	providers.ProvideDB,
+	providers.ProvideAsynqClient,
+	providers.ProvideTaskClient,
+	providers.ProvideLinkSigner,
 	RepositoryProviderSet,
+	providers.ProvideDataExportService,
+	wire.Bind(new(services.DataExportService), new(*svcImpl.DataExportService)),
+	svcImpl.NewAccountDeletionService,
+	wire.Bind(new(services.AccountDeletionService), new(*svcImpl.AccountDeletionService)),
 	providers.ProvideAsynqServer,
 	providers.ProvideScheduler,
 	providers.ProvideEmailTask,
 	providers.ProvideCleanupTask,
+	providers.ProvideDataExportTask,
+	providers.ProvideAccountDeletionReminderTask,
 	providers.ProvideTaskRegistry,
 	providers.ProvideServeMux,
 	providers.ProvideWorker,
 )
 
+// CLIProviderSet contains providers for administrative CLI commands
+var CLIProviderSet = wire.NewSet(
+	config.Load,
+	providers.ProvideDB,
+	providers.ProvideAsynqClient,
+	providers.ProvideTaskClient,
+	providers.ProvideRedisClient,
+	providers.ProvideAttemptStore,
+	repoImpl.NewUserRepository,
+	wire.Bind(new(repositories.UserRepository), new(*repoImpl.UserRepository)),
+	svcImpl.NewLoginLockoutService,
+	wire.Bind(new(services.LoginLockoutService), new(*svcImpl.LoginLockoutService)),
+	repoImpl.NewRoleRepository,
+	wire.Bind(new(repositories.RoleRepository), new(*repoImpl.RoleRepository)),
+	svcImpl.NewPermissionService,
+	wire.Bind(new(services.PermissionService), new(*svcImpl.PermissionService)),
+)
+
 // InitializeRouter creates the API router with all dependencies.
 // The cleanup function closes database connections and should be
 // deferred in main.
@@ -126,3 +234,19 @@ func InitializeWorker() (*worker.Worker, func(), error) {
 	wire.Build(WorkerProviderSet)
 	return nil, nil, nil
 }
+
+// InitializeLoginLockoutService creates the lockout service used by the
+// users CLI. The cleanup function closes database and Redis connections
+// and should be deferred by the caller.
+func InitializeLoginLockoutService() (services.LoginLockoutService, func(), error) {
+	wire.Build(CLIProviderSet)
+	return nil, nil, nil
+}
+
+// InitializePermissionService creates the permission service used by the
+// users CLI to grant and revoke roles. The cleanup function closes
+// database and Redis connections and should be deferred by the caller.
+func InitializePermissionService() (services.PermissionService, func(), error) {
+	wire.Build(CLIProviderSet)
+	return nil, nil, nil
+}
@@ -9,6 +9,7 @@ package wire
 import (
 	"github.com/google/wire"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go-reasonable-api/api/handlers"
 	repositories2 "go-reasonable-api/app/interfaces/repositories"
 	services2 "go-reasonable-api/app/interfaces/services"
@@ -31,32 +32,93 @@ func InitializeRouter() (*http.Router, func(), error) {
 		return nil, nil, err
 	}
 	logger := providers.ProvideLogger(configConfig)
-	db, cleanup, err := providers.ProvideDB(configConfig)
+	pool, cleanup, err := providers.ProvideDB(configConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	txManager := providers.ProvideTxManager(db)
-	userRepository := repositories.NewUserRepository(db)
-	authTokenRepository := repositories.NewAuthTokenRepository(db)
+	txManager := providers.ProvideTxManager(pool)
+	userRepository := repositories.NewUserRepository(pool)
+	authTokenRepository := repositories.NewAuthTokenRepository(pool)
+	apiKeyRepository := repositories.NewAPIKeyRepository(pool)
+	organizationRepository := repositories.NewOrganizationRepository(pool)
 	client, cleanup2, err := providers.ProvideAsynqClient(configConfig)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
 	taskClient := providers.ProvideTaskClient(client)
-	userService := services.NewUserService(configConfig, txManager, userRepository, authTokenRepository, taskClient)
-	sessionService := services.NewSessionService(configConfig, userRepository, authTokenRepository)
-	userHandler := handlers.NewUserHandler(userService, sessionService)
-	sessionHandler := handlers.NewSessionHandler(sessionService)
-	passwordResetRepository := repositories.NewPasswordResetRepository(db)
-	passwordResetService := services.NewPasswordResetService(configConfig, userRepository, passwordResetRepository, authTokenRepository, txManager, taskClient)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(pool)
+	tokenHasher := providers.ProvideTokenHasher(configConfig)
+	emailVerificationService := services.NewEmailVerificationService(configConfig, userRepository, emailVerificationRepository, txManager, taskClient, tokenHasher)
+	breachedPasswords := providers.ProvideBreachedPasswords(configConfig)
+	passwordPolicyService := services.NewPasswordPolicyService(configConfig, breachedPasswords)
+	passwordHasher := providers.ProvidePasswordHasher(configConfig)
+	redisClient, cleanup3, err := providers.ProvideRedisClient(configConfig)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tokenCache := providers.ProvideTokenCache(redisClient, configConfig)
+	linkSigner := providers.ProvideLinkSigner(configConfig)
+	userService := services.NewUserService(configConfig, txManager, userRepository, authTokenRepository, apiKeyRepository, organizationRepository, taskClient, emailVerificationService, passwordPolicyService, passwordHasher, tokenCache, linkSigner)
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(pool)
+	totpCredentialRepository := repositories.NewTOTPCredentialRepository(pool)
+	recoveryCodeRepository := repositories.NewRecoveryCodeRepository(pool)
+	twoFactorChallengeRepository := repositories.NewTwoFactorChallengeRepository(pool)
+	twoFactorService := services.NewTwoFactorService(configConfig, txManager, userRepository, totpCredentialRepository, recoveryCodeRepository, twoFactorChallengeRepository, passwordHasher, tokenHasher)
+	attemptStore := providers.ProvideAttemptStore(redisClient)
+	loginLockoutService := services.NewLoginLockoutService(configConfig, attemptStore, userRepository, taskClient)
+	loginDeviceRepository := repositories.NewLoginDeviceRepository(pool)
+	loginAlertService := services.NewLoginAlertService(configConfig, loginDeviceRepository, taskClient, tokenHasher)
+	sessionService := services.NewSessionService(configConfig, txManager, userRepository, authTokenRepository, refreshTokenRepository, twoFactorService, loginLockoutService, loginAlertService, passwordHasher, tokenHasher, tokenCache)
+	userHandler := handlers.NewUserHandler(configConfig, userService, sessionService)
+	sessionHandler := handlers.NewSessionHandler(configConfig, sessionService)
+	twoFactorHandler := handlers.NewTwoFactorHandler(twoFactorService)
+	webAuthn, err := providers.ProvideWebAuthn(configConfig)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	webAuthnCredentialRepository := repositories.NewWebAuthnCredentialRepository(pool)
+	webAuthnChallengeRepository := repositories.NewWebAuthnChallengeRepository(pool)
+	passkeyService := services.NewPasskeyService(configConfig, webAuthn, userRepository, webAuthnCredentialRepository, webAuthnChallengeRepository, sessionService)
+	passkeyHandler := handlers.NewPasskeyHandler(configConfig, passkeyService)
+	userIdentityRepository := repositories.NewUserIdentityRepository(pool)
+	oidcLoginStateRepository := repositories.NewOIDCLoginStateRepository(pool)
+	oidcService := services.NewOIDCService(configConfig, txManager, userRepository, userIdentityRepository, oidcLoginStateRepository, twoFactorService, sessionService)
+	oidcHandler := handlers.NewOIDCHandler(configConfig, oidcService)
+	magicLinkRepository := repositories.NewMagicLinkRepository(pool)
+	magicLinkService := services.NewMagicLinkService(configConfig, userRepository, magicLinkRepository, twoFactorService, sessionService, txManager, taskClient, tokenHasher)
+	magicLinkHandler := handlers.NewMagicLinkHandler(configConfig, magicLinkService)
+	passwordResetRepository := repositories.NewPasswordResetRepository(pool)
+	passwordResetService := services.NewPasswordResetService(configConfig, userRepository, passwordResetRepository, authTokenRepository, apiKeyRepository, txManager, taskClient, passwordPolicyService, passwordHasher, tokenHasher, tokenCache)
 	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
-	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
-	emailVerificationService := services.NewEmailVerificationService(configConfig, userRepository, emailVerificationRepository, txManager, taskClient)
 	emailVerificationHandler := handlers.NewEmailVerificationHandler(emailVerificationService)
-	healthHandler := handlers.NewHealthHandler(db, client)
-	router := http.NewRouter(configConfig, logger, userHandler, sessionHandler, passwordResetHandler, emailVerificationHandler, healthHandler, sessionService)
+	emailChangeRepository := repositories.NewEmailChangeRepository(pool)
+	emailChangeService := services.NewEmailChangeService(configConfig, userRepository, emailChangeRepository, authTokenRepository, apiKeyRepository, txManager, taskClient, passwordHasher, tokenHasher, tokenCache)
+	emailChangeHandler := handlers.NewEmailChangeHandler(emailChangeService)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepository, tokenHasher)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	adminService := services.NewAdminService(txManager, userRepository, authTokenRepository, apiKeyRepository, emailVerificationService, tokenCache)
+	adminHandler := handlers.NewAdminHandler(adminService, userService, passwordResetService)
+	roleRepository := repositories.NewRoleRepository(pool)
+	auditLogRepository := repositories.NewAuditLogRepository(pool)
+	impersonationService := services.NewImpersonationService(configConfig, txManager, userRepository, roleRepository, authTokenRepository, auditLogRepository, tokenHasher, tokenCache)
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationService)
+	organizationInvitationRepository := repositories.NewOrganizationInvitationRepository(pool)
+	organizationService := services.NewOrganizationService(configConfig, txManager, organizationRepository, organizationInvitationRepository, userRepository, taskClient, tokenHasher)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService)
+	dataExportRepository := repositories.NewDataExportRepository(pool)
+	dataExportService := providers.ProvideDataExportService(configConfig, dataExportRepository, userRepository, authTokenRepository, organizationRepository, userIdentityRepository, webAuthnCredentialRepository, apiKeyRepository, loginDeviceRepository, auditLogRepository, emailChangeRepository, totpCredentialRepository, roleRepository, taskClient, linkSigner)
+	dataExportHandler := handlers.NewDataExportHandler(dataExportService)
+	healthHandler := handlers.NewHealthHandler(pool, client)
+	permissionService := services.NewPermissionService(roleRepository, userRepository)
+	router := http.NewRouter(configConfig, logger, userHandler, sessionHandler, twoFactorHandler, passkeyHandler, oidcHandler, magicLinkHandler, passwordResetHandler, emailVerificationHandler, emailChangeHandler, apiKeyHandler, adminHandler, impersonationHandler, organizationHandler, dataExportHandler, healthHandler, sessionService, apiKeyService, permissionService, organizationService)
 	return router, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -77,20 +139,102 @@ func InitializeWorker() (*worker.Worker, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	db, cleanup, err := providers.ProvideDB(configConfig)
+	pool, cleanup, err := providers.ProvideDB(configConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	authTokenRepository := repositories.NewAuthTokenRepository(db)
-	passwordResetRepository := repositories.NewPasswordResetRepository(db)
-	emailVerificationRepository := repositories.NewEmailVerificationRepository(db)
-	userRepository := repositories.NewUserRepository(db)
-	cleanupTask := providers.ProvideCleanupTask(logger, authTokenRepository, passwordResetRepository, emailVerificationRepository, userRepository)
-	registry := providers.ProvideTaskRegistry(emailTask, cleanupTask)
+	authTokenRepository := repositories.NewAuthTokenRepository(pool)
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(pool)
+	twoFactorChallengeRepository := repositories.NewTwoFactorChallengeRepository(pool)
+	webAuthnChallengeRepository := repositories.NewWebAuthnChallengeRepository(pool)
+	oidcLoginStateRepository := repositories.NewOIDCLoginStateRepository(pool)
+	magicLinkRepository := repositories.NewMagicLinkRepository(pool)
+	passwordResetRepository := repositories.NewPasswordResetRepository(pool)
+	emailVerificationRepository := repositories.NewEmailVerificationRepository(pool)
+	emailChangeRepository := repositories.NewEmailChangeRepository(pool)
+	organizationInvitationRepository := repositories.NewOrganizationInvitationRepository(pool)
+	dataExportRepository := repositories.NewDataExportRepository(pool)
+	userRepository := repositories.NewUserRepository(pool)
+	client, cleanup2, err := providers.ProvideAsynqClient(configConfig)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	taskClient := providers.ProvideTaskClient(client)
+	linkSigner := providers.ProvideLinkSigner(configConfig)
+	accountDeletionService := services.NewAccountDeletionService(configConfig, userRepository, taskClient, linkSigner)
+	cleanupTask := providers.ProvideCleanupTask(logger, configConfig, authTokenRepository, refreshTokenRepository, twoFactorChallengeRepository, webAuthnChallengeRepository, oidcLoginStateRepository, magicLinkRepository, passwordResetRepository, emailVerificationRepository, emailChangeRepository, organizationInvitationRepository, dataExportRepository, accountDeletionService)
+	organizationRepository := repositories.NewOrganizationRepository(pool)
+	userIdentityRepository := repositories.NewUserIdentityRepository(pool)
+	webAuthnCredentialRepository := repositories.NewWebAuthnCredentialRepository(pool)
+	apiKeyRepository := repositories.NewAPIKeyRepository(pool)
+	loginDeviceRepository := repositories.NewLoginDeviceRepository(pool)
+	auditLogRepository := repositories.NewAuditLogRepository(pool)
+	totpCredentialRepository := repositories.NewTOTPCredentialRepository(pool)
+	roleRepository := repositories.NewRoleRepository(pool)
+	dataExportService := providers.ProvideDataExportService(configConfig, dataExportRepository, userRepository, authTokenRepository, organizationRepository, userIdentityRepository, webAuthnCredentialRepository, apiKeyRepository, loginDeviceRepository, auditLogRepository, emailChangeRepository, totpCredentialRepository, roleRepository, taskClient, linkSigner)
+	dataExportTask := providers.ProvideDataExportTask(logger, dataExportService)
+	accountDeletionReminderTask := providers.ProvideAccountDeletionReminderTask(logger, accountDeletionService)
+	registry := providers.ProvideTaskRegistry(emailTask, cleanupTask, dataExportTask, accountDeletionReminderTask)
 	serveMux := providers.ProvideServeMux(registry)
 	scheduler := providers.ProvideScheduler(configConfig)
 	workerWorker := providers.ProvideWorker(server, serveMux, scheduler, registry, logger)
 	return workerWorker, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// InitializeLoginLockoutService creates the lockout service used by the
+// users CLI. The cleanup function closes database and Redis connections
+// and should be deferred by the caller.
+func InitializeLoginLockoutService() (services2.LoginLockoutService, func(), error) {
+	configConfig, err := config.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, cleanup, err := providers.ProvideRedisClient(configConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	attemptStore := providers.ProvideAttemptStore(client)
+	pool, cleanup2, err := providers.ProvideDB(configConfig)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	userRepository := repositories.NewUserRepository(pool)
+	asynqClient, cleanup3, err := providers.ProvideAsynqClient(configConfig)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	taskClient := providers.ProvideTaskClient(asynqClient)
+	loginLockoutService := services.NewLoginLockoutService(configConfig, attemptStore, userRepository, taskClient)
+	return loginLockoutService, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// InitializePermissionService creates the permission service used by the
+// users CLI to grant and revoke roles. The cleanup function closes
+// database and Redis connections and should be deferred by the caller.
+func InitializePermissionService() (services2.PermissionService, func(), error) {
+	configConfig, err := config.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	pool, cleanup, err := providers.ProvideDB(configConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	roleRepository := repositories.NewRoleRepository(pool)
+	userRepository := repositories.NewUserRepository(pool)
+	permissionService := services.NewPermissionService(roleRepository, userRepository)
+	return permissionService, func() {
 		cleanup()
 	}, nil
 }
@@ -101,22 +245,25 @@ func InitializeWorker() (*worker.Worker, func(), error) {
 var BaseProviderSet = wire.NewSet(config.Load, providers.ProvideLogger, providers.ProvideEmailSender)
 
 // RepositoryProviderSet contains all repository providers
-var RepositoryProviderSet = wire.NewSet(repositories.NewUserRepository, wire.Bind(new(repositories2.UserRepository), new(*repositories.UserRepository)), repositories.NewAuthTokenRepository, wire.Bind(new(repositories2.AuthTokenRepository), new(*repositories.AuthTokenRepository)), repositories.NewPasswordResetRepository, wire.Bind(new(repositories2.PasswordResetRepository), new(*repositories.PasswordResetRepository)), repositories.NewEmailVerificationRepository, wire.Bind(new(repositories2.EmailVerificationRepository), new(*repositories.EmailVerificationRepository)))
+var RepositoryProviderSet = wire.NewSet(repositories.NewUserRepository, wire.Bind(new(repositories2.UserRepository), new(*repositories.UserRepository)), repositories.NewAuthTokenRepository, wire.Bind(new(repositories2.AuthTokenRepository), new(*repositories.AuthTokenRepository)), repositories.NewRefreshTokenRepository, wire.Bind(new(repositories2.RefreshTokenRepository), new(*repositories.RefreshTokenRepository)), repositories.NewTOTPCredentialRepository, wire.Bind(new(repositories2.TOTPCredentialRepository), new(*repositories.TOTPCredentialRepository)), repositories.NewRecoveryCodeRepository, wire.Bind(new(repositories2.RecoveryCodeRepository), new(*repositories.RecoveryCodeRepository)), repositories.NewTwoFactorChallengeRepository, wire.Bind(new(repositories2.TwoFactorChallengeRepository), new(*repositories.TwoFactorChallengeRepository)), repositories.NewWebAuthnCredentialRepository, wire.Bind(new(repositories2.WebAuthnCredentialRepository), new(*repositories.WebAuthnCredentialRepository)), repositories.NewWebAuthnChallengeRepository, wire.Bind(new(repositories2.WebAuthnChallengeRepository), new(*repositories.WebAuthnChallengeRepository)), repositories.NewUserIdentityRepository, wire.Bind(new(repositories2.UserIdentityRepository), new(*repositories.UserIdentityRepository)), repositories.NewOIDCLoginStateRepository, wire.Bind(new(repositories2.OIDCLoginStateRepository), new(*repositories.OIDCLoginStateRepository)), repositories.NewMagicLinkRepository, wire.Bind(new(repositories2.MagicLinkRepository), new(*repositories.MagicLinkRepository)), repositories.NewPasswordResetRepository, wire.Bind(new(repositories2.PasswordResetRepository), new(*repositories.PasswordResetRepository)), repositories.NewEmailVerificationRepository, wire.Bind(new(repositories2.EmailVerificationRepository), new(*repositories.EmailVerificationRepository)), repositories.NewEmailChangeRepository, wire.Bind(new(repositories2.EmailChangeRepository), new(*repositories.EmailChangeRepository)), repositories.NewAPIKeyRepository, wire.Bind(new(repositories2.APIKeyRepository), new(*repositories.APIKeyRepository)), repositories.NewRoleRepository, wire.Bind(new(repositories2.RoleRepository), new(*repositories.RoleRepository)), repositories.NewAuditLogRepository, wire.Bind(new(repositories2.AuditLogRepository), new(*repositories.AuditLogRepository)), repositories.NewLoginDeviceRepository, wire.Bind(new(repositories2.LoginDeviceRepository), new(*repositories.LoginDeviceRepository)), repositories.NewOrganizationRepository, wire.Bind(new(repositories2.OrganizationRepository), new(*repositories.OrganizationRepository)), repositories.NewOrganizationInvitationRepository, wire.Bind(new(repositories2.OrganizationInvitationRepository), new(*repositories.OrganizationInvitationRepository)), repositories.NewDataExportRepository, wire.Bind(new(repositories2.DataExportRepository), new(*repositories.DataExportRepository)))
 
 // ServiceProviderSet contains all service providers
-var ServiceProviderSet = wire.NewSet(services.NewUserService, wire.Bind(new(services2.UserService), new(*services.UserService)), services.NewSessionService, wire.Bind(new(services2.SessionService), new(*services.SessionService)), services.NewPasswordResetService, wire.Bind(new(services2.PasswordResetService), new(*services.PasswordResetService)), services.NewEmailVerificationService, wire.Bind(new(services2.EmailVerificationService), new(*services.EmailVerificationService)))
+var ServiceProviderSet = wire.NewSet(services.NewUserService, wire.Bind(new(services2.UserService), new(*services.UserService)), services.NewTwoFactorService, wire.Bind(new(services2.TwoFactorService), new(*services.TwoFactorService)), services.NewSessionService, wire.Bind(new(services2.SessionService), new(*services.SessionService)), services.NewPasskeyService, wire.Bind(new(services2.PasskeyService), new(*services.PasskeyService)), services.NewOIDCService, wire.Bind(new(services2.OIDCService), new(*services.OIDCService)), services.NewMagicLinkService, wire.Bind(new(services2.MagicLinkService), new(*services.MagicLinkService)), services.NewPasswordResetService, wire.Bind(new(services2.PasswordResetService), new(*services.PasswordResetService)), services.NewEmailVerificationService, wire.Bind(new(services2.EmailVerificationService), new(*services.EmailVerificationService)), services.NewEmailChangeService, wire.Bind(new(services2.EmailChangeService), new(*services.EmailChangeService)), services.NewLoginLockoutService, wire.Bind(new(services2.LoginLockoutService), new(*services.LoginLockoutService)), services.NewLoginAlertService, wire.Bind(new(services2.LoginAlertService), new(*services.LoginAlertService)), services.NewPasswordPolicyService, wire.Bind(new(services2.PasswordPolicyService), new(*services.PasswordPolicyService)), services.NewAPIKeyService, wire.Bind(new(services2.APIKeyService), new(*services.APIKeyService)), services.NewPermissionService, wire.Bind(new(services2.PermissionService), new(*services.PermissionService)), services.NewAdminService, wire.Bind(new(services2.AdminService), new(*services.AdminService)), services.NewImpersonationService, wire.Bind(new(services2.ImpersonationService), new(*services.ImpersonationService)), services.NewOrganizationService, wire.Bind(new(services2.OrganizationService), new(*services.OrganizationService)), providers.ProvideDataExportService, wire.Bind(new(services2.DataExportService), new(*services.DataExportService)))
 
 // HandlerProviderSet contains all handler providers
-var HandlerProviderSet = wire.NewSet(handlers.NewUserHandler, handlers.NewSessionHandler, handlers.NewPasswordResetHandler, handlers.NewEmailVerificationHandler, handlers.NewHealthHandler)
+var HandlerProviderSet = wire.NewSet(handlers.NewUserHandler, handlers.NewSessionHandler, handlers.NewTwoFactorHandler, handlers.NewPasskeyHandler, handlers.NewOIDCHandler, handlers.NewMagicLinkHandler, handlers.NewPasswordResetHandler, handlers.NewEmailVerificationHandler, handlers.NewEmailChangeHandler, handlers.NewAPIKeyHandler, handlers.NewAdminHandler, handlers.NewImpersonationHandler, handlers.NewOrganizationHandler, handlers.NewDataExportHandler, handlers.NewHealthHandler)
 
 // APIProviderSet contains providers specific to the API
 var APIProviderSet = wire.NewSet(
-	BaseProviderSet, providers.ProvideDB, providers.ProvideTxManager, providers.ProvideAsynqClient, wire.Bind(new(handlers.RedisPinger), new(*asynq.Client)), providers.ProvideTaskClient, RepositoryProviderSet,
+	BaseProviderSet, providers.ProvideDB, providers.ProvideTxManager, wire.Bind(new(handlers.DBPinger), new(*pgxpool.Pool)), providers.ProvideAsynqClient, wire.Bind(new(handlers.RedisPinger), new(*asynq.Client)), providers.ProvideTaskClient, providers.ProvideRedisClient, providers.ProvideAttemptStore, providers.ProvideBreachedPasswords, providers.ProvidePasswordHasher, providers.ProvideTokenHasher, providers.ProvideTokenCache, providers.ProvideLinkSigner, providers.ProvideWebAuthn, RepositoryProviderSet,
 	ServiceProviderSet,
 	HandlerProviderSet, http.NewRouter,
 )
 
 // WorkerProviderSet contains providers specific to the Worker
 var WorkerProviderSet = wire.NewSet(
-	BaseProviderSet, providers.ProvideDB, RepositoryProviderSet, providers.ProvideAsynqServer, providers.ProvideScheduler, providers.ProvideEmailTask, providers.ProvideCleanupTask, providers.ProvideTaskRegistry, providers.ProvideServeMux, providers.ProvideWorker,
+	BaseProviderSet, providers.ProvideDB, providers.ProvideAsynqClient, providers.ProvideTaskClient, providers.ProvideLinkSigner, RepositoryProviderSet, providers.ProvideDataExportService, wire.Bind(new(services2.DataExportService), new(*services.DataExportService)), services.NewAccountDeletionService, wire.Bind(new(services2.AccountDeletionService), new(*services.AccountDeletionService)), providers.ProvideAsynqServer, providers.ProvideScheduler, providers.ProvideEmailTask, providers.ProvideCleanupTask, providers.ProvideDataExportTask, providers.ProvideAccountDeletionReminderTask, providers.ProvideTaskRegistry, providers.ProvideServeMux, providers.ProvideWorker,
 )
+
+// CLIProviderSet contains providers for administrative CLI commands
+var CLIProviderSet = wire.NewSet(config.Load, providers.ProvideDB, providers.ProvideAsynqClient, providers.ProvideTaskClient, providers.ProvideRedisClient, providers.ProvideAttemptStore, repositories.NewUserRepository, wire.Bind(new(repositories2.UserRepository), new(*repositories.UserRepository)), services.NewLoginLockoutService, wire.Bind(new(services2.LoginLockoutService), new(*services.LoginLockoutService)), repositories.NewRoleRepository, wire.Bind(new(repositories2.RoleRepository), new(*repositories.RoleRepository)), services.NewPermissionService, wire.Bind(new(services2.PermissionService), new(*services.PermissionService)))